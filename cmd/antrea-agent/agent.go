@@ -15,11 +15,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/informers"
+	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog"
 
 	"github.com/vmware-tanzu/antrea/pkg/agent"
@@ -31,6 +34,7 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/agent/controller/noderoute"
 	"github.com/vmware-tanzu/antrea/pkg/agent/controller/traceflow"
 	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter/connections"
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter/exporter"
 	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
 	"github.com/vmware-tanzu/antrea/pkg/agent/metrics"
 	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
@@ -54,6 +58,13 @@ import (
 // https://github.com/kubernetes/kubernetes/blob/release-1.17/pkg/controller/apis/config/v1alpha1/defaults.go#L120
 const informerDefaultResync = 12 * time.Hour
 
+// The Service of the cluster DNS servers, whose ClusterIP is the default DNS
+// server of the Pods.
+const (
+	kubeDNSServiceNamespace = "kube-system"
+	kubeDNSServiceName      = "kube-dns"
+)
+
 // run starts Antrea agent with the given options and waits for termination signal.
 func run(o *Options) error {
 	klog.Infof("Starting Antrea agent (version %s)", version.GetFullVersion())
@@ -151,7 +162,9 @@ func run(o *Options) error {
 	// notifying NetworkPolicyController to reconcile rules related to the
 	// updated Pods.
 	podUpdates := make(chan v1beta1.PodReference, 100)
-	networkPolicyController := networkpolicy.NewNetworkPolicyController(antreaClientProvider, ofClient, ifaceStore, nodeConfig.Name, podUpdates)
+	networkPolicyController := networkpolicy.NewNetworkPolicyController(antreaClientProvider, ofClient, ifaceStore, nodeConfig.Name, podUpdates, o.config.PolicyStateFile, getDNSServerIPs(k8sClient, o.dnsServerIPs))
+	// Register the handler of the packets rejected or logged by Antrea-native policy rules.
+	ofClient.RegisterPacketInHandler(uint8(openflow.PacketInReasonNP), "networkpolicy", networkPolicyController)
 	isChaining := false
 	if networkConfig.TrafficEncapMode.IsNetworkPolicyOnly() {
 		isChaining = true
@@ -231,17 +244,46 @@ func run(o *Options) error {
 	}
 	go apiServer.Run(stopCh)
 
-	if features.DefaultFeatureGate.Enabled(features.Traceflow) {
-		go ofClient.StartPacketInHandler(stopCh)
-	}
-	// Create connection store that polls conntrack flows with a given polling interval.
+	go ofClient.StartPacketInHandler(stopCh)
+	// Create connection store that receives conntrack events and reconciles them with a dump of conntrack.
 	if features.DefaultFeatureGate.Enabled(features.FlowExporter) {
 		ctDumper := connections.NewConnTrackDumper(nodeConfig, serviceCIDRNet, connections.NewConnTrackInterfacer())
-		connStore := connections.NewConnectionStore(ctDumper, ifaceStore)
+		// The counters of the connections, which are used to detect idle flows, are not carried by
+		// conntrack events, so conntrack is reconciled more often than the idle flow export timeout.
+		// The Services of the connections are only known when AntreaProxy is enabled.
+		var serviceQuerier proxy.Querier
+		if proxier != nil {
+			serviceQuerier = proxier
+		}
+		connStore := connections.NewConnectionStore(ctDumper, ifaceStore, ofClient, serviceQuerier, connections.ReconcileInterval(o.idleFlowExportTimeout))
+		flowExporter, err := exporter.NewFlowExporter(connStore, nodeConfig.Name, o.flowCollectorAddr, o.flowCollectorProto, o.activeFlowExportTimeout, o.idleFlowExportTimeout)
+		if err != nil {
+			return fmt.Errorf("error when creating flow exporter: %v", err)
+		}
 		go connStore.Run(stopCh)
+		go flowExporter.Run(stopCh)
 	}
 
 	<-stopCh
 	klog.Info("Stopping Antrea agent")
 	return nil
 }
+
+// getDNSServerIPs returns the IPs of the DNS servers whose responses resolve the
+// FQDNs of policy rules, which default to the ClusterIP of the kube-dns Service.
+func getDNSServerIPs(k8sClient clientset.Interface, configuredIPs []net.IP) []net.IP {
+	if len(configuredIPs) > 0 {
+		return configuredIPs
+	}
+	svc, err := k8sClient.CoreV1().Services(kubeDNSServiceNamespace).Get(context.TODO(), kubeDNSServiceName, metav1.GetOptions{})
+	if err != nil {
+		klog.Warningf("Failed to get Service %s/%s, set dnsServers to resolve the FQDNs of policy rules: %v", kubeDNSServiceNamespace, kubeDNSServiceName, err)
+		return nil
+	}
+	ip := net.ParseIP(svc.Spec.ClusterIP)
+	if ip == nil || ip.To4() == nil {
+		klog.Warningf("Service %s/%s has no IPv4 ClusterIP, set dnsServers to resolve the FQDNs of policy rules", kubeDNSServiceNamespace, kubeDNSServiceName)
+		return nil
+	}
+	return []net.IP{ip.To4()}
+}
@@ -86,4 +86,28 @@ type AgentConfig struct {
 	// Enable metrics exposure via Prometheus. Initializes Prometheus metrics listener
 	// Defaults to false.
 	EnablePrometheusMetrics bool `yaml:"enablePrometheusMetrics,omitempty"`
+	// Provide flow collector address as string with format <IP>:<port>[:<proto>], where proto is tcp or udp.
+	// It is required when the FlowExporter feature is enabled, to send the IPFIX flow records of the
+	// conntrack connections on the OVS bridge to the collector. If no L4 transport proto is given,
+	// tcp is used.
+	// Defaults to "".
+	FlowCollectorAddr string `yaml:"flowCollectorAddr,omitempty"`
+	// Provide the active flow export timeout, which is the interval at which the records of
+	// long-lived flows are exported to the flow collector, as a duration string, e.g. "60s".
+	// Defaults to "60s".
+	ActiveFlowExportTimeout string `yaml:"activeFlowExportTimeout,omitempty"`
+	// Provide the idle flow export timeout, after which the record of a flow whose counters
+	// did not change is exported to the flow collector, as a duration string, e.g. "15s".
+	// Defaults to "15s".
+	IdleFlowExportTimeout string `yaml:"idleFlowExportTimeout,omitempty"`
+	// The IPv4 addresses of the DNS servers the Pods send their queries to. The FQDNs of
+	// Antrea-native policy rules are only resolved with the responses of these servers, to the
+	// queries sent by the Pods the rules are applied to.
+	// Defaults to the ClusterIP of the kube-dns Service in the kube-system Namespace.
+	DNSServers []string `yaml:"dnsServers,omitempty"`
+	// The file to which the NetworkPolicies received from the Antrea Controller are persisted,
+	// and from which they are restored when antrea-agent restarts. The directory must survive
+	// the restarts of the antrea-agent container.
+	// Defaults to /var/run/antrea/networkpolicy/state.json.
+	PolicyStateFile string `yaml:"policyStateFile,omitempty"`
 }
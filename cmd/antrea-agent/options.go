@@ -18,11 +18,14 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 	"gopkg.in/yaml.v2"
 
 	"github.com/vmware-tanzu/antrea/pkg/agent/config"
+	"github.com/vmware-tanzu/antrea/pkg/agent/controller/networkpolicy"
 	"github.com/vmware-tanzu/antrea/pkg/apis"
 	"github.com/vmware-tanzu/antrea/pkg/cni"
 	"github.com/vmware-tanzu/antrea/pkg/features"
@@ -30,11 +33,13 @@ import (
 )
 
 const (
-	defaultOVSBridge          = "br-int"
-	defaultHostGateway        = "antrea-gw0"
-	defaultHostProcPathPrefix = "/host"
-	defaultServiceCIDR        = "10.96.0.0/12"
-	defaultTunnelType         = ovsconfig.GeneveTunnel
+	defaultOVSBridge               = "br-int"
+	defaultHostGateway             = "antrea-gw0"
+	defaultHostProcPathPrefix      = "/host"
+	defaultServiceCIDR             = "10.96.0.0/12"
+	defaultTunnelType              = ovsconfig.GeneveTunnel
+	defaultActiveFlowExportTimeout = "60s"
+	defaultIdleFlowExportTimeout   = "15s"
 )
 
 type Options struct {
@@ -42,6 +47,15 @@ type Options struct {
 	configFile string
 	// The configuration object
 	config *AgentConfig
+	// The address of the flow collector, without the transport protocol.
+	flowCollectorAddr string
+	// The transport protocol used to send flow records to the collector.
+	flowCollectorProto string
+	// The timeouts after which active and idle flows are exported.
+	activeFlowExportTimeout time.Duration
+	idleFlowExportTimeout   time.Duration
+	// The IPs of the DNS servers whose responses resolve the FQDNs of policy rules.
+	dnsServerIPs []net.IP
 }
 
 func newOptions() *Options {
@@ -95,9 +109,53 @@ func (o *Options) validate(args []string) error {
 	if encapMode.SupportsNoEncap() && o.config.EnableIPSecTunnel {
 		return fmt.Errorf("IPSec tunnel may only be enabled on %s mode", config.TrafficEncapModeEncap)
 	}
-	if o.config.OVSDatapathType == ovsconfig.OVSDatapathNetdev && features.DefaultFeatureGate.Enabled(features.FlowExporter) {
-		return fmt.Errorf("FlowExporter feature is not supported for OVS datapath type %s", o.config.OVSDatapathType)
+	for _, server := range o.config.DNSServers {
+		ip := net.ParseIP(server)
+		if ip == nil || ip.To4() == nil {
+			return fmt.Errorf("DNS server %s is invalid, must be an IPv4 address", server)
+		}
+		o.dnsServerIPs = append(o.dnsServerIPs, ip.To4())
+	}
+	if features.DefaultFeatureGate.Enabled(features.FlowExporter) {
+		if o.config.OVSDatapathType == ovsconfig.OVSDatapathNetdev {
+			return fmt.Errorf("FlowExporter feature is not supported for OVS datapath type %s", o.config.OVSDatapathType)
+		}
+		if err := o.validateFlowExporterConfig(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateFlowExporterConfig parses the address of the flow collector, in the
+// format <IP>:<port>[:<proto>].
+func (o *Options) validateFlowExporterConfig() error {
+	if o.config.FlowCollectorAddr == "" {
+		return fmt.Errorf("flowCollectorAddr must be set when FlowExporter feature is enabled")
+	}
+	addr := o.config.FlowCollectorAddr
+	proto := "tcp"
+	if i := strings.LastIndex(addr, ":"); i >= 0 {
+		if suffix := addr[i+1:]; suffix == "tcp" || suffix == "udp" {
+			proto = suffix
+			addr = addr[:i]
+		}
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return fmt.Errorf("flowCollectorAddr %s is invalid: %v", o.config.FlowCollectorAddr, err)
+	}
+	activeTimeout, err := time.ParseDuration(o.config.ActiveFlowExportTimeout)
+	if err != nil || activeTimeout <= 0 {
+		return fmt.Errorf("activeFlowExportTimeout %s is invalid, must be a positive duration", o.config.ActiveFlowExportTimeout)
 	}
+	idleTimeout, err := time.ParseDuration(o.config.IdleFlowExportTimeout)
+	if err != nil || idleTimeout <= 0 {
+		return fmt.Errorf("idleFlowExportTimeout %s is invalid, must be a positive duration", o.config.IdleFlowExportTimeout)
+	}
+	o.flowCollectorAddr = addr
+	o.flowCollectorProto = proto
+	o.activeFlowExportTimeout = activeTimeout
+	o.idleFlowExportTimeout = idleTimeout
 	return nil
 }
 
@@ -146,4 +204,13 @@ func (o *Options) setDefaults() {
 	if o.config.APIPort == 0 {
 		o.config.APIPort = apis.AntreaAgentAPIPort
 	}
+	if o.config.ActiveFlowExportTimeout == "" {
+		o.config.ActiveFlowExportTimeout = defaultActiveFlowExportTimeout
+	}
+	if o.config.IdleFlowExportTimeout == "" {
+		o.config.IdleFlowExportTimeout = defaultIdleFlowExportTimeout
+	}
+	if o.config.PolicyStateFile == "" {
+		o.config.PolicyStateFile = networkpolicy.DefaultPolicyStateFile
+	}
 }
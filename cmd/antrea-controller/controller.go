@@ -39,6 +39,7 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/controller/networkpolicy"
 	"github.com/vmware-tanzu/antrea/pkg/controller/networkpolicy/store"
 	"github.com/vmware-tanzu/antrea/pkg/controller/querier"
+	"github.com/vmware-tanzu/antrea/pkg/controller/stats"
 	"github.com/vmware-tanzu/antrea/pkg/controller/traceflow"
 	"github.com/vmware-tanzu/antrea/pkg/features"
 	"github.com/vmware-tanzu/antrea/pkg/k8s"
@@ -67,9 +68,16 @@ func run(o *Options) error {
 	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, informerDefaultResync)
 	podInformer := informerFactory.Core().V1().Pods()
 	namespaceInformer := informerFactory.Core().V1().Namespaces()
+	serviceInformer := informerFactory.Core().V1().Services()
+	endpointsInformer := informerFactory.Core().V1().Endpoints()
 	networkPolicyInformer := informerFactory.Networking().V1().NetworkPolicies()
 	nodeInformer := informerFactory.Core().V1().Nodes()
+	serviceAccountInformer := informerFactory.Core().V1().ServiceAccounts()
 	cnpInformer := crdInformerFactory.Security().V1alpha1().ClusterNetworkPolicies()
+	anpInformer := crdInformerFactory.Security().V1alpha1().NetworkPolicies()
+	tierInformer := crdInformerFactory.Security().V1alpha1().Tiers()
+	externalEntityInformer := crdInformerFactory.Core().V1alpha1().ExternalEntities()
+	cgInformer := crdInformerFactory.Core().V1alpha1().ClusterGroups()
 	traceflowInformer := crdInformerFactory.Ops().V1alpha1().Traceflows()
 
 	// Create Antrea object storage.
@@ -81,16 +89,36 @@ func run(o *Options) error {
 		crdClient,
 		podInformer,
 		namespaceInformer,
+		serviceInformer,
+		endpointsInformer,
+		nodeInformer,
+		serviceAccountInformer,
 		networkPolicyInformer,
 		cnpInformer,
+		anpInformer,
+		tierInformer,
+		externalEntityInformer,
+		cgInformer,
 		addressGroupStore,
 		appliedToGroupStore,
 		networkPolicyStore)
 
+	networkPolicyValidator := networkpolicy.NewNetworkPolicyValidator(networkPolicyController)
+
 	controllerQuerier := querier.NewControllerQuerier(networkPolicyController, o.config.APIPort)
 
 	controllerMonitor := monitor.NewControllerMonitor(crdClient, nodeInformer, controllerQuerier)
 
+	var policyConflictReporter *networkpolicy.PolicyConflictReporter
+	if features.DefaultFeatureGate.Enabled(features.ClusterNetworkPolicy) || features.DefaultFeatureGate.Enabled(features.AntreaNetworkPolicy) {
+		policyConflictReporter = networkpolicy.NewPolicyConflictReporter(client, networkPolicyController)
+	}
+
+	var statsAggregator *stats.Aggregator
+	if features.DefaultFeatureGate.Enabled(features.NetworkPolicyStats) {
+		statsAggregator = stats.NewAggregator(networkPolicyInformer, cnpInformer, anpInformer)
+	}
+
 	var traceflowController *traceflow.Controller
 	if features.DefaultFeatureGate.Enabled(features.Traceflow) {
 		traceflowController = traceflow.NewTraceflowController(crdClient, traceflowInformer)
@@ -105,6 +133,9 @@ func run(o *Options) error {
 		appliedToGroupStore,
 		networkPolicyStore,
 		controllerQuerier,
+		networkPolicyValidator,
+		networkPolicyController,
+		statsAggregator,
 		o.config.EnablePrometheusMetrics)
 	if err != nil {
 		return fmt.Errorf("error creating API server config: %v", err)
@@ -130,6 +161,10 @@ func run(o *Options) error {
 
 	go apiServer.Run(stopCh)
 
+	if policyConflictReporter != nil {
+		go policyConflictReporter.Run(stopCh)
+	}
+
 	if o.config.EnablePrometheusMetrics {
 		metrics.InitializePrometheusMetrics()
 	}
@@ -152,10 +187,13 @@ func createAPIServerConfig(kubeconfig string,
 	appliedToGroupStore storage.Interface,
 	networkPolicyStore storage.Interface,
 	controllerQuerier querier.ControllerQuerier,
+	networkPolicyValidator *networkpolicy.NetworkPolicyValidator,
+	networkPolicyController *networkpolicy.NetworkPolicyController,
+	statsAggregator *stats.Aggregator,
 	enableMetrics bool) (*apiserver.Config, error) {
 	secureServing := genericoptions.NewSecureServingOptions().WithLoopback()
 	authentication := genericoptions.NewDelegatingAuthenticationOptions()
-	authorization := genericoptions.NewDelegatingAuthorizationOptions().WithAlwaysAllowPaths("/healthz")
+	authorization := genericoptions.NewDelegatingAuthorizationOptions().WithAlwaysAllowPaths("/healthz", "/validate/tier", "/validate/acnp", "/validate/anp", "/mutate/acnp", "/mutate/anp")
 
 	caCertController, err := certificate.ApplyServerCert(selfSignedCert, client, aggregatorClient, secureServing)
 	if err != nil {
@@ -199,5 +237,11 @@ func createAPIServerConfig(kubeconfig string,
 		appliedToGroupStore,
 		networkPolicyStore,
 		caCertController,
-		controllerQuerier), nil
+		controllerQuerier,
+		networkPolicyValidator,
+		networkPolicyController,
+		networkPolicyController,
+		networkPolicyController,
+		networkPolicyController,
+		statsAggregator), nil
 }
@@ -0,0 +1,37 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+type FlowAggregatorConfig struct {
+	// Provide the address of the downstream flow collector, to which the aggregated flow records
+	// are exported, as string with format <IP>:<port>[:<proto>], where proto is tcp or udp. If no
+	// L4 transport proto is given, tcp is used.
+	// Defaults to "".
+	FlowCollectorAddr string `yaml:"flowCollectorAddr,omitempty"`
+	// Provide the port on which the flow records exported by the Antrea Agents are collected.
+	// Defaults to 4739.
+	CollectorPort int `yaml:"collectorPort,omitempty"`
+	// Provide the L4 transport proto over which the flow records exported by the Antrea Agents are
+	// collected, which must match the proto of the flowCollectorAddr of the Agents: tcp or udp.
+	// Defaults to "tcp".
+	CollectorProto string `yaml:"collectorProto,omitempty"`
+	// Provide the correlation timeout, which is how long the record of one side of an inter-Node
+	// connection waits for the record of the other side before it is exported as is, as a
+	// duration string, e.g. "65s". The Agents of both sides export the records of active
+	// connections every activeFlowExportTimeout, at different times, so it must be longer than
+	// the activeFlowExportTimeout of the Agents.
+	// Defaults to "65s".
+	CorrelationTimeout string `yaml:"correlationTimeout,omitempty"`
+}
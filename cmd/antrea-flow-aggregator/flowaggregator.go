@@ -0,0 +1,70 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter/ipfix"
+	"github.com/vmware-tanzu/antrea/pkg/flowaggregator"
+	"github.com/vmware-tanzu/antrea/pkg/log"
+	"github.com/vmware-tanzu/antrea/pkg/signals"
+	"github.com/vmware-tanzu/antrea/pkg/version"
+)
+
+// run starts the Antrea Flow Aggregator with the given options and waits for
+// the termination signal.
+func run(o *Options) error {
+	klog.Infof("Starting Antrea Flow Aggregator (version %s)", version.GetFullVersion())
+
+	collector, err := ipfix.NewCollectingProcess(fmt.Sprintf(":%d", o.config.CollectorPort), o.config.CollectorProto)
+	if err != nil {
+		return fmt.Errorf("error creating IPFIX collecting process: %v", err)
+	}
+	process, err := ipfix.NewExportingProcess(o.flowCollectorAddr, o.flowCollectorProto, genObservationDomainID())
+	if err != nil {
+		return fmt.Errorf("error creating IPFIX exporting process: %v", err)
+	}
+	flowAggregator := flowaggregator.NewFlowAggregator(collector, process, o.correlationTimeout)
+
+	// Set up signal capture: the first SIGTERM / SIGINT signal is handled gracefully and will
+	// cause the stopCh channel to be closed; if another signal is received before the program
+	// exits, we will force exit.
+	stopCh := signals.RegisterSignalHandlers()
+
+	log.StartLogFileNumberMonitor(stopCh)
+
+	go flowAggregator.Run(stopCh)
+
+	<-stopCh
+	klog.Info("Stopping Antrea Flow Aggregator")
+	return nil
+}
+
+// genObservationDomainID generates the Observation Domain ID of the aggregated
+// records from the hostname, which is the name of the Pod of the aggregator.
+func genObservationDomainID() uint32 {
+	hostname, err := os.Hostname()
+	if err != nil {
+		klog.Warningf("Failed to get hostname, using the default Observation Domain ID: %v", err)
+	}
+	h := fnv.New32()
+	h.Write([]byte(hostname))
+	return h.Sum32()
+}
@@ -0,0 +1,135 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// defaultCollectorPort is the port registered by IANA for IPFIX.
+	defaultCollectorPort  = 4739
+	defaultCollectorProto = "tcp"
+	// defaultCorrelationTimeout is the default activeFlowExportTimeout of the Agents, plus the
+	// interval at which they check their connections.
+	defaultCorrelationTimeout = "65s"
+)
+
+type Options struct {
+	// The path of configuration file.
+	configFile string
+	// The configuration object
+	config *FlowAggregatorConfig
+	// The address of the downstream flow collector, without the L4 transport proto.
+	flowCollectorAddr string
+	// The L4 transport proto of the downstream flow collector, tcp or udp.
+	flowCollectorProto string
+	// The parsed correlation timeout.
+	correlationTimeout time.Duration
+}
+
+func newOptions() *Options {
+	return &Options{
+		config: new(FlowAggregatorConfig),
+	}
+}
+
+// addFlags adds flags to fs and binds them to options.
+func (o *Options) addFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.configFile, "config", o.configFile, "The path to the configuration file")
+}
+
+// complete completes all the required options.
+func (o *Options) complete(args []string) error {
+	if len(o.configFile) > 0 {
+		c, err := o.loadConfigFromFile(o.configFile)
+		if err != nil {
+			return err
+		}
+		o.config = c
+	}
+	o.setDefaults()
+	return nil
+}
+
+// validate validates all the required options.
+func (o *Options) validate(args []string) error {
+	if len(args) != 0 {
+		return errors.New("no positional arguments are supported")
+	}
+	if o.config.FlowCollectorAddr == "" {
+		return errors.New("flowCollectorAddr must be set")
+	}
+	// The address of the flow collector is in the format <IP>:<port>[:<proto>].
+	addr := o.config.FlowCollectorAddr
+	proto := "tcp"
+	if i := strings.LastIndex(addr, ":"); i >= 0 {
+		if suffix := addr[i+1:]; suffix == "tcp" || suffix == "udp" {
+			proto = suffix
+			addr = addr[:i]
+		}
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return fmt.Errorf("flowCollectorAddr %s is invalid: %v", o.config.FlowCollectorAddr, err)
+	}
+	if o.config.CollectorPort <= 0 || o.config.CollectorPort > 65535 {
+		return fmt.Errorf("collectorPort %d is invalid", o.config.CollectorPort)
+	}
+	if o.config.CollectorProto != "tcp" && o.config.CollectorProto != "udp" {
+		return fmt.Errorf("collectorProto %s is invalid, must be tcp or udp", o.config.CollectorProto)
+	}
+	correlationTimeout, err := time.ParseDuration(o.config.CorrelationTimeout)
+	if err != nil || correlationTimeout <= 0 {
+		return fmt.Errorf("correlationTimeout %s is invalid, must be a positive duration", o.config.CorrelationTimeout)
+	}
+	o.flowCollectorAddr = addr
+	o.flowCollectorProto = proto
+	o.correlationTimeout = correlationTimeout
+	return nil
+}
+
+func (o *Options) loadConfigFromFile(file string) (*FlowAggregatorConfig, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var c FlowAggregatorConfig
+	err = yaml.UnmarshalStrict(data, &c)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (o *Options) setDefaults() {
+	if o.config.CollectorPort == 0 {
+		o.config.CollectorPort = defaultCollectorPort
+	}
+	if o.config.CollectorProto == "" {
+		o.config.CollectorProto = defaultCollectorProto
+	}
+	if o.config.CorrelationTimeout == "" {
+		o.config.CorrelationTimeout = defaultCorrelationTimeout
+	}
+}
@@ -35,6 +35,8 @@ $GOPATH/bin/client-gen \
   --input "security/v1alpha1" \
   --input "core/v1alpha1" \
   --input "ops/v1alpha1" \
+  --input "stats/v1alpha1" \
+  --plural-exceptions "ClusterGroupMembers:ClusterGroupMembers,NetworkPolicyStats:NetworkPolicyStats,ClusterNetworkPolicyStats:ClusterNetworkPolicyStats,AntreaNetworkPolicyStats:AntreaNetworkPolicyStats" \
   --output-package "${ANTREA_PKG}/pkg/client/clientset" \
   --go-header-file hack/boilerplate/license_header.go.txt
 
@@ -62,6 +64,7 @@ $GOPATH/bin/deepcopy-gen \
   --input-dirs "${ANTREA_PKG}/pkg/apis/security/v1alpha1" \
   --input-dirs "${ANTREA_PKG}/pkg/apis/core/v1alpha1" \
   --input-dirs "${ANTREA_PKG}/pkg/apis/ops/v1alpha1" \
+  --input-dirs "${ANTREA_PKG}/pkg/apis/stats/v1alpha1" \
   -O zz_generated.deepcopy \
   --go-header-file hack/boilerplate/license_header.go.txt
 
@@ -74,6 +77,7 @@ $GOPATH/bin/openapi-gen  \
   --input-dirs "${ANTREA_PKG}/pkg/apis/networking/v1beta1" \
   --input-dirs "${ANTREA_PKG}/pkg/apis/clusterinformation/v1beta1" \
   --input-dirs "${ANTREA_PKG}/pkg/apis/system/v1beta1" \
+  --input-dirs "${ANTREA_PKG}/pkg/apis/stats/v1alpha1" \
   --input-dirs "k8s.io/apimachinery/pkg/apis/meta/v1,k8s.io/apimachinery/pkg/runtime,k8s.io/apimachinery/pkg/util/intstr" \
   --input-dirs "k8s.io/api/core/v1" \
   --output-package "${ANTREA_PKG}/pkg/apiserver/openapi" \
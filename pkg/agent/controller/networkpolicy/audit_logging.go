@@ -0,0 +1,247 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/contiv/libOpenflow/protocol"
+	"golang.org/x/time/rate"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/k8s"
+)
+
+const (
+	// defaultLogDir is the log directory of antrea-agent when the log_dir
+	// flag is not set.
+	defaultLogDir = "/var/log/antrea"
+	// AuditLogSubDir is the sub-directory of the antrea-agent log directory
+	// where the audit logs of Antrea-native policy rules are written.
+	AuditLogSubDir = "networkpolicy"
+	// AuditLogFileName is the name of the audit log file. Rotated files are
+	// suffixed with their rotation index, e.g. "np.log.1".
+	AuditLogFileName = "np.log"
+	// auditLogMaxSize is the size in bytes from which the audit log file is
+	// rotated.
+	auditLogMaxSize = 100 * 1024 * 1024
+	// auditLogMaxBackups is the maximum number of rotated audit log files
+	// which are kept.
+	auditLogMaxBackups = 3
+	// auditLogRate is the maximum number of audit log entries written per
+	// second, and auditLogBurst is the maximum number of entries which can be
+	// written at once. Entries exceeding the limit are suppressed.
+	auditLogRate  = 100
+	auditLogBurst = 500
+)
+
+// auditLogEntry is a log entry for a packet which matches an Antrea-native
+// policy rule with logging enabled.
+type auditLogEntry struct {
+	direction       v1beta1.Direction
+	policyName      string
+	policyNamespace string
+	action          secv1alpha1.RuleAction
+	srcPod          string
+	srcIP           string
+	srcPort         uint16
+	dstPod          string
+	dstIP           string
+	dstPort         uint16
+	protocol        uint8
+	length          uint16
+}
+
+// String returns the representation of the entry written to the audit log.
+// Unknown fields are represented by "-".
+func (e *auditLogEntry) String() string {
+	policy := k8s.NamespacedName(e.policyNamespace, e.policyName)
+	return fmt.Sprintf("%s %s %s %s %s %s %s %s %s %s %d",
+		e.direction, orUnknown(policy), orUnknown(string(e.action)),
+		orUnknown(e.srcPod), e.srcIP, portString(e.srcPort),
+		orUnknown(e.dstPod), e.dstIP, portString(e.dstPort),
+		protocolString(e.protocol), e.length)
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func portString(port uint16) string {
+	if port == 0 {
+		return "-"
+	}
+	return strconv.Itoa(int(port))
+}
+
+func protocolString(proto uint8) string {
+	switch proto {
+	case protocol.Type_TCP:
+		return "TCP"
+	case protocol.Type_UDP:
+		return "UDP"
+	case protocol.Type_ICMP:
+		return "ICMP"
+	case 132:
+		return "SCTP"
+	}
+	return strconv.Itoa(int(proto))
+}
+
+// auditLogger writes the audit logs of Antrea-native policy rules to a
+// rotating file. The number of entries written per second is limited, so that
+// a flood of matching packets cannot fill the disk of the Node.
+type auditLogger struct {
+	logger  *log.Logger
+	limiter *rate.Limiter
+	// suppressed is the number of entries suppressed by the rate limiter
+	// since the last written entry. auditLogger is only used by the
+	// goroutine handling the packet-in messages of NetworkPolicy, so it does
+	// not need to be protected by a lock.
+	suppressed uint64
+}
+
+// newAuditLogger returns a new *auditLogger writing to the audit log file
+// under the given antrea-agent log directory.
+func newAuditLogger(logDir string) (*auditLogger, error) {
+	dir := filepath.Join(logDir, AuditLogSubDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating audit log directory %s: %v", dir, err)
+	}
+	file, err := newRotatingFile(filepath.Join(dir, AuditLogFileName), auditLogMaxSize, auditLogMaxBackups)
+	if err != nil {
+		return nil, err
+	}
+	return &auditLogger{
+		logger:  log.New(file, "", log.Ldate|log.Lmicroseconds),
+		limiter: rate.NewLimiter(auditLogRate, auditLogBurst),
+	}, nil
+}
+
+// log writes the given entry to the audit log, unless the rate limit is
+// exceeded. The number of suppressed entries is logged before the next entry
+// which is written.
+func (l *auditLogger) log(entry *auditLogEntry) {
+	if !l.limiter.Allow() {
+		l.suppressed++
+		return
+	}
+	if l.suppressed > 0 {
+		l.logger.Printf("%d entries suppressed by rate limiting", l.suppressed)
+		l.suppressed = 0
+	}
+	l.logger.Println(entry.String())
+}
+
+// getLogDir returns the log directory of antrea-agent.
+func getLogDir() string {
+	logDirFlag := flag.CommandLine.Lookup("log_dir")
+	if logDirFlag == nil || logDirFlag.Value.String() == "" {
+		return defaultLogDir
+	}
+	return logDirFlag.Value.String()
+}
+
+// rotatingFile is an io.Writer which writes to a file and rotates it when its
+// size exceeds maxSize. The rotated files are named <path>.1 to
+// <path>.<maxBackups>, <path>.1 being the most recent one.
+type rotatingFile struct {
+	sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func newRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	f := &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening file %s: %v", f.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("error getting info of file %s: %v", f.path, err)
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// rotate renames the current file and the existing rotated files, and opens a
+// new file. The file is reopened even if renaming fails, so that the writer
+// remains usable.
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	renameErr := f.renameBackups()
+	if err := f.open(); err != nil {
+		return err
+	}
+	return renameErr
+}
+
+func (f *rotatingFile) renameBackups() error {
+	if f.maxBackups == 0 {
+		return os.Remove(f.path)
+	}
+	for i := f.maxBackups - 1; i > 0; i-- {
+		oldPath := fmt.Sprintf("%s.%d", f.path, i)
+		if _, err := os.Stat(oldPath); err != nil {
+			continue
+		}
+		if err := os.Rename(oldPath, fmt.Sprintf("%s.%d", f.path, i+1)); err != nil {
+			return err
+		}
+	}
+	return os.Rename(f.path, f.path+".1")
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.Lock()
+	defer f.Unlock()
+	if f.size+int64(len(p)) > f.maxSize && f.size > 0 {
+		if err := f.rotate(); err != nil {
+			return 0, fmt.Errorf("error rotating file %s: %v", f.path, err)
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
@@ -0,0 +1,119 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/contiv/libOpenflow/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+)
+
+func TestAuditLogEntryString(t *testing.T) {
+	tests := []struct {
+		name     string
+		entry    *auditLogEntry
+		expected string
+	}{
+		{
+			name: "anp-tcp",
+			entry: &auditLogEntry{
+				direction:       v1beta1.DirectionIn,
+				policyName:      "anp1",
+				policyNamespace: "ns1",
+				action:          secv1alpha1.RuleActionDrop,
+				srcIP:           "10.10.1.2",
+				srcPort:         34567,
+				dstPod:          "ns1/pod1",
+				dstIP:           "10.10.0.2",
+				dstPort:         80,
+				protocol:        protocol.Type_TCP,
+				length:          60,
+			},
+			expected: "In ns1/anp1 Drop - 10.10.1.2 34567 ns1/pod1 10.10.0.2 80 TCP 60",
+		},
+		{
+			name: "cnp-icmp",
+			entry: &auditLogEntry{
+				direction:  v1beta1.DirectionOut,
+				policyName: "cnp1",
+				action:     secv1alpha1.RuleActionAllow,
+				srcPod:     "ns1/pod1",
+				srcIP:      "10.10.0.2",
+				dstIP:      "8.8.8.8",
+				protocol:   protocol.Type_ICMP,
+				length:     84,
+			},
+			expected: "Out cnp1 Allow ns1/pod1 10.10.0.2 - - 8.8.8.8 - ICMP 84",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.entry.String())
+		})
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-audit-log")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, AuditLogFileName)
+
+	line := strings.Repeat("a", 9) + "\n"
+	f, err := newRotatingFile(path, 25, 2)
+	require.NoError(t, err)
+	// Each file can hold 2 lines, so writing 7 lines rotates the file 3
+	// times and the oldest rotated file is removed.
+	for i := 0; i < 7; i++ {
+		_, err := f.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, file := range files {
+		names = append(names, file.Name())
+	}
+	assert.ElementsMatch(t, []string{AuditLogFileName, AuditLogFileName + ".1", AuditLogFileName + ".2"}, names)
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, line, string(data))
+}
+
+func TestAuditLoggerRateLimit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-audit-log")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	logger, err := newAuditLogger(dir)
+	require.NoError(t, err)
+	entry := &auditLogEntry{direction: v1beta1.DirectionIn, srcIP: "10.10.0.1", dstIP: "10.10.0.2"}
+	for i := 0; i < auditLogBurst+10; i++ {
+		logger.log(entry)
+	}
+	assert.NotZero(t, logger.suppressed)
+	data, err := ioutil.ReadFile(filepath.Join(dir, AuditLogSubDir, AuditLogFileName))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, strings.Count(string(data), "\n"), auditLogBurst+1)
+}
@@ -44,7 +44,8 @@ const (
 // to construct a complete rule that can be used by reconciler to enforce.
 // The K8s NetworkPolicy object doesn't provide ID for its rule, here we
 // calculate an ID based on the rule's fields. That means:
-// 1. If a rule's selector/services/direction changes, it becomes "another" rule.
+// 1. If a rule's selector/services/direction/priorities change, it becomes
+//    "another" rule.
 // 2. If inserting rules before a rule or shuffling rules in a NetworkPolicy, we
 //    can know the existing rules don't change and skip processing them. Note that
 //    if a CNP/ANP rule's position (from top down) within a networkpolicy changes, it
@@ -66,6 +67,10 @@ type rule struct {
 	Priority int32
 	// Priority of the NetworkPolicy to which this rule belong. nil for k8s NetworkPolicy.
 	PolicyPriority *float64
+	// Priority of the Tier of the NetworkPolicy to which this rule belong. nil for k8s NetworkPolicy.
+	TierPriority *int32
+	// EnableLogging indicates whether audit logs are generated for the traffic matching this rule.
+	EnableLogging bool
 	// Targets of this rule.
 	AppliedToGroups []string
 	// The parent Policy ID. Used to identify rules belong to a specified
@@ -97,6 +102,9 @@ type CompletedRule struct {
 	ToAddresses v1beta1.GroupMemberPodSet
 	// Target Pods of this rule.
 	Pods v1beta1.GroupMemberPodSet
+	// Destination IPs resolved from the FQDNs of this rule, only used by
+	// egress rules.
+	ToFQDNIPs sets.String
 }
 
 // String returns the string representation of the CompletedRule.
@@ -105,10 +113,10 @@ func (r *CompletedRule) String() string {
 	if r.Direction == v1beta1.DirectionIn {
 		addressString = fmt.Sprintf("FromAddressGroups: %d, FromIPBlocks: %d, FromAddresses: %d", len(r.From.AddressGroups), len(r.From.IPBlocks), len(r.FromAddresses))
 	} else {
-		addressString = fmt.Sprintf("ToAddressGroups: %d, ToIPBlocks: %d, ToAddresses: %d", len(r.To.AddressGroups), len(r.To.IPBlocks), len(r.ToAddresses))
+		addressString = fmt.Sprintf("ToAddressGroups: %d, ToIPBlocks: %d, ToFQDNs: %d, ToAddresses: %d", len(r.To.AddressGroups), len(r.To.IPBlocks), len(r.To.FQDNs), len(r.ToAddresses))
 	}
-	return fmt.Sprintf("%s (Direction: %v, Pods: %d, %s, Services: %d, PolicyPriority: %v, RulePriority: %v)",
-		r.ID, r.Direction, len(r.Pods), addressString, len(r.Services), r.PolicyPriority, r.Priority)
+	return fmt.Sprintf("%s (Direction: %v, Pods: %d, %s, Services: %d, TierPriority: %v, PolicyPriority: %v, RulePriority: %v)",
+		r.ID, r.Direction, len(r.Pods), addressString, len(r.Services), r.TierPriority, r.PolicyPriority, r.Priority)
 }
 
 // isAntreaNetworkPolicyRule returns true if the rule is part of a ClusterNetworkPolicy.
@@ -131,13 +139,17 @@ type ruleCache struct {
 
 	policyMapLock sync.RWMutex
 	// policyMap is a map using NetworkPolicy UID as the key.
-	policyMap map[string]*types.NamespacedName
+	policyMap map[string]*v1beta1.NetworkPolicy
 
 	// rules is a storage that supports listing rules using multiple indexing functions.
 	// rules is thread-safe.
 	rules cache.Indexer
 	// dirtyRuleHandler is a callback that is run upon finding a rule out-of-sync.
 	dirtyRuleHandler func(string)
+	// stateChangeHandler is an optional callback that is run upon any change
+	// of the cached NetworkPolicies, AddressGroups or AppliedToGroups, even if
+	// it makes no rule dirty.
+	stateChangeHandler func()
 
 	// podUpdates is a channel for receiving Pod updates from CNIServer.
 	podUpdates <-chan v1beta1.PodReference
@@ -175,6 +187,24 @@ func (c *ruleCache) getNetworkPolicy(npName, npNamespace string) *v1beta1.Networ
 	return c.buildNetworkPolicyFromRules(npUID)
 }
 
+// getNetworkPolicyAndRuleIDs returns the cached NetworkPolicy with the given
+// UID and the IDs of its rules. nil is returned if the NetworkPolicy is not
+// found.
+func (c *ruleCache) getNetworkPolicyAndRuleIDs(uid string) (*v1beta1.NetworkPolicy, sets.String) {
+	c.policyMapLock.RLock()
+	defer c.policyMapLock.RUnlock()
+	policy, exists := c.policyMap[uid]
+	if !exists {
+		return nil, nil
+	}
+	ruleIDs := sets.NewString()
+	rules, _ := c.rules.ByIndex(policyIndex, uid)
+	for _, r := range rules {
+		ruleIDs.Insert(r.(*rule).ID)
+	}
+	return policy, ruleIDs
+}
+
 func (c *ruleCache) buildNetworkPolicyFromRules(uid string) *v1beta1.NetworkPolicy {
 	var np *v1beta1.NetworkPolicy
 	rules, _ := c.rules.ByIndex(policyIndex, uid)
@@ -202,12 +232,13 @@ func addRuleToNetworkPolicy(np *v1beta1.NetworkPolicy, rule *rule) *v1beta1.Netw
 		}
 	}
 	np.Rules = append(np.Rules, v1beta1.NetworkPolicyRule{
-		Direction: rule.Direction,
-		From:      rule.From,
-		To:        rule.To,
-		Services:  rule.Services,
-		Action:    rule.Action,
-		Priority:  rule.Priority})
+		Direction:     rule.Direction,
+		From:          rule.From,
+		To:            rule.To,
+		Services:      rule.Services,
+		Action:        rule.Action,
+		Priority:      rule.Priority,
+		EnableLogging: rule.EnableLogging})
 	return np
 
 }
@@ -317,7 +348,7 @@ func newRuleCache(dirtyRuleHandler func(string), podUpdate <-chan v1beta1.PodRef
 	cache := &ruleCache{
 		podSetByGroup:     make(map[string]v1beta1.GroupMemberPodSet),
 		addressSetByGroup: make(map[string]v1beta1.GroupMemberPodSet),
-		policyMap:         make(map[string]*types.NamespacedName),
+		policyMap:         make(map[string]*v1beta1.NetworkPolicy),
 		rules:             rules,
 		dirtyRuleHandler:  dirtyRuleHandler,
 		podUpdates:        podUpdate,
@@ -351,6 +382,13 @@ func (c *ruleCache) processPodUpdates() {
 	}
 }
 
+// onStateChange runs stateChangeHandler, if any.
+func (c *ruleCache) onStateChange() {
+	if c.stateChangeHandler != nil {
+		c.stateChangeHandler()
+	}
+}
+
 // GetAddressGroupNum gets the number of AddressGroup.
 func (c *ruleCache) GetAddressGroupNum() int {
 	c.addressSetLock.RLock()
@@ -378,6 +416,7 @@ func (c *ruleCache) ReplaceAddressGroups(groups []*v1beta1.AddressGroup) {
 
 	for key := range oldGroupKeys {
 		delete(c.addressSetByGroup, key)
+		c.onStateChange()
 	}
 	return
 }
@@ -402,12 +441,16 @@ func (c *ruleCache) addAddressGroupLocked(group *v1beta1.AddressGroup) error {
 		// https://github.com/golang/go/wiki/CommonMistakes#using-reference-to-loop-iterator-variable
 		podSet.Insert(&group.Pods[i])
 	}
+	for i := range group.GroupMembers {
+		podSet.Insert(groupMemberToAddresses(&group.GroupMembers[i])...)
+	}
 	oldPodSet, exists := c.addressSetByGroup[group.Name]
 	if exists && oldPodSet.Equal(podSet) {
 		return nil
 	}
 	c.addressSetByGroup[group.Name] = podSet
 	c.onAddressGroupUpdate(group.Name)
+	c.onStateChange()
 	return nil
 }
 
@@ -427,10 +470,28 @@ func (c *ruleCache) PatchAddressGroup(patch *v1beta1.AddressGroupPatch) error {
 	for i := range patch.RemovedPods {
 		podSet.Delete(&patch.RemovedPods[i])
 	}
+	for i := range patch.AddedGroupMembers {
+		podSet.Insert(groupMemberToAddresses(&patch.AddedGroupMembers[i])...)
+	}
+	for i := range patch.RemovedGroupMembers {
+		podSet.Delete(groupMemberToAddresses(&patch.RemovedGroupMembers[i])...)
+	}
 	c.onAddressGroupUpdate(patch.Name)
+	c.onStateChange()
 	return nil
 }
 
+// groupMemberToAddresses converts the Endpoints of a GroupMember, e.g. the
+// Endpoints of an ExternalEntity, to GroupMemberPods which only have the IP and
+// the named ports set, so that they can be used as addresses of rules.
+func groupMemberToAddresses(member *v1beta1.GroupMember) []*v1beta1.GroupMemberPod {
+	addresses := make([]*v1beta1.GroupMemberPod, 0, len(member.Endpoints))
+	for _, endpoint := range member.Endpoints {
+		addresses = append(addresses, &v1beta1.GroupMemberPod{IP: endpoint.IP, Ports: endpoint.Ports})
+	}
+	return addresses
+}
+
 // DeleteAddressGroup deletes a cached *v1beta1.AddressGroup.
 // It should only happen when a group is no longer referenced by any rule, so
 // no need to mark dirty rules.
@@ -439,6 +500,7 @@ func (c *ruleCache) DeleteAddressGroup(group *v1beta1.AddressGroup) error {
 	defer c.addressSetLock.Unlock()
 
 	delete(c.addressSetByGroup, group.Name)
+	c.onStateChange()
 	return nil
 }
 
@@ -469,6 +531,7 @@ func (c *ruleCache) ReplaceAppliedToGroups(groups []*v1beta1.AppliedToGroup) {
 
 	for key := range oldGroupKeys {
 		delete(c.podSetByGroup, key)
+		c.onStateChange()
 	}
 	return
 }
@@ -495,6 +558,7 @@ func (c *ruleCache) addAppliedToGroupLocked(group *v1beta1.AppliedToGroup) error
 	}
 	c.podSetByGroup[group.Name] = podSet
 	c.onAppliedToGroupUpdate(group.Name)
+	c.onStateChange()
 	return nil
 }
 
@@ -515,6 +579,7 @@ func (c *ruleCache) PatchAppliedToGroup(patch *v1beta1.AppliedToGroupPatch) erro
 		podSet.Delete(&patch.RemovedPods[i])
 	}
 	c.onAppliedToGroupUpdate(patch.Name)
+	c.onStateChange()
 	return nil
 }
 
@@ -526,6 +591,7 @@ func (c *ruleCache) DeleteAppliedToGroup(group *v1beta1.AppliedToGroup) error {
 	defer c.podSetLock.Unlock()
 
 	delete(c.podSetByGroup, group.Name)
+	c.onStateChange()
 	return nil
 }
 
@@ -538,13 +604,15 @@ func toRule(r *v1beta1.NetworkPolicyRule, policy *v1beta1.NetworkPolicy) *rule {
 		Services:        r.Services,
 		Action:          r.Action,
 		Priority:        r.Priority,
+		PolicyPriority:  policy.Priority,
+		TierPriority:    policy.TierPriority,
+		EnableLogging:   r.EnableLogging,
 		AppliedToGroups: policy.AppliedToGroups,
 		PolicyUID:       policy.UID,
 	}
 	rule.ID = hashRule(rule)
 	rule.PolicyNamespace = policy.Namespace
 	rule.PolicyName = policy.Name
-	rule.PolicyPriority = policy.Priority
 	return rule
 }
 
@@ -591,14 +659,21 @@ func (c *ruleCache) AddNetworkPolicy(policy *v1beta1.NetworkPolicy) error {
 }
 
 func (c *ruleCache) addNetworkPolicyLocked(policy *v1beta1.NetworkPolicy) error {
-	c.policyMap[string(policy.UID)] = &types.NamespacedName{policy.Namespace, policy.Name}
 	metrics.NetworkPolicyCount.Inc()
-	return c.UpdateNetworkPolicy(policy)
+	return c.updateNetworkPolicyLocked(policy)
 }
 
 // UpdateNetworkPolicy updates a cached *v1beta1.NetworkPolicy.
 // The added rules and removed rules will be regarded as dirty.
 func (c *ruleCache) UpdateNetworkPolicy(policy *v1beta1.NetworkPolicy) error {
+	c.policyMapLock.Lock()
+	defer c.policyMapLock.Unlock()
+
+	return c.updateNetworkPolicyLocked(policy)
+}
+
+func (c *ruleCache) updateNetworkPolicyLocked(policy *v1beta1.NetworkPolicy) error {
+	c.policyMap[string(policy.UID)] = policy
 	existingRules, _ := c.rules.ByIndex(policyIndex, string(policy.UID))
 	ruleByID := map[string]interface{}{}
 	for _, r := range existingRules {
@@ -635,6 +710,7 @@ func (c *ruleCache) UpdateNetworkPolicy(policy *v1beta1.NetworkPolicy) error {
 		}
 		c.dirtyRuleHandler(ruleID)
 	}
+	c.onStateChange()
 	return nil
 }
 
@@ -662,6 +738,7 @@ func (c *ruleCache) deleteNetworkPolicyLocked(uid string) error {
 		c.dirtyRuleHandler(ruleID)
 	}
 	metrics.NetworkPolicyCount.Dec()
+	c.onStateChange()
 	return nil
 }
 
@@ -22,7 +22,6 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
@@ -128,6 +127,16 @@ func newAddressGroupMember(ip string) *v1beta1.GroupMemberPod {
 	return &v1beta1.GroupMemberPod{IP: v1beta1.IPAddress(net.ParseIP(ip))}
 }
 
+func newExternalEntityMember(name string, ips ...string) *v1beta1.GroupMember {
+	member := &v1beta1.GroupMember{
+		ExternalEntity: &v1beta1.ExternalEntityReference{Name: name, Namespace: "ns1"},
+	}
+	for _, ip := range ips {
+		member.Endpoints = append(member.Endpoints, v1beta1.Endpoint{IP: v1beta1.IPAddress(net.ParseIP(ip))})
+	}
+	return member
+}
+
 func TestRuleCacheAddAddressGroup(t *testing.T) {
 	rule1 := &rule{
 		ID:   "rule1",
@@ -174,6 +183,17 @@ func TestRuleCacheAddAddressGroup(t *testing.T) {
 			[]*v1beta1.GroupMemberPod{newAddressGroupMember("1.1.1.1"), newAddressGroupMember("2.2.2.2")},
 			sets.NewString("rule1", "rule2"),
 		},
+		{
+			"external-entities",
+			[]*rule{rule1, rule2},
+			&v1beta1.AddressGroup{
+				ObjectMeta:   metav1.ObjectMeta{Name: "group2"},
+				Pods:         []v1beta1.GroupMemberPod{*newAddressGroupMember("1.1.1.1")},
+				GroupMembers: []v1beta1.GroupMember{*newExternalEntityMember("ee1", "2.2.2.2", "3.3.3.3")},
+			},
+			[]*v1beta1.GroupMemberPod{newAddressGroupMember("1.1.1.1"), newAddressGroupMember("2.2.2.2"), newAddressGroupMember("3.3.3.3")},
+			sets.NewString("rule2"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -430,7 +450,9 @@ func TestRuleCacheReplaceNetworkPolicies(t *testing.T) {
 			c, recorder, _ := newFakeRuleCache()
 			for _, rule := range tt.rules {
 				c.rules.Add(rule)
-				c.policyMap[string(rule.PolicyUID)] = &types.NamespacedName{rule.PolicyNamespace, rule.PolicyName}
+				c.policyMap[string(rule.PolicyUID)] = &v1beta1.NetworkPolicy{
+					ObjectMeta: metav1.ObjectMeta{UID: rule.PolicyUID, Namespace: rule.PolicyNamespace, Name: rule.PolicyName},
+				}
 			}
 			c.ReplaceNetworkPolicies(tt.args)
 
@@ -862,6 +884,19 @@ func TestRuleCachePatchAddressGroup(t *testing.T) {
 			sets.NewString("rule1", "rule2"),
 			false,
 		},
+		{
+			"add-and-remove-external-entities",
+			[]*rule{rule1, rule2},
+			map[string]v1beta1.GroupMemberPodSet{"group2": v1beta1.NewGroupMemberPodSet(newAddressGroupMember("1.1.1.1"), newAddressGroupMember("2.2.2.2"))},
+			&v1beta1.AddressGroupPatch{
+				ObjectMeta:          metav1.ObjectMeta{Name: "group2"},
+				AddedGroupMembers:   []v1beta1.GroupMember{*newExternalEntityMember("ee2", "3.3.3.3")},
+				RemovedGroupMembers: []v1beta1.GroupMember{*newExternalEntityMember("ee1", "2.2.2.2")},
+			},
+			[]*v1beta1.GroupMemberPod{newAddressGroupMember("1.1.1.1"), newAddressGroupMember("3.3.3.3")},
+			sets.NewString("rule2"),
+			false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
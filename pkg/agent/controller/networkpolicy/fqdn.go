@@ -0,0 +1,495 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/contiv/libOpenflow/protocol"
+	"github.com/contiv/ofnet/ofctrl"
+	"golang.org/x/net/dns/dnsmessage"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+)
+
+const (
+	// minDNSTTL is the minimum time an IP resolved from a DNS response is kept
+	// in the rules, so that the Pod which sent the query has a chance to
+	// connect to it even if the record has a very small TTL.
+	minDNSTTL = 5 * time.Second
+	// dnsResponseDelayTimeout is the maximum time an intercepted DNS response
+	// is held while the rules are being updated with the IPs it contains.
+	dnsResponseDelayTimeout = 2 * time.Second
+	// dnsCacheGCInterval is the interval at which expired DNS records and
+	// queries are removed from the cache.
+	dnsCacheGCInterval = time.Second
+	// dnsQueryTimeout is the maximum time the response to an intercepted DNS
+	// query is expected. Later responses are not trusted.
+	dnsQueryTimeout = 10 * time.Second
+	// dnsPacketInRate and dnsPacketInBurst limit the rate of the intercepted
+	// DNS packets processed by the agent, so that a Pod flooding DNS traffic
+	// cannot exhaust its resources. The excess responses are dropped, and the
+	// Pods retry their queries.
+	dnsPacketInRate  = 1000
+	dnsPacketInBurst = 1000
+)
+
+// dnsQueryKey identifies an intercepted DNS query by the IP and the UDP port of
+// the Pod which sent it, and its transaction ID.
+type dnsQueryKey struct {
+	podIP   string
+	podPort uint16
+	id      uint16
+}
+
+// fqdnController maintains the IPs of the FQDNs used in the egress rules of
+// Antrea-native policies. It intercepts the DNS traffic between the configured
+// DNS servers and the Pods to which such rules are applied, caches the IPs
+// resolved by the responses answering the queries of these Pods according to
+// the TTL of the records, and marks the rules as dirty when the IPs they
+// select change. The responses which do not answer an intercepted query are
+// delivered without being trusted, so that a peer of the Pod cannot add IPs to
+// its rules with forged responses.
+//
+// An intercepted DNS response is only delivered to the Pod once the rules have
+// been updated with the IPs it contains, so that the Pod cannot connect to an
+// IP before it is allowed. When a record expires, its IPs are removed from the
+// rules, which denies new connections to them. Established connections are
+// not affected as they skip the NetworkPolicy tables.
+type fqdnController struct {
+	ofClient         openflow.Client
+	ifaceStore       interfacestore.InterfaceStore
+	dirtyRuleHandler func(string)
+	// dnsServerIPs are the IPs of the DNS servers whose responses are
+	// intercepted.
+	dnsServerIPs []net.IP
+	// packetInLimiter limits the rate of the intercepted DNS packets. It is
+	// only used by the goroutine handling the packet-in messages.
+	packetInLimiter *rate.Limiter
+
+	lock sync.Mutex
+	// ruleFQDNs stores the FQDNs selected by each rule.
+	ruleFQDNs map[string]sets.String
+	// ruleOFPorts stores the OVS ports of the Pods to which each rule is
+	// applied, whose DNS responses must be intercepted.
+	ruleOFPorts map[string]sets.Int32
+	// ofPortRefs counts the rules which need the DNS responses destined to
+	// each OVS port to be intercepted.
+	ofPortRefs map[int32]int
+	// dnsCache stores the expiration time of each IP resolved for a name.
+	dnsCache map[string]map[string]time.Time
+	// dnsQueries stores the expiration time of the intercepted DNS queries
+	// which have not been answered yet.
+	dnsQueries map[dnsQueryKey]time.Time
+	// ruleSyncWaiters stores the waiters of each rule, which are notified when
+	// the rule has been synced.
+	ruleSyncWaiters map[string][]*sync.WaitGroup
+	// now is used to get the current time, it's replaced in unit tests.
+	now func() time.Time
+}
+
+func newFQDNController(ofClient openflow.Client, ifaceStore interfacestore.InterfaceStore, dnsServerIPs []net.IP, dirtyRuleHandler func(string)) *fqdnController {
+	if len(dnsServerIPs) == 0 {
+		klog.Warning("No DNS server is configured, FQDN-based rules will not select any IP")
+	}
+	return &fqdnController{
+		ofClient:         ofClient,
+		ifaceStore:       ifaceStore,
+		dirtyRuleHandler: dirtyRuleHandler,
+		dnsServerIPs:     dnsServerIPs,
+		packetInLimiter:  rate.NewLimiter(dnsPacketInRate, dnsPacketInBurst),
+		ruleFQDNs:        map[string]sets.String{},
+		ruleOFPorts:      map[string]sets.Int32{},
+		ofPortRefs:       map[int32]int{},
+		dnsCache:         map[string]map[string]time.Time{},
+		dnsQueries:       map[dnsQueryKey]time.Time{},
+		ruleSyncWaiters:  map[string][]*sync.WaitGroup{},
+		now:              time.Now,
+	}
+}
+
+// fqdnMatches returns whether the name is selected by the FQDN, which can be
+// an exact name or a wildcard like "*.example.com" matching all the subdomains
+// of "example.com".
+func fqdnMatches(fqdn, name string) bool {
+	if strings.HasPrefix(fqdn, "*.") {
+		return strings.HasSuffix(name, fqdn[1:])
+	}
+	return fqdn == name
+}
+
+// normalizeName returns the canonical form of a DNS name, i.e. lower case
+// without the trailing dot.
+func normalizeName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
+
+// setRule registers the FQDNs selected by the rule and the Pods to which the
+// rule is applied, and returns the IPs currently resolved for the FQDNs.
+func (f *fqdnController) setRule(ruleID string, fqdns []string, pods v1beta1.GroupMemberPodSet) (sets.String, error) {
+	ofPorts := sets.NewInt32()
+	for _, pod := range pods {
+		for _, iface := range f.ifaceStore.GetContainerInterfacesByPod(pod.Pod.Name, pod.Pod.Namespace) {
+			ofPorts.Insert(iface.OFPort)
+		}
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.ruleFQDNs[ruleID] = sets.NewString(fqdns...)
+	if err := f.updateRuleOFPortsLocked(ruleID, ofPorts); err != nil {
+		return nil, err
+	}
+
+	ips := sets.NewString()
+	now := f.now()
+	for name, records := range f.dnsCache {
+		if !f.ruleSelectsLocked(ruleID, name) {
+			continue
+		}
+		for ip, expiration := range records {
+			if expiration.After(now) {
+				ips.Insert(ip)
+			}
+		}
+	}
+	return ips, nil
+}
+
+// deleteRule unregisters the rule and stops intercepting the DNS traffic of
+// the Pods which are no longer selected by any FQDN-based rule.
+func (f *fqdnController) deleteRule(ruleID string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.updateRuleOFPortsLocked(ruleID, nil); err != nil {
+		return err
+	}
+	delete(f.ruleFQDNs, ruleID)
+	delete(f.ruleOFPorts, ruleID)
+	return nil
+}
+
+// updateRuleOFPortsLocked installs the DNS intercept flows for the OVS ports
+// newly used by the rule and uninstalls the flows of the OVS ports no longer
+// used by any rule.
+func (f *fqdnController) updateRuleOFPortsLocked(ruleID string, ofPorts sets.Int32) error {
+	oldOFPorts := f.ruleOFPorts[ruleID]
+	for ofPort := range ofPorts.Difference(oldOFPorts) {
+		if f.ofPortRefs[ofPort] == 0 {
+			if err := f.ofClient.InstallDNSInterceptFlows(uint32(ofPort), f.dnsServerIPs); err != nil {
+				return fmt.Errorf("error installing DNS intercept flows for OVS port %d: %v", ofPort, err)
+			}
+		}
+		f.ofPortRefs[ofPort]++
+		if f.ruleOFPorts[ruleID] == nil {
+			f.ruleOFPorts[ruleID] = sets.NewInt32()
+		}
+		f.ruleOFPorts[ruleID].Insert(ofPort)
+	}
+	for ofPort := range oldOFPorts.Difference(ofPorts) {
+		if f.ofPortRefs[ofPort] == 1 {
+			if err := f.ofClient.UninstallDNSInterceptFlows(uint32(ofPort)); err != nil {
+				return fmt.Errorf("error uninstalling DNS intercept flows for OVS port %d: %v", ofPort, err)
+			}
+			delete(f.ofPortRefs, ofPort)
+		} else {
+			f.ofPortRefs[ofPort]--
+		}
+		f.ruleOFPorts[ruleID].Delete(ofPort)
+	}
+	return nil
+}
+
+func (f *fqdnController) ruleSelectsLocked(ruleID string, name string) bool {
+	for fqdn := range f.ruleFQDNs[ruleID] {
+		if fqdnMatches(fqdn, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// rulesSelectingLocked returns the IDs of the rules selecting any of the
+// provided names.
+func (f *fqdnController) rulesSelectingLocked(names sets.String) sets.String {
+	ruleIDs := sets.NewString()
+	for ruleID := range f.ruleFQDNs {
+		for name := range names {
+			if f.ruleSelectsLocked(ruleID, name) {
+				ruleIDs.Insert(ruleID)
+				break
+			}
+		}
+	}
+	return ruleIDs
+}
+
+// onDNSQuery registers a DNS query intercepted from a Pod, whose response is
+// expected within dnsQueryTimeout.
+func (f *fqdnController) onDNSQuery(key dnsQueryKey) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.dnsQueries[key] = f.now().Add(dnsQueryTimeout)
+}
+
+// takeDNSQuery returns whether a DNS response to a Pod answers a query it
+// sent, in which case the query is forgotten so that it is only answered once.
+func (f *fqdnController) takeDNSQuery(key dnsQueryKey) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	expiration, exists := f.dnsQueries[key]
+	if !exists {
+		return false
+	}
+	delete(f.dnsQueries, key)
+	return expiration.After(f.now())
+}
+
+// onDNSResponse updates the DNS cache with the IPs resolved for the names and
+// returns the IDs of the rules which must be updated because they select a
+// name with new IPs.
+func (f *fqdnController) onDNSResponse(records map[string]map[string]time.Duration) sets.String {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	now := f.now()
+	updatedNames := sets.NewString()
+	for name, ips := range records {
+		cachedIPs, exists := f.dnsCache[name]
+		if !exists {
+			cachedIPs = map[string]time.Time{}
+			f.dnsCache[name] = cachedIPs
+		}
+		for ip, ttl := range ips {
+			if ttl < minDNSTTL {
+				ttl = minDNSTTL
+			}
+			expiration := now.Add(ttl)
+			oldExpiration, exists := cachedIPs[ip]
+			if !exists || !oldExpiration.After(now) {
+				updatedNames.Insert(name)
+			}
+			if !exists || expiration.After(oldExpiration) {
+				cachedIPs[ip] = expiration
+			}
+		}
+	}
+	return f.rulesSelectingLocked(updatedNames)
+}
+
+// removeExpiredRecords removes the expired IPs from the DNS cache and marks
+// the rules selecting them as dirty, so that new connections to them are
+// denied. It also removes the queries which were not answered in time.
+func (f *fqdnController) removeExpiredRecords() {
+	f.lock.Lock()
+	now := f.now()
+	for key, expiration := range f.dnsQueries {
+		if !expiration.After(now) {
+			delete(f.dnsQueries, key)
+		}
+	}
+	expiredNames := sets.NewString()
+	for name, ips := range f.dnsCache {
+		for ip, expiration := range ips {
+			if !expiration.After(now) {
+				delete(ips, ip)
+				expiredNames.Insert(name)
+			}
+		}
+		if len(ips) == 0 {
+			delete(f.dnsCache, name)
+		}
+	}
+	ruleIDs := f.rulesSelectingLocked(expiredNames)
+	f.lock.Unlock()
+
+	for ruleID := range ruleIDs {
+		klog.V(2).Infof("DNS records selected by rule %s expired", ruleID)
+		f.dirtyRuleHandler(ruleID)
+	}
+}
+
+// addRuleSyncWaiter registers a waiter which must be notified when the rule
+// has been synced by a sync started after the registration.
+func (f *fqdnController) addRuleSyncWaiter(ruleID string, waiter *sync.WaitGroup) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	waiter.Add(1)
+	f.ruleSyncWaiters[ruleID] = append(f.ruleSyncWaiters[ruleID], waiter)
+}
+
+// takeRuleSyncWaiters returns and forgets the waiters registered for the rule
+// so far. It must be called when a sync of the rule starts, and the returned
+// waiters must be notified when the sync is done.
+func (f *fqdnController) takeRuleSyncWaiters(ruleID string) []*sync.WaitGroup {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	waiters := f.ruleSyncWaiters[ruleID]
+	delete(f.ruleSyncWaiters, ruleID)
+	return waiters
+}
+
+// Run removes the expired DNS records and queries periodically until stopCh is closed.
+func (f *fqdnController) Run(stopCh <-chan struct{}) {
+	wait.Until(f.removeExpiredRecords, dnsCacheGCInterval, stopCh)
+}
+
+// parseDNSResponse returns the IPv4 addresses and their TTLs found in the DNS
+// response, indexed by name. The addresses are associated with both the names
+// owning the A records and the names of the questions, so that the rules
+// selecting a name which is an alias of another one are updated as well.
+func parseDNSResponse(data []byte) (map[string]map[string]time.Duration, error) {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(data)
+	if err != nil {
+		return nil, err
+	}
+	if !header.Response || header.RCode != dnsmessage.RCodeSuccess {
+		return nil, nil
+	}
+	questions, err := parser.AllQuestions()
+	if err != nil {
+		return nil, err
+	}
+	records := map[string]map[string]time.Duration{}
+	addRecord := func(name, ip string, ttl time.Duration) {
+		if records[name] == nil {
+			records[name] = map[string]time.Duration{}
+		}
+		records[name][ip] = ttl
+	}
+	for {
+		answerHeader, err := parser.AnswerHeader()
+		if err == dnsmessage.ErrSectionDone {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if answerHeader.Type != dnsmessage.TypeA || answerHeader.Class != dnsmessage.ClassINET {
+			if err := parser.SkipAnswer(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		resource, err := parser.AResource()
+		if err != nil {
+			return nil, err
+		}
+		ip := net.IP(resource.A[:]).String()
+		ttl := time.Duration(answerHeader.TTL) * time.Second
+		addRecord(normalizeName(answerHeader.Name.String()), ip, ttl)
+		for _, question := range questions {
+			addRecord(normalizeName(question.Name.String()), ip, ttl)
+		}
+	}
+	return records, nil
+}
+
+// handleDNSPacket processes a DNS packet intercepted by the DNS intercept
+// flows. The queries are registered, as they are forwarded by the flows
+// themselves. The responses are handled by handleDNSResponse.
+func (c *Controller) handleDNSPacket(pktIn *ofctrl.PacketIn, ipPacket *protocol.IPv4) error {
+	udpPacket, ok := ipPacket.Data.(*protocol.UDP)
+	if !ok {
+		return fmt.Errorf("invalid UDP packet")
+	}
+	if !c.fqdnController.packetInLimiter.Allow() {
+		klog.V(2).Infof("Dropping DNS packet from %s to %s: rate limit exceeded", ipPacket.NWSrc, ipPacket.NWDst)
+		return nil
+	}
+	var parser dnsmessage.Parser
+	header, err := parser.Start(udpPacket.Data)
+	if err != nil {
+		return fmt.Errorf("invalid DNS message from %s to %s: %v", ipPacket.NWSrc, ipPacket.NWDst, err)
+	}
+	if !header.Response {
+		c.fqdnController.onDNSQuery(dnsQueryKey{podIP: ipPacket.NWSrc.String(), podPort: udpPacket.PortSrc, id: header.ID})
+		return nil
+	}
+	return c.handleDNSResponse(pktIn, ipPacket, udpPacket, header.ID)
+}
+
+// handleDNSResponse processes a DNS response intercepted by the DNS intercept
+// flows. If it answers a query intercepted from the Pod, the rules selecting
+// the resolved names are updated before the response is delivered to the Pod.
+// Otherwise, the response is delivered without updating any rule.
+func (c *Controller) handleDNSResponse(pktIn *ofctrl.PacketIn, ipPacket *protocol.IPv4, udpPacket *protocol.UDP, id uint16) error {
+	var records map[string]map[string]time.Duration
+	if c.fqdnController.takeDNSQuery(dnsQueryKey{podIP: ipPacket.NWDst.String(), podPort: udpPacket.PortDst, id: id}) {
+		var err error
+		records, err = parseDNSResponse(udpPacket.Data)
+		if err != nil {
+			// Deliver the response anyway, the Pod will handle it.
+			klog.V(2).Infof("Failed to parse DNS response: %v", err)
+		}
+	} else {
+		klog.V(2).Infof("DNS response %d from %s to %s:%d does not answer any intercepted query", id, ipPacket.NWSrc, ipPacket.NWDst, udpPacket.PortDst)
+	}
+	ruleIDs := c.fqdnController.onDNSResponse(records)
+
+	var waiter sync.WaitGroup
+	for ruleID := range ruleIDs {
+		c.fqdnController.addRuleSyncWaiter(ruleID, &waiter)
+		c.enqueueRule(ruleID)
+	}
+	deliver := func() error {
+		dstIP := ipPacket.NWDst.String()
+		iface, ok := c.ifaceStore.GetInterfaceByIP(dstIP)
+		if !ok {
+			return fmt.Errorf("interface of DNS response destination %s not found", dstIP)
+		}
+		return c.ofClient.SendUDPPacketOut(
+			pktIn.Data.HWSrc.String(),
+			pktIn.Data.HWDst.String(),
+			ipPacket.NWSrc.String(),
+			dstIP,
+			uint32(iface.OFPort),
+			nil,
+			udpPacket.PortSrc,
+			udpPacket.PortDst,
+			udpPacket.Data)
+	}
+	if len(ruleIDs) == 0 {
+		return deliver()
+	}
+	// Don't block the processing of other packets while the rules are being
+	// updated.
+	go func() {
+		synced := make(chan struct{})
+		go func() {
+			waiter.Wait()
+			close(synced)
+		}()
+		select {
+		case <-synced:
+		case <-time.After(dnsResponseDelayTimeout):
+			klog.Warningf("Timed out waiting for rules %v to be updated with DNS response, delivering it", ruleIDs.List())
+		}
+		if err := deliver(); err != nil {
+			klog.Errorf("Failed to deliver DNS response: %v", err)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,252 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/contiv/libOpenflow/protocol"
+	"github.com/contiv/ofnet/ofctrl"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
+	openflowtest "github.com/vmware-tanzu/antrea/pkg/agent/openflow/testing"
+	"github.com/vmware-tanzu/antrea/pkg/agent/util"
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+)
+
+func TestFQDNMatches(t *testing.T) {
+	tests := []struct {
+		fqdn     string
+		name     string
+		expected bool
+	}{
+		{"www.example.com", "www.example.com", true},
+		{"www.example.com", "example.com", false},
+		{"*.example.com", "www.example.com", true},
+		{"*.example.com", "a.b.example.com", true},
+		{"*.example.com", "example.com", false},
+		{"*.example.com", "wwwexample.com", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, fqdnMatches(tt.fqdn, tt.name), "fqdn %s, name %s", tt.fqdn, tt.name)
+	}
+}
+
+func TestParseDNSResponse(t *testing.T) {
+	builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{Response: true, RCode: dnsmessage.RCodeSuccess})
+	require.NoError(t, builder.StartQuestions())
+	require.NoError(t, builder.Question(dnsmessage.Question{
+		Name:  dnsmessage.MustNewName("www.Example.com."),
+		Type:  dnsmessage.TypeA,
+		Class: dnsmessage.ClassINET,
+	}))
+	require.NoError(t, builder.StartAnswers())
+	require.NoError(t, builder.CNAMEResource(
+		dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName("www.Example.com."), Class: dnsmessage.ClassINET, TTL: 300},
+		dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName("cdn.example.net.")},
+	))
+	require.NoError(t, builder.AResource(
+		dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName("cdn.example.net."), Class: dnsmessage.ClassINET, TTL: 60},
+		dnsmessage.AResource{A: [4]byte{10, 0, 0, 1}},
+	))
+	data, err := builder.Finish()
+	require.NoError(t, err)
+
+	records, err := parseDNSResponse(data)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]time.Duration{
+		"www.example.com": {"10.0.0.1": 60 * time.Second},
+		"cdn.example.net": {"10.0.0.1": 60 * time.Second},
+	}, records)
+}
+
+var testDNSServerIPs = []net.IP{net.ParseIP("10.96.0.10").To4()}
+
+func newTestFQDNController(controller *gomock.Controller) (*fqdnController, *openflowtest.MockClient, *[]string, *time.Time) {
+	ifaceStore := interfacestore.NewInterfaceStore()
+	ifaceStore.AddInterface(&interfacestore.InterfaceConfig{
+		InterfaceName:            util.GenerateContainerInterfaceName("pod1", "ns1", "container1"),
+		IP:                       net.ParseIP("2.2.2.2"),
+		ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod1", PodNamespace: "ns1", ContainerID: "container1"},
+		OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: 1},
+	})
+	mockOFClient := openflowtest.NewMockClient(controller)
+	var dirtyRules []string
+	f := newFQDNController(mockOFClient, ifaceStore, testDNSServerIPs, func(ruleID string) {
+		dirtyRules = append(dirtyRules, ruleID)
+	})
+	now := time.Now()
+	f.now = func() time.Time { return now }
+	return f, mockOFClient, &dirtyRules, &now
+}
+
+func TestFQDNControllerRecords(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	f, mockOFClient, dirtyRules, now := newTestFQDNController(controller)
+	pods := v1beta1.NewGroupMemberPodSet(newAppliedToGroupMember("pod1", "ns1"))
+
+	// The DNS traffic of the Pod is intercepted once a rule is applied to it.
+	mockOFClient.EXPECT().InstallDNSInterceptFlows(uint32(1), testDNSServerIPs)
+	ips, err := f.setRule("rule1", []string{"*.example.com"}, pods)
+	require.NoError(t, err)
+	assert.Empty(t, ips)
+	ips, err = f.setRule("rule2", []string{"www.example.com"}, pods)
+	require.NoError(t, err)
+	assert.Empty(t, ips)
+
+	// Only the rules selecting the names with new IPs must be updated.
+	ruleIDs := f.onDNSResponse(map[string]map[string]time.Duration{
+		"db.example.com": {"10.0.0.1": 60 * time.Second},
+		"example.org":    {"10.0.0.2": 60 * time.Second},
+	})
+	assert.Equal(t, sets.NewString("rule1"), ruleIDs)
+	ips, err = f.setRule("rule1", []string{"*.example.com"}, pods)
+	require.NoError(t, err)
+	assert.Equal(t, sets.NewString("10.0.0.1"), ips)
+
+	// Refreshing the TTL of known IPs doesn't require any update.
+	ruleIDs = f.onDNSResponse(map[string]map[string]time.Duration{
+		"db.example.com": {"10.0.0.1": 120 * time.Second},
+	})
+	assert.Empty(t, ruleIDs)
+
+	// The rules selecting the expired IPs are updated.
+	*now = now.Add(61 * time.Second)
+	f.removeExpiredRecords()
+	assert.Empty(t, *dirtyRules)
+	*now = now.Add(60 * time.Second)
+	f.removeExpiredRecords()
+	assert.Equal(t, []string{"rule1"}, *dirtyRules)
+	ips, err = f.setRule("rule1", []string{"*.example.com"}, pods)
+	require.NoError(t, err)
+	assert.Empty(t, ips)
+
+	// The DNS traffic of the Pod is no longer intercepted once no rule is
+	// applied to it.
+	require.NoError(t, f.deleteRule("rule1"))
+	mockOFClient.EXPECT().UninstallDNSInterceptFlows(uint32(1))
+	require.NoError(t, f.deleteRule("rule2"))
+}
+
+func TestFQDNControllerMinTTL(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	f, _, _, now := newTestFQDNController(controller)
+	f.onDNSResponse(map[string]map[string]time.Duration{
+		"www.example.com": {"10.0.0.1": 0},
+	})
+	assert.Equal(t, now.Add(minDNSTTL), f.dnsCache["www.example.com"]["10.0.0.1"])
+}
+
+func TestFQDNControllerQueries(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	f, _, _, now := newTestFQDNController(controller)
+	query := dnsQueryKey{podIP: "2.2.2.2", podPort: 40000, id: 1}
+
+	// A response is only trusted if it answers a query, once.
+	assert.False(t, f.takeDNSQuery(query))
+	f.onDNSQuery(query)
+	assert.False(t, f.takeDNSQuery(dnsQueryKey{podIP: "2.2.2.2", podPort: 40000, id: 2}))
+	assert.False(t, f.takeDNSQuery(dnsQueryKey{podIP: "2.2.2.2", podPort: 40001, id: 1}))
+	assert.True(t, f.takeDNSQuery(query))
+	assert.False(t, f.takeDNSQuery(query))
+
+	// The queries which are not answered in time are forgotten.
+	f.onDNSQuery(query)
+	*now = now.Add(dnsQueryTimeout)
+	f.removeExpiredRecords()
+	assert.Empty(t, f.dnsQueries)
+	assert.False(t, f.takeDNSQuery(query))
+}
+
+func newDNSMessage(t *testing.T, id uint16, response bool) []byte {
+	builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id, Response: response, RCode: dnsmessage.RCodeSuccess})
+	require.NoError(t, builder.StartQuestions())
+	require.NoError(t, builder.Question(dnsmessage.Question{
+		Name:  dnsmessage.MustNewName("www.example.com."),
+		Type:  dnsmessage.TypeA,
+		Class: dnsmessage.ClassINET,
+	}))
+	if response {
+		require.NoError(t, builder.StartAnswers())
+		require.NoError(t, builder.AResource(
+			dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName("www.example.com."), Class: dnsmessage.ClassINET, TTL: 60},
+			dnsmessage.AResource{A: [4]byte{10, 0, 0, 1}},
+		))
+	}
+	data, err := builder.Finish()
+	require.NoError(t, err)
+	return data
+}
+
+func TestHandleDNSPacket(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	f, mockOFClient, _, _ := newTestFQDNController(controller)
+	c := &Controller{ofClient: mockOFClient, ifaceStore: f.ifaceStore, fqdnController: f}
+	podIP, dnsServerIP := net.ParseIP("2.2.2.2"), testDNSServerIPs[0]
+	podMAC, _ := net.ParseMAC("aa:bb:cc:dd:ee:ff")
+	gwMAC, _ := net.ParseMAC("aa:bb:cc:dd:ee:00")
+	newPacket := func(srcIP, dstIP net.IP, srcPort, dstPort uint16, data []byte) (*ofctrl.PacketIn, *protocol.IPv4) {
+		ipPacket := &protocol.IPv4{
+			NWSrc: srcIP,
+			NWDst: dstIP,
+			Data:  &protocol.UDP{PortSrc: srcPort, PortDst: dstPort, Data: data},
+		}
+		return &ofctrl.PacketIn{Data: protocol.Ethernet{HWSrc: gwMAC, HWDst: podMAC, Data: ipPacket}}, ipPacket
+	}
+
+	// A forged response which does not answer any query is delivered, but
+	// its records are not trusted.
+	response := newDNSMessage(t, 1, true)
+	mockOFClient.EXPECT().SendUDPPacketOut(gwMAC.String(), podMAC.String(), dnsServerIP.String(), podIP.String(), uint32(1), nil, uint16(53), uint16(40000), response)
+	require.NoError(t, c.handleDNSPacket(newPacket(dnsServerIP, podIP, 53, 40000, response)))
+	assert.Empty(t, f.dnsCache)
+
+	// The records of the response to a query are trusted.
+	require.NoError(t, c.handleDNSPacket(newPacket(podIP, dnsServerIP, 40000, 53, newDNSMessage(t, 1, false))))
+	mockOFClient.EXPECT().SendUDPPacketOut(gwMAC.String(), podMAC.String(), dnsServerIP.String(), podIP.String(), uint32(1), nil, uint16(53), uint16(40000), response)
+	require.NoError(t, c.handleDNSPacket(newPacket(dnsServerIP, podIP, 53, 40000, response)))
+	assert.Contains(t, f.dnsCache["www.example.com"], "10.0.0.1")
+}
+
+func TestHandleDNSPacketRateLimit(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	f, mockOFClient, _, _ := newTestFQDNController(controller)
+	f.packetInLimiter = rate.NewLimiter(0, 1)
+	c := &Controller{ofClient: mockOFClient, ifaceStore: f.ifaceStore, fqdnController: f}
+	podIP, dnsServerIP := net.ParseIP("2.2.2.2"), testDNSServerIPs[0]
+	for i := uint16(1); i <= 2; i++ {
+		ipPacket := &protocol.IPv4{
+			NWSrc: podIP,
+			NWDst: dnsServerIP,
+			Data:  &protocol.UDP{PortSrc: 40000, PortDst: 53, Data: newDNSMessage(t, i, false)},
+		}
+		require.NoError(t, c.handleDNSPacket(&ofctrl.PacketIn{}, ipPacket))
+	}
+	// The packets exceeding the rate limit are dropped.
+	assert.Len(t, f.dnsQueries, 1)
+}
@@ -17,6 +17,7 @@ package networkpolicy
 import (
 	"context"
 	"fmt"
+	"net"
 	"sync"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
 	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
 	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+	"github.com/vmware-tanzu/antrea/pkg/features"
 )
 
 const (
@@ -69,24 +71,63 @@ type Controller struct {
 	// reconciler provides interfaces to reconcile the desired state of
 	// NetworkPolicy rules with the actual state of Openflow entries.
 	reconciler Reconciler
+	// ofClient is used to send the packets which answer the packets rejected
+	// by Antrea-native policy rules, and to get the rules matching the packets
+	// sent to the controller.
+	ofClient openflow.Client
+	// ifaceStore is used to get the names of the local Pods in audit logs.
+	ifaceStore interfacestore.InterfaceStore
+	// auditLogger writes the audit logs of Antrea-native policy rules. It is
+	// created when the first packet needs to be logged.
+	auditLogger *auditLogger
+	// statusManager reports the realization status of Antrea-native policies
+	// to antrea-controller.
+	statusManager *statusController
+	// fqdnController maintains the IPs of the FQDNs used in the egress rules
+	// of Antrea-native policies.
+	fqdnController *fqdnController
+	// statsCollector reports the traffic stats of the NetworkPolicies to
+	// antrea-controller. It is nil if the NetworkPolicyStats feature is
+	// disabled.
+	statsCollector *statsCollector
+	// policyStateStore persists the state of ruleCache, so that it can be
+	// restored when the agent restarts while antrea-controller is
+	// unavailable. It is nil if no state file is configured.
+	policyStateStore *policyStateStore
 
 	networkPolicyWatcher  *watcher
 	appliedToGroupWatcher *watcher
 	addressGroupWatcher   *watcher
 }
 
-// NewNetworkPolicyController returns a new *Controller.
+// NewNetworkPolicyController returns a new *Controller. The received
+// NetworkPolicies are persisted to policyStateFile, unless it is empty. The
+// FQDNs of the rules are resolved with the DNS responses of the servers with
+// the given IPs.
 func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 	ofClient openflow.Client,
 	ifaceStore interfacestore.InterfaceStore,
 	nodeName string,
-	podUpdates <-chan v1beta1.PodReference) *Controller {
+	podUpdates <-chan v1beta1.PodReference,
+	policyStateFile string,
+	dnsServerIPs []net.IP) *Controller {
 	c := &Controller{
 		antreaClientProvider: antreaClientGetter,
 		queue:                workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "networkpolicyrule"),
 		reconciler:           newReconciler(ofClient, ifaceStore),
+		ofClient:             ofClient,
+		ifaceStore:           ifaceStore,
 	}
 	c.ruleCache = newRuleCache(c.enqueueRule, podUpdates)
+	if policyStateFile != "" {
+		c.policyStateStore = newPolicyStateStore(policyStateFile, c.ruleCache)
+		c.ruleCache.stateChangeHandler = c.policyStateStore.markDirty
+	}
+	c.statusManager = newStatusController(antreaClientGetter, nodeName, c.ruleCache)
+	c.fqdnController = newFQDNController(ofClient, ifaceStore, dnsServerIPs, c.enqueueRule)
+	if features.DefaultFeatureGate.Enabled(features.NetworkPolicyStats) {
+		c.statsCollector = newStatsCollector(antreaClientGetter, nodeName, ofClient, c.reconciler)
+	}
 
 	// Use nodeName to filter resources when watching resources.
 	options := metav1.ListOptions{
@@ -108,6 +149,7 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 				return fmt.Errorf("cannot convert to *v1beta1.NetworkPolicy: %v", obj)
 			}
 			c.ruleCache.AddNetworkPolicy(policy)
+			c.statusManager.Resync(policy.UID)
 			klog.Infof("NetworkPolicy %s/%s applied to Pods on this Node", policy.Namespace, policy.Name)
 			return nil
 		},
@@ -117,6 +159,8 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 				return fmt.Errorf("cannot convert to *v1beta1.NetworkPolicy: %v", obj)
 			}
 			c.ruleCache.UpdateNetworkPolicy(policy)
+			// The generation may have changed while the rules stay the same.
+			c.statusManager.Resync(policy.UID)
 			return nil
 		},
 		DeleteFunc: func(obj runtime.Object) error {
@@ -125,6 +169,7 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 				return fmt.Errorf("cannot convert to *v1beta1.NetworkPolicy: %v", obj)
 			}
 			c.ruleCache.DeleteNetworkPolicy(policy)
+			c.statusManager.Resync(policy.UID)
 			klog.Infof("NetworkPolicy %s/%s no longer applied to Pods on this Node", policy.Namespace, policy.Name)
 			return nil
 		},
@@ -139,6 +184,9 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 				klog.Infof("NetworkPolicy %s/%s applied to Pods on this Node", policies[i].Namespace, policies[i].Name)
 			}
 			c.ruleCache.ReplaceNetworkPolicies(policies)
+			for _, policy := range policies {
+				c.statusManager.Resync(policy.UID)
+			}
 			return nil
 		},
 	}
@@ -287,6 +335,15 @@ func (c *Controller) GetControllerConnectionStatus() bool {
 // and NetworkPolicies, and spawns workers that reconciles NetworkPolicy rules.
 // Run will not return until stopCh is closed.
 func (c *Controller) Run(stopCh <-chan struct{}) error {
+	if c.policyStateStore != nil {
+		// Enforce the last known NetworkPolicies until the watchers receive
+		// the current ones from antrea-controller.
+		if err := c.policyStateStore.restore(); err != nil {
+			klog.Warningf("Failed to restore NetworkPolicy state, ignoring it: %v", err)
+		}
+		go c.policyStateStore.Run(stopCh)
+	}
+
 	// Use NonSlidingUntil so that normal reconnection (disconnected after
 	// running a while) can reconnect immediately while abnormal reconnection
 	// won't be too aggressive.
@@ -297,6 +354,11 @@ func (c *Controller) Run(stopCh <-chan struct{}) error {
 	for i := 0; i < defaultWorkers; i++ {
 		go wait.Until(c.worker, time.Second, stopCh)
 	}
+	go c.statusManager.Run(stopCh)
+	go c.fqdnController.Run(stopCh)
+	if c.statsCollector != nil {
+		go c.statsCollector.Run(stopCh)
+	}
 
 	<-stopCh
 	return nil
@@ -334,12 +396,25 @@ func (c *Controller) syncRule(key string) error {
 		klog.V(4).Infof("Finished syncing rule %q. (%v)", key, time.Since(startTime))
 	}()
 
+	// The DNS responses waiting for the rule to be updated with the IPs they
+	// contain are delivered once this sync is done.
+	waiters := c.fqdnController.takeRuleSyncWaiters(key)
+	defer func() {
+		for _, waiter := range waiters {
+			waiter.Done()
+		}
+	}()
+
 	rule, exists, completed := c.ruleCache.GetCompletedRule(key)
 	if !exists {
 		klog.V(2).Infof("Rule %v had been deleted, removing its flows", key)
+		if err := c.fqdnController.deleteRule(key); err != nil {
+			return err
+		}
 		if err := c.reconciler.Forget(key); err != nil {
 			return err
 		}
+		c.statusManager.DeleteRuleRealization(key)
 		return nil
 	}
 	// If the rule is not complete, we can simply skip it as it will be marked as dirty
@@ -348,10 +423,17 @@ func (c *Controller) syncRule(key string) error {
 		klog.V(2).Infof("Rule %v was not complete, skipping", key)
 		return nil
 	}
-	if err := c.reconciler.Reconcile(rule); err != nil {
-		return err
+	if len(rule.To.FQDNs) > 0 {
+		ips, err := c.fqdnController.setRule(key, rule.To.FQDNs, rule.Pods)
+		if err != nil {
+			c.statusManager.SetRuleRealization(key, rule.PolicyUID, err)
+			return err
+		}
+		rule.ToFQDNIPs = ips
 	}
-	return nil
+	err := c.reconciler.Reconcile(rule)
+	c.statusManager.SetRuleRealization(key, rule.PolicyUID, err)
+	return err
 }
 
 func (c *Controller) handleErr(err error, key interface{}) {
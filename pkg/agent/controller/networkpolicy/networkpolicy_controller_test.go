@@ -44,7 +44,7 @@ func (g *antreaClientGetter) GetAntreaClient() (versioned.Interface, error) {
 func newTestController() (*Controller, *fake.Clientset, *mockReconciler) {
 	clientset := &fake.Clientset{}
 	ch := make(chan v1beta1.PodReference, 100)
-	controller := NewNetworkPolicyController(&antreaClientGetter{clientset}, nil, nil, "node1", ch)
+	controller := NewNetworkPolicyController(&antreaClientGetter{clientset}, nil, nil, "node1", ch, "", nil)
 	reconciler := newMockReconciler()
 	controller.reconciler = reconciler
 	return controller, clientset, reconciler
@@ -56,6 +56,7 @@ func newTestController() (*Controller, *fake.Clientset, *mockReconciler) {
 type mockReconciler struct {
 	sync.Mutex
 	lastRealized map[string]*CompletedRule
+	ofRules      map[uint32]*CompletedRule
 	updated      chan string
 	deleted      chan string
 }
@@ -63,6 +64,7 @@ type mockReconciler struct {
 func newMockReconciler() *mockReconciler {
 	return &mockReconciler{
 		lastRealized: map[string]*CompletedRule{},
+		ofRules:      map[uint32]*CompletedRule{},
 		updated:      make(chan string, 10),
 		deleted:      make(chan string, 10),
 	}
@@ -84,6 +86,13 @@ func (r *mockReconciler) Forget(ruleID string) error {
 	return nil
 }
 
+func (r *mockReconciler) GetRuleByFlowID(ofID uint32) (*CompletedRule, bool) {
+	r.Lock()
+	defer r.Unlock()
+	rule, exists := r.ofRules[ofID]
+	return rule, exists
+}
+
 func (r *mockReconciler) getLastRealized(ruleID string) (*CompletedRule, bool) {
 	r.Lock()
 	defer r.Unlock()
@@ -0,0 +1,118 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/contiv/libOpenflow/protocol"
+	"github.com/contiv/ofnet/ofctrl"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/k8s"
+	binding "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
+)
+
+// HandlePacketIn handles the packets sent to the controller by Antrea-native
+// policy rules, which are the packets rejected by the rules and the packets
+// matching the rules with audit logging enabled. The conjunction ID of the
+// matching rule is loaded in CNPConjIDReg. It also handles the DNS queries and
+// responses intercepted for the FQDN-based rules.
+func (c *Controller) HandlePacketIn(pktIn *ofctrl.PacketIn) error {
+	if pktIn.Data.Ethertype != protocol.IPv4_MSG {
+		return nil
+	}
+	ipPacket, ok := pktIn.Data.Data.(*protocol.IPv4)
+	if !ok {
+		return errors.New("invalid IPv4 packet")
+	}
+	matchers := pktIn.GetMatches()
+	// Traceflow packets are injected by antrea-agent and do not need to be
+	// answered or logged.
+	if matchers.GetMatchByName(fmt.Sprintf("%s%d", binding.NxmFieldReg, openflow.TraceflowReg)) != nil {
+		return nil
+	}
+	if binding.TableIDType(pktIn.TableId) == openflow.L2ForwardingOutTable {
+		return c.handleDNSPacket(pktIn, ipPacket)
+	}
+	match := matchers.GetMatchByName(fmt.Sprintf("%s%d", binding.NxmFieldReg, openflow.CNPConjIDReg))
+	if match == nil {
+		return errors.New("conjunction ID not found")
+	}
+	reg, ok := match.GetValue().(*ofctrl.NXRegister)
+	if !ok {
+		return errors.New("conjunction ID cannot be got")
+	}
+	conjID := reg.Data
+
+	ruleAction := c.ofClient.GetRuleActionFromConjunction(conjID)
+	if c.ofClient.GetRuleLoggingFromConjunction(conjID) {
+		c.logPacket(pktIn, ipPacket, conjID, ruleAction)
+	}
+	if ruleAction != nil && *ruleAction == secv1alpha1.RuleActionReject {
+		return c.rejectRequest(pktIn, ipPacket)
+	}
+	return nil
+}
+
+// logPacket writes an audit log entry for the given packet, which matches the
+// Antrea-native policy rule with the given conjunction ID.
+func (c *Controller) logPacket(pktIn *ofctrl.PacketIn, ipPacket *protocol.IPv4, conjID uint32, ruleAction *secv1alpha1.RuleAction) {
+	if c.auditLogger == nil {
+		logger, err := newAuditLogger(getLogDir())
+		if err != nil {
+			klog.Errorf("Failed to set up audit logger: %v", err)
+			return
+		}
+		c.auditLogger = logger
+	}
+	entry := &auditLogEntry{
+		direction: v1beta1.DirectionOut,
+		srcIP:     ipPacket.NWSrc.String(),
+		dstIP:     ipPacket.NWDst.String(),
+		protocol:  ipPacket.Protocol,
+		length:    ipPacket.Length,
+	}
+	if binding.TableIDType(pktIn.TableId) == openflow.CNPIngressRuleTable {
+		entry.direction = v1beta1.DirectionIn
+	}
+	entry.policyName, entry.policyNamespace = c.ofClient.GetPolicyFromConjunction(conjID)
+	if ruleAction != nil {
+		entry.action = *ruleAction
+	}
+	switch transport := ipPacket.Data.(type) {
+	case *protocol.TCP:
+		entry.srcPort, entry.dstPort = transport.PortSrc, transport.PortDst
+	case *protocol.UDP:
+		entry.srcPort, entry.dstPort = transport.PortSrc, transport.PortDst
+	}
+	entry.srcPod = c.getPodName(entry.srcIP)
+	entry.dstPod = c.getPodName(entry.dstIP)
+	c.auditLogger.log(entry)
+}
+
+// getPodName returns the namespaced name of the local Pod with the given IP,
+// or an empty string if the IP is not the IP of a local Pod.
+func (c *Controller) getPodName(ip string) string {
+	iface, ok := c.ifaceStore.GetInterfaceByIP(ip)
+	if !ok || iface.ContainerInterfaceConfig == nil {
+		return ""
+	}
+	return k8s.NamespacedName(iface.PodNamespace, iface.PodName)
+}
@@ -25,35 +25,65 @@ import (
 )
 
 const (
-	PriorityBottomCNP     = uint16(100)
-	InitialPriorityOffset = uint16(130)
-	InitialPriorityZones  = 100
-	DefaultTierStart      = uint16(13100)
+	PriorityBottomCNP = uint16(100)
+	// PriorityTopCNP is the highest OF priority which can be assigned to the
+	// rules of Antrea-native policies.
+	PriorityTopCNP       = uint16(64900)
+	InitialPriorityZones = 100
 )
 
 // priorityAssigner is a struct that maintains the current boundaries of
-// all ClusterNetworkPolicy categories/priorities and rule priorities, and knows
-// how to re-assign priorities if certain section overflows.
+// all Tiers, ClusterNetworkPolicy categories/priorities and rule priorities,
+// and knows how to re-assign priorities if certain section overflows.
+// The OF priority space is evenly split between the known Tiers, and the
+// section of each Tier is split into numPriorityZones priorityZones.
 type priorityAssigner struct {
 	// priorityMap maintains the current mapping between a known CNP priority to OF priority.
 	priorityMap map[types.Priority]uint16
-	// priorityOffset stores the current size of a priority zone.
-	// When tiering is introduced, each tier will keep its own priorityOffset.
+	// tierPriorities stores the sorted priorities of all the Tiers known to
+	// the priorityAssigner. The Tier with the lowest priority value is
+	// assigned the highest OF priorities.
+	tierPriorities []int32
+	// priorityOffset stores the current size of a priority zone. It shrinks
+	// when new Tiers are known to the priorityAssigner.
 	priorityOffset uint16
-	// numPriorityZones stores the current number of numPriorityZones (within the default tier).
-	// When tiering is introduced, each tier will keep its own numPriorityZones.
+	// numPriorityZones stores the current number of numPriorityZones within each Tier.
 	numPriorityZones int32
 }
 
 func newPriorityAssigner() *priorityAssigner {
 	pa := &priorityAssigner{
 		priorityMap:      map[types.Priority]uint16{},
-		priorityOffset:   InitialPriorityOffset,
 		numPriorityZones: InitialPriorityZones,
 	}
 	return pa
 }
 
+// isTierKnown returns true if the Tier with the given priority is known to
+// the priorityAssigner.
+func (pa *priorityAssigner) isTierKnown(tierPriority int32) bool {
+	idx := pa.getTierIndex(tierPriority)
+	return idx < len(pa.tierPriorities) && pa.tierPriorities[idx] == tierPriority
+}
+
+// getTierIndex returns the index of the Tier with the given priority among
+// the known Tiers.
+func (pa *priorityAssigner) getTierIndex(tierPriority int32) int {
+	return sort.Search(len(pa.tierPriorities), func(i int) bool {
+		return pa.tierPriorities[i] >= tierPriority
+	})
+}
+
+// getTierSize returns the size of the OF priority section of each Tier.
+func (pa *priorityAssigner) getTierSize() uint16 {
+	return (PriorityTopCNP - PriorityBottomCNP) / uint16(len(pa.tierPriorities))
+}
+
+// getTierStart returns the starting OF priority of the Tier with the given priority.
+func (pa *priorityAssigner) getTierStart(tierPriority int32) uint16 {
+	return PriorityTopCNP - pa.getTierSize()*uint16(pa.getTierIndex(tierPriority))
+}
+
 // getPriorityZoneIndex returns the priorityZone index for the given priority.
 // It maps policyPriority [0.0-1.0) to 0, [1.0-2.0) to 1 and so on so forth.
 // policyPriorities over 99.0 will be mapped to zone 99, without zone expansion for now.
@@ -68,14 +98,15 @@ func (pa *priorityAssigner) getPriorityZoneIndex(p types.Priority) int32 {
 // getPriorityZoneStart returns the starting OF priority for the priorityZone for the input.
 func (pa *priorityAssigner) getPriorityZoneStart(p types.Priority) uint16 {
 	priorityIndex := pa.getPriorityZoneIndex(p)
-	return DefaultTierStart - pa.priorityOffset*uint16(priorityIndex)
+	return pa.getTierStart(p.TierPriority) - pa.priorityOffset*uint16(priorityIndex)
 }
 
 // getPriorityZoneSize returns the size of the priorityZone for the input.
+// The last priorityZone of a Tier extends to the end of the Tier section.
 func (pa *priorityAssigner) getPriorityZoneSize(p types.Priority) uint16 {
-	zoneStart := pa.getPriorityZoneStart(p)
-	if zoneStart-pa.priorityOffset < PriorityBottomCNP {
-		return zoneStart - PriorityBottomCNP
+	if pa.getPriorityZoneIndex(p) == pa.numPriorityZones-1 {
+		tierEnd := pa.getTierStart(p.TierPriority) - pa.getTierSize()
+		return pa.getPriorityZoneStart(p) - tierEnd
 	}
 	return pa.priorityOffset
 }
@@ -83,6 +114,9 @@ func (pa *priorityAssigner) getPriorityZoneSize(p types.Priority) uint16 {
 // sortPriorities sorts a list of priorities.
 func (pa *priorityAssigner) sortPriorities(priorities []types.Priority) {
 	sort.Slice(priorities, func(i, j int) bool {
+		if priorities[i].TierPriority != priorities[j].TierPriority {
+			return priorities[i].TierPriority < priorities[j].TierPriority
+		}
 		if priorities[i].PolicyPriority == priorities[j].PolicyPriority {
 			return priorities[i].RulePriority < priorities[j].RulePriority
 		}
@@ -116,8 +150,8 @@ func (pa *priorityAssigner) syncPriorityZone(p types.Priority) (*uint16, map[uin
 	affected := pa.getIndexSamePriorityZone(p)
 	if uint16(len(affected)) > pa.getPriorityZoneSize(p) {
 		// TODO: Dynamically adjust priorityZone size to handle overflow
-		return nil, priorityUpdates, fmt.Errorf("priorityZone for [%v %v) has overflowed",
-			pa.getPriorityZoneIndex(p), pa.getPriorityZoneIndex(p)+1)
+		return nil, priorityUpdates, fmt.Errorf("priorityZone for [%v %v) of Tier %v has overflowed",
+			pa.getPriorityZoneIndex(p), pa.getPriorityZoneIndex(p)+1, p.TierPriority)
 	}
 	for offset, priority := range affected {
 		computedPriority := pa.getPriorityZoneStart(p) - uint16(offset)
@@ -134,14 +168,69 @@ func (pa *priorityAssigner) syncPriorityZone(p types.Priority) (*uint16, map[uin
 	return &newPriority, priorityUpdates, nil
 }
 
+// syncTiers adds the Tier of the input priority to the known Tiers, which
+// shrinks the OF priority section of each Tier. It computes the new expected
+// OF priorities for all known priorities and the input priority, and returns
+// installed priorities that need to be re-assigned.
+func (pa *priorityAssigner) syncTiers(p types.Priority) (*uint16, map[uint16]uint16, error) {
+	tierIndex := pa.getTierIndex(p.TierPriority)
+	tierPriorities := make([]int32, 0, len(pa.tierPriorities)+1)
+	tierPriorities = append(tierPriorities, pa.tierPriorities[:tierIndex]...)
+	tierPriorities = append(tierPriorities, p.TierPriority)
+	tierPriorities = append(tierPriorities, pa.tierPriorities[tierIndex:]...)
+	// Compute the new layout with a separate priorityAssigner, so that the
+	// current one is left unchanged if any priorityZone overflows.
+	newPA := &priorityAssigner{
+		priorityMap:      map[types.Priority]uint16{},
+		tierPriorities:   tierPriorities,
+		numPriorityZones: pa.numPriorityZones,
+	}
+	newPA.priorityOffset = newPA.getTierSize() / uint16(newPA.numPriorityZones)
+
+	priorities := []types.Priority{p}
+	for k := range pa.priorityMap {
+		priorities = append(priorities, k)
+	}
+	newPA.sortPriorities(priorities)
+	var zoneStart, offset uint16
+	for i, priority := range priorities {
+		if i == 0 || newPA.getPriorityZoneStart(priority) != zoneStart {
+			zoneStart = newPA.getPriorityZoneStart(priority)
+			offset = 0
+		}
+		if offset >= newPA.getPriorityZoneSize(priority) {
+			return nil, map[uint16]uint16{}, fmt.Errorf("priorityZone for [%v %v) of Tier %v has overflowed",
+				newPA.getPriorityZoneIndex(priority), newPA.getPriorityZoneIndex(priority)+1, priority.TierPriority)
+		}
+		newPA.priorityMap[priority] = zoneStart - offset
+		offset++
+	}
+
+	priorityUpdates := map[uint16]uint16{}
+	for priority, oldOFPriority := range pa.priorityMap {
+		if computedPriority := newPA.priorityMap[priority]; computedPriority != oldOFPriority {
+			klog.V(2).Infof("Original priority %d needs to be reassigned %d now", oldOFPriority, computedPriority)
+			priorityUpdates[oldOFPriority] = computedPriority
+		}
+	}
+	newPriority := newPA.priorityMap[p]
+	pa.priorityMap = newPA.priorityMap
+	pa.tierPriorities = newPA.tierPriorities
+	pa.priorityOffset = newPA.priorityOffset
+	return &newPriority, priorityUpdates, nil
+}
+
 // GetOFPriority retrieves the OFPriority for the input Priority to be installed,
 // and returns installed priorities that need to be re-assigned if necessary.
 func (pa *priorityAssigner) GetOFPriority(p types.Priority) (*uint16, map[uint16]uint16, error) {
 	ofPriority, exists := pa.priorityMap[p]
-	if !exists {
-		return pa.syncPriorityZone(p)
+	if exists {
+		return &ofPriority, map[uint16]uint16{}, nil
+	}
+	if !pa.isTierKnown(p.TierPriority) {
+		return pa.syncTiers(p)
 	}
-	return &ofPriority, map[uint16]uint16{}, nil
+	return pa.syncPriorityZone(p)
 }
 
 // Release removes the priority that currently corresponds to the input OFPriority from the priorityMap.
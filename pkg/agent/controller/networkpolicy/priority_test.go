@@ -0,0 +1,82 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/types"
+)
+
+func TestGetOFPriorityWithinTier(t *testing.T) {
+	pa := newPriorityAssigner()
+	p1 := types.Priority{TierPriority: 250, PolicyPriority: 1, RulePriority: 0}
+	p2 := types.Priority{TierPriority: 250, PolicyPriority: 1, RulePriority: 1}
+	p3 := types.Priority{TierPriority: 250, PolicyPriority: 5, RulePriority: 0}
+
+	ofPriority1, updates, err := pa.GetOFPriority(p1)
+	require.NoError(t, err)
+	assert.Empty(t, updates)
+	ofPriority3, updates, err := pa.GetOFPriority(p3)
+	require.NoError(t, err)
+	assert.Empty(t, updates)
+	ofPriority2, updates, err := pa.GetOFPriority(p2)
+	require.NoError(t, err)
+	assert.Empty(t, updates)
+
+	assert.Greater(t, *ofPriority1, *ofPriority2)
+	assert.Greater(t, *ofPriority2, *ofPriority3)
+
+	// Retrieving a known priority again must return the same OF priority.
+	ofPriority, updates, err := pa.GetOFPriority(p2)
+	require.NoError(t, err)
+	assert.Empty(t, updates)
+	assert.Equal(t, *ofPriority2, *ofPriority)
+}
+
+func TestGetOFPriorityAcrossTiers(t *testing.T) {
+	pa := newPriorityAssigner()
+	appPriority := types.Priority{TierPriority: 250, PolicyPriority: 1, RulePriority: 0}
+	emergencyPriority := types.Priority{TierPriority: 50, PolicyPriority: 90, RulePriority: 0}
+
+	ofAppPriority, _, err := pa.GetOFPriority(appPriority)
+	require.NoError(t, err)
+	assert.Equal(t, PriorityTopCNP, *ofAppPriority)
+
+	// Adding a Tier with higher precedence must move the existing rules of the
+	// lower precedence Tier below it.
+	ofEmergencyPriority, updates, err := pa.GetOFPriority(emergencyPriority)
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	newAppPriority, ok := updates[*ofAppPriority]
+	require.True(t, ok)
+	assert.Greater(t, *ofEmergencyPriority, newAppPriority)
+	assert.Equal(t, newAppPriority, pa.priorityMap[appPriority])
+	assert.Equal(t, []int32{50, 250}, pa.tierPriorities)
+	assert.Greater(t, newAppPriority, PriorityBottomCNP)
+}
+
+func TestReleaseOFPriority(t *testing.T) {
+	pa := newPriorityAssigner()
+	p := types.Priority{TierPriority: 250, PolicyPriority: 1, RulePriority: 0}
+	ofPriority, _, err := pa.GetOFPriority(p)
+	require.NoError(t, err)
+	require.NoError(t, pa.Release(*ofPriority))
+	_, exists := pa.priorityMap[p]
+	assert.False(t, exists)
+}
@@ -52,6 +52,10 @@ type Reconciler interface {
 
 	// Forget cleanups the actual state of Openflow entries of the specified ruleID.
 	Forget(ruleID string) error
+
+	// GetRuleByFlowID returns the rule whose Openflow entries are identified
+	// by the provided conjunction ID.
+	GetRuleByFlowID(ofID uint32) (*CompletedRule, bool)
 }
 
 // servicesHash is used to uniquely identify Services.
@@ -153,6 +157,10 @@ type reconciler struct {
 	// It's a mapping from ruleID to *lastRealized.
 	lastRealizeds sync.Map
 
+	// ofRules caches the rule each installed Openflow rule belongs to.
+	// It's a mapping from ofID to *CompletedRule.
+	ofRules sync.Map
+
 	// idAllocator provides interfaces to allocate and release uint32 id.
 	idAllocator *idAllocator
 
@@ -214,6 +222,12 @@ func (r *reconciler) getOFPriority(rule *CompletedRule) (*uint16, error) {
 		return nil, nil
 	}
 	p := types.Priority{PolicyPriority: *rule.PolicyPriority, RulePriority: rule.Priority}
+	// TierPriority is always set by antrea-controller for Antrea-native
+	// policies. If it is not, all such policies are considered to be in the
+	// same Tier.
+	if rule.TierPriority != nil {
+		p.TierPriority = *rule.TierPriority
+	}
 	ofPriority, priorityUpdates, err := r.priorityAssigner.GetOFPriority(p)
 	if err != nil {
 		return nil, err
@@ -252,12 +266,13 @@ func (r *reconciler) add(rule *CompletedRule, ofPriority *uint16) error {
 			ofPorts := r.getPodOFPorts(pods)
 			lastRealized.podOFPorts[svcHash] = ofPorts
 			ofRuleByServicesMap[svcHash] = &types.PolicyRule{
-				Direction: v1beta1.DirectionIn,
-				From:      append(from1, from2...),
-				To:        ofPortsToOFAddresses(ofPorts),
-				Service:   filterUnresolvablePort(servicesMap[svcHash]),
-				Action:    rule.Action,
-				Priority:  ofPriority,
+				Direction:     v1beta1.DirectionIn,
+				From:          append(from1, from2...),
+				To:            ofPortsToOFAddresses(ofPorts),
+				Service:       filterUnresolvablePort(servicesMap[svcHash]),
+				Action:        rule.Action,
+				Priority:      ofPriority,
+				EnableLogging: rule.EnableLogging,
 			}
 		}
 	} else {
@@ -265,15 +280,16 @@ func (r *reconciler) add(rule *CompletedRule, ofPriority *uint16) error {
 		lastRealized.podIPs = ips
 		from := ipsToOFAddresses(ips)
 
-		podsByServicesMap, servicesMap := groupPodsByServices(rule.Services, rule.ToAddresses)
+		podsByServicesMap, servicesMap := groupToAddressesByServices(rule)
 		for svcHash, pods := range podsByServicesMap {
 			ofRuleByServicesMap[svcHash] = &types.PolicyRule{
-				Direction: v1beta1.DirectionOut,
-				From:      from,
-				To:        podsToOFAddresses(pods),
-				Service:   filterUnresolvablePort(servicesMap[svcHash]),
-				Action:    rule.Action,
-				Priority:  ofPriority,
+				Direction:     v1beta1.DirectionOut,
+				From:          from,
+				To:            podsToOFAddresses(pods),
+				Service:       filterUnresolvablePort(servicesMap[svcHash]),
+				Action:        rule.Action,
+				Priority:      ofPriority,
+				EnableLogging: rule.EnableLogging,
 			}
 		}
 
@@ -281,19 +297,20 @@ func (r *reconciler) add(rule *CompletedRule, ofPriority *uint16) error {
 		// We must ensure there is at least one PolicyRule, otherwise the Pods won't be
 		// isolated, so we create a PolicyRule with the original services if it doesn't exist.
 		// If there are IPBlocks or Pods that cannot resolve any named port, they will share
-		// this PolicyRule. ClusterNetworkPolicy does not need this default isolation.
-		if !rule.isAntreaNetworkPolicyRule() || len(rule.To.IPBlocks) > 0 {
+		// this PolicyRule. Antrea-native policy rules do not need this default isolation.
+		if needsDefaultEgressRule(rule) {
 			svcHash := hashServices(rule.Services)
 			ofRule, exists := ofRuleByServicesMap[svcHash]
 			// Create a new Openflow rule if the group doesn't exist.
 			if !exists {
 				ofRule = &types.PolicyRule{
-					Direction: v1beta1.DirectionOut,
-					From:      from,
-					To:        []types.Address{},
-					Service:   filterUnresolvablePort(rule.Services),
-					Action:    rule.Action,
-					Priority:  nil,
+					Direction:     v1beta1.DirectionOut,
+					From:          from,
+					To:            []types.Address{},
+					Service:       filterUnresolvablePort(rule.Services),
+					Action:        rule.Action,
+					Priority:      ofPriority,
+					EnableLogging: rule.EnableLogging,
 				}
 				ofRuleByServicesMap[svcHash] = ofRule
 			}
@@ -302,6 +319,9 @@ func (r *reconciler) add(rule *CompletedRule, ofPriority *uint16) error {
 				to := ipBlocksToOFAddresses(rule.To.IPBlocks)
 				ofRule.To = append(ofRule.To, to...)
 			}
+			if len(rule.ToFQDNIPs) > 0 {
+				ofRule.To = append(ofRule.To, ipsToOFAddresses(rule.ToFQDNIPs)...)
+			}
 		}
 	}
 
@@ -314,6 +334,7 @@ func (r *reconciler) add(rule *CompletedRule, ofPriority *uint16) error {
 		}
 		// Record ofID only if its Openflow is installed successfully.
 		lastRealized.ofIDs[svcHash] = ofID
+		r.ofRules.Store(ofID, lastRealized.CompletedRule)
 	}
 
 	return nil
@@ -345,18 +366,20 @@ func (r *reconciler) update(lastRealized *lastRealized, newRule *CompletedRule,
 			// Install a new Openflow rule if this group doesn't exist, otherwise do incremental update.
 			if !exists {
 				ofRule := &types.PolicyRule{
-					Direction: v1beta1.DirectionIn,
-					From:      append(from1, from2...),
-					To:        ofPortsToOFAddresses(newOFPorts),
-					Service:   filterUnresolvablePort(servicesMap[svcHash]),
-					Action:    newRule.Action,
-					Priority:  ofPriority,
+					Direction:     v1beta1.DirectionIn,
+					From:          append(from1, from2...),
+					To:            ofPortsToOFAddresses(newOFPorts),
+					Service:       filterUnresolvablePort(servicesMap[svcHash]),
+					Action:        newRule.Action,
+					Priority:      ofPriority,
+					EnableLogging: newRule.EnableLogging,
 				}
 				ofID, err := r.installOFRule(ofRule, newRule.PolicyName, newRule.PolicyNamespace)
 				if err != nil {
 					return err
 				}
 				lastRealized.ofIDs[svcHash] = ofID
+				r.ofRules.Store(ofID, newRule)
 			} else {
 				addedTo := ofPortsToOFAddresses(newOFPorts.Difference(lastRealized.podOFPorts[svcHash]))
 				deletedTo := ofPortsToOFAddresses(lastRealized.podOFPorts[svcHash].Difference(newOFPorts))
@@ -374,34 +397,48 @@ func (r *reconciler) update(lastRealized *lastRealized, newRule *CompletedRule,
 		addedFrom := ipsToOFAddresses(newIPs.Difference(lastRealized.podIPs))
 		deletedFrom := ipsToOFAddresses(lastRealized.podIPs.Difference(newIPs))
 
-		podsByServicesMap, servicesMap := groupPodsByServices(newRule.Services, newRule.ToAddresses)
+		podsByServicesMap, servicesMap := groupToAddressesByServices(newRule)
 		// Same as the process in `add`, we must ensure the group for the original services is present
 		// in podsByServicesMap, so that this group won't be removed and its "From" will be updated.
-		svcHash := hashServices(newRule.Services)
-		if _, exists := podsByServicesMap[svcHash]; !exists {
-			podsByServicesMap[svcHash] = v1beta1.NewGroupMemberPodSet()
-			servicesMap[svcHash] = newRule.Services
+		// The IPs resolved from FQDNs are held by this group.
+		defaultSvcHash := hashServices(newRule.Services)
+		if needsDefaultEgressRule(newRule) {
+			if _, exists := podsByServicesMap[defaultSvcHash]; !exists {
+				podsByServicesMap[defaultSvcHash] = v1beta1.NewGroupMemberPodSet()
+				servicesMap[defaultSvcHash] = newRule.Services
+			}
 		}
-		prevPodsByServicesMap, _ := groupPodsByServices(lastRealized.Services, lastRealized.ToAddresses)
+		prevPodsByServicesMap, _ := groupToAddressesByServices(lastRealized.CompletedRule)
 		for svcHash, pods := range podsByServicesMap {
 			ofID, exists := lastRealized.ofIDs[svcHash]
 			if !exists {
 				ofRule := &types.PolicyRule{
-					Direction: v1beta1.DirectionOut,
-					From:      from,
-					To:        podsToOFAddresses(pods),
-					Service:   filterUnresolvablePort(servicesMap[svcHash]),
-					Action:    newRule.Action,
-					Priority:  ofPriority,
+					Direction:     v1beta1.DirectionOut,
+					From:          from,
+					To:            podsToOFAddresses(pods),
+					Service:       filterUnresolvablePort(servicesMap[svcHash]),
+					Action:        newRule.Action,
+					Priority:      ofPriority,
+					EnableLogging: newRule.EnableLogging,
+				}
+				if svcHash == defaultSvcHash {
+					ofRule.To = append(ofRule.To, ipsToOFAddresses(newRule.ToFQDNIPs)...)
 				}
 				ofID, err := r.installOFRule(ofRule, newRule.PolicyName, newRule.PolicyNamespace)
 				if err != nil {
 					return err
 				}
 				lastRealized.ofIDs[svcHash] = ofID
+				r.ofRules.Store(ofID, newRule)
 			} else {
 				addedTo := podsToOFAddresses(pods.Difference(prevPodsByServicesMap[svcHash]))
 				deletedTo := podsToOFAddresses(prevPodsByServicesMap[svcHash].Difference(pods))
+				if svcHash == defaultSvcHash {
+					// Removing the IPs of expired DNS records only denies new
+					// connections, established ones skip the rule tables.
+					addedTo = append(addedTo, ipsToOFAddresses(newRule.ToFQDNIPs.Difference(lastRealized.ToFQDNIPs))...)
+					deletedTo = append(deletedTo, ipsToOFAddresses(lastRealized.ToFQDNIPs.Difference(newRule.ToFQDNIPs))...)
+				}
 				if err := r.updateOFRule(ofID, addedFrom, addedTo, deletedFrom, deletedTo, ofPriority); err != nil {
 					return err
 				}
@@ -484,6 +521,7 @@ func (r *reconciler) uninstallOFRule(ofID uint32) error {
 			r.priorityAssigner.Release(uint16(priorityNum))
 		}
 	}
+	r.ofRules.Delete(ofID)
 	if err := r.idAllocator.release(ofID); err != nil {
 		// This should never happen. If it does, it is a programming error.
 		klog.Errorf("Error releasing Openflow ID for ofRule %v: %v", ofID, err)
@@ -516,6 +554,16 @@ func (r *reconciler) Forget(ruleID string) error {
 	return nil
 }
 
+// GetRuleByFlowID returns the rule whose Openflow entries are identified by
+// the provided conjunction ID, if any.
+func (r *reconciler) GetRuleByFlowID(ofID uint32) (*CompletedRule, bool) {
+	value, exists := r.ofRules.Load(ofID)
+	if !exists {
+		return nil, false
+	}
+	return value.(*CompletedRule), true
+}
+
 func (r *reconciler) getPodOFPorts(pods v1beta1.GroupMemberPodSet) sets.Int32 {
 	ofPorts := sets.NewInt32()
 	for _, pod := range pods {
@@ -550,6 +598,15 @@ func (r *reconciler) getPodIPs(pods v1beta1.GroupMemberPodSet) sets.String {
 	return ips
 }
 
+// needsDefaultEgressRule returns whether a PolicyRule with the original
+// services must be installed for the provided egress rule, even if no
+// "ToAddresses" resolve to them. K8s NetworkPolicy rules need it to isolate the
+// Pods, and rules with IPBlocks or FQDNs need it to hold the IPBlock addresses
+// or the IPs resolved from the FQDNs, which cannot resolve named ports.
+func needsDefaultEgressRule(rule *CompletedRule) bool {
+	return !rule.isAntreaNetworkPolicyRule() || len(rule.To.IPBlocks) > 0 || len(rule.To.FQDNs) > 0
+}
+
 // groupPodsByServices groups the provided Pods based on their services resolving result.
 // A map of servicesHash to the Pod groups and a map of servicesHash to the services resolving result will be returned.
 func groupPodsByServices(services []v1beta1.Service, pods v1beta1.GroupMemberPodSet) (map[servicesHash]v1beta1.GroupMemberPodSet, map[servicesHash][]v1beta1.Service) {
@@ -571,6 +628,39 @@ func groupPodsByServices(services []v1beta1.Service, pods v1beta1.GroupMemberPod
 	return podsByServicesMap, servicesMap
 }
 
+// groupToAddressesByServices groups the "ToAddresses" of the provided egress
+// rule based on the services they must be matched with. If the rule refers to
+// Services, its addresses are the ClusterIPs and the Endpoints of the Services,
+// each of which is matched with its own ports. Otherwise the services of the
+// rule are resolved for each address.
+func groupToAddressesByServices(rule *CompletedRule) (map[servicesHash]v1beta1.GroupMemberPodSet, map[servicesHash][]v1beta1.Service) {
+	if len(rule.To.ToServices) == 0 {
+		return groupPodsByServices(rule.Services, rule.ToAddresses)
+	}
+	podsByServicesMap := map[servicesHash]v1beta1.GroupMemberPodSet{}
+	servicesMap := map[servicesHash][]v1beta1.Service{}
+	for _, address := range rule.ToAddresses {
+		// An address without any port cannot receive the traffic of the
+		// Services, it must not match all ports.
+		if len(address.Ports) == 0 {
+			continue
+		}
+		services := make([]v1beta1.Service, 0, len(address.Ports))
+		for i := range address.Ports {
+			protocol := address.Ports[i].Protocol
+			port := intstr.FromInt(int(address.Ports[i].Port))
+			services = append(services, v1beta1.Service{Protocol: &protocol, Port: &port})
+		}
+		svcHash := hashServices(services)
+		if _, exists := podsByServicesMap[svcHash]; !exists {
+			podsByServicesMap[svcHash] = v1beta1.NewGroupMemberPodSet()
+			servicesMap[svcHash] = services
+		}
+		podsByServicesMap[svcHash].Insert(address)
+	}
+	return podsByServicesMap, servicesMap
+}
+
 func ofPortsToOFAddresses(ofPorts sets.Int32) []types.Address {
 	// Must not return nil as it means not restricted by addresses in Openflow implementation.
 	addresses := make([]types.Address, 0, len(ofPorts))
@@ -582,3 +582,326 @@ func TestReconcilerUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcilerReconcileAntreaNetworkPolicyNamedPort(t *testing.T) {
+	ifaceStore := interfacestore.NewInterfaceStore()
+	ifaceStore.AddInterface(&interfacestore.InterfaceConfig{
+		InterfaceName:            util.GenerateContainerInterfaceName("pod1", "ns1", "container1"),
+		IP:                       net.ParseIP("2.2.2.2"),
+		ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod1", PodNamespace: "ns1", ContainerID: "container1"},
+		OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: 1},
+	})
+	ifaceStore.AddInterface(&interfacestore.InterfaceConfig{
+		InterfaceName:            util.GenerateContainerInterfaceName("pod3", "ns1", "container3"),
+		IP:                       net.ParseIP("3.3.3.3"),
+		ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod3", PodNamespace: "ns1", ContainerID: "container3"},
+		OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: 3},
+	})
+	ipNet1 := newCIDR("10.10.0.0/16")
+	ipBlock1 := v1beta1.IPBlock{
+		CIDR: v1beta1.IPNet{IP: v1beta1.IPAddress(ipNet1.IP), PrefixLength: 16},
+	}
+	port8080 := intstr.FromInt(8080)
+	serviceTCP8080 := v1beta1.Service{Protocol: &protocolTCP, Port: &port8080}
+	// The Endpoints of ExternalEntities are converted to addresses carrying
+	// their named ports by the ruleCache.
+	externalEntityAddresses := v1beta1.NewGroupMemberPodSet(
+		&v1beta1.GroupMemberPod{IP: v1beta1.IPAddress(net.ParseIP("1.1.1.1")), Ports: []v1beta1.NamedPort{{Name: "http", Protocol: v1beta1.ProtocolTCP, Port: 80}}},
+		&v1beta1.GroupMemberPod{IP: v1beta1.IPAddress(net.ParseIP("1.1.1.2")), Ports: []v1beta1.NamedPort{{Name: "http", Protocol: v1beta1.ProtocolTCP, Port: 8080}}},
+		&v1beta1.GroupMemberPod{IP: v1beta1.IPAddress(net.ParseIP("1.1.1.3"))},
+	)
+	policyPriority := float64(1)
+	tierPriority := int32(250)
+	ofPriority, _, _ := newPriorityAssigner().GetOFPriority(types.Priority{TierPriority: tierPriority, PolicyPriority: policyPriority})
+
+	tests := []struct {
+		name            string
+		args            *CompletedRule
+		expectedOFRules []*types.PolicyRule
+	}{
+		{
+			"ingress-rule-with-diff-named-port",
+			&CompletedRule{
+				rule: &rule{
+					ID:             "ingress-rule",
+					Direction:      v1beta1.DirectionIn,
+					Services:       []v1beta1.Service{serviceHTTP},
+					PolicyPriority: &policyPriority,
+					TierPriority:   &tierPriority,
+				},
+				FromAddresses: addressGroup1,
+				Pods:          appliedToGroupWithDiffContainerPort,
+			},
+			[]*types.PolicyRule{
+				{
+					Direction: v1beta1.DirectionIn,
+					From:      ipsToOFAddresses(sets.NewString("1.1.1.1")),
+					To:        ofPortsToOFAddresses(sets.NewInt32(1)),
+					Service:   []v1beta1.Service{serviceTCP80},
+					Priority:  ofPriority,
+				},
+				{
+					Direction: v1beta1.DirectionIn,
+					From:      ipsToOFAddresses(sets.NewString("1.1.1.1")),
+					To:        ofPortsToOFAddresses(sets.NewInt32(3)),
+					Service:   []v1beta1.Service{serviceTCP443},
+					Priority:  ofPriority,
+				},
+			},
+		},
+		{
+			"egress-rule-with-diff-named-port",
+			&CompletedRule{
+				rule: &rule{
+					ID:             "egress-rule",
+					Direction:      v1beta1.DirectionOut,
+					Services:       []v1beta1.Service{serviceHTTP},
+					PolicyPriority: &policyPriority,
+					TierPriority:   &tierPriority,
+				},
+				ToAddresses: externalEntityAddresses,
+				Pods:        appliedToGroup1,
+			},
+			[]*types.PolicyRule{
+				{
+					Direction: v1beta1.DirectionOut,
+					From:      ipsToOFAddresses(sets.NewString("2.2.2.2")),
+					To:        ipsToOFAddresses(sets.NewString("1.1.1.1")),
+					Service:   []v1beta1.Service{serviceTCP80},
+					Priority:  ofPriority,
+				},
+				{
+					Direction: v1beta1.DirectionOut,
+					From:      ipsToOFAddresses(sets.NewString("2.2.2.2")),
+					To:        ipsToOFAddresses(sets.NewString("1.1.1.2")),
+					Service:   []v1beta1.Service{serviceTCP8080},
+					Priority:  ofPriority,
+				},
+				// The address which cannot resolve the named port must not
+				// match any port.
+				{
+					Direction: v1beta1.DirectionOut,
+					From:      ipsToOFAddresses(sets.NewString("2.2.2.2")),
+					To:        ipsToOFAddresses(sets.NewString("1.1.1.3")),
+					Service:   []v1beta1.Service{},
+					Priority:  ofPriority,
+				},
+			},
+		},
+		{
+			"egress-rule-with-named-port-and-ipblock",
+			&CompletedRule{
+				rule: &rule{
+					ID:             "egress-rule",
+					Direction:      v1beta1.DirectionOut,
+					To:             v1beta1.NetworkPolicyPeer{IPBlocks: []v1beta1.IPBlock{ipBlock1}},
+					Services:       []v1beta1.Service{serviceHTTP},
+					PolicyPriority: &policyPriority,
+					TierPriority:   &tierPriority,
+				},
+				ToAddresses: v1beta1.NewGroupMemberPodSet(
+					&v1beta1.GroupMemberPod{IP: v1beta1.IPAddress(net.ParseIP("1.1.1.1")), Ports: []v1beta1.NamedPort{{Name: "http", Protocol: v1beta1.ProtocolTCP, Port: 80}}},
+				),
+				Pods: appliedToGroup1,
+			},
+			[]*types.PolicyRule{
+				{
+					Direction: v1beta1.DirectionOut,
+					From:      ipsToOFAddresses(sets.NewString("2.2.2.2")),
+					To:        ipsToOFAddresses(sets.NewString("1.1.1.1")),
+					Service:   []v1beta1.Service{serviceTCP80},
+					Priority:  ofPriority,
+				},
+				// IPBlocks cannot resolve named ports, but they must be
+				// installed with the priority of the rule.
+				{
+					Direction: v1beta1.DirectionOut,
+					From:      ipsToOFAddresses(sets.NewString("2.2.2.2")),
+					To:        []types.Address{openflow.NewIPNetAddress(*ipNet1)},
+					Service:   []v1beta1.Service{},
+					Priority:  ofPriority,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+			mockOFClient := openflowtest.NewMockClient(controller)
+			for _, ofRule := range tt.expectedOFRules {
+				mockOFClient.EXPECT().InstallPolicyRuleFlows(gomock.Any(), gomock.Eq(ofRule), "", "")
+			}
+			r := newReconciler(mockOFClient, ifaceStore)
+			if err := r.Reconcile(tt.args); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestReconcilerUpdateAntreaNetworkPolicyNamedPort(t *testing.T) {
+	ifaceStore := interfacestore.NewInterfaceStore()
+	ifaceStore.AddInterface(&interfacestore.InterfaceConfig{
+		InterfaceName:            util.GenerateContainerInterfaceName("pod1", "ns1", "container1"),
+		IP:                       net.ParseIP("2.2.2.2"),
+		ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod1", PodNamespace: "ns1", ContainerID: "container1"},
+		OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: 1},
+	})
+	port8080 := intstr.FromInt(8080)
+	serviceTCP8080 := v1beta1.Service{Protocol: &protocolTCP, Port: &port8080}
+	address1 := &v1beta1.GroupMemberPod{IP: v1beta1.IPAddress(net.ParseIP("1.1.1.1")), Ports: []v1beta1.NamedPort{{Name: "http", Protocol: v1beta1.ProtocolTCP, Port: 80}}}
+	address2 := &v1beta1.GroupMemberPod{IP: v1beta1.IPAddress(net.ParseIP("1.1.1.2")), Ports: []v1beta1.NamedPort{{Name: "http", Protocol: v1beta1.ProtocolTCP, Port: 8080}}}
+	policyPriority := float64(1)
+	tierPriority := int32(250)
+	ofPriority, _, _ := newPriorityAssigner().GetOFPriority(types.Priority{TierPriority: tierPriority, PolicyPriority: policyPriority})
+	newRule := func(addresses v1beta1.GroupMemberPodSet) *CompletedRule {
+		return &CompletedRule{
+			rule: &rule{
+				ID:             "egress-rule",
+				Direction:      v1beta1.DirectionOut,
+				Services:       []v1beta1.Service{serviceHTTP},
+				PolicyPriority: &policyPriority,
+				TierPriority:   &tierPriority,
+			},
+			ToAddresses: addresses,
+			Pods:        appliedToGroup1,
+		}
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	mockOFClient := openflowtest.NewMockClient(controller)
+	// Only the PolicyRules of the resolved ports are installed, Antrea-native
+	// policy rules do not need a PolicyRule with the original services.
+	mockOFClient.EXPECT().InstallPolicyRuleFlows(gomock.Any(), gomock.Eq(&types.PolicyRule{
+		Direction: v1beta1.DirectionOut,
+		From:      ipsToOFAddresses(sets.NewString("2.2.2.2")),
+		To:        ipsToOFAddresses(sets.NewString("1.1.1.1")),
+		Service:   []v1beta1.Service{serviceTCP80},
+		Priority:  ofPriority,
+	}), "", "")
+	mockOFClient.EXPECT().InstallPolicyRuleFlows(gomock.Any(), gomock.Eq(&types.PolicyRule{
+		Direction: v1beta1.DirectionOut,
+		From:      ipsToOFAddresses(sets.NewString("2.2.2.2")),
+		To:        ipsToOFAddresses(sets.NewString("1.1.1.2")),
+		Service:   []v1beta1.Service{serviceTCP8080},
+		Priority:  ofPriority,
+	}), "", "")
+	r := newReconciler(mockOFClient, ifaceStore)
+	if err := r.Reconcile(newRule(v1beta1.NewGroupMemberPodSet(address1))); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := r.Reconcile(newRule(v1beta1.NewGroupMemberPodSet(address1, address2))); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+}
+
+func TestReconcilerUpdateFQDNIPs(t *testing.T) {
+	ifaceStore := interfacestore.NewInterfaceStore()
+	ifaceStore.AddInterface(&interfacestore.InterfaceConfig{
+		InterfaceName:            util.GenerateContainerInterfaceName("pod1", "ns1", "container1"),
+		IP:                       net.ParseIP("2.2.2.2"),
+		ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod1", PodNamespace: "ns1", ContainerID: "container1"},
+		OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: 1},
+	})
+	policyPriority := float64(1)
+	tierPriority := int32(250)
+	ofPriority, _, _ := newPriorityAssigner().GetOFPriority(types.Priority{TierPriority: tierPriority, PolicyPriority: policyPriority})
+	newRule := func(fqdnIPs sets.String) *CompletedRule {
+		return &CompletedRule{
+			rule: &rule{
+				ID:             "egress-rule",
+				Direction:      v1beta1.DirectionOut,
+				To:             v1beta1.NetworkPolicyPeer{FQDNs: []string{"*.example.com"}},
+				PolicyPriority: &policyPriority,
+				TierPriority:   &tierPriority,
+			},
+			ToAddresses: v1beta1.NewGroupMemberPodSet(),
+			Pods:        appliedToGroup1,
+			ToFQDNIPs:   fqdnIPs,
+		}
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	mockOFClient := openflowtest.NewMockClient(controller)
+	// The PolicyRule with the original services is installed to hold the IPs
+	// resolved from the FQDNs.
+	mockOFClient.EXPECT().InstallPolicyRuleFlows(gomock.Any(), gomock.Eq(&types.PolicyRule{
+		Direction: v1beta1.DirectionOut,
+		From:      ipsToOFAddresses(sets.NewString("2.2.2.2")),
+		To:        ipsToOFAddresses(sets.NewString("10.0.0.1")),
+		Priority:  ofPriority,
+	}), "", "")
+	mockOFClient.EXPECT().AddPolicyRuleAddress(gomock.Any(), types.DstAddress, ipsToOFAddresses(sets.NewString("10.0.0.2")), gomock.Any())
+	mockOFClient.EXPECT().DeletePolicyRuleAddress(gomock.Any(), types.DstAddress, ipsToOFAddresses(sets.NewString("10.0.0.1")), gomock.Any())
+	r := newReconciler(mockOFClient, ifaceStore)
+	if err := r.Reconcile(newRule(sets.NewString("10.0.0.1"))); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := r.Reconcile(newRule(sets.NewString("10.0.0.2"))); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+}
+
+func TestReconcilerAddToServices(t *testing.T) {
+	ifaceStore := interfacestore.NewInterfaceStore()
+	ifaceStore.AddInterface(&interfacestore.InterfaceConfig{
+		InterfaceName:            util.GenerateContainerInterfaceName("pod1", "ns1", "container1"),
+		IP:                       net.ParseIP("2.2.2.2"),
+		ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod1", PodNamespace: "ns1", ContainerID: "container1"},
+		OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: 1},
+	})
+	policyPriority := float64(1)
+	tierPriority := int32(250)
+	ofPriority, _, _ := newPriorityAssigner().GetOFPriority(types.Priority{TierPriority: tierPriority, PolicyPriority: policyPriority})
+	port8443 := intstr.FromInt(8443)
+	clusterIPMember := &v1beta1.GroupMemberPod{
+		IP:    v1beta1.IPAddress(net.ParseIP("10.96.0.10")),
+		Ports: []v1beta1.NamedPort{{Name: "https", Protocol: v1beta1.ProtocolTCP, Port: 443}},
+	}
+	endpointMember := &v1beta1.GroupMemberPod{
+		IP:    v1beta1.IPAddress(net.ParseIP("1.1.1.1")),
+		Ports: []v1beta1.NamedPort{{Name: "https", Protocol: v1beta1.ProtocolTCP, Port: 8443}},
+	}
+	rule := &CompletedRule{
+		rule: &rule{
+			ID:        "egress-rule",
+			Direction: v1beta1.DirectionOut,
+			To: v1beta1.NetworkPolicyPeer{
+				AddressGroups: []string{"addressGroup1"},
+				ToServices:    []v1beta1.ServiceReference{{Name: "svc1", Namespace: "ns1"}},
+			},
+			PolicyPriority: &policyPriority,
+			TierPriority:   &tierPriority,
+		},
+		// The address without any port must not be allowed.
+		ToAddresses: v1beta1.NewGroupMemberPodSet(clusterIPMember, endpointMember, newAddressGroupMember("1.1.1.2")),
+		Pods:        appliedToGroup1,
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	mockOFClient := openflowtest.NewMockClient(controller)
+	// Each address is only allowed on its own ports, and no default rule is
+	// installed for the egress rule.
+	mockOFClient.EXPECT().InstallPolicyRuleFlows(gomock.Any(), gomock.Eq(&types.PolicyRule{
+		Direction: v1beta1.DirectionOut,
+		From:      ipsToOFAddresses(sets.NewString("2.2.2.2")),
+		To:        ipsToOFAddresses(sets.NewString("10.96.0.10")),
+		Service:   []v1beta1.Service{serviceTCP443},
+		Priority:  ofPriority,
+	}), "", "")
+	mockOFClient.EXPECT().InstallPolicyRuleFlows(gomock.Any(), gomock.Eq(&types.PolicyRule{
+		Direction: v1beta1.DirectionOut,
+		From:      ipsToOFAddresses(sets.NewString("2.2.2.2")),
+		To:        ipsToOFAddresses(sets.NewString("1.1.1.1")),
+		Service:   []v1beta1.Service{{Protocol: &protocolTCP, Port: &port8443}},
+		Priority:  ofPriority,
+	}), "", "")
+	r := newReconciler(mockOFClient, ifaceStore)
+	if err := r.Reconcile(rule); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"errors"
+	"net"
+
+	"github.com/contiv/libOpenflow/protocol"
+	"github.com/contiv/ofnet/ofctrl"
+	"k8s.io/klog"
+)
+
+const (
+	tcpFlagSYN = 0x02
+	tcpFlagRST = 0x04
+	tcpFlagACK = 0x10
+
+	icmpTypeDestUnreachable = 3
+	// icmpCodeAdminProhibited is the code of the ICMP destination unreachable
+	// messages which indicate that the communication is administratively
+	// prohibited.
+	icmpCodeAdminProhibited = 13
+	// icmpOriginalPayloadLength is the number of bytes of the original
+	// payload included in an ICMP error message.
+	icmpOriginalPayloadLength = 8
+)
+
+// rejectRequest answers the given packet rejected by an Antrea-native policy
+// rule. A rejected TCP packet is answered with a TCP RST packet, and other
+// rejected packets are answered with an ICMP destination unreachable packet.
+// The answer is sent out of the OVS port which received the rejected packet.
+func (c *Controller) rejectRequest(pktIn *ofctrl.PacketIn, ipPacket *protocol.IPv4) error {
+	matchers := pktIn.GetMatches()
+	inPort, err := getInPort(matchers)
+	if err != nil {
+		return err
+	}
+	tunnelDstIP := getTunnelSrcIP(matchers)
+	// The answer is sent from the destination of the rejected packet to its
+	// source.
+	srcMAC := pktIn.Data.HWDst.String()
+	dstMAC := pktIn.Data.HWSrc.String()
+	srcIP := ipPacket.NWDst.String()
+	dstIP := ipPacket.NWSrc.String()
+
+	switch ipPacket.Protocol {
+	case protocol.Type_TCP:
+		tcpPacket, ok := ipPacket.Data.(*protocol.TCP)
+		if !ok {
+			return errors.New("invalid TCP packet")
+		}
+		if tcpPacket.Code&tcpFlagRST != 0 {
+			return nil
+		}
+		seqNum, ackNum, flags := getTCPResetFields(tcpPacket)
+		klog.V(2).Infof("Rejecting TCP packet from %s:%d to %s:%d", dstIP, tcpPacket.PortSrc, srcIP, tcpPacket.PortDst)
+		return c.ofClient.SendTCPPacketOut(srcMAC, dstMAC, srcIP, dstIP, inPort, tunnelDstIP,
+			tcpPacket.PortDst, tcpPacket.PortSrc, seqNum, ackNum, flags)
+	case protocol.Type_ICMP:
+		icmpPacket, ok := ipPacket.Data.(*protocol.ICMP)
+		if !ok {
+			return errors.New("invalid ICMP packet")
+		}
+		// ICMP error messages must not be sent in response to ICMP error
+		// messages.
+		if isICMPError(icmpPacket.Type) {
+			return nil
+		}
+	}
+	icmpData, err := getICMPErrorData(ipPacket)
+	if err != nil {
+		return err
+	}
+	klog.V(2).Infof("Rejecting IP packet from %s to %s with protocol %d", dstIP, srcIP, ipPacket.Protocol)
+	return c.ofClient.SendICMPPacketOut(srcMAC, dstMAC, srcIP, dstIP, inPort, tunnelDstIP,
+		icmpTypeDestUnreachable, icmpCodeAdminProhibited, icmpData)
+}
+
+// getTCPResetFields returns the sequence number, the acknowledgement number
+// and the flags of the TCP RST packet which resets the connection of the given
+// TCP packet, as described in RFC 793.
+func getTCPResetFields(tcpPacket *protocol.TCP) (uint32, uint32, uint8) {
+	if tcpPacket.Code&tcpFlagACK != 0 {
+		return tcpPacket.AckNum, 0, tcpFlagRST
+	}
+	ackNum := tcpPacket.SeqNum + uint32(len(tcpPacket.Data))
+	if tcpPacket.Code&tcpFlagSYN != 0 {
+		ackNum++
+	}
+	return 0, ackNum, tcpFlagRST | tcpFlagACK
+}
+
+// getICMPErrorData returns the data of an ICMP error message for the given
+// packet, which is composed of 4 unused bytes, the IP header of the packet and
+// the first 8 bytes of its payload.
+func getICMPErrorData(ipPacket *protocol.IPv4) ([]byte, error) {
+	ipHeader := *ipPacket
+	ipHeader.Data = nil
+	headerData, err := ipHeader.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	var payloadData []byte
+	if ipPacket.Data != nil {
+		payloadData, err = ipPacket.Data.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		if len(payloadData) > icmpOriginalPayloadLength {
+			payloadData = payloadData[:icmpOriginalPayloadLength]
+		}
+	}
+	data := make([]byte, 4, 4+len(headerData)+len(payloadData))
+	data = append(data, headerData...)
+	data = append(data, payloadData...)
+	return data, nil
+}
+
+// isICMPError returns true if the given ICMP type is the type of an ICMP error
+// message.
+func isICMPError(icmpType uint8) bool {
+	switch icmpType {
+	case 3, 4, 5, 11, 12:
+		return true
+	}
+	return false
+}
+
+func getInPort(matchers *ofctrl.Matchers) (uint32, error) {
+	match := matchers.GetMatchByName("OXM_OF_IN_PORT")
+	if match == nil {
+		return 0, errors.New("in_port field not found")
+	}
+	inPort, ok := match.GetValue().(uint32)
+	if !ok {
+		return 0, errors.New("in_port value cannot be got")
+	}
+	return inPort, nil
+}
+
+// getTunnelSrcIP returns the tunnel source IP of the given packet, or nil if
+// the packet was not received from the tunnel.
+func getTunnelSrcIP(matchers *ofctrl.Matchers) net.IP {
+	match := matchers.GetMatchByName("NXM_NX_TUN_IPV4_SRC")
+	if match == nil {
+		return nil
+	}
+	tunnelSrcIP, ok := match.GetValue().(net.IP)
+	if !ok {
+		return nil
+	}
+	return tunnelSrcIP
+}
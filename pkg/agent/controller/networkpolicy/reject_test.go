@@ -0,0 +1,93 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"net"
+	"testing"
+
+	"github.com/contiv/libOpenflow/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTCPResetFields(t *testing.T) {
+	tests := []struct {
+		name           string
+		tcpPacket      *protocol.TCP
+		expectedSeqNum uint32
+		expectedAckNum uint32
+		expectedFlags  uint8
+	}{
+		{
+			name:           "syn",
+			tcpPacket:      &protocol.TCP{SeqNum: 100, Code: tcpFlagSYN},
+			expectedSeqNum: 0,
+			expectedAckNum: 101,
+			expectedFlags:  tcpFlagRST | tcpFlagACK,
+		},
+		{
+			name:           "ack-with-data",
+			tcpPacket:      &protocol.TCP{SeqNum: 100, AckNum: 200, Code: tcpFlagACK, Data: []byte("data")},
+			expectedSeqNum: 200,
+			expectedAckNum: 0,
+			expectedFlags:  tcpFlagRST,
+		},
+		{
+			name:           "no-ack-with-data",
+			tcpPacket:      &protocol.TCP{SeqNum: 100, Data: []byte("data")},
+			expectedSeqNum: 0,
+			expectedAckNum: 104,
+			expectedFlags:  tcpFlagRST | tcpFlagACK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seqNum, ackNum, flags := getTCPResetFields(tt.tcpPacket)
+			assert.Equal(t, tt.expectedSeqNum, seqNum)
+			assert.Equal(t, tt.expectedAckNum, ackNum)
+			assert.Equal(t, tt.expectedFlags, flags)
+		})
+	}
+}
+
+func TestGetICMPErrorData(t *testing.T) {
+	ipPacket := &protocol.IPv4{
+		Version:  4,
+		IHL:      5,
+		TTL:      64,
+		Protocol: protocol.Type_UDP,
+		NWSrc:    net.ParseIP("10.10.0.1"),
+		NWDst:    net.ParseIP("10.10.0.2"),
+		Data: &protocol.UDP{
+			PortSrc: 10000,
+			PortDst: 53,
+			Data:    []byte("payload which exceeds 8 bytes"),
+		},
+	}
+	data, err := getICMPErrorData(ipPacket)
+	require.NoError(t, err)
+	// 4 unused bytes, the 20 bytes IP header and the first 8 bytes of the
+	// original payload.
+	assert.Len(t, data, 4+20+icmpOriginalPayloadLength)
+	assert.Equal(t, []byte{0, 0, 0, 0}, data[:4])
+}
+
+func TestIsICMPError(t *testing.T) {
+	assert.True(t, isICMPError(icmpTypeDestUnreachable))
+	// ICMP echo request and echo reply.
+	assert.False(t, isICMPError(8))
+	assert.False(t, isICMPError(0))
+}
@@ -0,0 +1,209 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+)
+
+const (
+	// DefaultPolicyStateFile is the default path of the file persisting the
+	// NetworkPolicies received by the agent. /var/run/antrea is mounted from
+	// the host, so the file survives the restarts of the agent.
+	DefaultPolicyStateFile = "/var/run/antrea/networkpolicy/state.json"
+	// policyStateVersion is the version of the format of the state file. A
+	// file with another version is ignored.
+	policyStateVersion = 1
+	// policyStateSyncPeriod is how often the state is persisted when it has
+	// changed.
+	policyStateSyncPeriod = 5 * time.Second
+)
+
+// policyState is the state of ruleCache which is persisted.
+type policyState struct {
+	NetworkPolicies []v1beta1.NetworkPolicy  `json:"networkPolicies,omitempty"`
+	AddressGroups   []v1beta1.AddressGroup   `json:"addressGroups,omitempty"`
+	AppliedToGroups []v1beta1.AppliedToGroup `json:"appliedToGroups,omitempty"`
+}
+
+// policyStateFile is the content of the state file. Checksum is the SHA-256
+// checksum of State, which detects a file truncated or corrupted, e.g. by a
+// crash of the Node while it was written.
+type policyStateFile struct {
+	Version  int             `json:"version"`
+	Checksum string          `json:"checksum"`
+	State    json.RawMessage `json:"state"`
+}
+
+// policyStateStore persists the NetworkPolicies, AddressGroups and
+// AppliedToGroups of ruleCache to a local file, and replays them into ruleCache
+// when the agent starts, so that the local Pods are protected by the last
+// known policies if the agent restarts while antrea-controller is unavailable.
+// The replayed state is replaced by the fresh data received from
+// antrea-controller once the watchers are connected, like on any reconnection
+// of the watchers.
+type policyStateStore struct {
+	path      string
+	ruleCache *ruleCache
+	// dirty is set to 1 when ruleCache has changed since the state was last
+	// persisted.
+	dirty int32
+}
+
+func newPolicyStateStore(path string, ruleCache *ruleCache) *policyStateStore {
+	return &policyStateStore{
+		path:      path,
+		ruleCache: ruleCache,
+	}
+}
+
+// markDirty notifies the store that ruleCache has changed. The state is
+// persisted asynchronously to avoid writing the file for each event.
+func (s *policyStateStore) markDirty() {
+	atomic.StoreInt32(&s.dirty, 1)
+}
+
+// restore replays the persisted state, if any, into ruleCache. It must be
+// called before the watchers are started.
+func (s *policyStateStore) restore() error {
+	state, err := loadPolicyState(s.path)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		klog.Infof("No NetworkPolicy state found in %s", s.path)
+		return nil
+	}
+	addressGroups := make([]*v1beta1.AddressGroup, len(state.AddressGroups))
+	for i := range state.AddressGroups {
+		addressGroups[i] = &state.AddressGroups[i]
+	}
+	appliedToGroups := make([]*v1beta1.AppliedToGroup, len(state.AppliedToGroups))
+	for i := range state.AppliedToGroups {
+		appliedToGroups[i] = &state.AppliedToGroups[i]
+	}
+	policies := make([]*v1beta1.NetworkPolicy, len(state.NetworkPolicies))
+	for i := range state.NetworkPolicies {
+		policies[i] = &state.NetworkPolicies[i]
+	}
+	// Add the groups first so that the rules of the policies are complete
+	// when they are enqueued.
+	s.ruleCache.ReplaceAddressGroups(addressGroups)
+	s.ruleCache.ReplaceAppliedToGroups(appliedToGroups)
+	s.ruleCache.ReplaceNetworkPolicies(policies)
+	klog.Infof("Restored %d NetworkPolicies, %d AddressGroups and %d AppliedToGroups from %s", len(policies), len(addressGroups), len(appliedToGroups), s.path)
+	return nil
+}
+
+// sync persists the state of ruleCache if it has changed.
+func (s *policyStateStore) sync() {
+	if !atomic.CompareAndSwapInt32(&s.dirty, 1, 0) {
+		return
+	}
+	if err := savePolicyState(s.path, s.ruleCache.getPolicyState()); err != nil {
+		klog.Errorf("Failed to persist NetworkPolicy state to %s: %v", s.path, err)
+		// Retry in the next period.
+		s.markDirty()
+	}
+}
+
+// Run persists the state of ruleCache periodically until stopCh is closed.
+func (s *policyStateStore) Run(stopCh <-chan struct{}) {
+	wait.Until(s.sync, policyStateSyncPeriod, stopCh)
+}
+
+// getPolicyState returns the current state of the cache.
+func (c *ruleCache) getPolicyState() *policyState {
+	state := &policyState{
+		AddressGroups:   c.GetAddressGroups(),
+		AppliedToGroups: c.GetAppliedToGroups(),
+	}
+	c.policyMapLock.RLock()
+	defer c.policyMapLock.RUnlock()
+	for _, policy := range c.policyMap {
+		state.NetworkPolicies = append(state.NetworkPolicies, *policy)
+	}
+	return state
+}
+
+// loadPolicyState reads the state from the given file. nil is returned if the
+// file doesn't exist.
+func loadPolicyState(path string) (*policyState, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var file policyStateFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("error decoding state file: %v", err)
+	}
+	if file.Version != policyStateVersion {
+		return nil, fmt.Errorf("unsupported state file version %d, expected %d", file.Version, policyStateVersion)
+	}
+	if checksum := policyStateChecksum(file.State); checksum != file.Checksum {
+		return nil, fmt.Errorf("state file checksum mismatch: got %s, expected %s", checksum, file.Checksum)
+	}
+	var state policyState
+	if err := json.Unmarshal(file.State, &state); err != nil {
+		return nil, fmt.Errorf("error decoding state: %v", err)
+	}
+	return &state, nil
+}
+
+// savePolicyState writes the state to the given file. The file is replaced
+// atomically so that it is never left partially written.
+func savePolicyState(path string, state *policyState) error {
+	stateData, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(&policyStateFile{
+		Version:  policyStateVersion,
+		Checksum: policyStateChecksum(stateData),
+		State:    stateData,
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func policyStateChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,163 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+	"github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/fake"
+)
+
+func TestPolicyStateRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-policy-state")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "networkpolicy", "state.json")
+
+	controller, _, _ := newTestController()
+	policy1 := newNetworkPolicy("policy1", []string{"addressGroup1"}, nil, []string{"appliedToGroup1"}, nil)
+	controller.ruleCache.AddAddressGroup(newAddressGroup("addressGroup1", []v1beta1.GroupMemberPod{*newAddressGroupMember("1.1.1.1")}))
+	controller.ruleCache.AddAppliedToGroup(newAppliedToGroup("appliedToGroup1", []v1beta1.GroupMemberPod{*newAppliedToGroupMember("pod1", "ns1")}))
+	controller.ruleCache.AddNetworkPolicy(policy1)
+	store := newPolicyStateStore(path, controller.ruleCache)
+	store.markDirty()
+	store.sync()
+
+	// The state is replayed into the cache of a restarted agent.
+	restartedController, _, _ := newTestController()
+	restartedStore := newPolicyStateStore(path, restartedController.ruleCache)
+	require.NoError(t, restartedStore.restore())
+	assert.Equal(t, 1, restartedController.GetNetworkPolicyNum())
+	assert.Equal(t, 1, restartedController.GetAddressGroupNum())
+	assert.Equal(t, 1, restartedController.GetAppliedToGroupNum())
+	assert.Equal(t, policy1, restartedController.GetNetworkPolicy(policy1.Name, policy1.Namespace))
+	ruleID := toRule(&policy1.Rules[0], policy1).ID
+	completedRule, exists, completed := restartedController.ruleCache.GetCompletedRule(ruleID)
+	require.True(t, exists)
+	require.True(t, completed)
+	assert.True(t, completedRule.FromAddresses.Has(newAddressGroupMember("1.1.1.1")))
+	assert.True(t, completedRule.Pods.Has(newAppliedToGroupMember("pod1", "ns1")))
+	// The rule is enqueued to be reconciled.
+	assert.Equal(t, 1, restartedController.queue.Len())
+}
+
+func TestPolicyStateMarkDirty(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-policy-state")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	ch := make(chan v1beta1.PodReference, 100)
+	controller := NewNetworkPolicyController(&antreaClientGetter{&fake.Clientset{}}, nil, nil, "node1", ch, filepath.Join(dir, "state.json"), nil)
+	store := controller.policyStateStore
+	require.NotNil(t, store)
+
+	// The changes of the cache which make no rule dirty must be persisted too.
+	tests := []struct {
+		name   string
+		change func(c *ruleCache)
+	}{
+		{
+			name: "add-unreferenced-address-group",
+			change: func(c *ruleCache) {
+				c.AddAddressGroup(newAddressGroup("addressGroup1", []v1beta1.GroupMemberPod{*newAddressGroupMember("1.1.1.1")}))
+			},
+		},
+		{
+			name: "add-unreferenced-applied-to-group",
+			change: func(c *ruleCache) {
+				c.AddAppliedToGroup(newAppliedToGroup("appliedToGroup1", []v1beta1.GroupMemberPod{*newAppliedToGroupMember("pod1", "ns1")}))
+			},
+		},
+		{
+			name: "add-policy-without-rules",
+			change: func(c *ruleCache) {
+				policy := newNetworkPolicy("policy1", nil, nil, []string{"appliedToGroup1"}, nil)
+				policy.Rules = nil
+				c.AddNetworkPolicy(policy)
+			},
+		},
+		{
+			name: "delete-address-group",
+			change: func(c *ruleCache) {
+				c.DeleteAddressGroup(newAddressGroup("addressGroup1", nil))
+			},
+		},
+		{
+			name: "replace-applied-to-groups",
+			change: func(c *ruleCache) {
+				c.ReplaceAppliedToGroups(nil)
+			},
+		},
+		{
+			name: "delete-policy-without-rules",
+			change: func(c *ruleCache) {
+				c.DeleteNetworkPolicy(newNetworkPolicy("policy1", nil, nil, nil, nil))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&store.dirty, 0)
+			tt.change(controller.ruleCache)
+			assert.Equal(t, int32(1), atomic.LoadInt32(&store.dirty))
+			assert.Equal(t, 0, controller.queue.Len(), "Expected no dirty rule")
+		})
+	}
+}
+
+func TestLoadPolicyState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-policy-state")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "state.json")
+
+	state, err := loadPolicyState(path)
+	require.NoError(t, err)
+	assert.Nil(t, state, "Expected no state when the file doesn't exist")
+
+	expectedState := &policyState{AddressGroups: []v1beta1.AddressGroup{*newAddressGroup("addressGroup1", []v1beta1.GroupMemberPod{*newAddressGroupMember("1.1.1.1")})}}
+	require.NoError(t, savePolicyState(path, expectedState))
+	state, err = loadPolicyState(path)
+	require.NoError(t, err)
+	assert.Equal(t, expectedState, state)
+
+	writeStateFile := func(file *policyStateFile) {
+		data, err := json.Marshal(file)
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(path, data, 0600))
+	}
+	stateData, err := json.Marshal(expectedState)
+	require.NoError(t, err)
+
+	writeStateFile(&policyStateFile{Version: policyStateVersion, Checksum: policyStateChecksum([]byte("{}")), State: stateData})
+	_, err = loadPolicyState(path)
+	assert.Error(t, err, "Expected error when the checksum doesn't match")
+
+	writeStateFile(&policyStateFile{Version: policyStateVersion + 1, Checksum: policyStateChecksum(stateData), State: stateData})
+	_, err = loadPolicyState(path)
+	assert.Error(t, err, "Expected error when the version is not supported")
+
+	require.NoError(t, ioutil.WriteFile(path, stateData[:len(stateData)/2], 0600))
+	_, err = loadPolicyState(path)
+	assert.Error(t, err, "Expected error when the file is truncated")
+}
@@ -0,0 +1,213 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent"
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	agenttypes "github.com/vmware-tanzu/antrea/pkg/agent/types"
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+)
+
+// statsCollectInterval is the interval at which the traffic stats of the
+// NetworkPolicy rules are collected and reported to antrea-controller.
+const statsCollectInterval = 60 * time.Second
+
+// statsControlInterface reports the traffic stats of the NetworkPolicies on
+// this Node to antrea-controller.
+type statsControlInterface interface {
+	ReportNodeStatsSummary(summary *v1beta1.NodeStatsSummary) error
+}
+
+type nodeStatsSummaryControl struct {
+	antreaClientProvider agent.AntreaClientProvider
+}
+
+func (c *nodeStatsSummaryControl) ReportNodeStatsSummary(summary *v1beta1.NodeStatsSummary) error {
+	antreaClient, err := c.antreaClientProvider.GetAntreaClient()
+	if err != nil {
+		return err
+	}
+	_, err = antreaClient.NetworkingV1beta1().NodeStatsSummaries().Create(context.TODO(), summary, metav1.CreateOptions{})
+	return err
+}
+
+// ofRuleStats is the traffic stats of an Openflow rule the last time they were
+// reported, along with the rule it belonged to at that time.
+type ofRuleStats struct {
+	ruleID string
+	metric agenttypes.RuleMetric
+}
+
+// statsCollector periodically reads the traffic stats of the Openflow rules
+// realizing the NetworkPolicy rules, aggregates them per NetworkPolicy, and
+// reports the increments since the last report to antrea-controller.
+type statsCollector struct {
+	nodeName string
+	ofClient openflow.Client
+	// reconciler provides the rule each Openflow rule belongs to.
+	reconciler Reconciler
+	// statsControlInterface is used to report the stats.
+	statsControlInterface statsControlInterface
+	// lastStats stores the stats of each Openflow rule when they were last
+	// reported successfully. It is only accessed by the single worker.
+	lastStats map[uint32]*ofRuleStats
+}
+
+func newStatsCollector(antreaClientProvider agent.AntreaClientProvider, nodeName string, ofClient openflow.Client, reconciler Reconciler) *statsCollector {
+	return &statsCollector{
+		nodeName:              nodeName,
+		ofClient:              ofClient,
+		reconciler:            reconciler,
+		statsControlInterface: &nodeStatsSummaryControl{antreaClientProvider: antreaClientProvider},
+		lastStats:             map[uint32]*ofRuleStats{},
+	}
+}
+
+// Run collects and reports the stats periodically until stopCh is closed.
+func (c *statsCollector) Run(stopCh <-chan struct{}) {
+	wait.Until(func() {
+		if err := c.collect(); err != nil {
+			klog.Errorf("Failed to report NetworkPolicy stats: %v", err)
+		}
+	}, statsCollectInterval, stopCh)
+}
+
+// policyKind is the kind of the policy a rule belongs to, which determines the
+// list its stats are reported in.
+type policyKind int
+
+const (
+	k8sNetworkPolicy policyKind = iota
+	clusterNetworkPolicy
+	antreaNetworkPolicy
+)
+
+func kindOfRule(rule *CompletedRule) policyKind {
+	if !rule.isAntreaNetworkPolicyRule() {
+		return k8sNetworkPolicy
+	}
+	if rule.PolicyNamespace == "" {
+		return clusterNetworkPolicy
+	}
+	return antreaNetworkPolicy
+}
+
+// collect computes the increments of the stats since the last successful
+// report, and reports them if any.
+func (c *statsCollector) collect() error {
+	metrics := c.ofClient.NetworkPolicyMetrics()
+	curStats := make(map[uint32]*ofRuleStats, len(metrics))
+	policyStats := map[types.UID]*v1beta1.NetworkPolicyStats{}
+	policyKinds := map[types.UID]policyKind{}
+	for ofID, metric := range metrics {
+		rule, exists := c.reconciler.GetRuleByFlowID(ofID)
+		if !exists {
+			// The Openflow rule is being installed or uninstalled.
+			continue
+		}
+		curStats[ofID] = &ofRuleStats{ruleID: rule.ID, metric: *metric}
+		delta := *metric
+		// The stats are counted from scratch if the ofID has been reallocated
+		// to another rule or the flows have been reinstalled.
+		if last, exists := c.lastStats[ofID]; exists && last.ruleID == rule.ID && last.metric.Packets <= metric.Packets {
+			delta.Packets -= last.metric.Packets
+			delta.Bytes -= last.metric.Bytes
+			delta.Sessions -= last.metric.Sessions
+		}
+		if delta.Packets == 0 {
+			continue
+		}
+		stats, exists := policyStats[rule.PolicyUID]
+		if !exists {
+			stats = &v1beta1.NetworkPolicyStats{Name: rule.PolicyName, Namespace: rule.PolicyNamespace}
+			policyStats[rule.PolicyUID] = stats
+			policyKinds[rule.PolicyUID] = kindOfRule(rule)
+		}
+		addTrafficStats(&stats.TrafficStats, &delta)
+		// Stats of individual rules are only reported for Antrea-native
+		// policies, whose rules are identified by their priorities.
+		if rule.isAntreaNetworkPolicyRule() {
+			addRuleTrafficStats(stats, rule.Direction, rule.Priority, &delta)
+		}
+	}
+
+	summary := &v1beta1.NodeStatsSummary{ObjectMeta: metav1.ObjectMeta{Name: c.nodeName}}
+	for uid, stats := range policyStats {
+		sort.Slice(stats.RuleTrafficStats, func(i, j int) bool {
+			a, b := stats.RuleTrafficStats[i], stats.RuleTrafficStats[j]
+			if a.Direction != b.Direction {
+				return a.Direction < b.Direction
+			}
+			return a.Priority < b.Priority
+		})
+		switch policyKinds[uid] {
+		case k8sNetworkPolicy:
+			summary.NetworkPolicies = append(summary.NetworkPolicies, *stats)
+		case clusterNetworkPolicy:
+			summary.ClusterNetworkPolicies = append(summary.ClusterNetworkPolicies, *stats)
+		case antreaNetworkPolicy:
+			summary.AntreaNetworkPolicies = append(summary.AntreaNetworkPolicies, *stats)
+		}
+	}
+	for _, list := range [][]v1beta1.NetworkPolicyStats{summary.NetworkPolicies, summary.ClusterNetworkPolicies, summary.AntreaNetworkPolicies} {
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Namespace != list[j].Namespace {
+				return list[i].Namespace < list[j].Namespace
+			}
+			return list[i].Name < list[j].Name
+		})
+	}
+
+	if len(policyStats) > 0 {
+		klog.V(2).Infof("Reporting stats of %d NetworkPolicies", len(policyStats))
+		if err := c.statsControlInterface.ReportNodeStatsSummary(summary); err != nil {
+			return err
+		}
+	}
+	c.lastStats = curStats
+	return nil
+}
+
+func addTrafficStats(stats *v1beta1.TrafficStats, metric *agenttypes.RuleMetric) {
+	stats.Packets += int64(metric.Packets)
+	stats.Bytes += int64(metric.Bytes)
+	stats.Sessions += int64(metric.Sessions)
+}
+
+// addRuleTrafficStats adds the metric to the stats of the rule with the
+// provided direction and priority, as several Openflow rules may realize the
+// same NetworkPolicy rule.
+func addRuleTrafficStats(stats *v1beta1.NetworkPolicyStats, direction v1beta1.Direction, priority int32, metric *agenttypes.RuleMetric) {
+	for i := range stats.RuleTrafficStats {
+		ruleStats := &stats.RuleTrafficStats[i]
+		if ruleStats.Direction == direction && ruleStats.Priority == priority {
+			addTrafficStats(&ruleStats.TrafficStats, metric)
+			return
+		}
+	}
+	ruleStats := v1beta1.RuleTrafficStats{Direction: direction, Priority: priority}
+	addTrafficStats(&ruleStats.TrafficStats, metric)
+	stats.RuleTrafficStats = append(stats.RuleTrafficStats, ruleStats)
+}
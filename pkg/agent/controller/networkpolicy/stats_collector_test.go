@@ -0,0 +1,131 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	openflowtest "github.com/vmware-tanzu/antrea/pkg/agent/openflow/testing"
+	agenttypes "github.com/vmware-tanzu/antrea/pkg/agent/types"
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+)
+
+type fakeStatsControl struct {
+	summaries []*v1beta1.NodeStatsSummary
+	err       error
+}
+
+func (c *fakeStatsControl) ReportNodeStatsSummary(summary *v1beta1.NodeStatsSummary) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.summaries = append(c.summaries, summary)
+	return nil
+}
+
+func TestStatsCollectorCollect(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	mockOFClient := openflowtest.NewMockClient(controller)
+	reconciler := newMockReconciler()
+	statsControl := &fakeStatsControl{}
+	c := newStatsCollector(nil, "node1", mockOFClient, reconciler)
+	c.statsControlInterface = statsControl
+
+	p10 := float64(10)
+	npRule := &CompletedRule{rule: &rule{ID: "rule1", Direction: v1beta1.DirectionIn, PolicyUID: "uid1", PolicyName: "np1", PolicyNamespace: "ns1"}}
+	cnpRule1 := &CompletedRule{rule: &rule{ID: "rule2", Direction: v1beta1.DirectionIn, Priority: 0, PolicyPriority: &p10, PolicyUID: "uid2", PolicyName: "cnp1"}}
+	cnpRule2 := &CompletedRule{rule: &rule{ID: "rule3", Direction: v1beta1.DirectionOut, Priority: 0, PolicyPriority: &p10, PolicyUID: "uid2", PolicyName: "cnp1"}}
+	anpRule := &CompletedRule{rule: &rule{ID: "rule4", Direction: v1beta1.DirectionIn, Priority: 1, PolicyPriority: &p10, PolicyUID: "uid3", PolicyName: "anp1", PolicyNamespace: "ns1"}}
+	reconciler.ofRules = map[uint32]*CompletedRule{1: npRule, 2: cnpRule1, 3: cnpRule1, 4: cnpRule2, 5: anpRule}
+
+	mockOFClient.EXPECT().NetworkPolicyMetrics().Return(map[uint32]*agenttypes.RuleMetric{
+		1: {Packets: 10, Bytes: 1000, Sessions: 10},
+		2: {Packets: 5, Bytes: 500, Sessions: 5},
+		3: {Packets: 1, Bytes: 100, Sessions: 1},
+		4: {Packets: 2, Bytes: 200},
+		5: {Packets: 0, Bytes: 0},
+		// The rule of the Openflow rule is unknown.
+		6: {Packets: 1, Bytes: 100, Sessions: 1},
+	})
+	require.NoError(t, c.collect())
+	require.Len(t, statsControl.summaries, 1)
+	summary := statsControl.summaries[0]
+	assert.Equal(t, "node1", summary.Name)
+	assert.Equal(t, []v1beta1.NetworkPolicyStats{
+		{Name: "np1", Namespace: "ns1", TrafficStats: v1beta1.TrafficStats{Packets: 10, Bytes: 1000, Sessions: 10}},
+	}, summary.NetworkPolicies)
+	assert.Equal(t, []v1beta1.NetworkPolicyStats{
+		{
+			Name:         "cnp1",
+			TrafficStats: v1beta1.TrafficStats{Packets: 8, Bytes: 800, Sessions: 6},
+			RuleTrafficStats: []v1beta1.RuleTrafficStats{
+				{Direction: v1beta1.DirectionIn, Priority: 0, TrafficStats: v1beta1.TrafficStats{Packets: 6, Bytes: 600, Sessions: 6}},
+				{Direction: v1beta1.DirectionOut, Priority: 0, TrafficStats: v1beta1.TrafficStats{Packets: 2, Bytes: 200}},
+			},
+		},
+	}, summary.ClusterNetworkPolicies)
+	assert.Empty(t, summary.AntreaNetworkPolicies)
+
+	// Only the increments are reported, and nothing is reported if the
+	// report fails.
+	statsControl.err = fmt.Errorf("controller unavailable")
+	mockOFClient.EXPECT().NetworkPolicyMetrics().Return(map[uint32]*agenttypes.RuleMetric{
+		1: {Packets: 12, Bytes: 1200, Sessions: 12},
+		5: {Packets: 3, Bytes: 300, Sessions: 3},
+	})
+	assert.Error(t, c.collect())
+	statsControl.err = nil
+	mockOFClient.EXPECT().NetworkPolicyMetrics().Return(map[uint32]*agenttypes.RuleMetric{
+		1: {Packets: 15, Bytes: 1500, Sessions: 15},
+		5: {Packets: 3, Bytes: 300, Sessions: 3},
+	})
+	require.NoError(t, c.collect())
+	require.Len(t, statsControl.summaries, 2)
+	summary = statsControl.summaries[1]
+	assert.Equal(t, []v1beta1.NetworkPolicyStats{
+		{Name: "np1", Namespace: "ns1", TrafficStats: v1beta1.TrafficStats{Packets: 5, Bytes: 500, Sessions: 5}},
+	}, summary.NetworkPolicies)
+	assert.Empty(t, summary.ClusterNetworkPolicies)
+	assert.Equal(t, []v1beta1.NetworkPolicyStats{
+		{
+			Name:             "anp1",
+			Namespace:        "ns1",
+			TrafficStats:     v1beta1.TrafficStats{Packets: 3, Bytes: 300, Sessions: 3},
+			RuleTrafficStats: []v1beta1.RuleTrafficStats{{Direction: v1beta1.DirectionIn, Priority: 1, TrafficStats: v1beta1.TrafficStats{Packets: 3, Bytes: 300, Sessions: 3}}},
+		},
+	}, summary.AntreaNetworkPolicies)
+
+	// The stats are counted from scratch when the ofID is reallocated.
+	reconciler.ofRules[1] = anpRule
+	mockOFClient.EXPECT().NetworkPolicyMetrics().Return(map[uint32]*agenttypes.RuleMetric{
+		1: {Packets: 1, Bytes: 100, Sessions: 1},
+	})
+	require.NoError(t, c.collect())
+	require.Len(t, statsControl.summaries, 3)
+	assert.Equal(t, v1beta1.TrafficStats{Packets: 1, Bytes: 100, Sessions: 1}, statsControl.summaries[2].AntreaNetworkPolicies[0].TrafficStats)
+
+	// Nothing is reported if there is no traffic.
+	mockOFClient.EXPECT().NetworkPolicyMetrics().Return(map[uint32]*agenttypes.RuleMetric{
+		1: {Packets: 1, Bytes: 100, Sessions: 1},
+	})
+	require.NoError(t, c.collect())
+	assert.Len(t, statsControl.summaries, 3)
+}
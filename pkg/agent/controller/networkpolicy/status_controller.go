@@ -0,0 +1,191 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent"
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+)
+
+// statusControlInterface reports the realization status of a NetworkPolicy
+// on this Node to antrea-controller.
+type statusControlInterface interface {
+	UpdateNetworkPolicyStatus(status *v1beta1.NetworkPolicyStatus) error
+}
+
+type networkPolicyStatusControl struct {
+	antreaClientProvider agent.AntreaClientProvider
+}
+
+func (c *networkPolicyStatusControl) UpdateNetworkPolicyStatus(status *v1beta1.NetworkPolicyStatus) error {
+	antreaClient, err := c.antreaClientProvider.GetAntreaClient()
+	if err != nil {
+		return err
+	}
+	_, err = antreaClient.NetworkingV1beta1().NetworkPolicyStatuses().Create(context.TODO(), status, metav1.CreateOptions{})
+	return err
+}
+
+// statusController keeps track of the realization of the rules of
+// Antrea-native policies on this Node, and reports to antrea-controller once
+// all rules of a policy have been realized or some of them failed to be
+// realized.
+type statusController struct {
+	nodeName string
+	// ruleCache provides the desired rules of each NetworkPolicy.
+	ruleCache *ruleCache
+	// statusControlInterface is used to report the statuses.
+	statusControlInterface statusControlInterface
+	// queue maintains the UIDs of the NetworkPolicies whose status need to be
+	// reported.
+	queue workqueue.RateLimitingInterface
+
+	// realizedRules stores the result of the last realization of each rule,
+	// nil meaning the rule has been realized successfully.
+	realizedRules      map[string]error
+	realizedRulesMutex sync.RWMutex
+
+	// reportedStatuses stores the last status reported for each NetworkPolicy
+	// so that identical statuses are not reported again. It is only accessed by
+	// the single worker.
+	reportedStatuses map[types.UID]*v1beta1.NetworkPolicyStatus
+}
+
+func newStatusController(antreaClientProvider agent.AntreaClientProvider, nodeName string, ruleCache *ruleCache) *statusController {
+	return &statusController{
+		nodeName:               nodeName,
+		ruleCache:              ruleCache,
+		statusControlInterface: &networkPolicyStatusControl{antreaClientProvider: antreaClientProvider},
+		queue:                  workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "networkpolicystatus"),
+		realizedRules:          map[string]error{},
+		reportedStatuses:       map[types.UID]*v1beta1.NetworkPolicyStatus{},
+	}
+}
+
+// SetRuleRealization records the result of the realization of the rule and
+// triggers the computation of the status of its NetworkPolicy.
+func (c *statusController) SetRuleRealization(ruleID string, policyUID types.UID, err error) {
+	c.realizedRulesMutex.Lock()
+	c.realizedRules[ruleID] = err
+	c.realizedRulesMutex.Unlock()
+	c.queue.Add(policyUID)
+}
+
+// DeleteRuleRealization forgets the realization of the rule.
+func (c *statusController) DeleteRuleRealization(ruleID string) {
+	c.realizedRulesMutex.Lock()
+	defer c.realizedRulesMutex.Unlock()
+	delete(c.realizedRules, ruleID)
+}
+
+// Resync triggers the computation of the status of the NetworkPolicy, e.g.
+// when its generation changed without any change to its rules.
+func (c *statusController) Resync(policyUID types.UID) {
+	c.queue.Add(policyUID)
+}
+
+// Run runs a single worker so that statuses are reported in order.
+func (c *statusController) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+	go wait.Until(c.worker, time.Second, stopCh)
+	<-stopCh
+}
+
+func (c *statusController) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *statusController) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.syncHandler(key.(types.UID)); err != nil {
+		klog.Errorf("Failed to report status of NetworkPolicy %s, retrying: %v", key, err)
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *statusController) syncHandler(uid types.UID) error {
+	policy, ruleIDs := c.ruleCache.getNetworkPolicyAndRuleIDs(string(uid))
+	if policy == nil {
+		delete(c.reportedStatuses, uid)
+		return nil
+	}
+	// Status is only reported for Antrea-native policies.
+	if policy.Priority == nil {
+		return nil
+	}
+
+	var errs []string
+	allRealized := true
+	c.realizedRulesMutex.RLock()
+	for ruleID := range ruleIDs {
+		err, realized := c.realizedRules[ruleID]
+		if !realized {
+			allRealized = false
+		} else if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	c.realizedRulesMutex.RUnlock()
+	sort.Strings(errs)
+	// Wait for all rules to be realized unless some of them already failed.
+	if !allRealized && len(errs) == 0 {
+		return nil
+	}
+
+	status := &v1beta1.NetworkPolicyStatus{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      policy.Name,
+			Namespace: policy.Namespace,
+		},
+		Nodes: []v1beta1.NetworkPolicyNodeStatus{
+			{
+				NodeName:   c.nodeName,
+				Generation: policy.Generation,
+				Error:      strings.Join(errs, "; "),
+			},
+		},
+	}
+	if reflect.DeepEqual(c.reportedStatuses[uid], status) {
+		return nil
+	}
+	klog.V(2).Infof("Reporting status of NetworkPolicy %s/%s: %+v", policy.Namespace, policy.Name, status.Nodes[0])
+	if err := c.statusControlInterface.UpdateNetworkPolicyStatus(status); err != nil {
+		return err
+	}
+	c.reportedStatuses[uid] = status
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+)
+
+type fakeStatusControl struct {
+	statuses []*v1beta1.NetworkPolicyStatus
+}
+
+func (c *fakeStatusControl) UpdateNetworkPolicyStatus(status *v1beta1.NetworkPolicyStatus) error {
+	c.statuses = append(c.statuses, status)
+	return nil
+}
+
+func newTestStatusController() (*statusController, *ruleCache, *fakeStatusControl) {
+	ruleCache, _, _ := newFakeRuleCache()
+	statusControl := &fakeStatusControl{}
+	statusController := newStatusController(nil, "node1", ruleCache)
+	statusController.statusControlInterface = statusControl
+	return statusController, ruleCache, statusControl
+}
+
+func TestStatusControllerSyncHandler(t *testing.T) {
+	p10 := float64(10)
+	policy := &v1beta1.NetworkPolicy{
+		ObjectMeta:      metav1.ObjectMeta{UID: "uid1", Name: "cnp1", Generation: 1},
+		Rules:           []v1beta1.NetworkPolicyRule{{Direction: v1beta1.DirectionIn, Priority: 0}, {Direction: v1beta1.DirectionOut, Priority: 1}},
+		AppliedToGroups: []string{"appliedToGroup1"},
+		Priority:        &p10,
+	}
+	c, ruleCache, statusControl := newTestStatusController()
+	ruleCache.AddNetworkPolicy(policy)
+	_, ruleIDs := ruleCache.getNetworkPolicyAndRuleIDs("uid1")
+	require.Equal(t, 2, ruleIDs.Len())
+	ruleID1, ruleID2 := ruleIDs.List()[0], ruleIDs.List()[1]
+
+	// Nothing is reported until all rules are realized.
+	c.SetRuleRealization(ruleID1, policy.UID, nil)
+	require.NoError(t, c.syncHandler(policy.UID))
+	assert.Empty(t, statusControl.statuses)
+
+	c.SetRuleRealization(ruleID2, policy.UID, nil)
+	require.NoError(t, c.syncHandler(policy.UID))
+	require.Len(t, statusControl.statuses, 1)
+	assert.Equal(t, "cnp1", statusControl.statuses[0].Name)
+	assert.Equal(t, []v1beta1.NetworkPolicyNodeStatus{{NodeName: "node1", Generation: 1}}, statusControl.statuses[0].Nodes)
+
+	// The same status is not reported twice.
+	require.NoError(t, c.syncHandler(policy.UID))
+	assert.Len(t, statusControl.statuses, 1)
+
+	// A new generation with the same rules is reported.
+	updatedPolicy := policy.DeepCopy()
+	updatedPolicy.Generation = 2
+	ruleCache.UpdateNetworkPolicy(updatedPolicy)
+	require.NoError(t, c.syncHandler(policy.UID))
+	require.Len(t, statusControl.statuses, 2)
+	assert.Equal(t, []v1beta1.NetworkPolicyNodeStatus{{NodeName: "node1", Generation: 2}}, statusControl.statuses[1].Nodes)
+
+	// Failures are reported.
+	c.SetRuleRealization(ruleID1, policy.UID, fmt.Errorf("failed to install flows"))
+	require.NoError(t, c.syncHandler(policy.UID))
+	require.Len(t, statusControl.statuses, 3)
+	assert.Equal(t, []v1beta1.NetworkPolicyNodeStatus{{NodeName: "node1", Generation: 2, Error: "failed to install flows"}}, statusControl.statuses[2].Nodes)
+}
+
+func TestStatusControllerIgnoreK8sNetworkPolicy(t *testing.T) {
+	policy := &v1beta1.NetworkPolicy{
+		ObjectMeta:      metav1.ObjectMeta{UID: "uid1", Name: "np1", Namespace: "ns1"},
+		Rules:           []v1beta1.NetworkPolicyRule{{Direction: v1beta1.DirectionIn}},
+		AppliedToGroups: []string{"appliedToGroup1"},
+	}
+	c, ruleCache, statusControl := newTestStatusController()
+	ruleCache.AddNetworkPolicy(policy)
+	_, ruleIDs := ruleCache.getNetworkPolicyAndRuleIDs("uid1")
+	c.SetRuleRealization(ruleIDs.List()[0], policy.UID, nil)
+	require.NoError(t, c.syncHandler(policy.UID))
+	assert.Empty(t, statusControl.statuses)
+}
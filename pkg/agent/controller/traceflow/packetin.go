@@ -29,6 +29,7 @@ import (
 
 	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
 	opsv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/ops/v1alpha1"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
 	binding "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
 )
 
@@ -134,8 +135,45 @@ func (c *Controller) parsePacketIn(pktIn *ofctrl.PacketIn) (*opsv1alpha1.Tracefl
 		obs = append(obs, *ob)
 	}
 
+	// Collect the conjunctionID of the Antrea-native policy rule which passed, dropped or rejected the packet, and
+	// get NetworkPolicy and rule action from cache.
+	cnpDenied := false
+	if match = getMatchRegField(matchers, uint32(openflow.CNPConjIDReg)); match != nil {
+		cnpConjInfo, err := getInfoInReg(match, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		ob := new(opsv1alpha1.Observation)
+		ob.Component = opsv1alpha1.NetworkPolicy
+		if tableID == uint8(openflow.IngressDefaultTable) {
+			ob.ComponentInfo = openflow.GetFlowTableName(openflow.IngressRuleTable)
+		} else {
+			ob.ComponentInfo = openflow.GetFlowTableName(openflow.EgressRuleTable)
+		}
+		npName, npNamespace := c.ofClient.GetPolicyFromConjunction(cnpConjInfo)
+		if npName != "" {
+			ob.NetworkPolicy = fmt.Sprintf("%s/%s", npNamespace, npName)
+		}
+		ruleAction := c.ofClient.GetRuleActionFromConjunction(cnpConjInfo)
+		if ruleAction != nil {
+			switch *ruleAction {
+			case secv1alpha1.RuleActionPass:
+				ob.Action = opsv1alpha1.Passed
+			case secv1alpha1.RuleActionReject:
+				ob.Action = opsv1alpha1.Rejected
+				cnpDenied = true
+			case secv1alpha1.RuleActionDrop:
+				ob.Action = opsv1alpha1.Dropped
+				cnpDenied = true
+			}
+		}
+		if ob.Action != "" {
+			obs = append(obs, *ob)
+		}
+	}
+
 	// Get drop table.
-	if tableID == uint8(openflow.EgressDefaultTable) || tableID == uint8(openflow.IngressDefaultTable) {
+	if !cnpDenied && (tableID == uint8(openflow.EgressDefaultTable) || tableID == uint8(openflow.IngressDefaultTable)) {
 		ob := new(opsv1alpha1.Observation)
 		ob.Action = opsv1alpha1.Dropped
 		ob.Component = opsv1alpha1.NetworkPolicy
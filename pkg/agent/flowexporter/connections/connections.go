@@ -15,6 +15,8 @@
 package connections
 
 import (
+	"encoding/binary"
+	"fmt"
 	"sync"
 	"time"
 
@@ -23,41 +25,117 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter"
 	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
 	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	"github.com/vmware-tanzu/antrea/pkg/agent/proxy"
 )
 
+// eventChanSize is the size of the buffer of the channels of conntrack events, which absorbs the
+// bursts of short-lived connections.
+const eventChanSize = 1024
+
+// serviceProtocols maps the IP protocol numbers to the protocols of Service ports.
+var serviceProtocols = map[uint8]string{
+	6:   "TCP",
+	17:  "UDP",
+	132: "SCTP",
+}
+
 var _ ConnectionStore = new(connectionStore)
 
 type ConnectionStore interface {
 	Run(stopCh <-chan struct{})
+	ForAllConnectionsDo(callback flowexporter.ConnectionMapCallBack) error
+	DeleteConnectionByKey(connKey flowexporter.ConnectionKey)
 }
 
 type connectionStore struct {
-	connections map[flowexporter.ConnectionKey]flowexporter.Connection // Add 5-tuple as string array
+	connections map[flowexporter.ConnectionKey]*flowexporter.Connection // Add 5-tuple as string array
 	connDumper  ConnTrackDumper
 	ifaceStore  interfacestore.InterfaceStore
-	mutex       sync.Mutex
+	// ofClient maps the conjunction IDs stored in the ct_label of the connections to the
+	// NetworkPolicies which admitted them.
+	ofClient openflow.Client
+	// serviceQuerier maps the original destinations of the connections to Services. It is nil when
+	// AntreaProxy is disabled.
+	serviceQuerier proxy.Querier
+	mutex          sync.Mutex
+	// reconcileInterval is the interval at which conntrack is dumped while the connections are
+	// updated with conntrack events.
+	reconcileInterval time.Duration
 }
 
-func NewConnectionStore(ctDumper ConnTrackDumper, ifaceStore interfacestore.InterfaceStore) *connectionStore {
+// NewConnectionStore returns a connectionStore which is updated with conntrack events, and
+// reconciled with a dump of conntrack every reconcileInterval. serviceQuerier can be nil if
+// AntreaProxy is disabled.
+func NewConnectionStore(ctDumper ConnTrackDumper, ifaceStore interfacestore.InterfaceStore, ofClient openflow.Client, serviceQuerier proxy.Querier, reconcileInterval time.Duration) *connectionStore {
 	return &connectionStore{
-		connections: make(map[flowexporter.ConnectionKey]flowexporter.Connection),
-		connDumper:  ctDumper,
-		ifaceStore:  ifaceStore,
+		connections:       make(map[flowexporter.ConnectionKey]*flowexporter.Connection),
+		connDumper:        ctDumper,
+		ifaceStore:        ifaceStore,
+		ofClient:          ofClient,
+		serviceQuerier:    serviceQuerier,
+		reconcileInterval: reconcileInterval,
 	}
 }
 
-// Run polls the connTrackDumper module periodically to get connections. These connections are used
-// to build connection store.
+// ReconcileInterval returns the interval at which conntrack must be dumped for the given idle flow
+// export timeout. The counters of the connections are only updated by the dumps, so they must be
+// dumped strictly more often than the idle timeout, otherwise a busy connection whose last dump is
+// older than the idle timeout is considered idle. The interval is at least flowexporter.PollInterval,
+// at which the connections are checked.
+func ReconcileInterval(idleTimeout time.Duration) time.Duration {
+	interval := idleTimeout / 2
+	if interval < flowexporter.PollInterval {
+		interval = flowexporter.PollInterval
+	}
+	return interval
+}
+
+// Run subscribes to conntrack events to build the connection store, and dumps conntrack
+// periodically to reconcile the connections whose events were missed, and to update the counters
+// of long-lived connections, which are not carried by events. When the events cannot be received,
+// conntrack is polled every flowexporter.PollInterval instead, until the subscription succeeds
+// again at the next reconciliation.
 func (cs *connectionStore) Run(stopCh <-chan struct{}) {
 	klog.Infof("Starting conntrack polling")
 
+	eventCh := make(chan flowexporter.ConnectionEvent, eventChanSize)
+	listenErrCh := make(chan error, 1)
+	listen := func() {
+		go func() {
+			listenErrCh <- cs.connDumper.ListenEvents(openflow.CtZone, eventCh, stopCh)
+		}()
+	}
+	listening, eventsSupported := true, true
+	listen()
+	var lastPollTime time.Time
+
 	ticker := time.NewTicker(flowexporter.PollInterval)
 	defer ticker.Stop()
 	for {
 		select {
 		case <-stopCh:
-			break
+			return
+		case err := <-listenErrCh:
+			listening = false
+			if err == errEventsNotSupported {
+				klog.Infof("Conntrack events are not supported, polling conntrack every %v", flowexporter.PollInterval)
+				eventsSupported = false
+			} else if err != nil {
+				klog.Errorf("Error when listening to conntrack events, polling conntrack every %v: %v", flowexporter.PollInterval, err)
+			}
+		case event := <-eventCh:
+			cs.handleEvent(event, time.Now())
 		case <-ticker.C:
+			if listening && !cs.reconcileDue(lastPollTime, time.Now()) {
+				continue
+			}
+			if !listening && eventsSupported {
+				// Events may have been missed while not listening, they are reconciled
+				// by the following poll.
+				listening = true
+				listen()
+			}
+			lastPollTime = time.Now()
 			_, err := cs.poll()
 			if err != nil {
 				// Not failing here as errors can be transient and could be resolved in future poll cycles.
@@ -68,25 +146,64 @@ func (cs *connectionStore) Run(stopCh <-chan struct{}) {
 	}
 }
 
-// addOrUpdateConn updates the connection if it is already present, i.e., update timestamp, counters etc.,
-// or adds a new Connection by 5-tuple of the flow along with local Pod and PodNameSpace.
-func (cs *connectionStore) addOrUpdateConn(conn *flowexporter.Connection) {
-	connKey := flowexporter.NewConnectionKey(conn)
-
-	existingConn, exists := cs.getConnByKey(connKey)
+// reconcileDue returns whether conntrack must be dumped at the given time while the connections
+// are updated with conntrack events.
+func (cs *connectionStore) reconcileDue(lastPollTime, now time.Time) bool {
+	return now.Sub(lastPollTime) >= cs.reconcileInterval
+}
 
+// handleEvent updates the connection store with a conntrack event. Destroyed connections are marked
+// inactive, so that their final records are exported before they are evicted, including the
+// connections which started and ended between two polls. A NEW event for a closed connection, or
+// for a connection whose final record was exported, means that its 5-tuple was reused by a new
+// connection, which replaces it so that its counters and start time are not merged with the
+// previous ones.
+func (cs *connectionStore) handleEvent(event flowexporter.ConnectionEvent, eventTime time.Time) {
 	cs.mutex.Lock()
 	defer cs.mutex.Unlock()
+	connKey := flowexporter.NewConnectionKey(event.Conn)
+	if event.Type == flowexporter.ConnectionNew {
+		if existingConn, exists := cs.connections[connKey]; exists && (!existingConn.IsActive || !existingConn.FinalExportAttemptTime.IsZero()) {
+			klog.V(2).Infof("Antrea flow replaced by a new connection: %v", existingConn)
+			delete(cs.connections, connKey)
+		}
+	}
+	cs.addOrUpdateConn(event.Conn, eventTime)
+	if event.Type == flowexporter.ConnectionDestroy {
+		cs.connections[connKey].IsActive = false
+	}
+}
+
+// addOrUpdateConn updates the connection if it is already present, i.e., update timestamp, counters etc.,
+// or adds a new Connection by 5-tuple of the flow along with local Pod and PodNameSpace. The connection
+// is marked as active, and pollTime is recorded as its last update time if its counters changed.
+// The caller must hold the lock of the store.
+func (cs *connectionStore) addOrUpdateConn(conn *flowexporter.Connection, pollTime time.Time) {
+	connKey := flowexporter.NewConnectionKey(conn)
+
+	existingConn, exists := cs.connections[connKey]
 	if exists {
+		if conn.OriginalPackets > existingConn.OriginalPackets || conn.ReversePackets > existingConn.ReversePackets {
+			existingConn.LastUpdateTime = pollTime
+			existingConn.IdleExported = false
+		}
 		// Update the necessary fields that are used in generating flow records.
 		// Can same 5-tuple flow get deleted and added to conntrack table? If so use ID.
 		existingConn.StopTime = conn.StopTime
-		existingConn.OriginalBytes = conn.OriginalBytes
-		existingConn.OriginalPackets = conn.OriginalPackets
-		existingConn.ReverseBytes = conn.ReverseBytes
-		existingConn.ReversePackets = conn.ReversePackets
-		// Reassign the flow to update the map
-		cs.connections[connKey] = *existingConn
+		// Conntrack events do not always carry the counters, which only increase for a given
+		// connection, so lower counters are ignored.
+		if conn.OriginalPackets >= existingConn.OriginalPackets && conn.ReversePackets >= existingConn.ReversePackets {
+			existingConn.OriginalBytes = conn.OriginalBytes
+			existingConn.OriginalPackets = conn.OriginalPackets
+			existingConn.ReverseBytes = conn.ReverseBytes
+			existingConn.ReversePackets = conn.ReversePackets
+		}
+		// The labels may be missing from the first events of the connection.
+		if len(existingConn.Labels) == 0 && len(conn.Labels) != 0 {
+			existingConn.Labels = conn.Labels
+			cs.addNetworkPolicyMetadata(existingConn)
+		}
+		existingConn.IsActive = true
 		klog.V(2).Infof("Antrea flow updated: %v", existingConn)
 	} else {
 		var srcFound, dstFound bool
@@ -103,17 +220,83 @@ func (cs *connectionStore) addOrUpdateConn(conn *flowexporter.Connection) {
 			conn.DestinationPodName = dIface.ContainerInterfaceConfig.PodName
 			conn.DestinationPodNamespace = dIface.ContainerInterfaceConfig.PodNamespace
 		}
+		cs.addNetworkPolicyMetadata(conn)
+		cs.addServiceMetadata(conn)
+		conn.IsActive = true
+		conn.LastUpdateTime = pollTime
 		klog.V(2).Infof("New Antrea flow added: %v", conn)
 		// Add new antrea connection to connection store
-		cs.connections[connKey] = *conn
+		cs.connections[connKey] = conn
 	}
 }
 
+// addNetworkPolicyMetadata sets the NetworkPolicies which admitted the connection, from the
+// conjunction IDs of their rules stored in ct_label when the connection was committed, see
+// openflow.IngressRuleCTLabel and openflow.EgressRuleCTLabel.
+func (cs *connectionStore) addNetworkPolicyMetadata(conn *flowexporter.Connection) {
+	if len(conn.Labels) < 8 {
+		return
+	}
+	// The ranges of ct_label are stored in little-endian order in the labels of conntrack flows.
+	if ingressRuleID := binary.LittleEndian.Uint32(conn.Labels[0:4]); ingressRuleID != 0 {
+		conn.IngressNetworkPolicyName, conn.IngressNetworkPolicyNamespace = cs.ofClient.GetPolicyFromConjunction(ingressRuleID)
+	}
+	if egressRuleID := binary.LittleEndian.Uint32(conn.Labels[4:8]); egressRuleID != 0 {
+		conn.EgressNetworkPolicyName, conn.EgressNetworkPolicyNamespace = cs.ofClient.GetPolicyFromConjunction(egressRuleID)
+	}
+}
+
+// addServiceMetadata sets the Service port of the connection if it was load-balanced by AntreaProxy,
+// in which case the original destination of the connection is the ClusterIP of the Service.
+func (cs *connectionStore) addServiceMetadata(conn *flowexporter.Connection) {
+	if cs.serviceQuerier == nil || conn.Mark != openflow.ServiceCTMark {
+		return
+	}
+	protocol, ok := serviceProtocols[conn.TupleOrig.Protocol]
+	if !ok {
+		return
+	}
+	serviceStr := fmt.Sprintf("%s:%d/%s", conn.TupleOrig.DestinationAddress.String(), conn.TupleOrig.DestinationPort, protocol)
+	svcPortName, exists := cs.serviceQuerier.GetServiceByIP(serviceStr)
+	if !exists {
+		klog.Warningf("Cannot map the destination %s of a Service connection to a Service", serviceStr)
+		return
+	}
+	conn.DestinationServicePortName = svcPortName.String()
+}
+
 func (cs *connectionStore) getConnByKey(flowTuple flowexporter.ConnectionKey) (*flowexporter.Connection, bool) {
 	cs.mutex.Lock()
 	defer cs.mutex.Unlock()
 	conn, found := cs.connections[flowTuple]
-	return &conn, found
+	return conn, found
+}
+
+// ForAllConnectionsDo executes the callback for each connection in the store,
+// and stops at the first error returned by the callback. The store is locked
+// while the callback is executed, so the callback can update the connections
+// but must not call the other methods of the store.
+func (cs *connectionStore) ForAllConnectionsDo(callback flowexporter.ConnectionMapCallBack) error {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	for k, v := range cs.connections {
+		if err := callback(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteConnectionByKey evicts the connection from the store if it is still
+// closed. It is called once the final record of a closed connection is
+// exported, and the connection is kept if its 5-tuple was reused by a new
+// connection in the meantime.
+func (cs *connectionStore) DeleteConnectionByKey(connKey flowexporter.ConnectionKey) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	if conn, exists := cs.connections[connKey]; exists && !conn.IsActive {
+		delete(cs.connections, connKey)
+	}
 }
 
 // poll returns number of filtered connections after poll cycle
@@ -125,9 +308,17 @@ func (cs *connectionStore) poll() (int, error) {
 		klog.Errorf("Error when dumping flows from conntrack: %v", err)
 		return 0, err
 	}
+	pollTime := time.Now()
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	// The connections which are not in conntrack anymore are closed. They stay inactive in the
+	// store until their final records are exported.
+	for _, conn := range cs.connections {
+		conn.IsActive = false
+	}
 	// Update only the Connection store. IPFIX records are generated based on Connection store.
 	for _, conn := range filteredConns {
-		cs.addOrUpdateConn(conn)
+		cs.addOrUpdateConn(conn, pollTime)
 	}
 	klog.V(2).Infof("Conntrack polling successful")
 
@@ -21,13 +21,26 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter"
 	connectionstest "github.com/vmware-tanzu/antrea/pkg/agent/flowexporter/connections/testing"
 	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
 	interfacestoretest "github.com/vmware-tanzu/antrea/pkg/agent/interfacestore/testing"
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	openflowtest "github.com/vmware-tanzu/antrea/pkg/agent/openflow/testing"
+	k8sproxy "github.com/vmware-tanzu/antrea/third_party/proxy"
 )
 
+// fakeServiceQuerier implements proxy.Querier with a static map of Services.
+type fakeServiceQuerier map[string]k8sproxy.ServicePortName
+
+func (q fakeServiceQuerier) GetServiceByIP(serviceStr string) (k8sproxy.ServicePortName, bool) {
+	svcPortName, exists := q[serviceStr]
+	return svcPortName, exists
+}
+
 func makeTuple(srcIP *net.IP, dstIP *net.IP, protoID uint8, srcPort uint16, dstPort uint16) (*flowexporter.Tuple, *flowexporter.Tuple) {
 	tuple := &flowexporter.Tuple{
 		SourceAddress:      *srcIP,
@@ -106,13 +119,13 @@ func TestConnectionStore_addAndUpdateConn(t *testing.T) {
 	mockCT := connectionstest.NewMockConnTrackDumper(ctrl)
 	// Create connectionStore
 	connStore := &connectionStore{
-		connections: make(map[flowexporter.ConnectionKey]flowexporter.Connection),
+		connections: make(map[flowexporter.ConnectionKey]*flowexporter.Connection),
 		connDumper:  mockCT,
 		ifaceStore:  iStore,
 	}
 	// Add flow1conn to the Connection map
 	testFlow1Tuple := flowexporter.NewConnectionKey(&testFlow1)
-	connStore.connections[testFlow1Tuple] = oldTestFlow1
+	connStore.connections[testFlow1Tuple] = &oldTestFlow1
 
 	updateConnTests := []struct {
 		flow flowexporter.Connection
@@ -134,8 +147,157 @@ func TestConnectionStore_addAndUpdateConn(t *testing.T) {
 			iStore.EXPECT().GetInterfaceByIP(test.flow.TupleOrig.SourceAddress.String()).Return(nil, false)
 			iStore.EXPECT().GetInterfaceByIP(test.flow.TupleReply.SourceAddress.String()).Return(interfaceFlow2, true)
 		}
-		connStore.addOrUpdateConn(&test.flow)
+		// Both connections are updated in the poll cycle, as their counters changed.
+		expConn.IsActive = true
+		expConn.LastUpdateTime = refTime
+		connStore.addOrUpdateConn(&test.flow, refTime)
 		actualConn, _ := connStore.getConnByKey(flowTuple)
 		assert.Equal(t, expConn, *actualConn, "Connections should be equal")
 	}
 }
+
+func TestConnectionStore_handleEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	refTime := time.Now()
+	iStore := interfacestoretest.NewMockInterfaceStore(ctrl)
+	iStore.EXPECT().GetInterfaceByIP(gomock.Any()).Return(nil, false).AnyTimes()
+	connStore := NewConnectionStore(connectionstest.NewMockConnTrackDumper(ctrl), iStore, nil, nil, time.Minute)
+
+	tuple1, revTuple1 := makeTuple(&net.IP{1, 2, 3, 4}, &net.IP{4, 3, 2, 1}, 6, 65280, 255)
+	newConn := func(packets, bytes uint64) *flowexporter.Connection {
+		return &flowexporter.Connection{
+			TupleOrig:       *tuple1,
+			TupleReply:      *revTuple1,
+			OriginalPackets: packets,
+			OriginalBytes:   bytes,
+			ReversePackets:  packets,
+			ReverseBytes:    bytes,
+		}
+	}
+	connKey := flowexporter.NewConnectionKey(newConn(0, 0))
+
+	connStore.handleEvent(flowexporter.ConnectionEvent{Type: flowexporter.ConnectionNew, Conn: newConn(0, 0)}, refTime)
+	conn, found := connStore.getConnByKey(connKey)
+	assert.True(t, found, "Connection should be added by NEW event")
+	assert.True(t, conn.IsActive)
+
+	// The counters set by a poll are kept when the event does not carry counters.
+	connStore.mutex.Lock()
+	connStore.addOrUpdateConn(newConn(10, 1000), refTime.Add(time.Second))
+	connStore.mutex.Unlock()
+	connStore.handleEvent(flowexporter.ConnectionEvent{Type: flowexporter.ConnectionUpdate, Conn: newConn(0, 0)}, refTime.Add(2*time.Second))
+	conn, _ = connStore.getConnByKey(connKey)
+	assert.Equal(t, uint64(10), conn.OriginalPackets)
+	assert.Equal(t, refTime.Add(time.Second), conn.LastUpdateTime)
+
+	// The final counters are set by the DESTROY event, and the connection is kept until it is exported.
+	connStore.handleEvent(flowexporter.ConnectionEvent{Type: flowexporter.ConnectionDestroy, Conn: newConn(12, 1200)}, refTime.Add(3*time.Second))
+	conn, found = connStore.getConnByKey(connKey)
+	assert.True(t, found, "Connection should be kept until its final record is exported")
+	assert.False(t, conn.IsActive)
+	assert.Equal(t, uint64(1200), conn.ReverseBytes)
+
+	// A connection which is active again is not deleted.
+	conn.IsActive = true
+	connStore.DeleteConnectionByKey(connKey)
+	_, found = connStore.getConnByKey(connKey)
+	assert.True(t, found, "Active connection should not be deleted")
+	conn.IsActive = false
+
+	// A connection which started and ended between two polls is added by its DESTROY event.
+	connStore.DeleteConnectionByKey(connKey)
+	connStore.handleEvent(flowexporter.ConnectionEvent{Type: flowexporter.ConnectionDestroy, Conn: newConn(3, 300)}, refTime.Add(4*time.Second))
+	conn, found = connStore.getConnByKey(connKey)
+	assert.True(t, found, "Connection should be added by DESTROY event")
+	assert.False(t, conn.IsActive)
+	assert.Equal(t, uint64(3), conn.OriginalPackets)
+
+	// A NEW event for the 5-tuple of the closed connection replaces it, with its own counters, start
+	// time and export state.
+	conn.StartTime = refTime
+	conn.LastExportTime = refTime.Add(4 * time.Second)
+	conn.PrevPackets, conn.PrevBytes = 3, 300
+	reusedConn := newConn(1, 100)
+	reusedConn.StartTime = refTime.Add(5 * time.Second)
+	connStore.handleEvent(flowexporter.ConnectionEvent{Type: flowexporter.ConnectionNew, Conn: reusedConn}, refTime.Add(5*time.Second))
+	conn, found = connStore.getConnByKey(connKey)
+	assert.True(t, found, "Connection should be replaced by NEW event")
+	assert.True(t, conn.IsActive)
+	assert.Equal(t, uint64(1), conn.OriginalPackets)
+	assert.Equal(t, uint64(100), conn.OriginalBytes)
+	assert.Equal(t, refTime.Add(5*time.Second), conn.StartTime)
+	assert.Equal(t, refTime.Add(5*time.Second), conn.LastUpdateTime)
+	assert.True(t, conn.LastExportTime.IsZero())
+	assert.Equal(t, uint64(0), conn.PrevPackets)
+	assert.Equal(t, uint64(0), conn.PrevBytes)
+}
+
+func TestConnectionStore_busyConnectionNotIdle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	iStore := interfacestoretest.NewMockInterfaceStore(ctrl)
+	iStore.EXPECT().GetInterfaceByIP(gomock.Any()).Return(nil, false).AnyTimes()
+	tuple, revTuple := makeTuple(&net.IP{1, 2, 3, 4}, &net.IP{4, 3, 2, 1}, 6, 65280, 255)
+
+	for _, idleTimeout := range []time.Duration{6 * time.Second, 15 * time.Second, 21 * time.Second, time.Minute} {
+		t.Run(idleTimeout.String(), func(t *testing.T) {
+			connStore := NewConnectionStore(connectionstest.NewMockConnTrackDumper(ctrl), iStore, nil, nil, ReconcileInterval(idleTimeout))
+			refTime := time.Now()
+			lastPollTime := refTime
+			connStore.addOrUpdateConn(&flowexporter.Connection{TupleOrig: *tuple, TupleReply: *revTuple, OriginalPackets: 1}, refTime)
+			connKey := flowexporter.NewConnectionKey(&flowexporter.Connection{TupleOrig: *tuple, TupleReply: *revTuple})
+			// The connection sends packets continuously, its counters are updated by each
+			// dump of conntrack, which happens at the ticks of the poll interval.
+			for i := 1; i <= 100; i++ {
+				now := refTime.Add(time.Duration(i) * flowexporter.PollInterval)
+				// The connection is checked by the exporter right before the dump.
+				conn, _ := connStore.getConnByKey(connKey)
+				if !assert.True(t, now.Sub(conn.LastUpdateTime) < idleTimeout, "Busy connection should not be idle after %v", now.Sub(refTime)) {
+					return
+				}
+				if !connStore.reconcileDue(lastPollTime, now) {
+					continue
+				}
+				lastPollTime = now
+				connStore.addOrUpdateConn(&flowexporter.Connection{TupleOrig: *tuple, TupleReply: *revTuple, OriginalPackets: uint64(i + 1)}, now)
+			}
+		})
+	}
+}
+
+func TestConnectionStore_addMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	iStore := interfacestoretest.NewMockInterfaceStore(ctrl)
+	iStore.EXPECT().GetInterfaceByIP(gomock.Any()).Return(nil, false).AnyTimes()
+	ofClient := openflowtest.NewMockClient(ctrl)
+	svcPortName := k8sproxy.ServicePortName{
+		NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "svc1"},
+		Port:           "http",
+		Protocol:       corev1.ProtocolTCP,
+	}
+	serviceQuerier := fakeServiceQuerier{"10.96.0.10:80/TCP": svcPortName}
+	connStore := NewConnectionStore(connectionstest.NewMockConnTrackDumper(ctrl), iStore, ofClient, serviceQuerier, time.Minute)
+
+	tuple, _ := makeTuple(&net.IP{10, 10, 0, 1}, &net.IP{10, 96, 0, 10}, 6, 50000, 80)
+	_, revTuple := makeTuple(&net.IP{10, 10, 0, 1}, &net.IP{10, 10, 1, 2}, 6, 50000, 8080)
+	// The ingress rule ID is 0, i.e. no ingress rule applies to the connection, and the egress rule
+	// ID is 5.
+	conn := &flowexporter.Connection{
+		TupleOrig:  *tuple,
+		TupleReply: *revTuple,
+		Mark:       openflow.ServiceCTMark,
+		Labels:     []byte{0, 0, 0, 0, 5, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+	}
+	ofClient.EXPECT().GetPolicyFromConjunction(uint32(5)).Return("np1", "ns1")
+	connStore.mutex.Lock()
+	connStore.addOrUpdateConn(conn, time.Now())
+	connStore.mutex.Unlock()
+
+	actualConn, _ := connStore.getConnByKey(flowexporter.NewConnectionKey(conn))
+	assert.Equal(t, "ns1/svc1:http", actualConn.DestinationServicePortName)
+	assert.Equal(t, "np1", actualConn.EgressNetworkPolicyName)
+	assert.Equal(t, "ns1", actualConn.EgressNetworkPolicyNamespace)
+	assert.Empty(t, actualConn.IngressNetworkPolicyName)
+}
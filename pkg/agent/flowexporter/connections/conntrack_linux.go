@@ -17,17 +17,21 @@
 package connections
 
 import (
+	"fmt"
 	"net"
 
 	"github.com/ti-mo/conntrack"
+	"github.com/ti-mo/netfilter"
 	"k8s.io/klog"
 
 	"github.com/vmware-tanzu/antrea/pkg/agent/config"
 	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter"
-	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
 	"github.com/vmware-tanzu/antrea/pkg/agent/util/sysctl"
 )
 
+// listenWorkers is the number of goroutines receiving conntrack events from netlink.
+const listenWorkers = 4
+
 var _ ConnTrackDumper = new(connTrackDumper)
 
 type connTrackDumper struct {
@@ -64,29 +68,11 @@ func (ctdump *connTrackDumper) DumpFlows(zoneFilter uint16) ([]*flowexporter.Con
 	}
 
 	filteredConns := make([]*flowexporter.Connection, 0, len(conns))
-	for _, conn := range conns {
-		if conn.Zone != openflow.CtZone {
-			continue
-		}
-		srcIP := conn.TupleOrig.IP.SourceAddress
-		dstIP := conn.TupleReply.IP.SourceAddress
-		// Only get Pod-to-Pod flows. Pod-to-ExternalService flows are ignored for now.
-		if srcIP.Equal(ctdump.nodeConfig.GatewayConfig.IP) || dstIP.Equal(ctdump.nodeConfig.GatewayConfig.IP) {
-			continue
-		}
-
-		// Pod-to-Service flows w/ kube-proxy: There are two conntrack flows for every Pod-to-Service flow.
-		// One is with ClusterIP as source or destination, where other IP is podIP. Second conntrack flow is
-		// with resolved Endpoint Pod IP corresponding to ClusterIP. Both conntrack flows have same stats, which makes them duplicate.
-		// Ideally, we have to correlate these two connections and maintain one connection with both Endpoint Pod IP and ClusterIP.
-		// To do the correlation, we need ClusterIP-to-EndpointIP mapping info, which is not available at Agent.
-		// Therefore, we ignore the connection with ClusterIP and keep the connection with Endpoint Pod IP.
-		// Conntrack flows will be different for Pod-to-Service flows w/ Antrea-proxy. This implementation will be simpler, when the
-		// Antrea proxy is supported.
-		if ctdump.serviceCIDR.Contains(srcIP) || ctdump.serviceCIDR.Contains(dstIP) {
+	for i := range conns {
+		if !ctdump.isAntreaFlow(&conns[i], zoneFilter) {
 			continue
 		}
-		filteredConns = append(filteredConns, createAntreaConn(&conn))
+		filteredConns = append(filteredConns, createAntreaConn(&conns[i]))
 	}
 
 	klog.V(2).Infof("Finished poll cycle -- total flows: %d flows in Antrea zoneID: %d", len(conns), len(filteredConns))
@@ -94,6 +80,79 @@ func (ctdump *connTrackDumper) DumpFlows(zoneFilter uint16) ([]*flowexporter.Con
 	return filteredConns, nil
 }
 
+// ListenEvents subscribes to the conntrack events, and sends the events of the
+// Antrea flows to eventCh until stopCh is closed or an error occurs.
+func (ctdump *connTrackDumper) ListenEvents(zoneFilter uint16, eventCh chan<- flowexporter.ConnectionEvent, stopCh <-chan struct{}) error {
+	ctEventCh := make(chan conntrack.Event, eventChanSize)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- ctdump.connTrack.Listen(ctEventCh, stopCh)
+	}()
+	for {
+		select {
+		case err := <-errCh:
+			return err
+		case ctEvent := <-ctEventCh:
+			event, ok := ctdump.connectionEvent(&ctEvent, zoneFilter)
+			if !ok {
+				continue
+			}
+			select {
+			case eventCh <- event:
+			case <-stopCh:
+				return nil
+			}
+		}
+	}
+}
+
+// connectionEvent converts a conntrack event to a ConnectionEvent. It returns
+// false if the event is not the event of an Antrea flow.
+func (ctdump *connTrackDumper) connectionEvent(ctEvent *conntrack.Event, zoneFilter uint16) (flowexporter.ConnectionEvent, bool) {
+	var eventType flowexporter.ConnectionEventType
+	switch ctEvent.Type {
+	case conntrack.EventNew:
+		eventType = flowexporter.ConnectionNew
+	case conntrack.EventUpdate:
+		eventType = flowexporter.ConnectionUpdate
+	case conntrack.EventDestroy:
+		eventType = flowexporter.ConnectionDestroy
+	default:
+		return flowexporter.ConnectionEvent{}, false
+	}
+	if ctEvent.Flow == nil || !ctdump.isAntreaFlow(ctEvent.Flow, zoneFilter) {
+		return flowexporter.ConnectionEvent{}, false
+	}
+	return flowexporter.ConnectionEvent{Type: eventType, Conn: createAntreaConn(ctEvent.Flow)}, true
+}
+
+// isAntreaFlow returns whether the conntrack flow is a Pod-to-Pod flow in the
+// given zone, which are the flows exported by the flow exporter.
+func (ctdump *connTrackDumper) isAntreaFlow(conn *conntrack.Flow, zoneFilter uint16) bool {
+	if conn.Zone != zoneFilter {
+		return false
+	}
+	srcIP := conn.TupleOrig.IP.SourceAddress
+	dstIP := conn.TupleReply.IP.SourceAddress
+	// Only get Pod-to-Pod flows. Pod-to-ExternalService flows are ignored for now.
+	if srcIP.Equal(ctdump.nodeConfig.GatewayConfig.IP) || dstIP.Equal(ctdump.nodeConfig.GatewayConfig.IP) {
+		return false
+	}
+
+	// Pod-to-Service flows w/ kube-proxy: There are two conntrack flows for every Pod-to-Service flow.
+	// One is with ClusterIP as source or destination, where other IP is podIP. Second conntrack flow is
+	// with resolved Endpoint Pod IP corresponding to ClusterIP. Both conntrack flows have same stats, which makes them duplicate.
+	// Ideally, we have to correlate these two connections and maintain one connection with both Endpoint Pod IP and ClusterIP.
+	// To do the correlation, we need ClusterIP-to-EndpointIP mapping info, which is not available at Agent.
+	// Therefore, we ignore the connection with ClusterIP and keep the connection with Endpoint Pod IP.
+	// Conntrack flows will be different for Pod-to-Service flows w/ Antrea-proxy. This implementation will be simpler, when the
+	// Antrea proxy is supported.
+	if ctdump.serviceCIDR.Contains(srcIP) || ctdump.serviceCIDR.Contains(dstIP) {
+		return false
+	}
+	return true
+}
+
 // connTrackSystem implements ConnTrackInterfacer
 var _ ConnTrackInterfacer = new(connTrackSystem)
 
@@ -102,6 +161,10 @@ var _ ConnTrackInterfacer = new(connTrackSystem)
 type ConnTrackInterfacer interface {
 	Dial() error
 	DumpFilter(filter conntrack.Filter) ([]conntrack.Flow, error)
+	// Listen opens a new netlink connection subscribed to the NEW, UPDATE and DESTROY conntrack
+	// events, and sends the received events to eventCh. It blocks until stopCh is closed or an
+	// error occurs, and closes the netlink connection before returning.
+	Listen(eventCh chan<- conntrack.Event, stopCh <-chan struct{}) error
 }
 
 type connTrackSystem struct {
@@ -117,6 +180,9 @@ func NewConnTrackInterfacer() *connTrackSystem {
 	// Do not handle error and continue with creation of interfacer object as we can still dump flows with no timestamps.
 	// If log says permission error, please ensure net.netfilter.nf_conntrack_timestamp to be set to 1.
 	sysctl.EnsureSysctlNetValue("netfilter/nf_conntrack_timestamp", 1)
+	// Ensure net.netfilter.nf_conntrack_events value to be 1. This will enable flow exporter to receive events of connections.
+	// Do not handle error and continue with creation of interfacer object as connections are still polled without events.
+	sysctl.EnsureSysctlNetValue("netfilter/nf_conntrack_events", 1)
 
 	return &connTrackSystem{}
 }
@@ -141,6 +207,31 @@ func (c *connTrackSystem) DumpFilter(filter conntrack.Filter) ([]conntrack.Flow,
 	return conns, nil
 }
 
+func (c *connTrackSystem) Listen(eventCh chan<- conntrack.Event, stopCh <-chan struct{}) error {
+	// Events are received on a separate netlink connection, as the connection used to dump flows
+	// cannot be subscribed to multicast groups.
+	conn, err := conntrack.Dial(nil)
+	if err != nil {
+		klog.Errorf("Error when dialing conntrack: %v", err)
+		return err
+	}
+	defer conn.Close()
+	errCh, err := conn.Listen(eventCh, listenWorkers, []netfilter.NetlinkGroup{
+		netfilter.GroupCTNew,
+		netfilter.GroupCTUpdate,
+		netfilter.GroupCTDestroy,
+	})
+	if err != nil {
+		return fmt.Errorf("error when subscribing to conntrack events: %v", err)
+	}
+	select {
+	case <-stopCh:
+		return nil
+	case err := <-errCh:
+		return fmt.Errorf("error when receiving conntrack events: %v", err)
+	}
+}
+
 func createAntreaConn(conn *conntrack.Flow) *flowexporter.Connection {
 	tupleOrig := flowexporter.Tuple{
 		SourceAddress:      conn.TupleOrig.IP.SourceAddress,
@@ -159,22 +250,20 @@ func createAntreaConn(conn *conntrack.Flow) *flowexporter.Connection {
 	}
 	// Assign all the applicable fields
 	newConn := flowexporter.Connection{
-		conn.ID,
-		conn.Timeout,
-		conn.Timestamp.Start,
-		conn.Timestamp.Stop,
-		conn.Zone,
-		uint32(conn.Status.Value),
-		tupleOrig,
-		tupleReply,
-		conn.CountersOrig.Packets,
-		conn.CountersOrig.Bytes,
-		conn.CountersReply.Packets,
-		conn.CountersReply.Bytes,
-		"",
-		"",
-		"",
-		"",
+		ID:              conn.ID,
+		Timeout:         conn.Timeout,
+		StartTime:       conn.Timestamp.Start,
+		StopTime:        conn.Timestamp.Stop,
+		Zone:            conn.Zone,
+		Mark:            conn.Mark,
+		Labels:          conn.Labels,
+		StatusFlag:      uint32(conn.Status.Value),
+		TupleOrig:       tupleOrig,
+		TupleReply:      tupleReply,
+		OriginalPackets: conn.CountersOrig.Packets,
+		OriginalBytes:   conn.CountersOrig.Bytes,
+		ReversePackets:  conn.CountersReply.Packets,
+		ReverseBytes:    conn.CountersReply.Bytes,
 	}
 
 	return &newConn
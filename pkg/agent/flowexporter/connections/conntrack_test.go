@@ -19,13 +19,16 @@ package connections
 import (
 	"net"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/ti-mo/conntrack"
 
 	"github.com/vmware-tanzu/antrea/pkg/agent/config"
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter"
 	connectionstest "github.com/vmware-tanzu/antrea/pkg/agent/flowexporter/connections/testing"
+	"github.com/vmware-tanzu/antrea/pkg/agent/interfacestore"
 	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
 )
 
@@ -152,3 +155,148 @@ func TestConnTrack_DumpFilter(t *testing.T) {
 	}
 	assert.Equal(t, 1, len(conns), "number of filtered connections should be equal")
 }
+
+func TestConnTrack_connectionEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	nodeConfig := &config.NodeConfig{
+		GatewayConfig: &config.GatewayConfig{IP: net.IP{8, 7, 6, 5}},
+	}
+	serviceCIDR := &net.IPNet{
+		IP:   net.IP{100, 50, 25, 0},
+		Mask: net.IPMask{255, 255, 255, 0},
+	}
+	connTrack := NewConnTrackDumper(nodeConfig, serviceCIDR, connectionstest.NewMockConnTrackInterfacer(ctrl))
+
+	antreaFlow := &conntrack.Flow{TupleOrig: tuple3, TupleReply: revTuple3, Zone: openflow.CtZone}
+	antreaServiceFlow := &conntrack.Flow{TupleOrig: tuple5, TupleReply: revTuple5, Zone: openflow.CtZone}
+	nonAntreaFlow := &conntrack.Flow{TupleOrig: tuple4, TupleReply: revTuple4, Zone: 100}
+	tests := []struct {
+		name         string
+		ctEvent      conntrack.Event
+		expectedOK   bool
+		expectedType flowexporter.ConnectionEventType
+	}{
+		{"new", conntrack.Event{Type: conntrack.EventNew, Flow: antreaFlow}, true, flowexporter.ConnectionNew},
+		{"update", conntrack.Event{Type: conntrack.EventUpdate, Flow: antreaFlow}, true, flowexporter.ConnectionUpdate},
+		{"destroy", conntrack.Event{Type: conntrack.EventDestroy, Flow: antreaFlow}, true, flowexporter.ConnectionDestroy},
+		{"service-flow", conntrack.Event{Type: conntrack.EventNew, Flow: antreaServiceFlow}, false, 0},
+		{"other-zone", conntrack.Event{Type: conntrack.EventNew, Flow: nonAntreaFlow}, false, 0},
+		{"expectation", conntrack.Event{Type: conntrack.EventExpNew}, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, ok := connTrack.connectionEvent(&tt.ctEvent, openflow.CtZone)
+			assert.Equal(t, tt.expectedOK, ok)
+			if tt.expectedOK {
+				assert.Equal(t, tt.expectedType, event.Type)
+				assert.Equal(t, tuple3.IP.SourceAddress, event.Conn.TupleOrig.SourceAddress)
+			}
+		})
+	}
+}
+
+const (
+	// benchmarkConns is the number of connections in conntrack in the benchmarks.
+	benchmarkConns = 50000
+	// benchmarkChurn is the number of connections which start and end in each poll interval.
+	benchmarkChurn = 500
+	// benchmarkReconcileCycles is the number of poll intervals in a reconcile interval, i.e. the
+	// default idle flow export timeout divided by the poll interval.
+	benchmarkReconcileCycles = 3
+)
+
+// fakeConnTrackInterfacer implements ConnTrackInterfacer with a static conntrack table.
+type fakeConnTrackInterfacer struct {
+	flows []conntrack.Flow
+}
+
+func (f *fakeConnTrackInterfacer) Dial() error {
+	return nil
+}
+
+func (f *fakeConnTrackInterfacer) DumpFilter(filter conntrack.Filter) ([]conntrack.Flow, error) {
+	return f.flows, nil
+}
+
+func (f *fakeConnTrackInterfacer) Listen(eventCh chan<- conntrack.Event, stopCh <-chan struct{}) error {
+	<-stopCh
+	return nil
+}
+
+func newBenchmarkFlow(i int) conntrack.Flow {
+	srcIP, dstIP := net.IP{10, 10, 0, 2}, net.IP{10, 10, byte(1 + i/60000), 1}
+	srcPort, dstPort := uint16(1024+i%60000), uint16(80)
+	return conntrack.Flow{
+		TupleOrig: conntrack.Tuple{
+			IP:    conntrack.IPTuple{SourceAddress: srcIP, DestinationAddress: dstIP},
+			Proto: conntrack.ProtoTuple{Protocol: 6, SourcePort: srcPort, DestinationPort: dstPort},
+		},
+		TupleReply: conntrack.Tuple{
+			IP:    conntrack.IPTuple{SourceAddress: dstIP, DestinationAddress: srcIP},
+			Proto: conntrack.ProtoTuple{Protocol: 6, SourcePort: dstPort, DestinationPort: srcPort},
+		},
+		Zone:          openflow.CtZone,
+		CountersOrig:  conntrack.Counter{Packets: uint64(i), Bytes: uint64(i) * 100},
+		CountersReply: conntrack.Counter{Packets: uint64(i), Bytes: uint64(i) * 100},
+	}
+}
+
+func newBenchmarkConnectionStore(b *testing.B) (*connectionStore, *connTrackDumper) {
+	flows := make([]conntrack.Flow, benchmarkConns)
+	for i := range flows {
+		flows[i] = newBenchmarkFlow(i)
+	}
+	nodeConfig := &config.NodeConfig{
+		GatewayConfig: &config.GatewayConfig{IP: net.IP{10, 10, 0, 1}},
+	}
+	_, serviceCIDR, _ := net.ParseCIDR("10.96.0.0/12")
+	ctDumper := NewConnTrackDumper(nodeConfig, serviceCIDR, &fakeConnTrackInterfacer{flows: flows})
+	ifaceStore := interfacestore.NewInterfaceStore()
+	ifaceStore.AddInterface(interfacestore.NewContainerInterface("pod1-abcd", "1", "pod1", "ns1", nil, net.IP{10, 10, 0, 2}))
+	connStore := NewConnectionStore(ctDumper, ifaceStore, nil, nil, benchmarkReconcileCycles*flowexporter.PollInterval)
+	if _, err := connStore.poll(); err != nil {
+		b.Fatalf("Error when polling conntrack: %v", err)
+	}
+	return connStore, ctDumper
+}
+
+// BenchmarkConnectionStorePoll measures the cost of a poll interval when the whole conntrack table
+// is dumped every poll interval.
+func BenchmarkConnectionStorePoll(b *testing.B) {
+	connStore, _ := newBenchmarkConnectionStore(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		connStore.poll()
+	}
+}
+
+// BenchmarkConnectionStoreEvents measures the cost of a poll interval when the connections are
+// updated with conntrack events, and the conntrack table is dumped every reconcile interval.
+func BenchmarkConnectionStoreEvents(b *testing.B) {
+	connStore, ctDumper := newBenchmarkConnectionStore(b)
+	ctEvents := make([]conntrack.Event, 0, 2*benchmarkChurn)
+	for i := 0; i < benchmarkChurn; i++ {
+		flow := newBenchmarkFlow(benchmarkConns + i)
+		ctEvents = append(ctEvents, conntrack.Event{Type: conntrack.EventNew, Flow: &flow})
+		ctEvents = append(ctEvents, conntrack.Event{Type: conntrack.EventDestroy, Flow: &flow})
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		now := time.Now()
+		for j := range ctEvents {
+			if event, ok := ctDumper.connectionEvent(&ctEvents[j], openflow.CtZone); ok {
+				connStore.handleEvent(event, now)
+			}
+		}
+		// The destroyed connections are evicted once exported.
+		for j := 1; j < len(ctEvents); j += 2 {
+			connStore.DeleteConnectionByKey(flowexporter.NewConnectionKey(createAntreaConn(ctEvents[j].Flow)))
+		}
+		if i%benchmarkReconcileCycles == 0 {
+			connStore.poll()
+		}
+	}
+}
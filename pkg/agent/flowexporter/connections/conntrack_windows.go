@@ -53,3 +53,7 @@ func NewConnTrackInterfacer() *connTrackSystem {
 func (cp *connTrackDumper) DumpFlows(zoneFilter uint16) ([]*flowexporter.Connection, error) {
 	return nil, nil
 }
+
+func (cp *connTrackDumper) ListenEvents(zoneFilter uint16, eventCh chan<- flowexporter.ConnectionEvent, stopCh <-chan struct{}) error {
+	return errEventsNotSupported
+}
@@ -15,11 +15,22 @@
 package connections
 
 import (
+	"errors"
+
 	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter"
 )
 
+// errEventsNotSupported is returned by ListenEvents when conntrack events are
+// not supported on the platform, in which case the connections are only polled.
+var errEventsNotSupported = errors.New("conntrack events are not supported")
+
 // ConnTrackDumper is an interface that is used to dump connections from
-// conntrack module.
+// conntrack module, and to receive the events of the connections.
 type ConnTrackDumper interface {
 	DumpFlows(zoneFilter uint16) ([]*flowexporter.Connection, error)
+	// ListenEvents subscribes to the NEW, UPDATE and DESTROY conntrack events, and sends the
+	// events of the connections in the given zone to eventCh. It blocks until stopCh is closed,
+	// or until an error occurs, e.g. when events were dropped because the netlink socket buffer
+	// overflowed, which is returned.
+	ListenEvents(zoneFilter uint16, eventCh chan<- flowexporter.ConnectionEvent, stopCh <-chan struct{}) error
 }
@@ -64,6 +64,20 @@ func (mr *MockConnTrackDumperMockRecorder) DumpFlows(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DumpFlows", reflect.TypeOf((*MockConnTrackDumper)(nil).DumpFlows), arg0)
 }
 
+// ListenEvents mocks base method
+func (m *MockConnTrackDumper) ListenEvents(arg0 uint16, arg1 chan<- flowexporter.ConnectionEvent, arg2 <-chan struct{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListenEvents", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListenEvents indicates an expected call of ListenEvents
+func (mr *MockConnTrackDumperMockRecorder) ListenEvents(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenEvents", reflect.TypeOf((*MockConnTrackDumper)(nil).ListenEvents), arg0, arg1, arg2)
+}
+
 // MockConnTrackInterfacer is a mock of ConnTrackInterfacer interface
 type MockConnTrackInterfacer struct {
 	ctrl     *gomock.Controller
@@ -115,3 +129,17 @@ func (mr *MockConnTrackInterfacerMockRecorder) DumpFilter(arg0 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DumpFilter", reflect.TypeOf((*MockConnTrackInterfacer)(nil).DumpFilter), arg0)
 }
+
+// Listen mocks base method
+func (m *MockConnTrackInterfacer) Listen(arg0 chan<- conntrack.Event, arg1 <-chan struct{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Listen", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Listen indicates an expected call of Listen
+func (mr *MockConnTrackInterfacerMockRecorder) Listen(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockConnTrackInterfacer)(nil).Listen), arg0, arg1)
+}
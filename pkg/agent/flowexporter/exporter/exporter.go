@@ -0,0 +1,330 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter"
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter/connections"
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter/ipfix"
+)
+
+const (
+	templateIDv4 = ipfix.MinTemplateID
+	templateIDv6 = ipfix.MinTemplateID + 1
+	// maxClosedConnectionAge is the maximum time during which the final
+	// record of a closed connection is exported again when it cannot be
+	// sent, after which the connection is evicted without being exported,
+	// so that the store does not grow while the collector is unreachable.
+	maxClosedConnectionAge = 5 * time.Minute
+)
+
+// The values of the flowEndReason IE, see
+// https://www.iana.org/assignments/ipfix/ipfix.xhtml#ipfix-flow-end-reason.
+const (
+	idleTimeoutReason   uint8 = 0x01
+	activeTimeoutReason uint8 = 0x02
+	endOfFlowReason     uint8 = 0x03
+)
+
+// The IEs common to the IPv4 and IPv6 templates, which follow the source and
+// destination addresses.
+var commonElements = []*ipfix.InfoElement{
+	ipfix.FlowStartSeconds,
+	ipfix.FlowEndSeconds,
+	ipfix.FlowEndReason,
+	ipfix.SourceTransportPort,
+	ipfix.DestinationTransportPort,
+	ipfix.ProtocolIdentifier,
+	ipfix.PacketTotalCount,
+	ipfix.OctetTotalCount,
+	ipfix.ReversePacketTotalCount,
+	ipfix.ReverseOctetTotalCount,
+	ipfix.PacketDeltaCount,
+	ipfix.OctetDeltaCount,
+	ipfix.ReversePacketDeltaCount,
+	ipfix.ReverseOctetDeltaCount,
+	ipfix.SourcePodNamespace,
+	ipfix.SourcePodName,
+	ipfix.DestinationPodNamespace,
+	ipfix.DestinationPodName,
+	ipfix.SourceNodeName,
+	ipfix.DestinationServicePortName,
+	ipfix.IngressNetworkPolicyName,
+	ipfix.IngressNetworkPolicyNamespace,
+	ipfix.EgressNetworkPolicyName,
+	ipfix.EgressNetworkPolicyNamespace,
+}
+
+var (
+	templateV4 = &ipfix.Template{
+		ID:       templateIDv4,
+		Elements: append([]*ipfix.InfoElement{ipfix.SourceIPv4Address, ipfix.DestinationIPv4Address}, commonElements...),
+	}
+	templateV6 = &ipfix.Template{
+		ID:       templateIDv6,
+		Elements: append([]*ipfix.InfoElement{ipfix.SourceIPv6Address, ipfix.DestinationIPv6Address}, commonElements...),
+	}
+)
+
+type FlowExporter interface {
+	Run(stopCh <-chan struct{})
+}
+
+type flowExporter struct {
+	connStore     connections.ConnectionStore
+	process       *ipfix.ExportingProcess
+	nodeName      string
+	activeTimeout time.Duration
+	idleTimeout   time.Duration
+}
+
+// NewFlowExporter returns a FlowExporter which exports the connections of the
+// store as IPFIX data records to the collector at the given address, over the
+// given transport protocol. Long-lived connections are exported every
+// activeTimeout, and connections whose counters did not change for idleTimeout
+// are exported once until they are updated again. Closed connections are
+// exported a last time, and then evicted from the store once their final
+// records are sent.
+func NewFlowExporter(connStore connections.ConnectionStore, nodeName, collectorAddr, collectorProto string, activeTimeout, idleTimeout time.Duration) (*flowExporter, error) {
+	process, err := ipfix.NewExportingProcess(collectorAddr, collectorProto, genObservationDomainID(nodeName))
+	if err != nil {
+		return nil, err
+	}
+	process.AddTemplate(templateV4)
+	process.AddTemplate(templateV6)
+	return &flowExporter{
+		connStore:     connStore,
+		process:       process,
+		nodeName:      nodeName,
+		activeTimeout: activeTimeout,
+		idleTimeout:   idleTimeout,
+	}, nil
+}
+
+// genObservationDomainID generates the Observation Domain ID of the Node, which
+// identifies the exporter of the records at the collector.
+func genObservationDomainID(nodeName string) uint32 {
+	h := fnv.New32()
+	h.Write([]byte(nodeName))
+	return h.Sum32()
+}
+
+// Run checks the connections of the store every poll interval and exports the
+// ones which timed out or were closed, until stopCh is closed.
+func (exp *flowExporter) Run(stopCh <-chan struct{}) {
+	klog.Infof("Starting flow exporter")
+	defer exp.process.Close()
+
+	ticker := time.NewTicker(flowexporter.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			if err := exp.export(time.Now()); err != nil {
+				// The connection to the collector is established again in
+				// the next cycle.
+				klog.Errorf("Error when exporting flow records: %v", err)
+			}
+		}
+	}
+}
+
+// flowEndReason returns whether a record of the connection must be exported at
+// the given time, and the reason of the export. Closed connections are always
+// exported. Idle connections are exported once, and then skipped until they are
+// updated again. Other connections are exported when activeTimeout elapsed
+// since their last export, or since their start if they were never exported.
+func (exp *flowExporter) flowEndReason(conn *flowexporter.Connection, now time.Time) (uint8, bool) {
+	if !conn.IsActive {
+		return endOfFlowReason, true
+	}
+	if now.Sub(conn.LastUpdateTime) >= exp.idleTimeout {
+		if conn.IdleExported {
+			return 0, false
+		}
+		return idleTimeoutReason, true
+	}
+	lastExportTime := conn.LastExportTime
+	if lastExportTime.IsZero() {
+		lastExportTime = conn.StartTime
+	}
+	if now.Sub(lastExportTime) >= exp.activeTimeout {
+		return activeTimeoutReason, true
+	}
+	return 0, false
+}
+
+// exportedConn is the state of a connection when its record was built, which
+// becomes the export state of the connection once the record is sent.
+type exportedConn struct {
+	// conn is the connection whose record was built, which may have been
+	// replaced in the store by a new connection with the same 5-tuple.
+	conn           *flowexporter.Connection
+	reason         uint8
+	isIPv4         bool
+	lastUpdateTime time.Time
+	packets        uint64
+	bytes          uint64
+	reversePackets uint64
+	reverseBytes   uint64
+}
+
+// export sends a data record for each connection of the store which must be
+// exported at the given time, and evicts the closed connections whose final
+// records are sent from the store. The delta counters of the records are the difference with the
+// counters of the previous records of the connections. The export state of the
+// connections, i.e. the reference of the next delta counters and the time of
+// the last export, is only updated once their records are sent, so that the
+// records which could not be sent are exported again in the next cycle with
+// their accumulated delta counters.
+func (exp *flowExporter) export(now time.Time) error {
+	var recordsV4, recordsV6 []ipfix.DataRecord
+	exportedConns := make(map[flowexporter.ConnectionKey]exportedConn)
+	exp.connStore.ForAllConnectionsDo(func(key flowexporter.ConnectionKey, conn *flowexporter.Connection) error {
+		reason, ok := exp.flowEndReason(conn, now)
+		if !ok {
+			return nil
+		}
+		if reason == endOfFlowReason && conn.FinalExportAttemptTime.IsZero() {
+			conn.FinalExportAttemptTime = now
+		}
+		record := exp.dataRecord(conn, reason)
+		isIPv4 := conn.TupleOrig.SourceAddress.To4() != nil
+		if isIPv4 {
+			recordsV4 = append(recordsV4, record)
+		} else {
+			recordsV6 = append(recordsV6, record)
+		}
+		exportedConns[key] = exportedConn{
+			conn:           conn,
+			reason:         reason,
+			isIPv4:         isIPv4,
+			lastUpdateTime: conn.LastUpdateTime,
+			packets:        conn.OriginalPackets,
+			bytes:          conn.OriginalBytes,
+			reversePackets: conn.ReversePackets,
+			reverseBytes:   conn.ReverseBytes,
+		}
+		return nil
+	})
+	// Both address families are attempted even if the records of one of
+	// them cannot be sent.
+	errV4 := exp.sendRecords(templateV4, recordsV4)
+	errV6 := exp.sendRecords(templateV6, recordsV6)
+
+	var closedConns []flowexporter.ConnectionKey
+	droppedConns := 0
+	exp.connStore.ForAllConnectionsDo(func(key flowexporter.ConnectionKey, conn *flowexporter.Connection) error {
+		exported, ok := exportedConns[key]
+		if !ok || exported.conn != conn {
+			return nil
+		}
+		failed := (exported.isIPv4 && errV4 != nil) || (!exported.isIPv4 && errV6 != nil)
+		// The closed connections are kept until their final records are
+		// sent, or until maxClosedConnectionAge elapsed since their first
+		// export attempt.
+		if exported.reason == endOfFlowReason {
+			if !failed {
+				closedConns = append(closedConns, key)
+			} else if now.Sub(conn.FinalExportAttemptTime) >= maxClosedConnectionAge {
+				closedConns = append(closedConns, key)
+				droppedConns++
+			}
+			return nil
+		}
+		if failed {
+			return nil
+		}
+		// The connection may have been updated since its record was built,
+		// in which case it is not idle anymore.
+		if exported.reason == idleTimeoutReason && conn.LastUpdateTime.Equal(exported.lastUpdateTime) {
+			conn.IdleExported = true
+		}
+		conn.LastExportTime = now
+		conn.PrevPackets = exported.packets
+		conn.PrevBytes = exported.bytes
+		conn.PrevReversePackets = exported.reversePackets
+		conn.PrevReverseBytes = exported.reverseBytes
+		return nil
+	})
+	// The connections are only deleted if they were not reused by new
+	// connections since their final records were built.
+	for _, key := range closedConns {
+		exp.connStore.DeleteConnectionByKey(key)
+	}
+	if droppedConns > 0 {
+		klog.Warningf("Evicted %d closed connections whose final records could not be exported for %v", droppedConns, maxClosedConnectionAge)
+	}
+	if errV4 != nil && errV6 != nil {
+		return fmt.Errorf("error sending IPv4 records: %v, error sending IPv6 records: %v", errV4, errV6)
+	} else if errV4 != nil {
+		return fmt.Errorf("error sending IPv4 records: %v", errV4)
+	} else if errV6 != nil {
+		return fmt.Errorf("error sending IPv6 records: %v", errV6)
+	}
+	klog.V(2).Infof("Exported %d flow records, evicted %d closed connections", len(recordsV4)+len(recordsV6), len(closedConns))
+	return nil
+}
+
+// sendRecords sends the data records of the given template, if any.
+func (exp *flowExporter) sendRecords(template *ipfix.Template, records []ipfix.DataRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	return exp.process.SendDataRecords(template, records)
+}
+
+// dataRecord returns the data record of the connection, with the values in the
+// order of the IEs of the templates. The destination is the source of the
+// reply tuple, which is the Endpoint selected for the connections to
+// Services.
+func (exp *flowExporter) dataRecord(conn *flowexporter.Connection, reason uint8) ipfix.DataRecord {
+	return ipfix.DataRecord{
+		conn.TupleOrig.SourceAddress,
+		conn.TupleReply.SourceAddress,
+		conn.StartTime,
+		conn.StopTime,
+		reason,
+		conn.TupleOrig.SourcePort,
+		conn.TupleReply.SourcePort,
+		conn.TupleOrig.Protocol,
+		conn.OriginalPackets,
+		conn.OriginalBytes,
+		conn.ReversePackets,
+		conn.ReverseBytes,
+		conn.OriginalPackets - conn.PrevPackets,
+		conn.OriginalBytes - conn.PrevBytes,
+		conn.ReversePackets - conn.PrevReversePackets,
+		conn.ReverseBytes - conn.PrevReverseBytes,
+		conn.SourcePodNamespace,
+		conn.SourcePodName,
+		conn.DestinationPodNamespace,
+		conn.DestinationPodName,
+		exp.nodeName,
+		conn.DestinationServicePortName,
+		conn.IngressNetworkPolicyName,
+		conn.IngressNetworkPolicyNamespace,
+		conn.EgressNetworkPolicyName,
+		conn.EgressNetworkPolicyNamespace,
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter"
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter/ipfix"
+)
+
+// fakeConnectionStore implements connections.ConnectionStore with a static
+// map of connections.
+type fakeConnectionStore struct {
+	conns map[flowexporter.ConnectionKey]*flowexporter.Connection
+}
+
+func newFakeConnectionStore(conns ...*flowexporter.Connection) *fakeConnectionStore {
+	s := &fakeConnectionStore{conns: make(map[flowexporter.ConnectionKey]*flowexporter.Connection)}
+	for _, conn := range conns {
+		s.conns[flowexporter.NewConnectionKey(conn)] = conn
+	}
+	return s
+}
+
+func (s *fakeConnectionStore) Run(stopCh <-chan struct{}) {}
+
+func (s *fakeConnectionStore) ForAllConnectionsDo(callback flowexporter.ConnectionMapCallBack) error {
+	for key, conn := range s.conns {
+		if err := callback(key, conn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeConnectionStore) DeleteConnectionByKey(connKey flowexporter.ConnectionKey) {
+	if conn, exists := s.conns[connKey]; exists && !conn.IsActive {
+		delete(s.conns, connKey)
+	}
+}
+
+func TestFlowExporterExport(t *testing.T) {
+	collector, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer collector.Close()
+
+	refTime := time.Now()
+	conn := &flowexporter.Connection{
+		StartTime:                     refTime.Add(-10 * time.Second),
+		StopTime:                      refTime,
+		TupleOrig:                     flowexporter.Tuple{SourceAddress: net.IP{10, 10, 0, 1}, DestinationAddress: net.IP{10, 96, 0, 1}, Protocol: 6, SourcePort: 50000, DestinationPort: 80},
+		TupleReply:                    flowexporter.Tuple{SourceAddress: net.IP{10, 10, 1, 2}, DestinationAddress: net.IP{10, 10, 0, 1}, Protocol: 6, SourcePort: 8080, DestinationPort: 50000},
+		OriginalPackets:               10,
+		OriginalBytes:                 1000,
+		ReversePackets:                5,
+		ReverseBytes:                  500,
+		SourcePodNamespace:            "ns1",
+		SourcePodName:                 "pod1",
+		DestinationPodNamespace:       "ns2",
+		DestinationPodName:            "pod2",
+		DestinationServicePortName:    "ns2/svc1:http",
+		IngressNetworkPolicyName:      "np1",
+		IngressNetworkPolicyNamespace: "ns2",
+		IsActive:                      true,
+		LastUpdateTime:                refTime,
+		LastExportTime:                refTime.Add(-time.Minute),
+		PrevPackets:                   4,
+		PrevBytes:                     400,
+		PrevReversePackets:            2,
+		PrevReverseBytes:              200,
+	}
+	connStore := newFakeConnectionStore(conn)
+	exp, err := NewFlowExporter(connStore, "node1", collector.LocalAddr().String(), "udp", time.Minute, 15*time.Second)
+	require.NoError(t, err)
+	defer exp.process.Close()
+	require.NoError(t, exp.export(refTime))
+
+	buf := make([]byte, 65535)
+	collector.SetReadDeadline(time.Now().Add(5 * time.Second))
+	// The IPv4 and IPv6 templates are sent first.
+	n, _, err := collector.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(2), binary.BigEndian.Uint16(buf[16:18]))
+	assert.Equal(t, genObservationDomainID("node1"), binary.BigEndian.Uint32(buf[12:16]))
+
+	n, _, err = collector.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, templateIDv4, binary.BigEndian.Uint16(buf[16:18]))
+	record := buf[20:n]
+	// The destination is the Endpoint, which is the source of the reply tuple.
+	assert.Equal(t, []byte{10, 10, 0, 1}, record[0:4])
+	assert.Equal(t, []byte{10, 10, 1, 2}, record[4:8])
+	assert.Equal(t, uint32(conn.StartTime.Unix()), binary.BigEndian.Uint32(record[8:12]))
+	assert.Equal(t, uint32(conn.StopTime.Unix()), binary.BigEndian.Uint32(record[12:16]))
+	assert.Equal(t, activeTimeoutReason, record[16])
+	assert.Equal(t, uint16(50000), binary.BigEndian.Uint16(record[17:19]))
+	assert.Equal(t, uint16(8080), binary.BigEndian.Uint16(record[19:21]))
+	assert.Equal(t, uint8(6), record[21])
+	assert.Equal(t, uint64(10), binary.BigEndian.Uint64(record[22:30]))
+	assert.Equal(t, uint64(500), binary.BigEndian.Uint64(record[46:54]))
+	assert.Equal(t, uint64(6), binary.BigEndian.Uint64(record[54:62]))
+	assert.Equal(t, uint64(300), binary.BigEndian.Uint64(record[78:86]))
+	assert.Equal(t, append([]byte{3}, "ns1"...), record[86:90])
+	// The node name is followed by the Service and NetworkPolicy metadata, where the egress
+	// NetworkPolicy is not set.
+	metadata := record[n-20-30:]
+	assert.Equal(t, append([]byte{5}, "node1"...), metadata[0:6])
+	assert.Equal(t, append([]byte{13}, "ns2/svc1:http"...), metadata[6:20])
+	assert.Equal(t, append([]byte{3}, "np1"...), metadata[20:24])
+	assert.Equal(t, append([]byte{3}, "ns2"...), metadata[24:28])
+	assert.Equal(t, []byte{0, 0}, metadata[28:30])
+
+	// The counters of the exported record are the reference of the next
+	// delta counters.
+	assert.Equal(t, refTime, conn.LastExportTime)
+	assert.Equal(t, uint64(10), conn.PrevPackets)
+	assert.Equal(t, uint64(500), conn.PrevReverseBytes)
+}
+
+func TestFlowExporterEvictClosedConnections(t *testing.T) {
+	refTime := time.Now()
+	closedConn := &flowexporter.Connection{
+		TupleOrig:      flowexporter.Tuple{SourceAddress: net.IP{10, 10, 0, 1}, DestinationAddress: net.IP{10, 10, 0, 2}, Protocol: 6, SourcePort: 50000, DestinationPort: 80},
+		TupleReply:     flowexporter.Tuple{SourceAddress: net.IP{10, 10, 0, 2}, DestinationAddress: net.IP{10, 10, 0, 1}, Protocol: 6, SourcePort: 80, DestinationPort: 50000},
+		LastUpdateTime: refTime,
+		LastExportTime: refTime,
+	}
+	activeConn := &flowexporter.Connection{
+		TupleOrig:      flowexporter.Tuple{SourceAddress: net.IP{10, 10, 0, 1}, DestinationAddress: net.IP{10, 10, 0, 2}, Protocol: 6, SourcePort: 50001, DestinationPort: 80},
+		TupleReply:     flowexporter.Tuple{SourceAddress: net.IP{10, 10, 0, 2}, DestinationAddress: net.IP{10, 10, 0, 1}, Protocol: 6, SourcePort: 80, DestinationPort: 50001},
+		IsActive:       true,
+		LastUpdateTime: refTime,
+		LastExportTime: refTime,
+	}
+	connStore := newFakeConnectionStore(closedConn, activeConn)
+	// The collector is unreachable, the closed connection must be kept until
+	// its final record is sent.
+	exp, err := NewFlowExporter(connStore, "node1", "127.0.0.1:0", "tcp", time.Minute, 15*time.Second)
+	require.NoError(t, err)
+	assert.Error(t, exp.export(refTime))
+	assert.Len(t, connStore.conns, 2)
+	assert.Equal(t, refTime, closedConn.FinalExportAttemptTime)
+	nextTime := refTime.Add(flowexporter.PollInterval)
+	assert.Error(t, exp.export(nextTime))
+	assert.Len(t, connStore.conns, 2)
+	assert.Equal(t, refTime, closedConn.FinalExportAttemptTime)
+
+	// The closed connection is evicted once its final record is sent.
+	collector, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer collector.Close()
+	exp.process, err = ipfix.NewExportingProcess(collector.LocalAddr().String(), "udp", genObservationDomainID("node1"))
+	require.NoError(t, err)
+	defer exp.process.Close()
+	exp.process.AddTemplate(templateV4)
+	exp.process.AddTemplate(templateV6)
+	require.NoError(t, exp.export(nextTime.Add(flowexporter.PollInterval)))
+	assert.Len(t, connStore.conns, 1)
+	assert.Contains(t, connStore.conns, flowexporter.NewConnectionKey(activeConn))
+}
+
+func TestFlowExporterEvictExpiredClosedConnections(t *testing.T) {
+	refTime := time.Now()
+	closedConn := &flowexporter.Connection{
+		TupleOrig:              flowexporter.Tuple{SourceAddress: net.IP{10, 10, 0, 1}, DestinationAddress: net.IP{10, 10, 0, 2}, Protocol: 6, SourcePort: 50000, DestinationPort: 80},
+		TupleReply:             flowexporter.Tuple{SourceAddress: net.IP{10, 10, 0, 2}, DestinationAddress: net.IP{10, 10, 0, 1}, Protocol: 6, SourcePort: 80, DestinationPort: 50000},
+		LastUpdateTime:         refTime,
+		FinalExportAttemptTime: refTime.Add(-maxClosedConnectionAge),
+	}
+	connStore := newFakeConnectionStore(closedConn)
+	// The final record of the closed connection could not be sent for
+	// maxClosedConnectionAge, the connection is evicted.
+	exp, err := NewFlowExporter(connStore, "node1", "127.0.0.1:0", "tcp", time.Minute, 15*time.Second)
+	require.NoError(t, err)
+	assert.Error(t, exp.export(refTime))
+	assert.Empty(t, connStore.conns)
+}
+
+func TestFlowExporterExportFailure(t *testing.T) {
+	refTime := time.Now()
+	lastExportTime := refTime.Add(-time.Minute)
+	conn := &flowexporter.Connection{
+		TupleOrig:       flowexporter.Tuple{SourceAddress: net.IP{10, 10, 0, 1}, DestinationAddress: net.IP{10, 10, 0, 2}, Protocol: 6, SourcePort: 50000, DestinationPort: 80},
+		TupleReply:      flowexporter.Tuple{SourceAddress: net.IP{10, 10, 0, 2}, DestinationAddress: net.IP{10, 10, 0, 1}, Protocol: 6, SourcePort: 80, DestinationPort: 50000},
+		OriginalPackets: 10,
+		OriginalBytes:   1000,
+		IsActive:        true,
+		LastUpdateTime:  refTime,
+		LastExportTime:  lastExportTime,
+		PrevPackets:     4,
+		PrevBytes:       400,
+	}
+	connStore := newFakeConnectionStore(conn)
+	// The collector is unreachable, the export state of the connection must
+	// not change.
+	exp, err := NewFlowExporter(connStore, "node1", "127.0.0.1:0", "tcp", time.Minute, 15*time.Second)
+	require.NoError(t, err)
+	assert.Error(t, exp.export(refTime))
+	assert.Equal(t, lastExportTime, conn.LastExportTime)
+	assert.Equal(t, uint64(4), conn.PrevPackets)
+	assert.Equal(t, uint64(400), conn.PrevBytes)
+
+	// The record is exported in the next cycle with the delta counters
+	// accumulated since the last record which was sent.
+	collector, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer collector.Close()
+	exp.process, err = ipfix.NewExportingProcess(collector.LocalAddr().String(), "udp", genObservationDomainID("node1"))
+	require.NoError(t, err)
+	defer exp.process.Close()
+	exp.process.AddTemplate(templateV4)
+	exp.process.AddTemplate(templateV6)
+	conn.OriginalPackets, conn.OriginalBytes = 15, 1500
+	nextTime := refTime.Add(flowexporter.PollInterval)
+	require.NoError(t, exp.export(nextTime))
+
+	buf := make([]byte, 65535)
+	collector.SetReadDeadline(time.Now().Add(5 * time.Second))
+	// Skip the templates.
+	_, _, err = collector.ReadFrom(buf)
+	require.NoError(t, err)
+	_, _, err = collector.ReadFrom(buf)
+	require.NoError(t, err)
+	record := buf[20:]
+	assert.Equal(t, uint64(11), binary.BigEndian.Uint64(record[54:62]))
+	assert.Equal(t, uint64(1100), binary.BigEndian.Uint64(record[62:70]))
+	assert.Equal(t, nextTime, conn.LastExportTime)
+	assert.Equal(t, uint64(15), conn.PrevPackets)
+	assert.Equal(t, uint64(1500), conn.PrevBytes)
+}
+
+func TestFlowEndReason(t *testing.T) {
+	refTime := time.Now()
+	exp := &flowExporter{activeTimeout: time.Minute, idleTimeout: 15 * time.Second}
+	tests := []struct {
+		name           string
+		conn           flowexporter.Connection
+		expectedExport bool
+		expectedReason uint8
+	}{
+		{
+			name:           "closed",
+			conn:           flowexporter.Connection{IsActive: false, LastUpdateTime: refTime, LastExportTime: refTime},
+			expectedExport: true,
+			expectedReason: endOfFlowReason,
+		},
+		{
+			name:           "idle",
+			conn:           flowexporter.Connection{IsActive: true, LastUpdateTime: refTime.Add(-20 * time.Second), LastExportTime: refTime.Add(-30 * time.Second)},
+			expectedExport: true,
+			expectedReason: idleTimeoutReason,
+		},
+		{
+			name:           "idle-already-exported",
+			conn:           flowexporter.Connection{IsActive: true, LastUpdateTime: refTime.Add(-20 * time.Minute), LastExportTime: refTime.Add(-10 * time.Minute), IdleExported: true},
+			expectedExport: false,
+		},
+		{
+			name:           "active-timeout",
+			conn:           flowexporter.Connection{IsActive: true, LastUpdateTime: refTime, LastExportTime: refTime.Add(-time.Minute)},
+			expectedExport: true,
+			expectedReason: activeTimeoutReason,
+		},
+		{
+			name:           "active-never-exported",
+			conn:           flowexporter.Connection{IsActive: true, StartTime: refTime.Add(-2 * time.Minute), LastUpdateTime: refTime},
+			expectedExport: true,
+			expectedReason: activeTimeoutReason,
+		},
+		{
+			name:           "active-recently-exported",
+			conn:           flowexporter.Connection{IsActive: true, LastUpdateTime: refTime, LastExportTime: refTime.Add(-30 * time.Second)},
+			expectedExport: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, export := exp.flowEndReason(&tt.conn, refTime)
+			assert.Equal(t, tt.expectedExport, export)
+			if tt.expectedExport {
+				assert.Equal(t, tt.expectedReason, reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipfix
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"k8s.io/klog"
+)
+
+// recordChanSize is the size of the channel of the decoded data records, which
+// absorbs the bursts of records exported by the agents.
+const recordChanSize = 4096
+
+// CollectingProcess receives IPFIX messages from exporters over TCP or UDP, and
+// delivers their decoded data records. The templates are kept per transport
+// session, which is a TCP connection or the messages received over UDP from
+// the same address.
+type CollectingProcess struct {
+	protocol string
+	listener net.Listener
+	conn     net.PacketConn
+	recordCh chan *DecodedRecord
+}
+
+// NewCollectingProcess returns a new *CollectingProcess listening at the given
+// address. protocol is either "tcp" or "udp".
+func NewCollectingProcess(address, protocol string) (*CollectingProcess, error) {
+	cp := &CollectingProcess{
+		protocol: protocol,
+		recordCh: make(chan *DecodedRecord, recordChanSize),
+	}
+	var err error
+	switch protocol {
+	case "tcp":
+		cp.listener, err = net.Listen("tcp", address)
+	case "udp":
+		cp.conn, err = net.ListenPacket("udp", address)
+	default:
+		return nil, fmt.Errorf("unsupported transport protocol %s, must be tcp or udp", protocol)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error listening on %s over %s: %v", address, protocol, err)
+	}
+	return cp, nil
+}
+
+// Addr returns the address the process is listening at.
+func (cp *CollectingProcess) Addr() net.Addr {
+	if cp.listener != nil {
+		return cp.listener.Addr()
+	}
+	return cp.conn.LocalAddr()
+}
+
+// Records returns the channel of the decoded data records.
+func (cp *CollectingProcess) Records() <-chan *DecodedRecord {
+	return cp.recordCh
+}
+
+// Run receives the IPFIX messages until stopCh is closed.
+func (cp *CollectingProcess) Run(stopCh <-chan struct{}) {
+	klog.Infof("Starting IPFIX collecting process on %s over %s", cp.Addr(), cp.protocol)
+	go func() {
+		<-stopCh
+		if cp.listener != nil {
+			cp.listener.Close()
+		} else {
+			cp.conn.Close()
+		}
+	}()
+	if cp.listener != nil {
+		cp.runTCP(stopCh)
+	} else {
+		cp.runUDP(stopCh)
+	}
+}
+
+func (cp *CollectingProcess) runTCP(stopCh <-chan struct{}) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := cp.listener.Accept()
+		if err != nil {
+			select {
+			case <-stopCh:
+				return
+			default:
+			}
+			klog.Errorf("Error when accepting IPFIX exporter connection: %v", err)
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cp.handleTCPConn(conn, stopCh)
+		}()
+	}
+}
+
+// handleTCPConn decodes the messages received on a connection, which is a
+// transport session whose templates are discarded when it is closed.
+func (cp *CollectingProcess) handleTCPConn(conn net.Conn, stopCh <-chan struct{}) {
+	defer conn.Close()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-stopCh:
+			conn.Close()
+		case <-done:
+		}
+	}()
+	exporterAddr := conn.RemoteAddr().String()
+	klog.V(2).Infof("Accepted IPFIX exporter connection from %s", exporterAddr)
+	s := newSession(exporterAddr)
+	header := make([]byte, messageHeaderLength)
+	for {
+		if _, err := io.ReadFull(conn, header); err != nil {
+			if err != io.EOF {
+				klog.V(2).Infof("Closing IPFIX exporter connection from %s: %v", exporterAddr, err)
+			}
+			return
+		}
+		length := int(binary.BigEndian.Uint16(header[2:4]))
+		if length < messageHeaderLength {
+			klog.Errorf("Closing IPFIX exporter connection from %s: invalid message length %d", exporterAddr, length)
+			return
+		}
+		msg := make([]byte, length)
+		copy(msg, header)
+		if _, err := io.ReadFull(conn, msg[messageHeaderLength:]); err != nil {
+			klog.V(2).Infof("Closing IPFIX exporter connection from %s: %v", exporterAddr, err)
+			return
+		}
+		// The messages are delimited by their length, so that a malformed
+		// message does not prevent decoding the following ones.
+		if !cp.handleMessage(s, msg, stopCh) {
+			return
+		}
+	}
+}
+
+// runUDP decodes the messages received over UDP. There is no way to detect that
+// an exporter restarted, which re-sends its templates periodically instead.
+func (cp *CollectingProcess) runUDP(stopCh <-chan struct{}) {
+	sessions := make(map[string]*session)
+	buf := make([]byte, maxTCPMessageSize)
+	for {
+		n, addr, err := cp.conn.ReadFrom(buf)
+		if err != nil {
+			select {
+			case <-stopCh:
+				return
+			default:
+			}
+			klog.Errorf("Error when receiving IPFIX message: %v", err)
+			continue
+		}
+		exporterAddr := addr.String()
+		s, ok := sessions[exporterAddr]
+		if !ok {
+			s = newSession(exporterAddr)
+			sessions[exporterAddr] = s
+		}
+		if !cp.handleMessage(s, buf[:n], stopCh) {
+			return
+		}
+	}
+}
+
+// handleMessage decodes a message and delivers its data records. It returns
+// false if stopCh was closed while delivering the records.
+func (cp *CollectingProcess) handleMessage(s *session, msg []byte, stopCh <-chan struct{}) bool {
+	records, err := s.decodeMessage(msg)
+	if err != nil {
+		klog.Errorf("Error when decoding IPFIX message from %s: %v", s.exporterAddr, err)
+		return true
+	}
+	for _, record := range records {
+		select {
+		case cp.recordCh <- record:
+		case <-stopCh:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,316 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipfix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+// DataType is the abstract data type of an Information Element, see RFC 7012.
+type DataType uint8
+
+const (
+	Unsigned8 DataType = iota
+	Unsigned16
+	Unsigned32
+	Unsigned64
+	DateTimeSeconds
+	IPv4Address
+	IPv6Address
+	String
+	// OctetArray is the data type of the IEs unknown to the collectors,
+	// whose values are decoded as raw bytes.
+	OctetArray
+)
+
+const (
+	// IANAEnterpriseID is the enterprise number of the IEs registered by
+	// IANA, which is not encoded in templates.
+	IANAEnterpriseID uint32 = 0
+	// IANAReversedEnterpriseID is the Private Enterprise Number reserved by
+	// RFC 5103 for the reverse IEs of bidirectional flows.
+	IANAReversedEnterpriseID uint32 = 29305
+	// AntreaEnterpriseID is the Private Enterprise Number of the Antrea IEs.
+	AntreaEnterpriseID uint32 = 56506
+	// VariableLength is the length of the variable-length IEs in templates.
+	VariableLength uint16 = 65535
+)
+
+// InfoElement is an IPFIX Information Element.
+type InfoElement struct {
+	Name         string
+	ElementID    uint16
+	DataType     DataType
+	EnterpriseID uint32
+	// Len is the length of the values of the IE in data records, which is
+	// VariableLength for strings.
+	Len uint16
+}
+
+// The IANA IEs exported by Antrea, see
+// https://www.iana.org/assignments/ipfix/ipfix.xhtml.
+var (
+	FlowStartSeconds         = &InfoElement{"flowStartSeconds", 150, DateTimeSeconds, IANAEnterpriseID, 4}
+	FlowEndSeconds           = &InfoElement{"flowEndSeconds", 151, DateTimeSeconds, IANAEnterpriseID, 4}
+	FlowEndReason            = &InfoElement{"flowEndReason", 136, Unsigned8, IANAEnterpriseID, 1}
+	SourceIPv4Address        = &InfoElement{"sourceIPv4Address", 8, IPv4Address, IANAEnterpriseID, 4}
+	DestinationIPv4Address   = &InfoElement{"destinationIPv4Address", 12, IPv4Address, IANAEnterpriseID, 4}
+	SourceIPv6Address        = &InfoElement{"sourceIPv6Address", 27, IPv6Address, IANAEnterpriseID, 16}
+	DestinationIPv6Address   = &InfoElement{"destinationIPv6Address", 28, IPv6Address, IANAEnterpriseID, 16}
+	SourceTransportPort      = &InfoElement{"sourceTransportPort", 7, Unsigned16, IANAEnterpriseID, 2}
+	DestinationTransportPort = &InfoElement{"destinationTransportPort", 11, Unsigned16, IANAEnterpriseID, 2}
+	ProtocolIdentifier       = &InfoElement{"protocolIdentifier", 4, Unsigned8, IANAEnterpriseID, 1}
+	PacketTotalCount         = &InfoElement{"packetTotalCount", 86, Unsigned64, IANAEnterpriseID, 8}
+	OctetTotalCount          = &InfoElement{"octetTotalCount", 85, Unsigned64, IANAEnterpriseID, 8}
+	ReversePacketTotalCount  = &InfoElement{"reversePacketTotalCount", 86, Unsigned64, IANAReversedEnterpriseID, 8}
+	ReverseOctetTotalCount   = &InfoElement{"reverseOctetTotalCount", 85, Unsigned64, IANAReversedEnterpriseID, 8}
+	PacketDeltaCount         = &InfoElement{"packetDeltaCount", 2, Unsigned64, IANAEnterpriseID, 8}
+	OctetDeltaCount          = &InfoElement{"octetDeltaCount", 1, Unsigned64, IANAEnterpriseID, 8}
+	ReversePacketDeltaCount  = &InfoElement{"reversePacketDeltaCount", 2, Unsigned64, IANAReversedEnterpriseID, 8}
+	ReverseOctetDeltaCount   = &InfoElement{"reverseOctetDeltaCount", 1, Unsigned64, IANAReversedEnterpriseID, 8}
+)
+
+// The Antrea enterprise-specific IEs.
+var (
+	SourcePodNamespace            = &InfoElement{"sourcePodNamespace", 100, String, AntreaEnterpriseID, VariableLength}
+	SourcePodName                 = &InfoElement{"sourcePodName", 101, String, AntreaEnterpriseID, VariableLength}
+	DestinationPodNamespace       = &InfoElement{"destinationPodNamespace", 102, String, AntreaEnterpriseID, VariableLength}
+	DestinationPodName            = &InfoElement{"destinationPodName", 103, String, AntreaEnterpriseID, VariableLength}
+	SourceNodeName                = &InfoElement{"sourceNodeName", 104, String, AntreaEnterpriseID, VariableLength}
+	DestinationServicePortName    = &InfoElement{"destinationServicePortName", 105, String, AntreaEnterpriseID, VariableLength}
+	IngressNetworkPolicyName      = &InfoElement{"ingressNetworkPolicyName", 106, String, AntreaEnterpriseID, VariableLength}
+	IngressNetworkPolicyNamespace = &InfoElement{"ingressNetworkPolicyNamespace", 107, String, AntreaEnterpriseID, VariableLength}
+	EgressNetworkPolicyName       = &InfoElement{"egressNetworkPolicyName", 108, String, AntreaEnterpriseID, VariableLength}
+	EgressNetworkPolicyNamespace  = &InfoElement{"egressNetworkPolicyNamespace", 109, String, AntreaEnterpriseID, VariableLength}
+	DestinationNodeName           = &InfoElement{"destinationNodeName", 110, String, AntreaEnterpriseID, VariableLength}
+)
+
+// ieKey identifies an IE in template records.
+type ieKey struct {
+	enterpriseID uint32
+	elementID    uint16
+}
+
+// knownElements holds the IEs which can be decoded by collectors by name.
+var knownElements = map[ieKey]*InfoElement{}
+
+func init() {
+	for _, ie := range []*InfoElement{
+		FlowStartSeconds, FlowEndSeconds, FlowEndReason,
+		SourceIPv4Address, DestinationIPv4Address, SourceIPv6Address, DestinationIPv6Address,
+		SourceTransportPort, DestinationTransportPort, ProtocolIdentifier,
+		PacketTotalCount, OctetTotalCount, ReversePacketTotalCount, ReverseOctetTotalCount,
+		PacketDeltaCount, OctetDeltaCount, ReversePacketDeltaCount, ReverseOctetDeltaCount,
+		SourcePodNamespace, SourcePodName, DestinationPodNamespace, DestinationPodName,
+		SourceNodeName, DestinationNodeName, DestinationServicePortName,
+		IngressNetworkPolicyName, IngressNetworkPolicyNamespace,
+		EgressNetworkPolicyName, EgressNetworkPolicyNamespace,
+	} {
+		knownElements[ieKey{ie.EnterpriseID, ie.ElementID}] = ie
+	}
+}
+
+// lookupInfoElement returns the IE of a field specifier of a received template
+// with the length of the field, which may differ from the length of the known
+// IE with reduced-size encoding, see RFC 7011 section 6.2. Unknown IEs are
+// returned as octet arrays, so that their values can be skipped.
+func lookupInfoElement(enterpriseID uint32, elementID uint16, length uint16) *InfoElement {
+	known, ok := knownElements[ieKey{enterpriseID, elementID}]
+	if !ok {
+		return &InfoElement{fmt.Sprintf("unknown-%d-%d", enterpriseID, elementID), elementID, OctetArray, enterpriseID, length}
+	}
+	ie := *known
+	ie.Len = length
+	return &ie
+}
+
+// encodeFieldSpecifier writes the field specifier of the IE in a template
+// record. The enterprise bit is set for enterprise-specific IEs, which are
+// followed by their enterprise number.
+func (ie *InfoElement) encodeFieldSpecifier(buf *bytes.Buffer) {
+	id := ie.ElementID
+	if ie.EnterpriseID != IANAEnterpriseID {
+		id |= 0x8000
+	}
+	binary.Write(buf, binary.BigEndian, id)
+	binary.Write(buf, binary.BigEndian, ie.Len)
+	if ie.EnterpriseID != IANAEnterpriseID {
+		binary.Write(buf, binary.BigEndian, ie.EnterpriseID)
+	}
+}
+
+// encodeValue writes a value of the IE in a data record.
+func (ie *InfoElement) encodeValue(buf *bytes.Buffer, value interface{}) error {
+	var ok bool
+	switch ie.DataType {
+	case Unsigned8:
+		var v uint8
+		if v, ok = value.(uint8); ok {
+			buf.WriteByte(v)
+		}
+	case Unsigned16:
+		var v uint16
+		if v, ok = value.(uint16); ok {
+			binary.Write(buf, binary.BigEndian, v)
+		}
+	case Unsigned32:
+		var v uint32
+		if v, ok = value.(uint32); ok {
+			binary.Write(buf, binary.BigEndian, v)
+		}
+	case Unsigned64:
+		var v uint64
+		if v, ok = value.(uint64); ok {
+			binary.Write(buf, binary.BigEndian, v)
+		}
+	case DateTimeSeconds:
+		var v time.Time
+		if v, ok = value.(time.Time); ok {
+			binary.Write(buf, binary.BigEndian, uint32(v.Unix()))
+		}
+	case IPv4Address:
+		var v net.IP
+		if v, ok = value.(net.IP); ok {
+			ip := v.To4()
+			if ip == nil {
+				return fmt.Errorf("invalid IPv4 address %s for IE %s", v, ie.Name)
+			}
+			buf.Write(ip)
+		}
+	case IPv6Address:
+		var v net.IP
+		if v, ok = value.(net.IP); ok {
+			ip := v.To16()
+			if ip == nil {
+				return fmt.Errorf("invalid IPv6 address %s for IE %s", v, ie.Name)
+			}
+			buf.Write(ip)
+		}
+	case String:
+		var v string
+		if v, ok = value.(string); ok {
+			if err := ie.encodeLength(buf, len(v)); err != nil {
+				return err
+			}
+			buf.WriteString(v)
+		}
+	case OctetArray:
+		var v []byte
+		if v, ok = value.([]byte); ok {
+			if ie.Len == VariableLength {
+				if err := ie.encodeLength(buf, len(v)); err != nil {
+					return err
+				}
+			} else if len(v) != int(ie.Len) {
+				return fmt.Errorf("invalid length %d of value of IE %s, must be %d", len(v), ie.Name, ie.Len)
+			}
+			buf.Write(v)
+		}
+	default:
+		return fmt.Errorf("unsupported data type %d of IE %s", ie.DataType, ie.Name)
+	}
+	if !ok {
+		return fmt.Errorf("invalid value type %T for IE %s", value, ie.Name)
+	}
+	return nil
+}
+
+// encodeLength writes the length prefix of a variable-length value, on 3 bytes
+// if it is not less than 255, see RFC 7011 section 7.
+func (ie *InfoElement) encodeLength(buf *bytes.Buffer, length int) error {
+	if length < 255 {
+		buf.WriteByte(uint8(length))
+	} else if length <= 65535 {
+		buf.WriteByte(255)
+		binary.Write(buf, binary.BigEndian, uint16(length))
+	} else {
+		return fmt.Errorf("value of IE %s is too long: %d bytes", ie.Name, length)
+	}
+	return nil
+}
+
+// decodeValue reads a value of the IE at the start of data, and returns it with
+// the number of bytes it takes in the data record. The unsigned values may use
+// reduced-size encoding, and are returned with the type of the IE.
+func (ie *InfoElement) decodeValue(data []byte) (interface{}, int, error) {
+	offset, length := 0, int(ie.Len)
+	if ie.Len == VariableLength {
+		if len(data) < 1 {
+			return nil, 0, fmt.Errorf("missing length of value of IE %s", ie.Name)
+		}
+		offset, length = 1, int(data[0])
+		if length == 255 {
+			if len(data) < 3 {
+				return nil, 0, fmt.Errorf("missing length of value of IE %s", ie.Name)
+			}
+			offset, length = 3, int(binary.BigEndian.Uint16(data[1:3]))
+		}
+	}
+	if len(data) < offset+length {
+		return nil, 0, fmt.Errorf("value of IE %s is truncated", ie.Name)
+	}
+	v := data[offset : offset+length]
+	var maxLength int
+	switch ie.DataType {
+	case Unsigned8:
+		maxLength = 1
+	case Unsigned16:
+		maxLength = 2
+	case Unsigned32, DateTimeSeconds, IPv4Address:
+		maxLength = 4
+	case Unsigned64:
+		maxLength = 8
+	case IPv6Address:
+		maxLength = 16
+	default:
+		maxLength = 65535
+	}
+	if length > maxLength || (length != maxLength && (ie.DataType == IPv4Address || ie.DataType == IPv6Address)) {
+		return nil, 0, fmt.Errorf("invalid length %d of value of IE %s", length, ie.Name)
+	}
+	var value interface{}
+	switch ie.DataType {
+	case Unsigned8:
+		value = uint8(decodeUnsigned(v))
+	case Unsigned16:
+		value = uint16(decodeUnsigned(v))
+	case Unsigned32:
+		value = uint32(decodeUnsigned(v))
+	case Unsigned64:
+		value = decodeUnsigned(v)
+	case DateTimeSeconds:
+		value = time.Unix(int64(decodeUnsigned(v)), 0)
+	case IPv4Address, IPv6Address:
+		value = net.IP(append([]byte(nil), v...))
+	case String:
+		value = string(v)
+	default:
+		value = append([]byte(nil), v...)
+	}
+	return value, offset + length, nil
+}
+
+// decodeUnsigned decodes a big-endian unsigned integer of at most 8 bytes.
+func decodeUnsigned(v []byte) uint64 {
+	var u uint64
+	for _, b := range v {
+		u = u<<8 | uint64(b)
+	}
+	return u
+}
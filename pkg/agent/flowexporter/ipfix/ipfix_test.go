@@ -0,0 +1,216 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipfix
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTemplate = &Template{
+	ID:       MinTemplateID,
+	Elements: []*InfoElement{SourceIPv4Address, SourcePodName},
+}
+
+func TestEncodeTemplateRecord(t *testing.T) {
+	expected := []byte{
+		0x01, 0x00, 0x00, 0x02, // template ID and field count
+		0x00, 0x08, 0x00, 0x04, // sourceIPv4Address
+		0x80, 0x65, 0xff, 0xff, 0x00, 0x00, 0xdc, 0xba, // sourcePodName with the enterprise bit and number
+	}
+	assert.Equal(t, expected, testTemplate.encodeTemplateRecord())
+}
+
+func TestEncodeDataRecord(t *testing.T) {
+	record, err := testTemplate.encodeDataRecord(DataRecord{net.IP{10, 0, 0, 1}, "pod1"})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{10, 0, 0, 1, 4, 'p', 'o', 'd', '1'}, record)
+
+	longName := strings.Repeat("a", 300)
+	record, err = testTemplate.encodeDataRecord(DataRecord{net.ParseIP("10.0.0.1"), longName})
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte{10, 0, 0, 1, 255, 0x01, 0x2c}, longName...), record)
+
+	_, err = testTemplate.encodeDataRecord(DataRecord{net.ParseIP("fd00::1"), "pod1"})
+	assert.Error(t, err, "Expected error for an IPv6 address in an IPv4 IE")
+	_, err = testTemplate.encodeDataRecord(DataRecord{net.IP{10, 0, 0, 1}, 1})
+	assert.Error(t, err, "Expected error for a value of a wrong type")
+	_, err = testTemplate.encodeDataRecord(DataRecord{net.IP{10, 0, 0, 1}})
+	assert.Error(t, err, "Expected error for a missing value")
+}
+
+// readMessage reads an IPFIX message from the connection, and returns its
+// sequence number and the ID of its set.
+func readMessage(t *testing.T, conn net.Conn) (uint32, uint16) {
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	header := make([]byte, messageHeaderLength)
+	_, err := io.ReadFull(conn, header)
+	require.NoError(t, err)
+	require.Equal(t, ipfixVersion, binary.BigEndian.Uint16(header[0:2]))
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32(header[12:16]), "Observation Domain ID should match")
+	body := make([]byte, int(binary.BigEndian.Uint16(header[2:4]))-messageHeaderLength)
+	_, err = io.ReadFull(conn, body)
+	require.NoError(t, err)
+	require.Equal(t, len(body), int(binary.BigEndian.Uint16(body[2:4])), "Set length should match the message length")
+	return binary.BigEndian.Uint32(header[8:12]), binary.BigEndian.Uint16(body[0:2])
+}
+
+func TestExportingProcessTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	process, err := NewExportingProcess(listener.Addr().String(), "tcp", 1)
+	require.NoError(t, err)
+	defer process.Close()
+	process.AddTemplate(testTemplate)
+	records := []DataRecord{{net.IP{10, 0, 0, 1}, "pod1"}, {net.IP{10, 0, 0, 2}, "pod2"}}
+
+	require.NoError(t, process.SendDataRecords(testTemplate, records))
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	// The template is sent first.
+	seq, setID := readMessage(t, conn)
+	assert.Equal(t, uint32(0), seq)
+	assert.Equal(t, templateSetID, setID)
+	seq, setID = readMessage(t, conn)
+	assert.Equal(t, uint32(0), seq)
+	assert.Equal(t, testTemplate.ID, setID)
+
+	// The template is not sent again on the same connection.
+	require.NoError(t, process.SendDataRecords(testTemplate, records[:1]))
+	seq, setID = readMessage(t, conn)
+	assert.Equal(t, uint32(2), seq)
+	assert.Equal(t, testTemplate.ID, setID)
+
+	// The template is re-sent when reconnecting.
+	process.Close()
+	require.NoError(t, process.SendDataRecords(testTemplate, records))
+	newConn, err := listener.Accept()
+	require.NoError(t, err)
+	defer newConn.Close()
+	seq, setID = readMessage(t, newConn)
+	assert.Equal(t, uint32(0), seq)
+	assert.Equal(t, templateSetID, setID)
+	seq, setID = readMessage(t, newConn)
+	assert.Equal(t, uint32(0), seq)
+	assert.Equal(t, testTemplate.ID, setID)
+}
+
+func TestExportingProcessUDPMessageSize(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+	process, err := NewExportingProcess(conn.LocalAddr().String(), "udp", 1)
+	require.NoError(t, err)
+	defer process.Close()
+	process.AddTemplate(testTemplate)
+	// Each record takes 4+1+100 bytes, so the records don't fit in a single
+	// message.
+	var records []DataRecord
+	for i := 0; i < 20; i++ {
+		records = append(records, DataRecord{net.IP{10, 0, 0, byte(i)}, strings.Repeat("p", 100)})
+	}
+	require.NoError(t, process.SendDataRecords(testTemplate, records))
+
+	buf := make([]byte, maxTCPMessageSize)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, templateSetID, binary.BigEndian.Uint16(buf[16:18]))
+	receivedRecords := 0
+	for receivedRecords < len(records) {
+		n, _, err = conn.ReadFrom(buf)
+		require.NoError(t, err)
+		require.LessOrEqual(t, n, maxUDPMessageSize)
+		assert.Equal(t, uint32(receivedRecords), binary.BigEndian.Uint32(buf[8:12]))
+		receivedRecords += (n - messageHeaderLength - setHeaderLength) / 105
+	}
+	assert.Equal(t, len(records), receivedRecords)
+}
+
+func TestDecodeMessage(t *testing.T) {
+	s := newSession("10.0.0.1:50000")
+	// The data set is received before its template and skipped.
+	dataRecord, err := testTemplate.encodeDataRecord(DataRecord{net.IP{10, 0, 0, 1}, "pod1"})
+	require.NoError(t, err)
+	dataMsg := encodeMessage(messageHeader{observationDomainID: 1}, testTemplate.ID, [][]byte{dataRecord, dataRecord})
+	records, err := s.decodeMessage(dataMsg)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	// The template has an unknown IE, and a reduced-size counter.
+	templateRecord := append(testTemplate.encodeTemplateRecord(), 0x80, 0x01, 0x00, 0x02, 0x00, 0x00, 0x00, 0x01, 0x00, 0x56, 0x00, 0x04)
+	templateRecord[3] = 4
+	templateMsg := encodeMessage(messageHeader{observationDomainID: 1}, templateSetID, [][]byte{templateRecord})
+	_, err = s.decodeMessage(templateMsg)
+	require.NoError(t, err)
+	dataRecord = append(dataRecord, 0xaa, 0xbb, 0x00, 0x00, 0x01, 0x00)
+	dataMsg = encodeMessage(messageHeader{observationDomainID: 1}, testTemplate.ID, [][]byte{dataRecord, dataRecord})
+	records, err = s.decodeMessage(dataMsg)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "10.0.0.1:50000", records[0].ExporterAddr)
+	assert.Equal(t, uint32(1), records[0].ObservationDomainID)
+	assert.Equal(t, map[string]interface{}{
+		SourceIPv4Address.Name: net.IP{10, 0, 0, 1},
+		SourcePodName.Name:     "pod1",
+		PacketTotalCount.Name:  uint64(256),
+	}, records[0].Values)
+
+	// The templates are kept per Observation Domain.
+	dataMsg = encodeMessage(messageHeader{observationDomainID: 2}, testTemplate.ID, [][]byte{dataRecord})
+	records, err = s.decodeMessage(dataMsg)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	_, err = s.decodeMessage(dataMsg[:len(dataMsg)-1])
+	assert.Error(t, err, "Expected error for a truncated message")
+}
+
+func TestCollectingProcess(t *testing.T) {
+	for _, protocol := range []string{"tcp", "udp"} {
+		t.Run(protocol, func(t *testing.T) {
+			collector, err := NewCollectingProcess("127.0.0.1:0", protocol)
+			require.NoError(t, err)
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			go collector.Run(stopCh)
+
+			process, err := NewExportingProcess(collector.Addr().String(), protocol, 1)
+			require.NoError(t, err)
+			defer process.Close()
+			process.AddTemplate(testTemplate)
+			require.NoError(t, process.SendDataRecords(testTemplate, []DataRecord{{net.IP{10, 0, 0, 1}, "pod1"}, {net.IP{10, 0, 0, 2}, "pod2"}}))
+
+			for _, expected := range []string{"pod1", "pod2"} {
+				select {
+				case record := <-collector.Records():
+					assert.Equal(t, testTemplate.ID, record.TemplateID)
+					assert.Equal(t, expected, record.Values[SourcePodName.Name])
+				case <-time.After(5 * time.Second):
+					t.Fatalf("Timeout when waiting for data record of %s", expected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipfix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"k8s.io/klog"
+)
+
+const (
+	// ipfixVersion is the version number in the header of IPFIX messages.
+	ipfixVersion uint16 = 10
+	// messageHeaderLength is the length of the header of IPFIX messages.
+	messageHeaderLength = 16
+	// setHeaderLength is the length of the header of IPFIX sets.
+	setHeaderLength = 4
+	// templateSetID is the ID of the sets of template records.
+	templateSetID uint16 = 2
+	// MinTemplateID is the minimum ID of templates, the lower IDs are
+	// reserved for the sets of template records.
+	MinTemplateID uint16 = 256
+)
+
+// Template is an IPFIX template, which describes the IEs of the data records
+// exported with its ID.
+type Template struct {
+	ID       uint16
+	Elements []*InfoElement
+}
+
+// DataRecord holds the values of a data record, in the order of the IEs of its
+// template.
+type DataRecord []interface{}
+
+// encodeTemplateRecord returns the template record describing the template.
+func (t *Template) encodeTemplateRecord() []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, t.ID)
+	binary.Write(&buf, binary.BigEndian, uint16(len(t.Elements)))
+	for _, ie := range t.Elements {
+		ie.encodeFieldSpecifier(&buf)
+	}
+	return buf.Bytes()
+}
+
+// encodeDataRecord returns the data record holding the given values.
+func (t *Template) encodeDataRecord(record DataRecord) ([]byte, error) {
+	if len(record) != len(t.Elements) {
+		return nil, fmt.Errorf("data record has %d values while template %d has %d IEs", len(record), t.ID, len(t.Elements))
+	}
+	var buf bytes.Buffer
+	for i, ie := range t.Elements {
+		if err := ie.encodeValue(&buf, record[i]); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// messageHeader is the header of an IPFIX message, see RFC 7011 section 3.1.
+type messageHeader struct {
+	// exportTime is the time at which the message leaves the exporter, in
+	// seconds since the UNIX epoch.
+	exportTime uint32
+	// sequenceNumber is the number of data records sent before this message
+	// in the transport session, modulo 2^32.
+	sequenceNumber      uint32
+	observationDomainID uint32
+}
+
+// encodeMessage returns an IPFIX message holding a single set of records with
+// the given set ID, which is either templateSetID or the ID of the template of
+// the data records.
+func encodeMessage(header messageHeader, setID uint16, records [][]byte) []byte {
+	setLength := setHeaderLength
+	for _, record := range records {
+		setLength += len(record)
+	}
+	var buf bytes.Buffer
+	buf.Grow(messageHeaderLength + setLength)
+	binary.Write(&buf, binary.BigEndian, ipfixVersion)
+	binary.Write(&buf, binary.BigEndian, uint16(messageHeaderLength+setLength))
+	binary.Write(&buf, binary.BigEndian, header.exportTime)
+	binary.Write(&buf, binary.BigEndian, header.sequenceNumber)
+	binary.Write(&buf, binary.BigEndian, header.observationDomainID)
+	binary.Write(&buf, binary.BigEndian, setID)
+	binary.Write(&buf, binary.BigEndian, uint16(setLength))
+	for _, record := range records {
+		buf.Write(record)
+	}
+	return buf.Bytes()
+}
+
+// DecodedRecord is a data record received by a collector.
+type DecodedRecord struct {
+	// ExporterAddr is the address of the exporter which sent the record.
+	ExporterAddr        string
+	ObservationDomainID uint32
+	TemplateID          uint16
+	// Values holds the values of the record by IE name.
+	Values map[string]interface{}
+}
+
+// session holds the templates received in a transport session from an
+// exporter, by Observation Domain ID and template ID, see RFC 7011 section 8.
+type session struct {
+	exporterAddr string
+	templates    map[uint32]map[uint16]*Template
+}
+
+func newSession(exporterAddr string) *session {
+	return &session{exporterAddr: exporterAddr, templates: make(map[uint32]map[uint16]*Template)}
+}
+
+// decodeMessage decodes an IPFIX message received in the session, and returns
+// its data records. The templates of the message are added to the session, and
+// the data sets of unknown templates are skipped.
+func (s *session) decodeMessage(msg []byte) ([]*DecodedRecord, error) {
+	if len(msg) < messageHeaderLength {
+		return nil, fmt.Errorf("IPFIX message is too short: %d bytes", len(msg))
+	}
+	if version := binary.BigEndian.Uint16(msg[0:2]); version != ipfixVersion {
+		return nil, fmt.Errorf("unsupported IPFIX version %d", version)
+	}
+	if length := int(binary.BigEndian.Uint16(msg[2:4])); length != len(msg) {
+		return nil, fmt.Errorf("IPFIX message length %d does not match the received %d bytes", length, len(msg))
+	}
+	observationDomainID := binary.BigEndian.Uint32(msg[12:16])
+	var records []*DecodedRecord
+	for offset := messageHeaderLength; offset < len(msg); {
+		if len(msg)-offset < setHeaderLength {
+			return nil, fmt.Errorf("IPFIX set header is truncated")
+		}
+		setID := binary.BigEndian.Uint16(msg[offset : offset+2])
+		setLength := int(binary.BigEndian.Uint16(msg[offset+2 : offset+4]))
+		if setLength < setHeaderLength || offset+setLength > len(msg) {
+			return nil, fmt.Errorf("invalid length %d of IPFIX set %d", setLength, setID)
+		}
+		set := msg[offset+setHeaderLength : offset+setLength]
+		offset += setLength
+		switch {
+		case setID == templateSetID:
+			if err := s.decodeTemplateSet(observationDomainID, set); err != nil {
+				return nil, err
+			}
+		case setID >= MinTemplateID:
+			template, ok := s.templates[observationDomainID][setID]
+			if !ok {
+				klog.V(2).Infof("Skipping IPFIX data set of unknown template %d from %s", setID, s.exporterAddr)
+				continue
+			}
+			setRecords, err := s.decodeDataSet(observationDomainID, template, set)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, setRecords...)
+		default:
+			// The options template sets are not used.
+		}
+	}
+	return records, nil
+}
+
+// decodeTemplateSet adds the templates of a template set to the session. A
+// template record without IEs withdraws the template.
+func (s *session) decodeTemplateSet(observationDomainID uint32, set []byte) error {
+	templates, ok := s.templates[observationDomainID]
+	if !ok {
+		templates = make(map[uint16]*Template)
+		s.templates[observationDomainID] = templates
+	}
+	// The remaining bytes after the last record are padding.
+	for len(set) >= 4 {
+		templateID := binary.BigEndian.Uint16(set[0:2])
+		fieldCount := int(binary.BigEndian.Uint16(set[2:4]))
+		set = set[4:]
+		if fieldCount == 0 {
+			delete(templates, templateID)
+			continue
+		}
+		template := &Template{ID: templateID}
+		for i := 0; i < fieldCount; i++ {
+			if len(set) < 4 {
+				return fmt.Errorf("template record %d is truncated", templateID)
+			}
+			elementID := binary.BigEndian.Uint16(set[0:2])
+			length := binary.BigEndian.Uint16(set[2:4])
+			set = set[4:]
+			enterpriseID := IANAEnterpriseID
+			if elementID&0x8000 != 0 {
+				if len(set) < 4 {
+					return fmt.Errorf("template record %d is truncated", templateID)
+				}
+				elementID &^= 0x8000
+				enterpriseID = binary.BigEndian.Uint32(set[0:4])
+				set = set[4:]
+			}
+			template.Elements = append(template.Elements, lookupInfoElement(enterpriseID, elementID, length))
+		}
+		templates[templateID] = template
+	}
+	return nil
+}
+
+// decodeDataSet decodes the data records of a data set of the given template.
+func (s *session) decodeDataSet(observationDomainID uint32, template *Template, set []byte) ([]*DecodedRecord, error) {
+	minRecordLength := 0
+	for _, ie := range template.Elements {
+		if ie.Len == VariableLength {
+			minRecordLength++
+		} else {
+			minRecordLength += int(ie.Len)
+		}
+	}
+	if minRecordLength == 0 {
+		return nil, fmt.Errorf("template %d has no data", template.ID)
+	}
+	var records []*DecodedRecord
+	// The remaining bytes after the last record are padding.
+	for len(set) > 0 && len(set) >= minRecordLength {
+		record := &DecodedRecord{
+			ExporterAddr:        s.exporterAddr,
+			ObservationDomainID: observationDomainID,
+			TemplateID:          template.ID,
+			Values:              make(map[string]interface{}, len(template.Elements)),
+		}
+		for _, ie := range template.Elements {
+			value, n, err := ie.decodeValue(set)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding data record of template %d: %v", template.ID, err)
+			}
+			set = set[n:]
+			if ie.DataType != OctetArray {
+				record.Values[ie.Name] = value
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipfix
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"k8s.io/klog"
+)
+
+const (
+	// maxTCPMessageSize is the maximum size of the messages sent over TCP,
+	// which is bounded by the 16-bit length of the message header.
+	maxTCPMessageSize = 65535
+	// maxUDPMessageSize is the maximum size of the messages sent over UDP,
+	// which must not be fragmented, see RFC 7011 section 10.3.3.
+	maxUDPMessageSize = 1400
+	// templateRefreshTimeout is how often the templates are re-sent over
+	// UDP, as the collector cannot detect that the exporter restarted.
+	templateRefreshTimeout = 10 * time.Minute
+	dialTimeout            = 5 * time.Second
+	writeTimeout           = 5 * time.Second
+)
+
+// ExportingProcess sends IPFIX messages to a collector over TCP or UDP. The
+// templates are sent when the connection to the collector is established, so
+// that the collector can decode the data records after the exporter
+// reconnected or the collector restarted.
+type ExportingProcess struct {
+	collectorAddr       string
+	protocol            string
+	observationDomainID uint32
+	maxMessageSize      int
+
+	mutex     sync.Mutex
+	templates []*Template
+	// conn is the connection to the collector, nil if it is not established.
+	conn net.Conn
+	// sequenceNumber is the number of data records sent in the current
+	// transport session.
+	sequenceNumber      uint32
+	templatesLastSentAt time.Time
+}
+
+// NewExportingProcess returns a new *ExportingProcess sending messages to the
+// collector at the given address. protocol is either "tcp" or "udp".
+func NewExportingProcess(collectorAddr, protocol string, observationDomainID uint32) (*ExportingProcess, error) {
+	var maxMessageSize int
+	switch protocol {
+	case "tcp":
+		maxMessageSize = maxTCPMessageSize
+	case "udp":
+		maxMessageSize = maxUDPMessageSize
+	default:
+		return nil, fmt.Errorf("unsupported transport protocol %s, must be tcp or udp", protocol)
+	}
+	return &ExportingProcess{
+		collectorAddr:       collectorAddr,
+		protocol:            protocol,
+		observationDomainID: observationDomainID,
+		maxMessageSize:      maxMessageSize,
+	}, nil
+}
+
+// AddTemplate registers a template. It is sent to the collector before the
+// data records exported with it.
+func (ep *ExportingProcess) AddTemplate(template *Template) {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+	ep.templates = append(ep.templates, template)
+	// Send the template with the next data records.
+	ep.templatesLastSentAt = time.Time{}
+}
+
+// SendDataRecords sends the data records of the given template to the
+// collector, connecting to it first if needed. When an error occurs, the
+// connection is closed, so that a new one is established and the templates
+// are re-sent on the next call.
+func (ep *ExportingProcess) SendDataRecords(template *Template, records []DataRecord) error {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+	if err := ep.ensureConnected(); err != nil {
+		return err
+	}
+	var encodedRecords [][]byte
+	size := messageHeaderLength + setHeaderLength
+	for _, record := range records {
+		encodedRecord, err := template.encodeDataRecord(record)
+		if err != nil {
+			// Skip the invalid records only.
+			klog.Errorf("Failed to encode IPFIX data record: %v", err)
+			continue
+		}
+		if size+len(encodedRecord) > ep.maxMessageSize && len(encodedRecords) > 0 {
+			if err := ep.sendMessage(template.ID, encodedRecords); err != nil {
+				return err
+			}
+			encodedRecords = nil
+			size = messageHeaderLength + setHeaderLength
+		}
+		encodedRecords = append(encodedRecords, encodedRecord)
+		size += len(encodedRecord)
+	}
+	if len(encodedRecords) == 0 {
+		return nil
+	}
+	return ep.sendMessage(template.ID, encodedRecords)
+}
+
+// Close closes the connection to the collector.
+func (ep *ExportingProcess) Close() {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+	ep.closeConn()
+}
+
+// ensureConnected connects to the collector if needed, and sends the templates
+// on new connections, and periodically over UDP.
+func (ep *ExportingProcess) ensureConnected() error {
+	if ep.conn == nil {
+		conn, err := net.DialTimeout(ep.protocol, ep.collectorAddr, dialTimeout)
+		if err != nil {
+			return fmt.Errorf("error connecting to IPFIX collector %s over %s: %v", ep.collectorAddr, ep.protocol, err)
+		}
+		klog.Infof("Connected to IPFIX collector %s over %s", ep.collectorAddr, ep.protocol)
+		ep.conn = conn
+		ep.sequenceNumber = 0
+		ep.templatesLastSentAt = time.Time{}
+	}
+	if !ep.templatesLastSentAt.IsZero() && (ep.protocol == "tcp" || time.Since(ep.templatesLastSentAt) < templateRefreshTimeout) {
+		return nil
+	}
+	if len(ep.templates) == 0 {
+		return nil
+	}
+	var templateRecords [][]byte
+	for _, template := range ep.templates {
+		templateRecords = append(templateRecords, template.encodeTemplateRecord())
+	}
+	if err := ep.sendMessage(templateSetID, templateRecords); err != nil {
+		return err
+	}
+	ep.templatesLastSentAt = time.Now()
+	return nil
+}
+
+// sendMessage sends a message holding the given records. Only data records are
+// counted in the sequence number.
+func (ep *ExportingProcess) sendMessage(setID uint16, records [][]byte) error {
+	msg := encodeMessage(messageHeader{
+		exportTime:          uint32(time.Now().Unix()),
+		sequenceNumber:      ep.sequenceNumber,
+		observationDomainID: ep.observationDomainID,
+	}, setID, records)
+	ep.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := ep.conn.Write(msg); err != nil {
+		ep.closeConn()
+		return fmt.Errorf("error sending IPFIX message to collector %s: %v", ep.collectorAddr, err)
+	}
+	if setID != templateSetID {
+		ep.sequenceNumber += uint32(len(records))
+	}
+	return nil
+}
+
+func (ep *ExportingProcess) closeConn() {
+	if ep.conn == nil {
+		return
+	}
+	ep.conn.Close()
+	ep.conn = nil
+}
@@ -25,6 +25,25 @@ const (
 
 type ConnectionKey [5]string
 
+type ConnectionMapCallBack func(key ConnectionKey, conn *Connection) error
+
+// ConnectionEventType is the type of a conntrack event.
+type ConnectionEventType uint8
+
+const (
+	ConnectionNew ConnectionEventType = iota
+	ConnectionUpdate
+	ConnectionDestroy
+)
+
+// ConnectionEvent is a conntrack event received for a connection. The counters and timestamps of
+// the connection are only set when conntrack includes them in the event, which is always the case
+// for ConnectionDestroy events when conntrack accounting is enabled.
+type ConnectionEvent struct {
+	Type ConnectionEventType
+	Conn *Connection
+}
+
 type Tuple struct {
 	SourceAddress      net.IP
 	DestinationAddress net.IP
@@ -42,6 +61,8 @@ type Connection struct {
 	// For established connections: StopTime is latest time when it was polled.
 	StopTime                       time.Time
 	Zone                           uint16
+	Mark                           uint32
+	Labels                         []byte
 	StatusFlag                     uint32
 	TupleOrig, TupleReply          Tuple
 	OriginalPackets, OriginalBytes uint64
@@ -51,4 +72,30 @@ type Connection struct {
 	SourcePodName           string
 	DestinationPodNamespace string
 	DestinationPodName      string
+	// DestinationServicePortName is the name of the Service port, as "namespace/name:port", for the
+	// connections whose original destination was a Service ClusterIP load-balanced by AntreaProxy.
+	DestinationServicePortName string
+	// Names and Namespaces of the NetworkPolicies whose ingress and egress rules admitted the
+	// connection, which are empty if no rule applies to the connection.
+	IngressNetworkPolicyName      string
+	IngressNetworkPolicyNamespace string
+	EgressNetworkPolicyName       string
+	EgressNetworkPolicyNamespace  string
+	// Fields used to export the connection
+	// IsActive is false when the connection was not found in the last conntrack poll, i.e. it is
+	// closed. The connection is evicted from the connection store once its final record is exported.
+	IsActive bool
+	// LastUpdateTime is the time of the last poll which found the counters of the connection changed.
+	LastUpdateTime time.Time
+	// IdleExported is true when a record was exported after the connection became idle, and the
+	// connection was not updated since then.
+	IdleExported bool
+	// LastExportTime is the time when the last record of the connection was exported.
+	LastExportTime time.Time
+	// FinalExportAttemptTime is the time of the first attempt to export the final record of the
+	// closed connection, used to evict the closed connections whose final records cannot be sent.
+	FinalExportAttemptTime time.Time
+	// Counters of the connection when its last record was exported, used to compute the delta counters.
+	PrevPackets, PrevBytes               uint64
+	PrevReversePackets, PrevReverseBytes uint64
 }
@@ -15,16 +15,19 @@
 package openflow
 
 import (
+	"encoding/binary"
 	"fmt"
 	"math/rand"
 	"net"
 
+	"github.com/contiv/libOpenflow/openflow13"
 	"github.com/contiv/ofnet/ofctrl"
 	"k8s.io/klog"
 
 	"github.com/vmware-tanzu/antrea/pkg/agent/config"
 	"github.com/vmware-tanzu/antrea/pkg/agent/openflow/cookie"
 	"github.com/vmware-tanzu/antrea/pkg/agent/types"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
 	binding "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
 	"github.com/vmware-tanzu/antrea/third_party/proxy"
 )
@@ -177,6 +180,10 @@ type Client interface {
 	// rules.
 	GetNetworkPolicyFlowKeys(npName, npNamespace string) []string
 
+	// NetworkPolicyMetrics returns the traffic stats of the NetworkPolicy rules installed on the OVS bridge, keyed
+	// by the ruleIDs (conjunction IDs) used in InstallPolicyRuleFlows.
+	NetworkPolicyMetrics() map[uint32]*types.RuleMetric
+
 	// ReassignFlowPriorities takes a list of priority updates, and update the actionFlows to replace
 	// the old priority with the desired one, for each priority update.
 	ReassignFlowPriorities(updates map[uint16]uint16) error
@@ -216,8 +223,66 @@ type Client interface {
 	// Find network policy and namespace by conjunction ID.
 	GetPolicyFromConjunction(ruleID uint32) (string, string)
 
-	// RegisterPacketInHandler registers PacketIn handler to process PacketIn event.
-	RegisterPacketInHandler(packetHandlerName string, packetInHandler interface{})
+	// GetRuleActionFromConjunction returns the action of the Antrea-native policy rule by conjunction ID.
+	GetRuleActionFromConjunction(ruleID uint32) *secv1alpha1.RuleAction
+
+	// GetRuleLoggingFromConjunction returns whether audit logging is enabled for the Antrea-native policy rule by
+	// conjunction ID.
+	GetRuleLoggingFromConjunction(ruleID uint32) bool
+
+	// SendTCPPacketOut sends a TCP packet out of the specified OVS port. If tunnelDstIP is not nil, the packet is
+	// sent to the remote Node with that IP through the tunnel. It is used to reject TCP connections.
+	SendTCPPacketOut(
+		srcMAC string,
+		dstMAC string,
+		srcIP string,
+		dstIP string,
+		outPort uint32,
+		tunnelDstIP net.IP,
+		TCPSrcPort uint16,
+		TCPDstPort uint16,
+		TCPSeqNum uint32,
+		TCPAckNum uint32,
+		TCPFlags uint8) error
+
+	// SendICMPPacketOut sends an ICMP packet out of the specified OVS port. If tunnelDstIP is not nil, the packet
+	// is sent to the remote Node with that IP through the tunnel. It is used to reject non-TCP traffic.
+	SendICMPPacketOut(
+		srcMAC string,
+		dstMAC string,
+		srcIP string,
+		dstIP string,
+		outPort uint32,
+		tunnelDstIP net.IP,
+		ICMPType uint8,
+		ICMPCode uint8,
+		ICMPData []byte) error
+
+	// SendUDPPacketOut sends a UDP packet out of the specified OVS port. If tunnelDstIP is not nil, the packet is
+	// sent to the remote Node with that IP through the tunnel. It is used to deliver the DNS responses intercepted
+	// for FQDN-based rules.
+	SendUDPPacketOut(
+		srcMAC string,
+		dstMAC string,
+		srcIP string,
+		dstIP string,
+		outPort uint32,
+		tunnelDstIP net.IP,
+		UDPSrcPort uint16,
+		UDPDstPort uint16,
+		UDPData []byte) error
+
+	// InstallDNSInterceptFlows installs the flows which copy the DNS queries sent from the specified OVS port to the
+	// specified DNS servers to the controller, and which send the responses of these servers to the controller
+	// instead of delivering them, so that the agent can learn the IPs of the FQDNs used in NetworkPolicy rules
+	// before the Pod uses them.
+	InstallDNSInterceptFlows(ofPort uint32, dnsServerIPs []net.IP) error
+
+	// UninstallDNSInterceptFlows removes the flows installed by InstallDNSInterceptFlows.
+	UninstallDNSInterceptFlows(ofPort uint32) error
+
+	// RegisterPacketInHandler registers PacketIn handler to process PacketIn event with the specified reason.
+	RegisterPacketInHandler(packetHandlerReason uint8, packetHandlerName string, packetInHandler interface{})
 	// RegisterPacketInHandler uses SubscribePacketIn to get PacketIn message and process received
 	// packets through registered handlers.
 	StartPacketInHandler(stopCh <-chan struct{})
@@ -335,6 +400,21 @@ func (c *client) UninstallPodFlows(interfaceName string) error {
 	return c.deleteFlows(c.podFlowCache, interfaceName)
 }
 
+func (c *client) InstallDNSInterceptFlows(ofPort uint32, dnsServerIPs []net.IP) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	cacheKey := fmt.Sprintf("%d", ofPort)
+	flows := c.dnsInterceptFlows(ofPort, dnsServerIPs, cookie.Policy)
+	return c.addFlows(c.dnsFlowCache, cacheKey, flows)
+}
+
+func (c *client) UninstallDNSInterceptFlows(ofPort uint32) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	cacheKey := fmt.Sprintf("%d", ofPort)
+	return c.deleteFlows(c.dnsFlowCache, cacheKey)
+}
+
 func (c *client) GetPodFlowKeys(interfaceName string) []string {
 	fCacheI, ok := c.podFlowCache.Load(interfaceName)
 	if !ok {
@@ -521,6 +601,9 @@ func (c *client) initialize() error {
 	if err := c.ofEntryOperations.AddAll(c.establishedConnectionFlows(cookie.Default)); err != nil {
 		return fmt.Errorf("failed to install flows to skip established connections: %v", err)
 	}
+	if err := c.ofEntryOperations.AddAll(c.cnpDenyFlows(cookie.Default)); err != nil {
+		return fmt.Errorf("failed to install flows to drop packets denied by Antrea-native policies: %v", err)
+	}
 	if c.encapMode.SupportsNoEncap() {
 		if err := c.ofEntryOperations.Add(c.l2ForwardOutputReentInPortFlow(c.gatewayPort, cookie.Default)); err != nil {
 			return fmt.Errorf("failed to install L2 forward same in-port and out-port flow: %v", err)
@@ -622,6 +705,7 @@ func (c *client) ReplayFlows() {
 	c.nodeFlowCache.Range(installCachedFlows)
 	c.podFlowCache.Range(installCachedFlows)
 	c.serviceFlowCache.Range(installCachedFlows)
+	c.dnsFlowCache.Range(installCachedFlows)
 
 	c.replayPolicyFlows()
 }
@@ -731,6 +815,89 @@ func (c *client) SendTraceflowPacket(
 	return c.bridge.SendPacketOut(packetOutObj)
 }
 
+func (c *client) SendTCPPacketOut(
+	srcMAC string,
+	dstMAC string,
+	srcIP string,
+	dstIP string,
+	outPort uint32,
+	tunnelDstIP net.IP,
+	TCPSrcPort uint16,
+	TCPDstPort uint16,
+	TCPSeqNum uint32,
+	TCPAckNum uint32,
+	TCPFlags uint8) error {
+	packetOutBuilder := c.newPacketOutBuilder(srcMAC, dstMAC, srcIP, dstIP, outPort, tunnelDstIP)
+	packetOutBuilder = packetOutBuilder.SetIPProtocol(binding.ProtocolTCP)
+	packetOutBuilder = packetOutBuilder.SetTCPSrcPort(TCPSrcPort)
+	packetOutBuilder = packetOutBuilder.SetTCPDstPort(TCPDstPort)
+	packetOutBuilder = packetOutBuilder.SetTCPSeqNum(TCPSeqNum)
+	packetOutBuilder = packetOutBuilder.SetTCPAckNum(TCPAckNum)
+	packetOutBuilder = packetOutBuilder.SetTCPFlags(TCPFlags)
+
+	packetOutObj := packetOutBuilder.Done()
+	return c.bridge.SendPacketOut(packetOutObj)
+}
+
+func (c *client) SendICMPPacketOut(
+	srcMAC string,
+	dstMAC string,
+	srcIP string,
+	dstIP string,
+	outPort uint32,
+	tunnelDstIP net.IP,
+	ICMPType uint8,
+	ICMPCode uint8,
+	ICMPData []byte) error {
+	packetOutBuilder := c.newPacketOutBuilder(srcMAC, dstMAC, srcIP, dstIP, outPort, tunnelDstIP)
+	packetOutBuilder = packetOutBuilder.SetIPProtocol(binding.ProtocolICMP)
+	packetOutBuilder = packetOutBuilder.SetICMPType(ICMPType)
+	packetOutBuilder = packetOutBuilder.SetICMPCode(ICMPCode)
+	packetOutBuilder = packetOutBuilder.SetICMPData(ICMPData)
+
+	packetOutObj := packetOutBuilder.Done()
+	return c.bridge.SendPacketOut(packetOutObj)
+}
+
+func (c *client) SendUDPPacketOut(
+	srcMAC string,
+	dstMAC string,
+	srcIP string,
+	dstIP string,
+	outPort uint32,
+	tunnelDstIP net.IP,
+	UDPSrcPort uint16,
+	UDPDstPort uint16,
+	UDPData []byte) error {
+	packetOutBuilder := c.newPacketOutBuilder(srcMAC, dstMAC, srcIP, dstIP, outPort, tunnelDstIP)
+	packetOutBuilder = packetOutBuilder.SetIPProtocol(binding.ProtocolUDP)
+	packetOutBuilder = packetOutBuilder.SetUDPSrcPort(UDPSrcPort)
+	packetOutBuilder = packetOutBuilder.SetUDPDstPort(UDPDstPort)
+	packetOutBuilder = packetOutBuilder.SetUDPData(UDPData)
+
+	packetOutObj := packetOutBuilder.Done()
+	return c.bridge.SendPacketOut(packetOutObj)
+}
+
+// newPacketOutBuilder returns a PacketOutBuilder for a packet which is sent
+// by the controller directly out of the given OVS port.
+func (c *client) newPacketOutBuilder(srcMAC, dstMAC, srcIP, dstIP string, outPort uint32, tunnelDstIP net.IP) binding.PacketOutBuilder {
+	packetOutBuilder := c.bridge.BuildPacketOut()
+	parsedSrcMAC, _ := net.ParseMAC(srcMAC)
+	parsedDstMAC, _ := net.ParseMAC(dstMAC)
+	packetOutBuilder = packetOutBuilder.SetSrcMAC(parsedSrcMAC)
+	packetOutBuilder = packetOutBuilder.SetDstMAC(parsedDstMAC)
+	packetOutBuilder = packetOutBuilder.SetSrcIP(net.ParseIP(srcIP))
+	packetOutBuilder = packetOutBuilder.SetDstIP(net.ParseIP(dstIP))
+	packetOutBuilder = packetOutBuilder.SetTTL(64)
+	packetOutBuilder = packetOutBuilder.SetInport(openflow13.P_CONTROLLER)
+	packetOutBuilder = packetOutBuilder.SetOutport(outPort)
+	if tunnelDstIP != nil {
+		packetOutBuilder = packetOutBuilder.AddLoadAction(binding.NxmFieldTunIPv4Dst, uint64(binary.BigEndian.Uint32(tunnelDstIP.To4())), binding.Range{0, 31})
+	}
+	return packetOutBuilder
+}
+
 func (c *client) InstallTraceflowFlows(dataplaneTag uint8) error {
 	flow := c.traceflowL2ForwardOutputFlow(dataplaneTag, cookie.Default)
 	if err := c.Add(flow); err != nil {
@@ -740,6 +907,9 @@ func (c *client) InstallTraceflowFlows(dataplaneTag uint8) error {
 	if err := c.Add(flow); err != nil {
 		return err
 	}
+	if err := c.AddAll(c.traceflowCNPDenyFlows(dataplaneTag, cookie.Default)); err != nil {
+		return err
+	}
 	flows := []binding.Flow{}
 	c.conjMatchFlowLock.Lock()
 	defer c.conjMatchFlowLock.Unlock()
@@ -750,7 +920,7 @@ func (c *client) InstallTraceflowFlows(dataplaneTag uint8) error {
 				ctx.dropFlow.CopyToBuilder(priorityNormal+2).
 					MatchRegRange(int(TraceflowReg), uint32(dataplaneTag), OfTraceflowMarkRange).
 					SetHardTimeout(300).
-					Action().SendToController(1).
+					Action().SendToController(uint8(PacketInReasonTF)).
 					Done())
 		}
 	}
@@ -37,6 +37,7 @@ const (
 	Service
 	Policy
 	SNAT
+	PolicyMetric
 )
 
 func (c Category) String() string {
@@ -55,6 +56,8 @@ func (c Category) String() string {
 		return "Policy"
 	case SNAT:
 		return "SNAT"
+	case PolicyMetric:
+		return "PolicyMetric"
 	default:
 		return "Invalid"
 	}
@@ -96,6 +99,11 @@ func (i ID) Category() Category {
 	return Category((i.Raw() & CategoryMask) >> BitwidthReserved)
 }
 
+// ObjectID returns the objectID of the ID.
+func (i ID) ObjectID() uint32 {
+	return uint32(i.Raw())
+}
+
 // String returns the string representation of the ID.
 func (i ID) String() string {
 	return fmt.Sprintf("<round:%d,category:%s>", i.Round(), i.Category().String())
@@ -73,3 +73,11 @@ func TestConcurrentAllocate(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestRequestWithObjectID(t *testing.T) {
+	a := NewAllocator(3)
+	id := a.RequestWithObjectID(PolicyMetric, 0xffff_fffe)
+	assert.Equal(t, uint64(3), id.Round())
+	assert.Equal(t, PolicyMetric, id.Category())
+	assert.Equal(t, uint32(0xffff_fffe), id.ObjectID())
+}
@@ -21,6 +21,7 @@ import (
 
 	"k8s.io/klog"
 
+	"github.com/vmware-tanzu/antrea/pkg/agent/openflow/cookie"
 	"github.com/vmware-tanzu/antrea/pkg/agent/types"
 	"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
 	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
@@ -37,6 +38,8 @@ const (
 	MatchTCPDstPort
 	MatchUDPDstPort
 	MatchSCTPDstPort
+	MatchICMP
+	MatchIPProtocol
 	Unsupported
 )
 
@@ -450,9 +453,17 @@ type policyRuleConjunction struct {
 	toClause      *clause
 	serviceClause *clause
 	actionFlows   []binding.Flow
+	// metricFlows count the packets of the connections allowed by the rule.
+	metricFlows []binding.Flow
 	// NetworkPolicy name and Namespace information for debugging usage.
 	npName      string
 	npNamespace string
+	// ruleAction is the action of the Antrea-native policy rule. It is nil
+	// for K8s NetworkPolicy rules.
+	ruleAction *secv1alpha1.RuleAction
+	// enableLogging indicates whether the packets matching the rule are sent
+	// to the controller for audit logging.
+	enableLogging bool
 }
 
 // clause groups conjunctive match flows. Matches in a clause represent source addresses(for fromClause), or destination
@@ -544,6 +555,9 @@ func (c *clause) generateAddressConjMatch(addr types.Address, addrType types.Add
 }
 
 func getServiceMatchType(protocol *v1beta1.Protocol) int {
+	if protocol == nil {
+		return MatchTCPDstPort
+	}
 	switch *protocol {
 	case v1beta1.ProtocolTCP:
 		return MatchTCPDstPort
@@ -556,20 +570,121 @@ func getServiceMatchType(protocol *v1beta1.Protocol) int {
 	}
 }
 
-func (c *clause) generateServicePortConjMatch(port v1beta1.Service, priority *uint16) *conjunctiveMatch {
-	matchKey := getServiceMatchType(port.Protocol)
+// generateServicePortConjMatches generates the conjunctiveMatches of the provided Service. A port range is compiled
+// into the minimal set of masked port matches which covers exactly the ports of the range. ICMP Services are matched
+// with their type and code, and Services with an IP protocol number are matched with the number only.
+func (c *clause) generateServicePortConjMatches(port v1beta1.Service, priority *uint16) []*conjunctiveMatch {
+	var matchKey int
+	var matchValues []interface{}
+	switch {
+	case port.IPProtocol != nil:
+		matchKey = MatchIPProtocol
+		matchValues = append(matchValues, uint8(*port.IPProtocol))
+	case port.Protocol != nil && *port.Protocol == v1beta1.ProtocolICMP:
+		matchKey = MatchICMP
+		matchValues = append(matchValues, newICMPMatch(port.ICMPType, port.ICMPCode))
+	default:
+		matchKey = getServiceMatchType(port.Protocol)
+		matchValues = getServicePortMatchValues(port)
+	}
+	matches := make([]*conjunctiveMatch, 0, len(matchValues))
+	for _, matchValue := range matchValues {
+		matches = append(matches, &conjunctiveMatch{
+			tableID:    c.ruleTable.GetID(),
+			matchKey:   matchKey,
+			matchValue: matchValue,
+			priority:   priority,
+		})
+	}
+	return matches
+}
+
+// getServicePortMatchValues returns the matchValues of the transport destination port of the provided Service.
+func getServicePortMatchValues(port v1beta1.Service) []interface{} {
 	// Match all ports with the given protocol type if the matchValue is not specified (value is 0).
-	matchValue := uint16(0)
-	if port.Port != nil {
-		matchValue = uint16(port.Port.IntVal)
+	var matchValues []interface{}
+	switch {
+	case port.Port == nil:
+		matchValues = append(matchValues, uint16(0))
+	case port.EndPort == nil || *port.EndPort <= port.Port.IntVal:
+		// Ranges whose end is lower than their start are rejected by the
+		// validation of the Controller. Should one be received anyway, only
+		// its start port is matched rather than any port.
+		matchValues = append(matchValues, uint16(port.Port.IntVal))
+	default:
+		for _, m := range portRangeToMasks(uint16(port.Port.IntVal), uint16(*port.EndPort)) {
+			matchValues = append(matchValues, m.matchValue())
+		}
 	}
-	match := &conjunctiveMatch{
-		tableID:    c.ruleTable.GetID(),
-		matchKey:   matchKey,
-		matchValue: matchValue,
-		priority:   priority,
+	return matchValues
+}
+
+// icmpMatch matches the type and the code of ICMP messages. A nil field matches all the values.
+type icmpMatch struct {
+	icmpType *uint8
+	icmpCode *uint8
+}
+
+func newICMPMatch(icmpType, icmpCode *int32) icmpMatch {
+	var m icmpMatch
+	if icmpType != nil {
+		t := uint8(*icmpType)
+		m.icmpType = &t
 	}
-	return match
+	if icmpCode != nil {
+		c := uint8(*icmpCode)
+		m.icmpCode = &c
+	}
+	return m
+}
+
+func (m icmpMatch) String() string {
+	valueString := func(v *uint8) string {
+		if v == nil {
+			return "*"
+		}
+		return strconv.Itoa(int(*v))
+	}
+	return fmt.Sprintf("type=%s/code=%s", valueString(m.icmpType), valueString(m.icmpCode))
+}
+
+// portMaskMatch is a transport destination port matched with a bitwise mask.
+type portMaskMatch struct {
+	port uint16
+	mask uint16
+}
+
+func (m portMaskMatch) String() string {
+	return fmt.Sprintf("0x%x/0x%x", m.port, m.mask)
+}
+
+// matchValue returns the value of a conjunctiveMatch for the portMaskMatch. Exact matches are represented by the port
+// number only, so that they share the conjMatchFlowContext of the rules which match a single port. A match with an
+// empty mask matches all ports, which is represented by port 0.
+func (m portMaskMatch) matchValue() interface{} {
+	switch m.mask {
+	case 0xffff:
+		return m.port
+	case 0:
+		return uint16(0)
+	}
+	return m
+}
+
+// portRangeToMasks returns the minimal set of portMaskMatches which matches exactly the ports from start to end, both
+// included. Each match covers the largest block of ports which is aligned on its size and fits in the rest of the
+// range.
+func portRangeToMasks(start, end uint16) []portMaskMatch {
+	var matches []portMaskMatch
+	for port := uint32(start); port <= uint32(end); {
+		size := uint32(1)
+		for port&(size*2-1) == 0 && port+size*2-1 <= uint32(end) {
+			size *= 2
+		}
+		matches = append(matches, portMaskMatch{port: uint16(port), mask: uint16(^(size - 1))})
+		port += size
+	}
+	return matches
 }
 
 // addAddrFlows translates the specified addresses to conjunctiveMatchFlows, and returns the corresponding changes on the
@@ -592,9 +707,10 @@ func (c *clause) addAddrFlows(client *client, addrType types.AddressType, addres
 func (c *clause) addServiceFlows(client *client, ports []v1beta1.Service, priority *uint16) []*conjMatchFlowContextChange {
 	var conjMatchFlowContextChanges []*conjMatchFlowContextChange
 	for _, port := range ports {
-		match := c.generateServicePortConjMatch(port, priority)
-		ctxChange := c.addConjunctiveMatchFlow(client, match)
-		conjMatchFlowContextChanges = append(conjMatchFlowContextChanges, ctxChange)
+		for _, match := range c.generateServicePortConjMatches(port, priority) {
+			ctxChange := c.addConjunctiveMatchFlow(client, match)
+			conjMatchFlowContextChanges = append(conjMatchFlowContextChanges, ctxChange)
+		}
 	}
 	return conjMatchFlowContextChanges
 }
@@ -726,6 +842,10 @@ func (c *client) InstallPolicyRuleFlows(ruleID uint32, rule *types.PolicyRule, n
 		id:          ruleID,
 		npName:      npName,
 		npNamespace: npNamespace}
+	if rule.IsAntreaNetworkPolicyRule() {
+		conj.ruleAction = rule.Action
+		conj.enableLogging = rule.EnableLogging
+	}
 	nClause, ruleTable, dropTable := conj.calculateClauses(rule, c)
 
 	// Conjunction action flows are installed only if the number of clauses in the conjunction is > 1. It should be a rule
@@ -734,12 +854,21 @@ func (c *client) InstallPolicyRuleFlows(ruleID uint32, rule *types.PolicyRule, n
 	if nClause > 1 {
 		// Install action flows.
 		var actionFlows []binding.Flow
-		if rule.IsAntreaNetworkPolicyRule() && *rule.Action == secv1alpha1.RuleActionDrop {
-			actionFlows = append(actionFlows, c.conjunctionActionDropFlow(ruleID, ruleTable.GetID(), rule.Priority))
-		} else {
-			actionFlows = append(actionFlows, c.conjunctionActionFlow(ruleID, ruleTable.GetID(), dropTable.GetNext(), rule.Priority))
+		var ruleAction secv1alpha1.RuleAction
+		if rule.IsAntreaNetworkPolicyRule() {
+			ruleAction = *rule.Action
 		}
-		if err := c.ofEntryOperations.AddAll(actionFlows); err != nil {
+		switch ruleAction {
+		case secv1alpha1.RuleActionDrop, secv1alpha1.RuleActionReject:
+			isReject := ruleAction == secv1alpha1.RuleActionReject
+			actionFlows = append(actionFlows, c.conjunctionActionDenyFlow(ruleID, ruleTable.GetID(), dropTable.GetID(), rule.Priority, isReject, conj.enableLogging))
+		case secv1alpha1.RuleActionPass:
+			actionFlows = append(actionFlows, c.conjunctionActionPassFlow(ruleID, ruleTable.GetID(), rule.Priority, conj.enableLogging))
+		default:
+			actionFlows = append(actionFlows, c.conjunctionActionFlow(ruleID, ruleTable.GetID(), dropTable.GetNext(), rule.Priority, conj.enableLogging))
+			conj.metricFlows = []binding.Flow{c.conjunctionMetricFlow(ruleID, ruleTable.GetID())}
+		}
+		if err := c.ofEntryOperations.AddAll(append(actionFlows, conj.metricFlows...)); err != nil {
 			return nil
 		}
 		// Add the action flows after the Openflow entries are installed on the OVS bridge successfully.
@@ -830,7 +959,7 @@ func (c *policyRuleConjunction) calculateClauses(rule *types.PolicyRule, clnt *c
 	switch rule.Direction {
 	case v1beta1.DirectionOut:
 		if rule.IsAntreaNetworkPolicyRule() {
-			ruleTable = clnt.pipeline[cnpEgressRuleTable]
+			ruleTable = clnt.pipeline[CNPEgressRuleTable]
 		} else {
 			ruleTable = clnt.pipeline[EgressRuleTable]
 		}
@@ -838,7 +967,7 @@ func (c *policyRuleConjunction) calculateClauses(rule *types.PolicyRule, clnt *c
 		isEgressRule = true
 	default:
 		if rule.IsAntreaNetworkPolicyRule() {
-			ruleTable = clnt.pipeline[cnpIngressRuleTable]
+			ruleTable = clnt.pipeline[CNPIngressRuleTable]
 		} else {
 			ruleTable = clnt.pipeline[IngressRuleTable]
 		}
@@ -924,6 +1053,9 @@ func (c *policyRuleConjunction) getAllFlowKeys() []string {
 	for _, flow := range c.actionFlows {
 		flowKeys = append(flowKeys, flow.MatchString())
 	}
+	for _, flow := range c.metricFlows {
+		flowKeys = append(flowKeys, flow.MatchString())
+	}
 
 	addClauseFlowKeys := func(clause *clause) {
 		if clause == nil {
@@ -962,6 +1094,27 @@ func (c *client) GetPolicyFromConjunction(ruleID uint32) (string, string) {
 	return conjunction.npName, conjunction.npNamespace
 }
 
+// GetRuleActionFromConjunction returns the action of the Antrea-native policy
+// rule corresponding to the given conjunction ID. It returns nil if the rule is
+// not found or is a K8s NetworkPolicy rule.
+func (c *client) GetRuleActionFromConjunction(ruleID uint32) *secv1alpha1.RuleAction {
+	conjunction := c.getPolicyRuleConjunction(ruleID)
+	if conjunction == nil {
+		return nil
+	}
+	return conjunction.ruleAction
+}
+
+// GetRuleLoggingFromConjunction returns whether audit logging is enabled for the
+// Antrea-native policy rule corresponding to the given conjunction ID.
+func (c *client) GetRuleLoggingFromConjunction(ruleID uint32) bool {
+	conjunction := c.getPolicyRuleConjunction(ruleID)
+	if conjunction == nil {
+		return false
+	}
+	return conjunction.enableLogging
+}
+
 // UninstallPolicyRuleFlows removes the Openflow entry relevant to the specified NetworkPolicy rule.
 // It also returns a slice of stale ofPriorities used by ClusterNetworkPolicies.
 // UninstallPolicyRuleFlows will do nothing if no Openflow entry for the rule is installed.
@@ -978,8 +1131,8 @@ func (c *client) UninstallPolicyRuleFlows(ruleID uint32) ([]string, error) {
 	ofPrioritiesToUninstallFlows := conj.ActionFlowPriorities()
 	klog.V(2).Infof("Old priority %v found", ofPrioritiesToUninstallFlows)
 	var staleOFPriorities []string
-	// Delete action flows from the OVS bridge.
-	if err := c.ofEntryOperations.DeleteAll(conj.actionFlows); err != nil {
+	// Delete action flows and metric flows from the OVS bridge.
+	if err := c.ofEntryOperations.DeleteAll(append(conj.actionFlows, conj.metricFlows...)); err != nil {
 		return nil, err
 	}
 
@@ -1010,6 +1163,10 @@ func (c *client) replayPolicyFlows() {
 			flow.Reset()
 			flows = append(flows, flow)
 		}
+		for _, flow := range conj.metricFlows {
+			flow.Reset()
+			flows = append(flows, flow)
+		}
 	}
 
 	for _, conj := range c.policyCache.List() {
@@ -1109,6 +1266,53 @@ func (c *client) GetNetworkPolicyFlowKeys(npName, npNamespace string) []string {
 	return flowKeys
 }
 
+// NetworkPolicyMetrics returns the traffic stats of the NetworkPolicy rules installed on the OVS bridge, keyed by
+// their conjunction IDs. The stats of all the rules are read with a single dump of the flows of the current round,
+// whose cookies carry the conjunction IDs. The packets of the connections allowed by a rule are counted by its
+// metricFlow, which is hit by all the packets of the connections, while its actionFlow is only hit by the first packet
+// of each connection, and counts the sessions. The packets denied by a rule keep hitting its actionFlow, so the
+// sessions of dropping or rejecting rules are not counted.
+func (c *client) NetworkPolicyMetrics() map[uint32]*types.RuleMetric {
+	result := map[uint32]*types.RuleMetric{}
+	cookieID, cookieMask := cookie.CookieMaskForRound(c.roundInfo.RoundNum)
+	flowStats, err := c.bridge.DumpFlows(cookieID, cookieMask)
+	if err != nil {
+		klog.Errorf("Failed to dump flow stats: %v", err)
+		return result
+	}
+	actionStats := map[uint32]*binding.FlowStates{}
+	metricStats := map[uint32]*binding.FlowStates{}
+	for flowCookie, stats := range flowStats {
+		id := cookie.ID(flowCookie)
+		switch id.Category() {
+		case cookie.Policy:
+			actionStats[id.ObjectID()] = stats
+		case cookie.PolicyMetric:
+			metricStats[id.ObjectID()] = stats
+		}
+	}
+
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	for _, conjObj := range c.policyCache.List() {
+		conj := conjObj.(*policyRuleConjunction)
+		metric := &types.RuleMetric{}
+		if stats, ok := actionStats[conj.id]; ok {
+			metric.Packets = stats.PacketCount
+			metric.Bytes = stats.ByteCount
+			if conj.ruleAction == nil || (*conj.ruleAction != secv1alpha1.RuleActionDrop && *conj.ruleAction != secv1alpha1.RuleActionReject) {
+				metric.Sessions = stats.PacketCount
+			}
+		}
+		if stats, ok := metricStats[conj.id]; ok {
+			metric.Packets = stats.PacketCount
+			metric.Bytes = stats.ByteCount
+		}
+		result[conj.id] = metric
+	}
+	return result
+}
+
 // flowUpdates stores updates to the actionFlows and matchFlows in a policyRuleConjunction.
 type flowUpdates struct {
 	newActionFlows []binding.Flow
@@ -1173,8 +1377,11 @@ func (c *client) updateConjunctionActionFlows(conj *policyRuleConjunction, updat
 		toClause:      conj.toClause,
 		serviceClause: conj.serviceClause,
 		actionFlows:   newActionFlows,
+		metricFlows:   conj.metricFlows,
 		npName:        conj.npName,
 		npNamespace:   conj.npNamespace,
+		ruleAction:    conj.ruleAction,
+		enableLogging: conj.enableLogging,
 	}
 	return newConj
 }
@@ -23,10 +23,11 @@ import (
 )
 
 var (
-	c             *client
-	outTable      *mocks.MockTable
-	outDropTable  *mocks.MockTable
-	outAllowTable *mocks.MockTable
+	c              *client
+	outTable       *mocks.MockTable
+	outDropTable   *mocks.MockTable
+	outMetricTable *mocks.MockTable
+	outAllowTable  *mocks.MockTable
 
 	ruleFlowBuilder *mocks.MockFlowBuilder
 	ruleFlow        *mocks.MockFlow
@@ -171,7 +172,7 @@ func TestInstallPolicyRuleFlows(t *testing.T) {
 	err = c.InstallPolicyRuleFlows(ruleID2, rule2, "np1", "ns1")
 	require.Nil(t, err)
 	checkConjunctionConfig(t, ruleID2, 1, 2, 1, 0)
-	assert.Equal(t, 6, len(c.GetNetworkPolicyFlowKeys("np1", "ns1")))
+	assert.Equal(t, 7, len(c.GetNetworkPolicyFlowKeys("np1", "ns1")))
 
 	ruleID3 := uint32(103)
 	port1 := intstr.FromInt(8080)
@@ -206,7 +207,7 @@ func TestInstallPolicyRuleFlows(t *testing.T) {
 	err = c.InstallPolicyRuleFlows(ruleID3, rule3, "np1", "ns1")
 	require.Nil(t, err, "Failed to invoke InstallPolicyRuleFlows")
 	checkConjunctionConfig(t, ruleID3, 1, 2, 1, 2)
-	assert.Equal(t, 14, len(c.GetNetworkPolicyFlowKeys("np1", "ns1")))
+	assert.Equal(t, 16, len(c.GetNetworkPolicyFlowKeys("np1", "ns1")))
 
 	ctxChanges4 := conj.calculateChangesForRuleDeletion()
 	matchFlows4, dropFlows4 := getChangedFlows(ctxChanges4)
@@ -223,7 +224,7 @@ func TestInstallPolicyRuleFlows(t *testing.T) {
 	assert.Equal(t, 2, getChangedFlowOPCount(matchFlows5, deletion))
 	assert.Equal(t, 1, getChangedFlowOPCount(matchFlows5, modification))
 	err = c.applyConjunctiveMatchFlows(ctxChanges5)
-	assert.Equal(t, 11, len(c.GetNetworkPolicyFlowKeys("np1", "ns1")))
+	assert.Equal(t, 13, len(c.GetNetworkPolicyFlowKeys("np1", "ns1")))
 	require.Nil(t, err)
 }
 
@@ -268,6 +269,170 @@ func TestConjMatchFlowContextKeyConflict(t *testing.T) {
 	assert.Equal(t, clause2.action, act2)
 }
 
+func TestPortRangeToMasks(t *testing.T) {
+	tests := []struct {
+		name       string
+		start      uint16
+		end        uint16
+		expMatches []portMaskMatch
+	}{
+		{
+			name:       "single-port",
+			start:      8080,
+			end:        8080,
+			expMatches: []portMaskMatch{{port: 8080, mask: 0xffff}},
+		},
+		{
+			name:       "aligned-range",
+			start:      0x1000,
+			end:        0x1fff,
+			expMatches: []portMaskMatch{{port: 0x1000, mask: 0xf000}},
+		},
+		{
+			name:  "unaligned-range",
+			start: 80,
+			end:   90,
+			expMatches: []portMaskMatch{
+				{port: 0x50, mask: 0xfff8},
+				{port: 0x58, mask: 0xfffe},
+				{port: 0x5a, mask: 0xffff},
+			},
+		},
+		{
+			name:  "node-port-range",
+			start: 30000,
+			end:   32767,
+			expMatches: []portMaskMatch{
+				{port: 0x7530, mask: 0xfff0},
+				{port: 0x7540, mask: 0xffc0},
+				{port: 0x7580, mask: 0xff80},
+				{port: 0x7600, mask: 0xfe00},
+				{port: 0x7800, mask: 0xf800},
+			},
+		},
+		{
+			name:       "all-ports",
+			start:      0,
+			end:        65535,
+			expMatches: []portMaskMatch{{port: 0, mask: 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := portRangeToMasks(tt.start, tt.end)
+			assert.Equal(t, tt.expMatches, matches)
+			// The matches must cover exactly the ports of the range.
+			for port := 0; port <= 65535; port++ {
+				matched := 0
+				for _, m := range matches {
+					if uint16(port)&m.mask == m.port {
+						matched++
+					}
+				}
+				expMatched := 0
+				if port >= int(tt.start) && port <= int(tt.end) {
+					expMatched = 1
+				}
+				if matched != expMatched {
+					t.Errorf("Port %d is matched %d times, expected %d", port, matched, expMatched)
+				}
+			}
+		})
+	}
+}
+
+func TestPortMaskMatchValue(t *testing.T) {
+	assert.Equal(t, uint16(80), portMaskMatch{port: 80, mask: 0xffff}.matchValue())
+	assert.Equal(t, uint16(0), portMaskMatch{port: 0, mask: 0}.matchValue())
+	assert.Equal(t, portMaskMatch{port: 0x50, mask: 0xfff8}, portMaskMatch{port: 0x50, mask: 0xfff8}.matchValue())
+}
+
+func TestGenerateServicePortConjMatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	table := createMockTable(ctrl, EgressRuleTable, EgressDefaultTable, binding.TableMissActionNext)
+	conj := &policyRuleConjunction{id: 1}
+	clause := conj.newClause(3, 3, table, nil)
+
+	protocolTCP := v1beta1.ProtocolTCP
+	protocolICMP := v1beta1.ProtocolICMP
+	port80 := intstr.FromInt(80)
+	endPort83 := int32(83)
+	icmpType := int32(8)
+	ipProtocol := int32(47)
+	echoRequest := uint8(8)
+	tests := []struct {
+		name           string
+		service        v1beta1.Service
+		expMatchKey    int
+		expMatchValues []interface{}
+	}{
+		{
+			name:           "all-tcp-ports",
+			service:        v1beta1.Service{Protocol: &protocolTCP},
+			expMatchKey:    MatchTCPDstPort,
+			expMatchValues: []interface{}{uint16(0)},
+		},
+		{
+			name:           "tcp-port-range",
+			service:        v1beta1.Service{Protocol: &protocolTCP, Port: &port80, EndPort: &endPort83},
+			expMatchKey:    MatchTCPDstPort,
+			expMatchValues: []interface{}{portMaskMatch{port: 80, mask: 0xfffc}},
+		},
+		{
+			name:           "icmp-type",
+			service:        v1beta1.Service{Protocol: &protocolICMP, ICMPType: &icmpType},
+			expMatchKey:    MatchICMP,
+			expMatchValues: []interface{}{icmpMatch{icmpType: &echoRequest}},
+		},
+		{
+			name:           "ip-protocol",
+			service:        v1beta1.Service{IPProtocol: &ipProtocol},
+			expMatchKey:    MatchIPProtocol,
+			expMatchValues: []interface{}{uint8(47)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := clause.generateServicePortConjMatches(tt.service, nil)
+			require.Equal(t, len(tt.expMatchValues), len(matches))
+			for i, match := range matches {
+				assert.Equal(t, EgressRuleTable, match.tableID)
+				assert.Equal(t, tt.expMatchKey, match.matchKey)
+				assert.Equal(t, tt.expMatchValues[i], match.matchValue)
+			}
+		})
+	}
+	// The global map keys of ICMP matches must differ by type and code.
+	icmpKey := (&conjunctiveMatch{tableID: EgressRuleTable, matchKey: MatchICMP, matchValue: icmpMatch{icmpType: &echoRequest}}).generateGlobalMapKey()
+	allICMPKey := (&conjunctiveMatch{tableID: EgressRuleTable, matchKey: MatchICMP, matchValue: icmpMatch{}}).generateGlobalMapKey()
+	assert.NotEqual(t, icmpKey, allICMPKey)
+}
+
+func TestNetworkPolicyMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	c = prepareClient(ctrl)
+
+	dropAction := secv1alpha1.RuleActionDrop
+	c.policyCache.Add(&policyRuleConjunction{id: 1})
+	c.policyCache.Add(&policyRuleConjunction{id: 2, ruleAction: &dropAction})
+	allocator := cookie.NewAllocator(0)
+	c.bridge.(*mocks.MockBridge).EXPECT().DumpFlows(gomock.Any(), cookie.RoundMask).Return(map[uint64]*binding.FlowStates{
+		allocator.Request(cookie.Policy).Raw():                      {PacketCount: 100, ByteCount: 6000},
+		allocator.RequestWithObjectID(cookie.Policy, 1).Raw():       {PacketCount: 2, ByteCount: 120},
+		allocator.RequestWithObjectID(cookie.PolicyMetric, 1).Raw(): {PacketCount: 10, ByteCount: 1000},
+		allocator.RequestWithObjectID(cookie.Policy, 2).Raw():       {PacketCount: 3, ByteCount: 180},
+	}, nil)
+
+	// The sessions of the allowing rule are counted by its actionFlow, and its
+	// packets by its metricFlow.
+	assert.Equal(t, map[uint32]*types.RuleMetric{
+		1: {Packets: 10, Bytes: 1000, Sessions: 2},
+		2: {Packets: 3, Bytes: 180},
+	}, c.NetworkPolicyMetrics())
+}
+
 func getChangedFlowCount(flows []*flowChange) int {
 	var count int
 	for _, changedFlow := range flows {
@@ -391,6 +556,20 @@ func newMockRuleFlowBuilder(ctrl *gomock.Controller) *mocks.MockFlowBuilder {
 	return ruleFlowBuilder
 }
 
+func newMockMetricFlowBuilder(ctrl *gomock.Controller) *mocks.MockFlowBuilder {
+	metricFlowBuilder := mocks.NewMockFlowBuilder(ctrl)
+	metricFlowBuilder.EXPECT().Cookie(gomock.Any()).Return(metricFlowBuilder).AnyTimes()
+	metricFlowBuilder.EXPECT().MatchProtocol(gomock.Any()).Return(metricFlowBuilder).AnyTimes()
+	metricFlowBuilder.EXPECT().MatchReg(gomock.Any(), gomock.Any()).Return(metricFlowBuilder).AnyTimes()
+	action := mocks.NewMockAction(ctrl)
+	action.EXPECT().GotoTable(gomock.Any()).Return(metricFlowBuilder).AnyTimes()
+	metricFlowBuilder.EXPECT().Action().Return(action).AnyTimes()
+	metricFlow := mocks.NewMockFlow(ctrl)
+	metricFlowBuilder.EXPECT().Done().Return(metricFlow).AnyTimes()
+	metricFlow.EXPECT().MatchString().Return("").AnyTimes()
+	return metricFlowBuilder
+}
+
 func parseAddresses(addrs []string) []types.Address {
 	var addresses = make([]types.Address, 0)
 	for _, addr := range addrs {
@@ -424,12 +603,15 @@ func prepareClient(ctrl *gomock.Controller) *client {
 	bridge := mocks.NewMockBridge(ctrl)
 	bridge.EXPECT().AddFlowsInBundle(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	outTable = createMockTable(ctrl, EgressRuleTable, EgressDefaultTable, binding.TableMissActionNext)
-	outDropTable = createMockTable(ctrl, EgressDefaultTable, l3ForwardingTable, binding.TableMissActionNext)
+	outDropTable = createMockTable(ctrl, EgressDefaultTable, EgressMetricTable, binding.TableMissActionNext)
+	outMetricTable = createMockTable(ctrl, EgressMetricTable, l3ForwardingTable, binding.TableMissActionNext)
+	outMetricTable.EXPECT().BuildFlow(gomock.Any()).Return(newMockMetricFlowBuilder(ctrl)).AnyTimes()
 	outAllowTable = createMockTable(ctrl, l3ForwardingTable, l2ForwardingCalcTable, binding.TableMissActionNext)
 	c = &client{
 		pipeline: map[binding.TableIDType]binding.Table{
 			EgressRuleTable:    outTable,
 			EgressDefaultTable: outDropTable,
+			EgressMetricTable:  outMetricTable,
 			l3ForwardingTable:  outAllowTable,
 		},
 		policyCache:              policyCache,
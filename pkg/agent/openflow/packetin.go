@@ -15,45 +15,66 @@
 package openflow
 
 import (
+	"fmt"
+
 	"github.com/contiv/ofnet/ofctrl"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog"
 )
 
-type ofpPacketInReason uint
+type ofpPacketInReason uint8
 
 type PacketInHandler interface {
 	HandlePacketIn(pktIn *ofctrl.PacketIn) error
 }
 
 const (
-	// Action explicitly output to controller.
-	ofprAction ofpPacketInReason = 1
+	// PacketInReasonTF specifies the reason of the PacketIn messages sent by
+	// Traceflow flows. It is also the reason used by OpenFlow when an action
+	// explicitly outputs the packet to the controller.
+	PacketInReasonTF ofpPacketInReason = 1
+	// PacketInReasonNP specifies the reason of the PacketIn messages sent by
+	// NetworkPolicy flows, e.g. to reject a packet.
+	PacketInReasonNP ofpPacketInReason = 0
 	// Max packetInQueue size.
 	packetInQueueSize int = 256
 )
 
-func (c *client) RegisterPacketInHandler(packetHandlerName string, packetInHandler interface{}) {
+// RegisterPacketInHandler registers a PacketIn handler for the PacketIn
+// messages with the given reason.
+func (c *client) RegisterPacketInHandler(packetHandlerReason uint8, packetHandlerName string, packetInHandler interface{}) {
 	handler, ok := packetInHandler.(PacketInHandler)
 	if !ok {
-		klog.Errorf("Invalid Traceflow controller.")
+		klog.Errorf("Invalid PacketIn handler %s.", packetHandlerName)
 		return
 	}
-	c.packetInHandlers[packetHandlerName] = handler
+	if c.packetInHandlers[packetHandlerReason] == nil {
+		c.packetInHandlers[packetHandlerReason] = map[string]PacketInHandler{}
+	}
+	c.packetInHandlers[packetHandlerReason][packetHandlerName] = handler
 }
 
+// StartPacketInHandler subscribes the PacketIn messages of all the reasons for
+// which a handler is registered, and dispatches them to the handlers.
 func (c *client) StartPacketInHandler(stopCh <-chan struct{}) {
 	if len(c.packetInHandlers) == 0 {
 		return
 	}
+	for reason := range c.packetInHandlers {
+		go c.subscribePacketIn(reason, stopCh)
+	}
+	<-stopCh
+}
+
+func (c *client) subscribePacketIn(reason uint8, stopCh <-chan struct{}) {
 	ch := make(chan *ofctrl.PacketIn)
-	err := c.SubscribePacketIn(uint8(ofprAction), ch)
+	err := c.SubscribePacketIn(reason, ch)
 	if err != nil {
-		klog.Errorf("Subscribe PacketIn failed %+v", err)
+		klog.Errorf("Subscribe PacketIn with reason %d failed %+v", reason, err)
 		return
 	}
-	packetInQueue := workqueue.NewNamed("packetIn")
-	go c.parsePacketIn(packetInQueue, stopCh)
+	packetInQueue := workqueue.NewNamed(fmt.Sprintf("packetIn-%d", reason))
+	go c.parsePacketIn(reason, packetInQueue, stopCh)
 
 	for {
 		select {
@@ -66,12 +87,12 @@ func (c *client) StartPacketInHandler(stopCh <-chan struct{}) {
 			}
 		case <-stopCh:
 			packetInQueue.ShutDown()
-			break
+			return
 		}
 	}
 }
 
-func (c *client) parsePacketIn(packetInQueue workqueue.Interface, stopCh <-chan struct{}) {
+func (c *client) parsePacketIn(reason uint8, packetInQueue workqueue.Interface, stopCh <-chan struct{}) {
 	for {
 		obj, quit := packetInQueue.Get()
 		if quit {
@@ -83,7 +104,7 @@ func (c *client) parsePacketIn(packetInQueue workqueue.Interface, stopCh <-chan
 			klog.Errorf("Invalid packet in data in queue, skipping.")
 			continue
 		}
-		for name, handler := range c.packetInHandlers {
+		for name, handler := range c.packetInHandlers[reason] {
 			err := handler.HandlePacketIn(pktIn)
 			if err != nil {
 				klog.Errorf("PacketIn handler %s failed to process packet: %+v", name, err)
@@ -47,14 +47,16 @@ const (
 	dnatTable             binding.TableIDType = 40
 	serviceLBTable        binding.TableIDType = 41
 	endpointDNATTable     binding.TableIDType = 42
-	cnpEgressRuleTable    binding.TableIDType = 45
+	CNPEgressRuleTable    binding.TableIDType = 45
 	EgressRuleTable       binding.TableIDType = 50
 	EgressDefaultTable    binding.TableIDType = 60
+	EgressMetricTable     binding.TableIDType = 61
 	l3ForwardingTable     binding.TableIDType = 70
 	l2ForwardingCalcTable binding.TableIDType = 80
-	cnpIngressRuleTable   binding.TableIDType = 85
+	CNPIngressRuleTable   binding.TableIDType = 85
 	IngressRuleTable      binding.TableIDType = 90
 	IngressDefaultTable   binding.TableIDType = 100
+	IngressMetricTable    binding.TableIDType = 101
 	conntrackCommitTable  binding.TableIDType = 105
 	hairpinSNATTable      binding.TableIDType = 106
 	L2ForwardingOutTable  binding.TableIDType = 110
@@ -93,14 +95,16 @@ var (
 		{sessionAffinityTable, "SessionAffinity"},
 		{serviceLBTable, "ServiceLB"},
 		{endpointDNATTable, "EndpointDNAT"},
-		{cnpEgressRuleTable, "CNPEgressRule"},
+		{CNPEgressRuleTable, "CNPEgressRule"},
 		{EgressRuleTable, "EgressRule"},
 		{EgressDefaultTable, "EgressDefaultRule"},
+		{EgressMetricTable, "EgressMetric"},
 		{l3ForwardingTable, "l3Forwarding"},
 		{l2ForwardingCalcTable, "L2Forwarding"},
-		{cnpIngressRuleTable, "CNPIngressRule"},
+		{CNPIngressRuleTable, "CNPIngressRule"},
 		{IngressRuleTable, "IngressRule"},
 		{IngressDefaultTable, "IngressDefaultRule"},
+		{IngressMetricTable, "IngressMetric"},
 		{conntrackCommitTable, "ConntrackCommit"},
 		{hairpinSNATTable, "HairpinSNATTable"},
 		{L2ForwardingOutTable, "Output"},
@@ -155,6 +159,7 @@ const (
 	serviceLearnReg         = endpointPortReg // Use reg4[16..18] to store endpoint selection states.
 	EgressReg       regType = 5
 	IngressReg      regType = 6
+	CNPConjIDReg    regType = 7 // Use reg7 to store the conjunction ID of the Antrea-native policy rule which passed, dropped, rejected or logged the packet.
 	TraceflowReg    regType = 9 // Use reg9[28..31] to store traceflow dataplaneTag.
 	// marksRegServiceNeedLB indicates a packet need to do service selection.
 	marksRegServiceNeedLB uint32 = 0b001
@@ -170,10 +175,15 @@ const (
 	snatRequiredMark = 0b1
 	hairpinMark      = 0b1
 	macRewriteMark   = 0b1
+	cnpDenyMark      = 0b1
 
 	gatewayCTMark = 0x20
 	snatCTMark    = 0x40
-	serviceCTMark = 0x21
+	// ServiceCTMark is the ct_mark of the connections to Services load-balanced by AntreaProxy.
+	ServiceCTMark = 0x21
+
+	// dnsPort is the UDP port of the DNS servers whose traffic is intercepted for FQDN-based NetworkPolicy rules.
+	dnsPort = 53
 )
 
 var (
@@ -193,6 +203,10 @@ var (
 	// macRewriteMarkRange takes the 19th bit of register marksReg to indicate
 	// if the packet's MAC addresses need to be rewritten. Its value is 0x1 if yes.
 	macRewriteMarkRange = binding.Range{19, 19}
+	// cnpDenyMarkRange takes the 20th bit of register marksReg to indicate
+	// if the packet is denied (dropped or rejected) by an Antrea-native policy
+	// rule. Its value is 0x1 if yes.
+	cnpDenyMarkRange = binding.Range{20, 20}
 	// endpointIPRegRange takes a 32-bit range of register endpointIPReg to store
 	// the selected Service Endpoint IP.
 	endpointIPRegRange = binding.Range{0, 31}
@@ -204,6 +218,14 @@ var (
 	// Endpoint, still needs to select an Endpoint, or if an Endpoint has already
 	// been selected and the selection decision needs to be learned.
 	serviceLearnRegRange = binding.Range{16, 18}
+	// IngressRuleCTLabel takes the range 0-31 of ct_label to store the conjunction ID of the ingress
+	// NetworkPolicy rule which admitted the connection, loaded from IngressReg when the connection is
+	// committed. It is 0 if no ingress rule applies to the connection.
+	IngressRuleCTLabel = binding.Range{0, 31}
+	// EgressRuleCTLabel takes the range 32-63 of ct_label to store the conjunction ID of the egress
+	// NetworkPolicy rule which admitted the connection, loaded from EgressReg when the connection is
+	// committed. It is 0 if no egress rule applies to the connection.
+	EgressRuleCTLabel = binding.Range{32, 63}
 
 	globalVirtualMAC, _ = net.ParseMAC("aa:bb:cc:dd:ee:ff")
 	ReentranceMAC, _    = net.ParseMAC("de:ad:be:ef:de:ad")
@@ -233,6 +255,9 @@ type client struct {
 	bridge                                        binding.Bridge
 	pipeline                                      map[binding.TableIDType]binding.Table
 	nodeFlowCache, podFlowCache, serviceFlowCache *flowCategoryCache // cache for corresponding deletions
+	// dnsFlowCache caches the flows intercepting DNS traffic for FQDN-based NetworkPolicy rules, indexed by the
+	// OVS port of the Pod.
+	dnsFlowCache *flowCategoryCache
 	// "fixed" flows installed by the agent after initialization and which do not change during
 	// the lifetime of the client.
 	gatewayFlows, defaultServiceFlows, defaultTunnelFlows, hostNetworkingFlows []binding.Flow
@@ -252,8 +277,8 @@ type client struct {
 	nodeConfig  *config.NodeConfig
 	encapMode   config.TrafficEncapModeType
 	gatewayPort uint32 // OVSOFPort number
-	// packetInHandlers stores handler to process PacketIn event
-	packetInHandlers map[string]PacketInHandler
+	// packetInHandlers stores handlers to process PacketIn events, indexed by PacketIn reason.
+	packetInHandlers map[uint8]map[string]PacketInHandler
 }
 
 func (c *client) GetTunnelVirtualMAC() net.HardwareAddr {
@@ -439,7 +464,8 @@ func (c *client) hostBridgeUplinkFlows(uplinkPort uint32, bridgeLocalPort uint32
 }
 
 // connectionTrackFlows generates flows that redirect traffic to ct_zone and handle traffic according to ct_state:
-// 1) commit new connections to ct_zone(0xfff0) in the conntrackCommitTable.
+// 1) commit new connections to ct_zone(0xfff0) in the conntrackCommitTable, with the conjunction IDs of the
+//    NetworkPolicy rules which admitted them in ct_label.
 // 2) Add ct_mark on the packet if it is sent to the switch from the host gateway.
 // 3) Allow traffic if it hits ct_mark and is sent from the host gateway.
 // 4) Drop all invalid traffic.
@@ -465,9 +491,22 @@ func (c *client) connectionTrackFlows(category cookie.Category) []binding.Flow {
 				Action().CT(false, connectionTrackTable.GetNext(), CtZone).NAT().CTDone().
 				Cookie(c.cookieAllocator.Request(category).Raw()).
 				Done(),
+			// The connections to Services are committed when the Endpoint is selected, before the
+			// NetworkPolicy rules are evaluated, so they are committed again to store the rules which
+			// admitted them.
+			connectionTrackCommitTable.BuildFlow(priorityLow+1).MatchProtocol(binding.ProtocolIP).
+				MatchCTStateNew(true).MatchCTStateTrk(true).
+				MatchCTMark(ServiceCTMark).
+				MatchRegRange(int(serviceLearnReg), marksRegServiceSelected, serviceLearnRegRange).
+				Cookie(c.cookieAllocator.Request(category).Raw()).
+				Action().CT(true, connectionTrackCommitTable.GetNext(), CtZone).
+				MoveToLabel(IngressReg.nxm(), &binding.Range{0, 31}, &IngressRuleCTLabel).
+				MoveToLabel(EgressReg.nxm(), &binding.Range{0, 31}, &EgressRuleCTLabel).
+				CTDone().
+				Done(),
 			connectionTrackCommitTable.BuildFlow(priorityLow).MatchProtocol(binding.ProtocolIP).
 				MatchCTStateTrk(true).
-				MatchCTMark(serviceCTMark).
+				MatchCTMark(ServiceCTMark).
 				MatchRegRange(int(serviceLearnReg), marksRegServiceSelected, serviceLearnRegRange).
 				Cookie(c.cookieAllocator.Request(category).Raw()).
 				Action().GotoTable(connectionTrackCommitTable.GetNext()).
@@ -497,12 +536,18 @@ func (c *client) connectionTrackFlows(category cookie.Category) []binding.Flow {
 		connectionTrackCommitTable.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIP).
 			MatchRegRange(int(marksReg), markTrafficFromGateway, binding.Range{0, 15}).
 			MatchCTStateNew(true).MatchCTStateTrk(true).
-			Action().CT(true, connectionTrackCommitTable.GetNext(), CtZone).LoadToMark(gatewayCTMark).CTDone().
+			Action().CT(true, connectionTrackCommitTable.GetNext(), CtZone).LoadToMark(gatewayCTMark).
+			MoveToLabel(IngressReg.nxm(), &binding.Range{0, 31}, &IngressRuleCTLabel).
+			MoveToLabel(EgressReg.nxm(), &binding.Range{0, 31}, &EgressRuleCTLabel).
+			CTDone().
 			Cookie(c.cookieAllocator.Request(category).Raw()).
 			Done(),
 		connectionTrackCommitTable.BuildFlow(priorityLow).MatchProtocol(binding.ProtocolIP).
 			MatchCTStateNew(true).MatchCTStateTrk(true).
-			Action().CT(true, connectionTrackCommitTable.GetNext(), CtZone).CTDone().
+			Action().CT(true, connectionTrackCommitTable.GetNext(), CtZone).
+			MoveToLabel(IngressReg.nxm(), &binding.Range{0, 31}, &IngressRuleCTLabel).
+			MoveToLabel(EgressReg.nxm(), &binding.Range{0, 31}, &EgressRuleCTLabel).
+			CTDone().
 			Cookie(c.cookieAllocator.Request(category).Raw()).
 			Done(),
 	)
@@ -553,7 +598,7 @@ func (c *client) ctRewriteDstMACFlow(gatewayMAC net.HardwareAddr, category cooki
 func (c *client) serviceLBBypassFlow() binding.Flow {
 	connectionTrackStateTable := c.pipeline[conntrackStateTable]
 	return connectionTrackStateTable.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIP).
-		MatchCTMark(serviceCTMark).
+		MatchCTMark(ServiceCTMark).
 		MatchCTStateNew(false).MatchCTStateTrk(true).
 		Action().LoadRegRange(int(marksReg), macRewriteMark, macRewriteMarkRange).
 		Action().GotoTable(EgressRuleTable).
@@ -582,6 +627,43 @@ func (c *client) l2ForwardOutputFlow(category cookie.Category) binding.Flow {
 		Done()
 }
 
+// dnsInterceptFlows generates the flows that intercept the DNS traffic between the given OVS port and the given DNS
+// servers. The queries sent by the Pod are copied to the controller, so that the agent only trusts the responses
+// answering them, and the responses destined to the Pod are sent to the controller instead of being output. The agent
+// delivers the responses with packet-out messages once the IPs they contain have been added to the FQDN-based
+// NetworkPolicy rules. The connections are matched with their original direction tuple, whose destination is the DNS
+// server IP the Pod sent the queries to, so that other UDP packets with source port 53 are not intercepted.
+func (c *client) dnsInterceptFlows(ofPort uint32, dnsServerIPs []net.IP, category cookie.Category) []binding.Flow {
+	l2FwdOutTable := c.pipeline[L2ForwardingOutTable]
+	var flows []binding.Flow
+	for _, dnsServerIP := range dnsServerIPs {
+		flows = append(flows,
+			l2FwdOutTable.BuildFlow(priorityNormal+1).MatchProtocol(binding.ProtocolUDP).
+				MatchInPort(ofPort).
+				MatchCTStateTrk(true).MatchCTStateRpl(false).
+				MatchCTProtocol(binding.ProtocolUDP).
+				MatchCTDstIP(dnsServerIP).
+				MatchCTDstPort(dnsPort).
+				MatchRegRange(int(marksReg), portFoundMark, ofPortMarkRange).
+				Action().SendToController(uint8(PacketInReasonNP)).
+				Action().OutputRegRange(int(portCacheReg), ofPortRegRange).
+				Cookie(c.cookieAllocator.Request(category).Raw()).
+				Done(),
+			l2FwdOutTable.BuildFlow(priorityNormal+1).MatchProtocol(binding.ProtocolUDP).
+				MatchCTStateTrk(true).MatchCTStateRpl(true).
+				MatchCTProtocol(binding.ProtocolUDP).
+				MatchCTDstIP(dnsServerIP).
+				MatchCTDstPort(dnsPort).
+				MatchRegRange(int(marksReg), portFoundMark, ofPortMarkRange).
+				MatchReg(int(portCacheReg), ofPort).
+				Action().SendToController(uint8(PacketInReasonNP)).
+				Cookie(c.cookieAllocator.Request(category).Raw()).
+				Done(),
+		)
+	}
+	return flows
+}
+
 // traceflowL2ForwardOutputFlow generates Traceflow specific flow that outputs traceflow packets to OVS port and Antrea
 // Agent after L2forwarding calculation.
 func (c *client) traceflowL2ForwardOutputFlow(dataplaneTag uint8, category cookie.Category) binding.Flow {
@@ -594,7 +676,7 @@ func (c *client) traceflowL2ForwardOutputFlow(dataplaneTag uint8, category cooki
 		MatchRegRange(int(marksReg), portFoundMark, ofPortMarkRange).
 		Action().MoveRange(regName, tunMetadataName, OfTraceflowMarkRange, OfTraceflowMarkRange).
 		Action().OutputRegRange(int(portCacheReg), ofPortRegRange).
-		Action().SendToController(1).
+		Action().SendToController(uint8(PacketInReasonTF)).
 		Cookie(c.cookieAllocator.Request(category).Raw()).
 		Done()
 }
@@ -870,7 +952,9 @@ func (c *client) arpNormalFlow(category cookie.Category) binding.Flow {
 
 // conjunctionActionFlow generates the flow to jump to a specific table if policyRuleConjunction ID is matched. Priority of
 // conjunctionActionFlow is created at priorityLow for k8s network policies, and *priority assigned by PriorityAssigner for CNP.
-func (c *client) conjunctionActionFlow(conjunctionID uint32, tableID binding.TableIDType, nextTable binding.TableIDType, priority *uint16) binding.Flow {
+// If audit logging is enabled for the rule, the packet is also sent to the controller with the policyRuleConjunction ID
+// loaded in CNPConjIDReg.
+func (c *client) conjunctionActionFlow(conjunctionID uint32, tableID binding.TableIDType, nextTable binding.TableIDType, priority *uint16, enableLogging bool) binding.Flow {
 	var ofPriority uint16
 	if priority == nil {
 		ofPriority = priorityLow
@@ -878,29 +962,110 @@ func (c *client) conjunctionActionFlow(conjunctionID uint32, tableID binding.Tab
 		ofPriority = *priority
 	}
 	conjReg := IngressReg
-	if tableID == EgressRuleTable {
+	if tableID == EgressRuleTable || tableID == CNPEgressRuleTable {
 		conjReg = EgressReg
 	}
-	return c.pipeline[tableID].BuildFlow(ofPriority).MatchProtocol(binding.ProtocolIP).
+	flowBuilder := c.pipeline[tableID].BuildFlow(ofPriority).MatchProtocol(binding.ProtocolIP).
 		MatchConjID(conjunctionID).
 		MatchPriority(ofPriority).
-		Action().LoadRegRange(int(conjReg), conjunctionID, binding.Range{0, 31}). // Traceflow.
-		Action().GotoTable(nextTable).
-		Cookie(c.cookieAllocator.Request(cookie.Policy).Raw()).
+		Action().LoadRegRange(int(conjReg), conjunctionID, binding.Range{0, 31}) // Traceflow.
+	if enableLogging {
+		flowBuilder = flowBuilder.
+			Action().LoadRegRange(int(CNPConjIDReg), conjunctionID, binding.Range{0, 31}).
+			Action().SendToController(uint8(PacketInReasonNP))
+	}
+	return flowBuilder.Action().GotoTable(nextTable).
+		Cookie(c.cookieAllocator.RequestWithObjectID(cookie.Policy, conjunctionID).Raw()).
 		Done()
 }
 
-// conjunctionActionFlow generates the flow to drop traffic if policyRuleConjunction ID is matched.
-func (c *client) conjunctionActionDropFlow(conjunctionID uint32, tableID binding.TableIDType, priority *uint16) binding.Flow {
+// conjunctionMetricFlow generates the flow to count the packets of the connections allowed by the policyRuleConjunction
+// in the metric table following the dropTable. The conjunction ID is loaded in the conjReg of the packets of new
+// connections by the conjunctionActionFlow, and in the conjReg of the packets of established connections from
+// ct_label by the establishedConnectionFlows, so that the flow is hit by all the packets of the connections.
+func (c *client) conjunctionMetricFlow(conjunctionID uint32, tableID binding.TableIDType) binding.Flow {
+	metricTable := c.pipeline[IngressMetricTable]
+	conjReg := IngressReg
+	if tableID == EgressRuleTable || tableID == CNPEgressRuleTable {
+		metricTable = c.pipeline[EgressMetricTable]
+		conjReg = EgressReg
+	}
+	return metricTable.BuildFlow(priorityNormal).MatchProtocol(binding.ProtocolIP).
+		MatchReg(int(conjReg), conjunctionID).
+		Action().GotoTable(metricTable.GetNext()).
+		Cookie(c.cookieAllocator.RequestWithObjectID(cookie.PolicyMetric, conjunctionID).Raw()).
+		Done()
+}
+
+// conjunctionActionDenyFlow generates the flow to deny traffic if policyRuleConjunction ID is matched. The packet
+// is marked as denied and sent to the dropTable, where it is dropped by the flow generated by cnpDenyFlows. If the
+// packet should be rejected, it is also sent to the controller, which answers it with a TCP RST or an ICMP
+// destination unreachable packet. If audit logging is enabled for the rule, the packet is also sent to the controller.
+func (c *client) conjunctionActionDenyFlow(conjunctionID uint32, tableID binding.TableIDType, dropTableID binding.TableIDType, priority *uint16, isReject, enableLogging bool) binding.Flow {
 	ofPriority := *priority
-	return c.pipeline[tableID].BuildFlow(ofPriority).MatchProtocol(binding.ProtocolIP).
+	flowBuilder := c.pipeline[tableID].BuildFlow(ofPriority).MatchProtocol(binding.ProtocolIP).
 		MatchConjID(conjunctionID).
 		MatchPriority(ofPriority).
-		Action().Drop().
-		Cookie(c.cookieAllocator.Request(cookie.Policy).Raw()).
+		Action().LoadRegRange(int(CNPConjIDReg), conjunctionID, binding.Range{0, 31}).
+		Action().LoadRegRange(int(marksReg), cnpDenyMark, cnpDenyMarkRange)
+	if isReject || enableLogging {
+		flowBuilder = flowBuilder.Action().SendToController(uint8(PacketInReasonNP))
+	}
+	return flowBuilder.Action().GotoTable(dropTableID).
+		Cookie(c.cookieAllocator.RequestWithObjectID(cookie.Policy, conjunctionID).Raw()).
 		Done()
 }
 
+// conjunctionActionPassFlow generates the flow to skip the remaining Antrea-native policy rules if policyRuleConjunction
+// ID is matched. The packet is sent to the K8s NetworkPolicy rule table, so that it is evaluated by the K8s
+// NetworkPolicies which apply to it. If audit logging is enabled for the rule, the packet is also sent to the controller.
+func (c *client) conjunctionActionPassFlow(conjunctionID uint32, tableID binding.TableIDType, priority *uint16, enableLogging bool) binding.Flow {
+	ofPriority := *priority
+	nextTable := IngressRuleTable
+	if tableID == CNPEgressRuleTable {
+		nextTable = EgressRuleTable
+	}
+	flowBuilder := c.pipeline[tableID].BuildFlow(ofPriority).MatchProtocol(binding.ProtocolIP).
+		MatchConjID(conjunctionID).
+		MatchPriority(ofPriority).
+		Action().LoadRegRange(int(CNPConjIDReg), conjunctionID, binding.Range{0, 31}) // Traceflow.
+	if enableLogging {
+		flowBuilder = flowBuilder.Action().SendToController(uint8(PacketInReasonNP))
+	}
+	return flowBuilder.Action().GotoTable(nextTable).
+		Cookie(c.cookieAllocator.RequestWithObjectID(cookie.Policy, conjunctionID).Raw()).
+		Done()
+}
+
+// cnpDenyFlows generates the flows to drop the packets which are denied by Antrea-native policy rules.
+func (c *client) cnpDenyFlows(category cookie.Category) []binding.Flow {
+	var flows []binding.Flow
+	for _, tableID := range []binding.TableIDType{EgressDefaultTable, IngressDefaultTable} {
+		flows = append(flows, c.pipeline[tableID].BuildFlow(priorityHigh).MatchProtocol(binding.ProtocolIP).
+			MatchRegRange(int(marksReg), cnpDenyMark, cnpDenyMarkRange).
+			Action().Drop().
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done())
+	}
+	return flows
+}
+
+// traceflowCNPDenyFlows generates Traceflow specific flows that send the Traceflow packets denied by Antrea-native
+// policy rules to the controller.
+func (c *client) traceflowCNPDenyFlows(dataplaneTag uint8, category cookie.Category) []binding.Flow {
+	var flows []binding.Flow
+	for _, tableID := range []binding.TableIDType{EgressDefaultTable, IngressDefaultTable} {
+		flows = append(flows, c.pipeline[tableID].BuildFlow(priorityHigh+2).MatchProtocol(binding.ProtocolIP).
+			MatchRegRange(int(marksReg), cnpDenyMark, cnpDenyMarkRange).
+			MatchRegRange(int(TraceflowReg), uint32(dataplaneTag), OfTraceflowMarkRange).
+			SetHardTimeout(300).
+			Action().SendToController(uint8(PacketInReasonTF)).
+			Cookie(c.cookieAllocator.Request(category).Raw()).
+			Done())
+	}
+	return flows
+}
+
 func (c *client) Disconnect() error {
 	return c.bridge.Disconnect()
 }
@@ -909,7 +1074,9 @@ func newFlowCategoryCache() *flowCategoryCache {
 	return &flowCategoryCache{}
 }
 
-// establishedConnectionFlows generates flows to ensure established connections skip the NetworkPolicy rules.
+// establishedConnectionFlows generates flows to ensure established connections skip the NetworkPolicy rules. The
+// conjunction ID of the rule which admitted the connection is moved from ct_label to the register read by the metric
+// tables, so that the packets of established connections are counted by the metric flow of the rule.
 func (c *client) establishedConnectionFlows(category cookie.Category) (flows []binding.Flow) {
 	// egressDropTable checks the source address of packets, and drops packets sent from the AppliedToGroup but not
 	// matching the NetworkPolicy rules. Packets in the established connections need not to be checked with the
@@ -917,11 +1084,13 @@ func (c *client) establishedConnectionFlows(category cookie.Category) (flows []b
 	egressDropTable := c.pipeline[EgressDefaultTable]
 	egressEstFlow := c.pipeline[EgressRuleTable].BuildFlow(priorityHigh).MatchProtocol(binding.ProtocolIP).
 		MatchCTStateNew(false).MatchCTStateEst(true).
+		Action().MoveRange(binding.NxmFieldCtLabel, EgressReg.nxm(), EgressRuleCTLabel, binding.Range{0, 31}).
 		Action().GotoTable(egressDropTable.GetNext()).
 		Cookie(c.cookieAllocator.Request(category).Raw()).
 		Done()
-	cnpEgressEstFlow := c.pipeline[cnpEgressRuleTable].BuildFlow(priorityTopCNP).MatchProtocol(binding.ProtocolIP).
+	cnpEgressEstFlow := c.pipeline[CNPEgressRuleTable].BuildFlow(priorityTopCNP).MatchProtocol(binding.ProtocolIP).
 		MatchCTStateNew(false).MatchCTStateEst(true).
+		Action().MoveRange(binding.NxmFieldCtLabel, EgressReg.nxm(), EgressRuleCTLabel, binding.Range{0, 31}).
 		Action().GotoTable(egressDropTable.GetNext()).
 		Cookie(c.cookieAllocator.Request(category).Raw()).
 		Done()
@@ -931,11 +1100,13 @@ func (c *client) establishedConnectionFlows(category cookie.Category) (flows []b
 	ingressDropTable := c.pipeline[IngressDefaultTable]
 	ingressEstFlow := c.pipeline[IngressRuleTable].BuildFlow(priorityHigh).MatchProtocol(binding.ProtocolIP).
 		MatchCTStateNew(false).MatchCTStateEst(true).
+		Action().MoveRange(binding.NxmFieldCtLabel, IngressReg.nxm(), IngressRuleCTLabel, binding.Range{0, 31}).
 		Action().GotoTable(ingressDropTable.GetNext()).
 		Cookie(c.cookieAllocator.Request(category).Raw()).
 		Done()
-	cnpIngressEstFlow := c.pipeline[cnpIngressRuleTable].BuildFlow(priorityTopCNP).MatchProtocol(binding.ProtocolIP).
+	cnpIngressEstFlow := c.pipeline[CNPIngressRuleTable].BuildFlow(priorityTopCNP).MatchProtocol(binding.ProtocolIP).
 		MatchCTStateNew(false).MatchCTStateEst(true).
+		Action().MoveRange(binding.NxmFieldCtLabel, IngressReg.nxm(), IngressRuleCTLabel, binding.Range{0, 31}).
 		Action().GotoTable(ingressDropTable.GetNext()).
 		Cookie(c.cookieAllocator.Request(category).Raw()).
 		Done()
@@ -959,22 +1130,45 @@ func (c *client) addFlowMatch(fb binding.FlowBuilder, matchType int, matchValue
 		fb = fb.MatchProtocol(binding.ProtocolIP).MatchInPort(uint32(matchValue.(int32)))
 	case MatchTCPDstPort:
 		fb = fb.MatchProtocol(binding.ProtocolTCP)
-		portValue := matchValue.(uint16)
-		if portValue > 0 {
-			fb = fb.MatchTCPDstPort(portValue)
+		switch portValue := matchValue.(type) {
+		case uint16:
+			if portValue > 0 {
+				fb = fb.MatchTCPDstPort(portValue)
+			}
+		case portMaskMatch:
+			fb = fb.MatchTCPDstPortMask(portValue.port, portValue.mask)
 		}
 	case MatchUDPDstPort:
 		fb = fb.MatchProtocol(binding.ProtocolUDP)
-		portValue := matchValue.(uint16)
-		if portValue > 0 {
-			fb = fb.MatchUDPDstPort(portValue)
+		switch portValue := matchValue.(type) {
+		case uint16:
+			if portValue > 0 {
+				fb = fb.MatchUDPDstPort(portValue)
+			}
+		case portMaskMatch:
+			fb = fb.MatchUDPDstPortMask(portValue.port, portValue.mask)
 		}
 	case MatchSCTPDstPort:
 		fb = fb.MatchProtocol(binding.ProtocolSCTP)
-		portValue := matchValue.(uint16)
-		if portValue > 0 {
-			fb = fb.MatchSCTPDstPort(portValue)
+		switch portValue := matchValue.(type) {
+		case uint16:
+			if portValue > 0 {
+				fb = fb.MatchSCTPDstPort(portValue)
+			}
+		case portMaskMatch:
+			fb = fb.MatchSCTPDstPortMask(portValue.port, portValue.mask)
+		}
+	case MatchICMP:
+		fb = fb.MatchProtocol(binding.ProtocolICMP)
+		icmpValue := matchValue.(icmpMatch)
+		if icmpValue.icmpType != nil {
+			fb = fb.MatchICMPType(*icmpValue.icmpType)
+		}
+		if icmpValue.icmpCode != nil {
+			fb = fb.MatchICMPCode(*icmpValue.icmpCode)
 		}
+	case MatchIPProtocol:
+		fb = fb.MatchIPProtocolValue(matchValue.(uint8))
 	}
 	return fb
 }
@@ -983,7 +1177,7 @@ func (c *client) addFlowMatch(fb binding.FlowBuilder, matchType int, matchValue
 // Keeping this for reference to generic exception flow.
 func (c *client) conjunctionExceptionFlow(conjunctionID uint32, tableID binding.TableIDType, nextTable binding.TableIDType, matchKey int, matchValue interface{}) binding.Flow {
 	conjReg := IngressReg
-	if tableID == EgressRuleTable {
+	if tableID == EgressRuleTable || tableID == CNPEgressRuleTable {
 		conjReg = EgressReg
 	}
 	fb := c.pipeline[tableID].BuildFlow(priorityNormal).MatchConjID(conjunctionID)
@@ -1242,7 +1436,7 @@ func (c *client) endpointDNATFlow(endpointIP net.IP, endpointPort uint16, protoc
 			&binding.IPRange{StartIP: endpointIP, EndIP: endpointIP},
 			&binding.PortRange{StartPort: endpointPort, EndPort: endpointPort},
 		).
-		LoadToMark(serviceCTMark).
+		LoadToMark(ServiceCTMark).
 		CTDone().
 		Done()
 }
@@ -1319,15 +1513,17 @@ func generatePipeline(bridge binding.Bridge, enableProxy bool) map[binding.Table
 			conntrackStateTable:   bridge.CreateTable(conntrackStateTable, endpointDNATTable, binding.TableMissActionNext),
 			sessionAffinityTable:  bridge.CreateTable(sessionAffinityTable, binding.LastTableID, binding.TableMissActionNone),
 			serviceLBTable:        bridge.CreateTable(serviceLBTable, endpointDNATTable, binding.TableMissActionNext),
-			endpointDNATTable:     bridge.CreateTable(endpointDNATTable, cnpEgressRuleTable, binding.TableMissActionNext),
-			cnpEgressRuleTable:    bridge.CreateTable(cnpEgressRuleTable, EgressRuleTable, binding.TableMissActionNext),
+			endpointDNATTable:     bridge.CreateTable(endpointDNATTable, CNPEgressRuleTable, binding.TableMissActionNext),
+			CNPEgressRuleTable:    bridge.CreateTable(CNPEgressRuleTable, EgressRuleTable, binding.TableMissActionNext),
 			EgressRuleTable:       bridge.CreateTable(EgressRuleTable, EgressDefaultTable, binding.TableMissActionNext),
-			EgressDefaultTable:    bridge.CreateTable(EgressDefaultTable, l3ForwardingTable, binding.TableMissActionNext),
+			EgressDefaultTable:    bridge.CreateTable(EgressDefaultTable, EgressMetricTable, binding.TableMissActionNext),
+			EgressMetricTable:     bridge.CreateTable(EgressMetricTable, l3ForwardingTable, binding.TableMissActionNext),
 			l3ForwardingTable:     bridge.CreateTable(l3ForwardingTable, l2ForwardingCalcTable, binding.TableMissActionNext),
-			l2ForwardingCalcTable: bridge.CreateTable(l2ForwardingCalcTable, cnpIngressRuleTable, binding.TableMissActionNext),
-			cnpIngressRuleTable:   bridge.CreateTable(cnpIngressRuleTable, IngressRuleTable, binding.TableMissActionNext),
+			l2ForwardingCalcTable: bridge.CreateTable(l2ForwardingCalcTable, CNPIngressRuleTable, binding.TableMissActionNext),
+			CNPIngressRuleTable:   bridge.CreateTable(CNPIngressRuleTable, IngressRuleTable, binding.TableMissActionNext),
 			IngressRuleTable:      bridge.CreateTable(IngressRuleTable, IngressDefaultTable, binding.TableMissActionNext),
-			IngressDefaultTable:   bridge.CreateTable(IngressDefaultTable, conntrackCommitTable, binding.TableMissActionNext),
+			IngressDefaultTable:   bridge.CreateTable(IngressDefaultTable, IngressMetricTable, binding.TableMissActionNext),
+			IngressMetricTable:    bridge.CreateTable(IngressMetricTable, conntrackCommitTable, binding.TableMissActionNext),
 			conntrackCommitTable:  bridge.CreateTable(conntrackCommitTable, hairpinSNATTable, binding.TableMissActionNext),
 			hairpinSNATTable:      bridge.CreateTable(hairpinSNATTable, L2ForwardingOutTable, binding.TableMissActionNext),
 			L2ForwardingOutTable:  bridge.CreateTable(L2ForwardingOutTable, binding.LastTableID, binding.TableMissActionDrop),
@@ -1339,15 +1535,17 @@ func generatePipeline(bridge binding.Bridge, enableProxy bool) map[binding.Table
 		arpResponderTable:     bridge.CreateTable(arpResponderTable, binding.LastTableID, binding.TableMissActionDrop),
 		conntrackTable:        bridge.CreateTable(conntrackTable, conntrackStateTable, binding.TableMissActionNone),
 		conntrackStateTable:   bridge.CreateTable(conntrackStateTable, dnatTable, binding.TableMissActionNext),
-		dnatTable:             bridge.CreateTable(dnatTable, cnpEgressRuleTable, binding.TableMissActionNext),
-		cnpEgressRuleTable:    bridge.CreateTable(cnpEgressRuleTable, EgressRuleTable, binding.TableMissActionNext),
+		dnatTable:             bridge.CreateTable(dnatTable, CNPEgressRuleTable, binding.TableMissActionNext),
+		CNPEgressRuleTable:    bridge.CreateTable(CNPEgressRuleTable, EgressRuleTable, binding.TableMissActionNext),
 		EgressRuleTable:       bridge.CreateTable(EgressRuleTable, EgressDefaultTable, binding.TableMissActionNext),
-		EgressDefaultTable:    bridge.CreateTable(EgressDefaultTable, l3ForwardingTable, binding.TableMissActionNext),
+		EgressDefaultTable:    bridge.CreateTable(EgressDefaultTable, EgressMetricTable, binding.TableMissActionNext),
+		EgressMetricTable:     bridge.CreateTable(EgressMetricTable, l3ForwardingTable, binding.TableMissActionNext),
 		l3ForwardingTable:     bridge.CreateTable(l3ForwardingTable, l2ForwardingCalcTable, binding.TableMissActionNext),
-		l2ForwardingCalcTable: bridge.CreateTable(l2ForwardingCalcTable, cnpIngressRuleTable, binding.TableMissActionNext),
-		cnpIngressRuleTable:   bridge.CreateTable(cnpIngressRuleTable, IngressRuleTable, binding.TableMissActionNext),
+		l2ForwardingCalcTable: bridge.CreateTable(l2ForwardingCalcTable, CNPIngressRuleTable, binding.TableMissActionNext),
+		CNPIngressRuleTable:   bridge.CreateTable(CNPIngressRuleTable, IngressRuleTable, binding.TableMissActionNext),
 		IngressRuleTable:      bridge.CreateTable(IngressRuleTable, IngressDefaultTable, binding.TableMissActionNext),
-		IngressDefaultTable:   bridge.CreateTable(IngressDefaultTable, conntrackCommitTable, binding.TableMissActionNext),
+		IngressDefaultTable:   bridge.CreateTable(IngressDefaultTable, IngressMetricTable, binding.TableMissActionNext),
+		IngressMetricTable:    bridge.CreateTable(IngressMetricTable, conntrackCommitTable, binding.TableMissActionNext),
 		conntrackCommitTable:  bridge.CreateTable(conntrackCommitTable, L2ForwardingOutTable, binding.TableMissActionNext),
 		L2ForwardingOutTable:  bridge.CreateTable(L2ForwardingOutTable, binding.LastTableID, binding.TableMissActionDrop),
 	}
@@ -1366,10 +1564,11 @@ func NewClient(bridgeName, mgmtAddr string, enableProxy bool) Client {
 		nodeFlowCache:            newFlowCategoryCache(),
 		podFlowCache:             newFlowCategoryCache(),
 		serviceFlowCache:         newFlowCategoryCache(),
+		dnsFlowCache:             newFlowCategoryCache(),
 		policyCache:              policyCache,
 		groupCache:               sync.Map{},
 		globalConjMatchFlowCache: map[string]*conjMatchFlowContext{},
-		packetInHandlers:         map[string]PacketInHandler{},
+		packetInHandlers:         map[uint8]map[string]PacketInHandler{},
 	}
 	c.ofEntryOperations = c
 	c.enableProxy = enableProxy
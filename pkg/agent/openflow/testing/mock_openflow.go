@@ -24,6 +24,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	config "github.com/vmware-tanzu/antrea/pkg/agent/config"
 	types "github.com/vmware-tanzu/antrea/pkg/agent/types"
+	v1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
 	openflow "github.com/vmware-tanzu/antrea/pkg/ovs/openflow"
 	proxy "github.com/vmware-tanzu/antrea/third_party/proxy"
 	net "net"
@@ -166,6 +167,34 @@ func (mr *MockClientMockRecorder) GetPolicyFromConjunction(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicyFromConjunction", reflect.TypeOf((*MockClient)(nil).GetPolicyFromConjunction), arg0)
 }
 
+// GetRuleActionFromConjunction mocks base method
+func (m *MockClient) GetRuleActionFromConjunction(arg0 uint32) *v1alpha1.RuleAction {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuleActionFromConjunction", arg0)
+	ret0, _ := ret[0].(*v1alpha1.RuleAction)
+	return ret0
+}
+
+// GetRuleActionFromConjunction indicates an expected call of GetRuleActionFromConjunction
+func (mr *MockClientMockRecorder) GetRuleActionFromConjunction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleActionFromConjunction", reflect.TypeOf((*MockClient)(nil).GetRuleActionFromConjunction), arg0)
+}
+
+// GetRuleLoggingFromConjunction mocks base method
+func (m *MockClient) GetRuleLoggingFromConjunction(arg0 uint32) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuleLoggingFromConjunction", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// GetRuleLoggingFromConjunction indicates an expected call of GetRuleLoggingFromConjunction
+func (mr *MockClientMockRecorder) GetRuleLoggingFromConjunction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleLoggingFromConjunction", reflect.TypeOf((*MockClient)(nil).GetRuleLoggingFromConjunction), arg0)
+}
+
 // GetTunnelVirtualMAC mocks base method
 func (m *MockClient) GetTunnelVirtualMAC() net.HardwareAddr {
 	m.ctrl.T.Helper()
@@ -251,6 +280,20 @@ func (mr *MockClientMockRecorder) InstallClusterServiceFlows() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallClusterServiceFlows", reflect.TypeOf((*MockClient)(nil).InstallClusterServiceFlows))
 }
 
+// InstallDNSInterceptFlows mocks base method
+func (m *MockClient) InstallDNSInterceptFlows(arg0 uint32, arg1 []net.IP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallDNSInterceptFlows", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallDNSInterceptFlows indicates an expected call of InstallDNSInterceptFlows
+func (mr *MockClientMockRecorder) InstallDNSInterceptFlows(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallDNSInterceptFlows", reflect.TypeOf((*MockClient)(nil).InstallDNSInterceptFlows), arg0, arg1)
+}
+
 // InstallDefaultTunnelFlows mocks base method
 func (m *MockClient) InstallDefaultTunnelFlows(arg0 uint32) error {
 	m.ctrl.T.Helper()
@@ -419,6 +462,20 @@ func (mr *MockClientMockRecorder) IsConnected() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsConnected", reflect.TypeOf((*MockClient)(nil).IsConnected))
 }
 
+// NetworkPolicyMetrics mocks base method
+func (m *MockClient) NetworkPolicyMetrics() map[uint32]*types.RuleMetric {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkPolicyMetrics")
+	ret0, _ := ret[0].(map[uint32]*types.RuleMetric)
+	return ret0
+}
+
+// NetworkPolicyMetrics indicates an expected call of NetworkPolicyMetrics
+func (mr *MockClientMockRecorder) NetworkPolicyMetrics() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkPolicyMetrics", reflect.TypeOf((*MockClient)(nil).NetworkPolicyMetrics))
+}
+
 // ReassignFlowPriorities mocks base method
 func (m *MockClient) ReassignFlowPriorities(arg0 map[uint16]uint16) error {
 	m.ctrl.T.Helper()
@@ -434,15 +491,15 @@ func (mr *MockClientMockRecorder) ReassignFlowPriorities(arg0 interface{}) *gomo
 }
 
 // RegisterPacketInHandler mocks base method
-func (m *MockClient) RegisterPacketInHandler(arg0 string, arg1 interface{}) {
+func (m *MockClient) RegisterPacketInHandler(arg0 byte, arg1 string, arg2 interface{}) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "RegisterPacketInHandler", arg0, arg1)
+	m.ctrl.Call(m, "RegisterPacketInHandler", arg0, arg1, arg2)
 }
 
 // RegisterPacketInHandler indicates an expected call of RegisterPacketInHandler
-func (mr *MockClientMockRecorder) RegisterPacketInHandler(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) RegisterPacketInHandler(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterPacketInHandler", reflect.TypeOf((*MockClient)(nil).RegisterPacketInHandler), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterPacketInHandler", reflect.TypeOf((*MockClient)(nil).RegisterPacketInHandler), arg0, arg1, arg2)
 }
 
 // ReplayFlows mocks base method
@@ -457,6 +514,34 @@ func (mr *MockClientMockRecorder) ReplayFlows() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayFlows", reflect.TypeOf((*MockClient)(nil).ReplayFlows))
 }
 
+// SendICMPPacketOut mocks base method
+func (m *MockClient) SendICMPPacketOut(arg0, arg1, arg2, arg3 string, arg4 uint32, arg5 net.IP, arg6, arg7 byte, arg8 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendICMPPacketOut", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendICMPPacketOut indicates an expected call of SendICMPPacketOut
+func (mr *MockClientMockRecorder) SendICMPPacketOut(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendICMPPacketOut", reflect.TypeOf((*MockClient)(nil).SendICMPPacketOut), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+}
+
+// SendTCPPacketOut mocks base method
+func (m *MockClient) SendTCPPacketOut(arg0, arg1, arg2, arg3 string, arg4 uint32, arg5 net.IP, arg6, arg7 uint16, arg8, arg9 uint32, arg10 byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendTCPPacketOut", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendTCPPacketOut indicates an expected call of SendTCPPacketOut
+func (mr *MockClientMockRecorder) SendTCPPacketOut(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTCPPacketOut", reflect.TypeOf((*MockClient)(nil).SendTCPPacketOut), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10)
+}
+
 // SendTraceflowPacket mocks base method
 func (m *MockClient) SendTraceflowPacket(arg0 byte, arg1, arg2, arg3, arg4 string, arg5, arg6 byte, arg7, arg8, arg9 uint16, arg10 byte, arg11, arg12 uint16, arg13, arg14 byte, arg15, arg16 uint16, arg17 uint32, arg18 int32) error {
 	m.ctrl.T.Helper()
@@ -471,6 +556,20 @@ func (mr *MockClientMockRecorder) SendTraceflowPacket(arg0, arg1, arg2, arg3, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTraceflowPacket", reflect.TypeOf((*MockClient)(nil).SendTraceflowPacket), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15, arg16, arg17, arg18)
 }
 
+// SendUDPPacketOut mocks base method
+func (m *MockClient) SendUDPPacketOut(arg0, arg1, arg2, arg3 string, arg4 uint32, arg5 net.IP, arg6, arg7 uint16, arg8 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendUDPPacketOut", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendUDPPacketOut indicates an expected call of SendUDPPacketOut
+func (mr *MockClientMockRecorder) SendUDPPacketOut(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendUDPPacketOut", reflect.TypeOf((*MockClient)(nil).SendUDPPacketOut), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+}
+
 // StartPacketInHandler mocks base method
 func (m *MockClient) StartPacketInHandler(arg0 <-chan struct{}) {
 	m.ctrl.T.Helper()
@@ -497,6 +596,20 @@ func (mr *MockClientMockRecorder) SubscribePacketIn(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribePacketIn", reflect.TypeOf((*MockClient)(nil).SubscribePacketIn), arg0, arg1)
 }
 
+// UninstallDNSInterceptFlows mocks base method
+func (m *MockClient) UninstallDNSInterceptFlows(arg0 uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UninstallDNSInterceptFlows", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UninstallDNSInterceptFlows indicates an expected call of UninstallDNSInterceptFlows
+func (mr *MockClientMockRecorder) UninstallDNSInterceptFlows(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UninstallDNSInterceptFlows", reflect.TypeOf((*MockClient)(nil).UninstallDNSInterceptFlows), arg0)
+}
+
 // UninstallEndpointFlows mocks base method
 func (m *MockClient) UninstallEndpointFlows(arg0 openflow.Protocol, arg1 proxy.Endpoint) error {
 	m.ctrl.T.Helper()
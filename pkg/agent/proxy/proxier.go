@@ -38,6 +38,13 @@ const (
 	componentName = "antrea-agent-proxy"
 )
 
+// Querier is the interface to query the Services installed by the Proxier.
+type Querier interface {
+	GetServiceByIP(serviceStr string) (k8sproxy.ServicePortName, bool)
+}
+
+var _ Querier = new(Proxier)
+
 // TODO: Add metrics
 type Proxier struct {
 	once            sync.Once
@@ -60,6 +67,10 @@ type Proxier struct {
 	// endpointInstalledMap stores endpoints we actually installed.
 	endpointInstalledMap map[k8sproxy.ServicePortName]map[string]struct{}
 	groupCounter         types.GroupCounter
+	// serviceStringMap maps the "ClusterIP:Port/Protocol" strings of the installed Services to
+	// their names, so that the connections to the Services can be mapped back to them.
+	serviceStringMap      map[string]k8sproxy.ServicePortName
+	serviceStringMapMutex sync.RWMutex
 
 	runner       *k8sproxy.BoundedFrequencyRunner
 	stopChan     <-chan struct{}
@@ -101,6 +112,7 @@ func (p *Proxier) removeStaleServices() {
 			continue
 		}
 		delete(p.serviceInstalledMap, svcPortName)
+		p.deleteServiceByIP(svcInfo.String())
 		p.groupCounter.Recycle(svcPortName)
 	}
 }
@@ -185,9 +197,31 @@ func (p *Proxier) installServices() {
 			}
 		}
 		p.serviceInstalledMap[svcPortName] = svcPort
+		p.addServiceByIP(svcInfo.String(), svcPortName)
 	}
 }
 
+func (p *Proxier) addServiceByIP(serviceStr string, svcPortName k8sproxy.ServicePortName) {
+	p.serviceStringMapMutex.Lock()
+	defer p.serviceStringMapMutex.Unlock()
+	p.serviceStringMap[serviceStr] = svcPortName
+}
+
+func (p *Proxier) deleteServiceByIP(serviceStr string) {
+	p.serviceStringMapMutex.Lock()
+	defer p.serviceStringMapMutex.Unlock()
+	delete(p.serviceStringMap, serviceStr)
+}
+
+// GetServiceByIP returns the name of the installed Service with the given "ClusterIP:Port/Protocol"
+// string, e.g. "10.96.0.10:53/UDP".
+func (p *Proxier) GetServiceByIP(serviceStr string) (k8sproxy.ServicePortName, bool) {
+	p.serviceStringMapMutex.RLock()
+	defer p.serviceStringMapMutex.RUnlock()
+	svcPortName, exists := p.serviceStringMap[serviceStr]
+	return svcPortName, exists
+}
+
 // syncProxyRulesMutex applies current changes in change trackers and then updates
 // flows for services and endpoints. It will abort if either endpoints or services
 // resources is not synced.
@@ -282,6 +316,7 @@ func New(hostname string, informerFactory informers.SharedInformerFactory, ofCli
 		endpointInstalledMap: map[k8sproxy.ServicePortName]map[string]struct{}{},
 		endpointsMap:         types.EndpointsMap{},
 		groupCounter:         types.NewGroupCounter(),
+		serviceStringMap:     map[string]k8sproxy.ServicePortName{},
 		ofClient:             ofClient,
 	}
 	p.serviceConfig.RegisterEventHandler(p)
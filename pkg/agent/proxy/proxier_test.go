@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -91,6 +92,7 @@ func NewFakeProxier(ofClient openflow.Client) *Proxier {
 		endpointInstalledMap: map[k8sproxy.ServicePortName]map[string]struct{}{},
 		endpointsMap:         types.EndpointsMap{},
 		groupCounter:         types.NewGroupCounter(),
+		serviceStringMap:     map[string]k8sproxy.ServicePortName{},
 		ofClient:             ofClient,
 	}
 	return p
@@ -142,6 +144,9 @@ func TestClusterIP(t *testing.T) {
 	mockOFClient.EXPECT().InstallServiceFlows(groupID, svcIPv4, uint16(svcPort), binding.ProtocolTCP, uint16(0)).Times(1)
 
 	fp.syncProxyRules()
+	installedSvcPortName, exists := fp.GetServiceByIP("10.20.30.41:80/TCP")
+	assert.True(t, exists)
+	assert.Equal(t, svcPortName, installedSvcPortName)
 }
 
 func TestClusterIPRemoval(t *testing.T) {
@@ -194,6 +199,8 @@ func TestClusterIPRemoval(t *testing.T) {
 
 	fp.serviceChanges.OnServiceUpdate(svc, nil)
 	fp.syncProxyRules()
+	_, exists := fp.GetServiceByIP("10.20.30.41:80/TCP")
+	assert.False(t, exists)
 }
 
 func TestClusterIPNoEndpoint(t *testing.T) {
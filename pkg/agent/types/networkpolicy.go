@@ -42,22 +42,28 @@ type Address interface {
 
 // PolicyRule groups configurations to set up conjunctive match for egress/ingress policy rules.
 type PolicyRule struct {
-	Direction v1beta1.Direction
-	From      []Address
-	To        []Address
-	Service   []v1beta1.Service
-	Action    *secv1alpha1.RuleAction
-	Priority  *uint16
+	Direction     v1beta1.Direction
+	From          []Address
+	To            []Address
+	Service       []v1beta1.Service
+	Action        *secv1alpha1.RuleAction
+	Priority      *uint16
+	EnableLogging bool
 }
 
 func (r *PolicyRule) IsAntreaNetworkPolicyRule() bool {
 	return r.Priority != nil
 }
 
-// Priority is a struct that is composed of CNP priority, rule priority and
-// tier/category priority in the future. It is used as the basic unit for
-// priority sorting.
+// Priority is a struct that is composed of Tier priority, CNP priority and
+// rule priority. It is used as the basic unit for priority sorting.
 type Priority struct {
+	TierPriority   int32
 	PolicyPriority float64
 	RulePriority   int32
 }
+
+// RuleMetric contains the traffic stats of a NetworkPolicy rule on the Node.
+type RuleMetric struct {
+	Bytes, Packets, Sessions uint64
+}
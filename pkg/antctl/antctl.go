@@ -23,14 +23,19 @@ import (
 	"github.com/vmware-tanzu/antrea/pkg/agent/apiserver/handlers/ovstracing"
 	"github.com/vmware-tanzu/antrea/pkg/agent/apiserver/handlers/podinterface"
 	"github.com/vmware-tanzu/antrea/pkg/agent/openflow"
+	"github.com/vmware-tanzu/antrea/pkg/antctl/raw/query"
 	"github.com/vmware-tanzu/antrea/pkg/antctl/raw/supportbundle"
 	"github.com/vmware-tanzu/antrea/pkg/antctl/transform/addressgroup"
 	"github.com/vmware-tanzu/antrea/pkg/antctl/transform/appliedtogroup"
+	"github.com/vmware-tanzu/antrea/pkg/antctl/transform/clustergroupmember"
 	"github.com/vmware-tanzu/antrea/pkg/antctl/transform/controllerinfo"
 	"github.com/vmware-tanzu/antrea/pkg/antctl/transform/networkpolicy"
+	"github.com/vmware-tanzu/antrea/pkg/antctl/transform/networkpolicystats"
 	"github.com/vmware-tanzu/antrea/pkg/antctl/transform/version"
 	networkingv1beta1 "github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+	statsv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1"
 	systemv1beta1 "github.com/vmware-tanzu/antrea/pkg/apis/system/v1beta1"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/handlers/policyconflict"
 	controllerinforest "github.com/vmware-tanzu/antrea/pkg/apiserver/registry/system/controllerinfo"
 	"github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/scheme"
 )
@@ -160,6 +165,106 @@ var CommandList = &commandList{
 			},
 			transformedResponse: reflect.TypeOf(addressgroup.Response{}),
 		},
+		{
+			use:     "clustergroup",
+			aliases: []string{"clustergroups", "cg"},
+			short:   "Print the members of a ClusterGroup",
+			long:    "Print the Pods, ExternalEntities and IPBlocks selected by a ClusterGroup and its child groups in ${component}. 'name' is required.",
+			example: `  Get the members of a ClusterGroup
+  $ antctl get clustergroup cg1`,
+			commandGroup: get,
+			controllerEndpoint: &endpoint{
+				resourceEndpoint: &resourceEndpoint{
+					groupVersionResource: &networkingv1beta1.ClusterGroupMembersVersionResource,
+				},
+				addonTransform: clustergroupmember.Transform,
+			},
+			transformedResponse: reflect.TypeOf(clustergroupmember.Response{}),
+		},
+		{
+			use:     "netpolstats",
+			aliases: []string{"networkpolicystats", "nps"},
+			short:   "Print NetworkPolicy statistics",
+			long:    "Print the traffic statistics of K8s NetworkPolicies in ${component}, accumulated across all Nodes. The NetworkPolicyStats feature must be enabled.",
+			example: `  Get the statistics of a specific K8s NetworkPolicy
+  $ antctl get netpolstats np1 -n ns1
+  Get the list of K8s NetworkPolicy statistics in a Namespace
+  $ antctl get netpolstats -n ns1
+  Get the list of K8s NetworkPolicy statistics in all Namespaces
+  $ antctl get netpolstats`,
+			commandGroup: get,
+			controllerEndpoint: &endpoint{
+				resourceEndpoint: &resourceEndpoint{
+					groupVersionResource: &statsv1alpha1.NetworkPolicyStatsVersionResource,
+					namespaced:           true,
+				},
+				addonTransform: networkpolicystats.Transform,
+			},
+			transformedResponse: reflect.TypeOf(networkpolicystats.Response{}),
+		},
+		{
+			use:     "cnpstats",
+			aliases: []string{"clusternetworkpolicystats"},
+			short:   "Print ClusterNetworkPolicy statistics",
+			long:    "Print the traffic statistics of Antrea ClusterNetworkPolicies and of their rules in ${component}, accumulated across all Nodes. The NetworkPolicyStats feature must be enabled.",
+			example: `  Get the statistics of a specific Antrea ClusterNetworkPolicy
+  $ antctl get cnpstats cnp1
+  Get the list of Antrea ClusterNetworkPolicy statistics
+  $ antctl get cnpstats`,
+			commandGroup: get,
+			controllerEndpoint: &endpoint{
+				resourceEndpoint: &resourceEndpoint{
+					groupVersionResource: &statsv1alpha1.ClusterNetworkPolicyStatsVersionResource,
+				},
+				addonTransform: networkpolicystats.ClusterTransform,
+			},
+			transformedResponse: reflect.TypeOf(networkpolicystats.Response{}),
+		},
+		{
+			use:     "anpstats",
+			aliases: []string{"antreanetworkpolicystats"},
+			short:   "Print Antrea NetworkPolicy statistics",
+			long:    "Print the traffic statistics of Antrea NetworkPolicies and of their rules in ${component}, accumulated across all Nodes. The NetworkPolicyStats feature must be enabled.",
+			example: `  Get the statistics of a specific Antrea NetworkPolicy
+  $ antctl get anpstats anp1 -n ns1
+  Get the list of Antrea NetworkPolicy statistics in a Namespace
+  $ antctl get anpstats -n ns1
+  Get the list of Antrea NetworkPolicy statistics in all Namespaces
+  $ antctl get anpstats`,
+			commandGroup: get,
+			controllerEndpoint: &endpoint{
+				resourceEndpoint: &resourceEndpoint{
+					groupVersionResource: &statsv1alpha1.AntreaNetworkPolicyStatsVersionResource,
+					namespaced:           true,
+				},
+				addonTransform: networkpolicystats.AntreaTransform,
+			},
+			transformedResponse: reflect.TypeOf(networkpolicystats.Response{}),
+		},
+		{
+			use:     "policyconflict",
+			aliases: []string{"policyconflicts", "pc"},
+			short:   "Print conflicts between Antrea-native policy rules",
+			long:    "Print the rules of Antrea-native policies which are shadowed by or redundant with a rule evaluated before them, and the rules selecting the same traffic with opposite actions.",
+			example: `  Get all the conflicts
+  $ antctl get policyconflict
+  Get the shadowed rules
+  $ antctl get policyconflict --type Shadowed`,
+			controllerEndpoint: &endpoint{
+				nonResourceEndpoint: &nonResourceEndpoint{
+					path: "/policyconflicts",
+					params: []flagInfo{
+						{
+							name:  "type",
+							usage: "Get conflicts of a specific type: Shadowed, Redundant or Overlap.",
+						},
+					},
+					outputType: multiple,
+				},
+			},
+			commandGroup:        get,
+			transformedResponse: reflect.TypeOf(policyconflict.Response{}),
+		},
 		{
 			use:     "controllerinfo",
 			aliases: []string{"controllerinfos", "ci"},
@@ -324,6 +429,11 @@ var CommandList = &commandList{
 			supportAgent:      true,
 			supportController: true,
 		},
+		{
+			cobraCommand:      query.Command,
+			supportAgent:      false,
+			supportController: true,
+		},
 	},
 	codec: scheme.Codecs,
 }
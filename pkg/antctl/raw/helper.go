@@ -0,0 +1,83 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog"
+
+	agentapiserver "github.com/vmware-tanzu/antrea/pkg/agent/apiserver"
+	"github.com/vmware-tanzu/antrea/pkg/agent/controller/noderoute"
+	"github.com/vmware-tanzu/antrea/pkg/antctl/runtime"
+	systemv1beta1 "github.com/vmware-tanzu/antrea/pkg/apis/system/v1beta1"
+	controllerapiserver "github.com/vmware-tanzu/antrea/pkg/apiserver"
+	antrea "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned"
+)
+
+// SetupKubeconfig sets up the config of the clients of the Antrea agent and
+// controller apiservers.
+// TODO: enable secure connection.
+// TODO: generate kubeconfig in Antrea agent for antctl in-Pod access.
+func SetupKubeconfig(kubeconfig *rest.Config) {
+	kubeconfig.APIPath = "/apis"
+	kubeconfig.GroupVersion = &systemv1beta1.SchemeGroupVersion
+	kubeconfig.NegotiatedSerializer = scheme.Codecs.WithoutConversion()
+	kubeconfig.Insecure = true
+	kubeconfig.CAFile = ""
+	kubeconfig.CAData = nil
+	if runtime.InPod {
+		if runtime.Mode == runtime.ModeAgent {
+			kubeconfig.Host = net.JoinHostPort("127.0.0.1", "10350")
+			kubeconfig.BearerTokenFile = agentapiserver.TokenPath
+		} else {
+			kubeconfig.Host = net.JoinHostPort("127.0.0.1", "10349")
+			kubeconfig.BearerTokenFile = controllerapiserver.TokenPath
+		}
+	}
+}
+
+// CreateControllerClient creates a client of the Antrea controller apiserver,
+// which is reached through the IP of the Node the controller runs on.
+func CreateControllerClient(k8sClientset kubernetes.Interface, antreaClientset antrea.Interface, cfgTmpl *rest.Config) (*rest.RESTClient, error) {
+	controllerInfo, err := antreaClientset.ClusterinformationV1beta1().AntreaControllerInfos().Get(context.TODO(), "antrea-controller", metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	controllerNode, err := k8sClientset.CoreV1().Nodes().Get(context.TODO(), controllerInfo.NodeRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error when searching the Node of the controller: %w", err)
+	}
+	var controllerNodeIP net.IP
+	controllerNodeIP, err = noderoute.GetNodeAddr(controllerNode)
+	if err != nil {
+		return nil, fmt.Errorf("error when parsing controllre IP: %w", err)
+	}
+
+	cfg := rest.CopyConfig(cfgTmpl)
+	cfg.Host = net.JoinHostPort(controllerNodeIP.String(), fmt.Sprint(controllerInfo.APIPort))
+	controllerClient, err := rest.RESTClientFor(cfg)
+	if err != nil {
+		klog.Warningf("Error when creating controller client for node: %s", controllerInfo.NodeRef.Name)
+	}
+	return controllerClient, nil
+}
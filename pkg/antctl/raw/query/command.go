@@ -0,0 +1,187 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/antrea/pkg/antctl/raw"
+	"github.com/vmware-tanzu/antrea/pkg/antctl/runtime"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/handlers/endpointpair"
+	antrea "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned"
+)
+
+// Command is the query command implementation.
+var Command *cobra.Command
+
+var option = &struct {
+	source      string
+	destination string
+	protocol    string
+	port        int32
+	policyFile  string
+	output      string
+}{}
+
+var endpointPairLongDescription = strings.TrimSpace(`
+Evaluate the NetworkPolicies, ClusterNetworkPolicies and Antrea NetworkPolicies applied to the traffic between two endpoints, without generating any packet.
+It prints the rules matching the traffic in the order they are evaluated, and the effective action of the traffic.
+A candidate policy can be provided to evaluate the traffic as if it was applied, in which case it replaces the existing policy with the same kind, Namespace and name.
+`)
+
+var endpointPairExample = strings.Trim(`
+  Evaluate the TCP traffic from Pod default/client to Pod default/server on port 80
+  $ antctl query endpoint-pair -S default/client -D default/server --port 80
+  Evaluate the UDP traffic from Pod default/client to IP 10.10.1.1 on port 53
+  $ antctl query endpoint-pair -S default/client -D 10.10.1.1 --protocol UDP --port 53
+  Evaluate the traffic as if the policy in policy.yaml was applied
+  $ antctl query endpoint-pair -S default/client -D default/server --port 80 --policy policy.yaml
+`, "\n")
+
+func init() {
+	endpointPairCommand := &cobra.Command{
+		Use:     "endpoint-pair",
+		Short:   "Evaluate the policies applied to the traffic between two endpoints",
+		Long:    endpointPairLongDescription,
+		Example: endpointPairExample,
+		Args:    cobra.NoArgs,
+		RunE:    endpointPairRunE,
+	}
+	endpointPairCommand.Flags().StringVarP(&option.source, "source", "S", "", "source of the traffic, either a Pod (Namespace/name) or an IP address")
+	endpointPairCommand.Flags().StringVarP(&option.destination, "destination", "D", "", "destination of the traffic, either a Pod (Namespace/name) or an IP address")
+	endpointPairCommand.Flags().StringVar(&option.protocol, "protocol", "TCP", "protocol of the traffic: TCP, UDP or SCTP")
+	endpointPairCommand.Flags().Int32Var(&option.port, "port", 0, "destination port of the traffic")
+	endpointPairCommand.Flags().StringVar(&option.policyFile, "policy", "", "path of the YAML file of a candidate policy")
+	endpointPairCommand.Flags().StringVarP(&option.output, "output", "o", "table", "output format: table, json or yaml")
+	endpointPairCommand.MarkFlagRequired("source")
+	endpointPairCommand.MarkFlagRequired("destination")
+
+	Command = &cobra.Command{
+		Use:   "query",
+		Short: "Query the policies computed by the Antrea controller",
+	}
+	Command.AddCommand(endpointPairCommand)
+}
+
+func createControllerClient(cmd *cobra.Command) (*rest.RESTClient, error) {
+	kubeconfigPath, err := cmd.Flags().GetString("kubeconfig")
+	if err != nil {
+		return nil, err
+	}
+	kubeconfig, err := runtime.ResolveKubeconfig(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	restconfigTmpl := rest.CopyConfig(kubeconfig)
+	raw.SetupKubeconfig(restconfigTmpl)
+	if runtime.InPod {
+		return rest.RESTClientFor(restconfigTmpl)
+	}
+	k8sClientset, err := kubernetes.NewForConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+	antreaClientset, err := antrea.NewForConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("error when creating antrea clientset: %w", err)
+	}
+	return raw.CreateControllerClient(k8sClientset, antreaClientset, restconfigTmpl)
+}
+
+func endpointPairRunE(cmd *cobra.Command, _ []string) error {
+	if option.output != "table" && option.output != "json" && option.output != "yaml" {
+		return fmt.Errorf("unsupported output format %s", option.output)
+	}
+	req := &endpointpair.Request{
+		Source:      option.source,
+		Destination: option.destination,
+		Protocol:    option.protocol,
+		Port:        option.port,
+	}
+	if option.policyFile != "" {
+		policy, err := ioutil.ReadFile(option.policyFile)
+		if err != nil {
+			return fmt.Errorf("error when reading the candidate policy: %w", err)
+		}
+		req.Policy = string(policy)
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	client, err := createControllerClient(cmd)
+	if err != nil {
+		return fmt.Errorf("error when creating controller client: %w", err)
+	}
+	result, err := client.Post().
+		AbsPath("/endpointpair").
+		SetHeader("Content-Type", "application/json").
+		Body(body).
+		DoRaw(context.TODO())
+	if err != nil {
+		return fmt.Errorf("error when querying the controller: %w", err)
+	}
+	var resp endpointpair.Response
+	if err := json.Unmarshal(result, &resp); err != nil {
+		return fmt.Errorf("error when decoding the response: %w", err)
+	}
+	return output(cmd.OutOrStdout(), &resp)
+}
+
+func output(out io.Writer, resp *endpointpair.Response) error {
+	switch option.output {
+	case "json":
+		data, err := json.MarshalIndent(resp, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	case "yaml":
+		data, err := yaml.Marshal(resp)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	}
+	if len(resp.Rules) > 0 {
+		w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "POLICY TYPE\tNAMESPACE\tNAME\tDIRECTION\tINDEX\tACTION\tEFFECTIVE\tCANDIDATE")
+		for _, rule := range resp.Rules {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", rule.PolicyType, rule.Namespace, rule.Name, rule.Direction,
+				strconv.Itoa(int(rule.Index)), rule.Action, strconv.FormatBool(rule.Effective), strconv.FormatBool(rule.Candidate))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintf(out, "Action: %s\nReason: %s\n", resp.Action, resp.Reason)
+	return nil
+}
@@ -35,15 +35,13 @@ import (
 	"gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog"
 
-	agentapiserver "github.com/vmware-tanzu/antrea/pkg/agent/apiserver"
 	"github.com/vmware-tanzu/antrea/pkg/agent/controller/noderoute"
+	"github.com/vmware-tanzu/antrea/pkg/antctl/raw"
 	"github.com/vmware-tanzu/antrea/pkg/antctl/runtime"
 	systemv1beta1 "github.com/vmware-tanzu/antrea/pkg/apis/system/v1beta1"
-	controllerapiserver "github.com/vmware-tanzu/antrea/pkg/apiserver"
 	antrea "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned"
 )
 
@@ -104,26 +102,6 @@ func init() {
 	}
 }
 
-// TODO: enable secure connection.
-// TODO: generate kubeconfig in Antrea agent for antctl in-Pod access.
-func setupKubeconfig(kubeconfig *rest.Config) {
-	kubeconfig.APIPath = "/apis"
-	kubeconfig.GroupVersion = &systemv1beta1.SchemeGroupVersion
-	kubeconfig.NegotiatedSerializer = scheme.Codecs.WithoutConversion()
-	kubeconfig.Insecure = true
-	kubeconfig.CAFile = ""
-	kubeconfig.CAData = nil
-	if runtime.InPod {
-		if runtime.Mode == runtime.ModeAgent {
-			kubeconfig.Host = net.JoinHostPort("127.0.0.1", "10350")
-			kubeconfig.BearerTokenFile = agentapiserver.TokenPath
-		} else {
-			kubeconfig.Host = net.JoinHostPort("127.0.0.1", "10349")
-			kubeconfig.BearerTokenFile = controllerapiserver.TokenPath
-		}
-	}
-}
-
 func localSupportBundleRequest(cmd *cobra.Command, mode string) error {
 	kubeconfigPath, err := cmd.Flags().GetString("kubeconfig")
 	if err != nil {
@@ -133,7 +111,7 @@ func localSupportBundleRequest(cmd *cobra.Command, mode string) error {
 	if err != nil {
 		return err
 	}
-	setupKubeconfig(kubeconfig)
+	raw.SetupKubeconfig(kubeconfig)
 	client, err := rest.RESTClientFor(kubeconfig)
 	if err != nil {
 		return fmt.Errorf("error when creating rest client: %w", err)
@@ -316,31 +294,6 @@ func createAgentClients(k8sClientset kubernetes.Interface, antreaClientset antre
 	return clients, nil
 }
 
-func createControllerClient(k8sClientset kubernetes.Interface, antreaClientset antrea.Interface, cfgTmpl *rest.Config) (*rest.RESTClient, error) {
-	controllerInfo, err := antreaClientset.ClusterinformationV1beta1().AntreaControllerInfos().Get(context.TODO(), "antrea-controller", metav1.GetOptions{})
-	if err != nil {
-		return nil, err
-	}
-
-	controllerNode, err := k8sClientset.CoreV1().Nodes().Get(context.TODO(), controllerInfo.NodeRef.Name, metav1.GetOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("error when searching the Node of the controller: %w", err)
-	}
-	var controllerNodeIP net.IP
-	controllerNodeIP, err = noderoute.GetNodeAddr(controllerNode)
-	if err != nil {
-		return nil, fmt.Errorf("error when parsing controllre IP: %w", err)
-	}
-
-	cfg := rest.CopyConfig(cfgTmpl)
-	cfg.Host = net.JoinHostPort(controllerNodeIP.String(), fmt.Sprint(controllerInfo.APIPort))
-	controllerClient, err := rest.RESTClientFor(cfg)
-	if err != nil {
-		klog.Warningf("Error when creating controller client for node: %s", controllerInfo.NodeRef.Name)
-	}
-	return controllerClient, nil
-}
-
 func getClusterInfo(k8sClient kubernetes.Interface) (io.Reader, error) {
 	g := new(errgroup.Group)
 	var writeLock sync.Mutex
@@ -450,7 +403,7 @@ func controllerRemoteRunE(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	restconfigTmpl := rest.CopyConfig(kubeconfig)
-	setupKubeconfig(restconfigTmpl)
+	raw.SetupKubeconfig(restconfigTmpl)
 	if server, err := Command.Flags().GetString("server"); err != nil {
 		kubeconfig.Host = server
 	}
@@ -469,7 +422,7 @@ func controllerRemoteRunE(cmd *cobra.Command, args []string) error {
 	// Collect controller bundle when no Node name or label filter is specified, or
 	// when --controller-only is set.
 	if (len(args) == 0 && option.labelSelector == "") || option.controllerOnly {
-		controllerClient, err = createControllerClient(k8sClientset, antreaClientset, restconfigTmpl)
+		controllerClient, err = raw.CreateControllerClient(k8sClientset, antreaClientset, restconfigTmpl)
 		if err != nil {
 			return fmt.Errorf("error when creating controller client: %w", err)
 		}
@@ -0,0 +1,89 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustergroupmember
+
+import (
+	"io"
+	"reflect"
+
+	"github.com/vmware-tanzu/antrea/pkg/antctl/transform"
+	"github.com/vmware-tanzu/antrea/pkg/antctl/transform/common"
+	networkingv1beta1 "github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
+	"github.com/vmware-tanzu/antrea/pkg/util/ip"
+)
+
+type Response struct {
+	Name             string                  `json:"name" yaml:"name"`
+	Pods             []common.GroupMemberPod `json:"pods,omitempty"`
+	ExternalEntities []string                `json:"externalEntities,omitempty"`
+	IPBlocks         []string                `json:"ipBlocks,omitempty"`
+}
+
+func objectTransform(o interface{}) (interface{}, error) {
+	members := o.(*networkingv1beta1.ClusterGroupMembers)
+	var pods []common.GroupMemberPod
+	for _, pod := range members.Pods {
+		pods = append(pods, common.GroupMemberPodTransform(pod))
+	}
+	var entities []string
+	for _, member := range members.GroupMembers {
+		if member.ExternalEntity != nil {
+			entities = append(entities, member.ExternalEntity.Namespace+"/"+member.ExternalEntity.Name)
+		}
+	}
+	var ipBlocks []string
+	for i := range members.IPBlocks {
+		ipBlocks = append(ipBlocks, ip.IPNetToNetIPNet(&members.IPBlocks[i].CIDR).String())
+	}
+	return Response{Name: members.Name, Pods: pods, ExternalEntities: entities, IPBlocks: ipBlocks}, nil
+}
+
+// Transform only supports a single object as the members of all ClusterGroups
+// cannot be listed.
+func Transform(reader io.Reader, single bool) (interface{}, error) {
+	return transform.GenericFactory(
+		reflect.TypeOf(networkingv1beta1.ClusterGroupMembers{}),
+		reflect.TypeOf(networkingv1beta1.ClusterGroupMembers{}),
+		objectTransform,
+		nil,
+	)(reader, single)
+}
+
+var _ common.TableOutput = new(Response)
+
+func (r Response) GetTableHeader() []string {
+	return []string{"NAME", "POD-IPS", "EXTERNAL-ENTITIES", "IP-BLOCKS"}
+}
+
+func (r Response) GetPodNames(maxColumnLength int) string {
+	list := make([]string, len(r.Pods))
+	for i, pod := range r.Pods {
+		list[i] = pod.IP
+	}
+	return common.GenerateTableElementWithSummary(list, maxColumnLength)
+}
+
+func (r Response) GetTableRow(maxColumnLength int) []string {
+	return []string{
+		r.Name,
+		r.GetPodNames(maxColumnLength),
+		common.GenerateTableElementWithSummary(r.ExternalEntities, maxColumnLength),
+		common.GenerateTableElementWithSummary(r.IPBlocks, maxColumnLength),
+	}
+}
+
+func (r Response) SortRows() bool {
+	return true
+}
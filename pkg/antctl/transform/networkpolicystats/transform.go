@@ -0,0 +1,142 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicystats
+
+import (
+	"io"
+	"reflect"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/antrea/pkg/antctl/transform"
+	"github.com/vmware-tanzu/antrea/pkg/antctl/transform/common"
+	statsv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1"
+)
+
+type Response struct {
+	Namespace    string                           `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Name         string                           `json:"name" yaml:"name"`
+	CreationTime string                           `json:"creationTime" yaml:"creationTime"`
+	TrafficStats statsv1alpha1.TrafficStats       `json:"trafficStats" yaml:"trafficStats"`
+	Rules        []statsv1alpha1.RuleTrafficStats `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+func newResponse(meta *metav1.ObjectMeta, trafficStats statsv1alpha1.TrafficStats, rules []statsv1alpha1.RuleTrafficStats) Response {
+	return Response{
+		Namespace:    meta.Namespace,
+		Name:         meta.Name,
+		CreationTime: meta.CreationTimestamp.Format(time.RFC3339),
+		TrafficStats: trafficStats,
+		Rules:        rules,
+	}
+}
+
+func objectTransform(o interface{}) (interface{}, error) {
+	stats := o.(*statsv1alpha1.NetworkPolicyStats)
+	return newResponse(&stats.ObjectMeta, stats.TrafficStats, nil), nil
+}
+
+func listTransform(l interface{}) (interface{}, error) {
+	statsList := l.(*statsv1alpha1.NetworkPolicyStatsList)
+	result := []interface{}{}
+	for i := range statsList.Items {
+		o, _ := objectTransform(&statsList.Items[i])
+		result = append(result, o.(Response))
+	}
+	return result, nil
+}
+
+// Transform transforms NetworkPolicyStats.
+func Transform(reader io.Reader, single bool) (interface{}, error) {
+	return transform.GenericFactory(
+		reflect.TypeOf(statsv1alpha1.NetworkPolicyStats{}),
+		reflect.TypeOf(statsv1alpha1.NetworkPolicyStatsList{}),
+		objectTransform,
+		listTransform,
+	)(reader, single)
+}
+
+func clusterObjectTransform(o interface{}) (interface{}, error) {
+	stats := o.(*statsv1alpha1.ClusterNetworkPolicyStats)
+	return newResponse(&stats.ObjectMeta, stats.TrafficStats, stats.RuleTrafficStats), nil
+}
+
+func clusterListTransform(l interface{}) (interface{}, error) {
+	statsList := l.(*statsv1alpha1.ClusterNetworkPolicyStatsList)
+	result := []interface{}{}
+	for i := range statsList.Items {
+		o, _ := clusterObjectTransform(&statsList.Items[i])
+		result = append(result, o.(Response))
+	}
+	return result, nil
+}
+
+// ClusterTransform transforms ClusterNetworkPolicyStats.
+func ClusterTransform(reader io.Reader, single bool) (interface{}, error) {
+	return transform.GenericFactory(
+		reflect.TypeOf(statsv1alpha1.ClusterNetworkPolicyStats{}),
+		reflect.TypeOf(statsv1alpha1.ClusterNetworkPolicyStatsList{}),
+		clusterObjectTransform,
+		clusterListTransform,
+	)(reader, single)
+}
+
+func antreaObjectTransform(o interface{}) (interface{}, error) {
+	stats := o.(*statsv1alpha1.AntreaNetworkPolicyStats)
+	return newResponse(&stats.ObjectMeta, stats.TrafficStats, stats.RuleTrafficStats), nil
+}
+
+func antreaListTransform(l interface{}) (interface{}, error) {
+	statsList := l.(*statsv1alpha1.AntreaNetworkPolicyStatsList)
+	result := []interface{}{}
+	for i := range statsList.Items {
+		o, _ := antreaObjectTransform(&statsList.Items[i])
+		result = append(result, o.(Response))
+	}
+	return result, nil
+}
+
+// AntreaTransform transforms AntreaNetworkPolicyStats.
+func AntreaTransform(reader io.Reader, single bool) (interface{}, error) {
+	return transform.GenericFactory(
+		reflect.TypeOf(statsv1alpha1.AntreaNetworkPolicyStats{}),
+		reflect.TypeOf(statsv1alpha1.AntreaNetworkPolicyStatsList{}),
+		antreaObjectTransform,
+		antreaListTransform,
+	)(reader, single)
+}
+
+var _ common.TableOutput = new(Response)
+
+func (r Response) GetTableHeader() []string {
+	return []string{"NAMESPACE", "NAME", "SESSIONS", "PACKETS", "BYTES", "CREATED AT"}
+}
+
+func (r Response) GetTableRow(maxColumnLength int) []string {
+	return []string{
+		r.Namespace,
+		r.Name,
+		strconv.FormatInt(r.TrafficStats.Sessions, 10),
+		strconv.FormatInt(r.TrafficStats.Packets, 10),
+		strconv.FormatInt(r.TrafficStats.Bytes, 10),
+		r.CreationTime,
+	}
+}
+
+func (r Response) SortRows() bool {
+	return true
+}
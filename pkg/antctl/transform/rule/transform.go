@@ -15,13 +15,18 @@
 package rule
 
 import (
+	"fmt"
+
 	networkingv1beta1 "github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1"
 	"github.com/vmware-tanzu/antrea/pkg/util/ip"
 )
 
 type service struct {
-	Protocol string `json:"protocol,omitempty"`
-	Port     string `json:"port,omitempty"`
+	Protocol   string `json:"protocol,omitempty"`
+	Port       string `json:"port,omitempty"`
+	ICMPType   *int32 `json:"icmpType,omitempty"`
+	ICMPCode   *int32 `json:"icmpCode,omitempty"`
+	IPProtocol *int32 `json:"ipProtocol,omitempty"`
 }
 
 type ipBlock struct {
@@ -44,10 +49,21 @@ type Response struct {
 func serviceTransform(services ...networkingv1beta1.Service) []service {
 	var ret []service
 	for _, s := range services {
-		ret = append(ret, service{
-			Protocol: string(*s.Protocol),
-			Port:     s.Port.String(),
-		})
+		svc := service{
+			ICMPType:   s.ICMPType,
+			ICMPCode:   s.ICMPCode,
+			IPProtocol: s.IPProtocol,
+		}
+		if s.Protocol != nil {
+			svc.Protocol = string(*s.Protocol)
+		}
+		if s.Port != nil {
+			svc.Port = s.Port.String()
+			if s.EndPort != nil {
+				svc.Port = fmt.Sprintf("%s-%d", svc.Port, *s.EndPort)
+			}
+		}
+		ret = append(ret, svc)
 	}
 	return ret
 }
@@ -17,6 +17,8 @@ package v1alpha1
 import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
 )
 
 // +genclient
@@ -74,3 +76,63 @@ type ExternalEntityList struct {
 
 	Items []ExternalEntity `json:"items,omitempty"`
 }
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterGroup is a named set of workloads and IP addresses, which can be
+// referenced by ClusterNetworkPolicies in place of inline selectors.
+type ClusterGroup struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard metadata of the object.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Desired state of the group.
+	Spec GroupSpec `json:"spec"`
+}
+
+// GroupSpec defines the members of a ClusterGroup. Either the selectors,
+// the IPBlocks or the ChildGroups can be set.
+type GroupSpec struct {
+	// Select Pods matched by this selector. If set with NamespaceSelector,
+	// Pods are matched from Namespaces matched by the NamespaceSelector;
+	// otherwise, Pods are matched from all Namespaces.
+	// Cannot be set with any other selector except NamespaceSelector.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+	// Select all Pods from Namespaces matched by this selector. If set with
+	// PodSelector, Pods are matched from Namespaces matched by the
+	// NamespaceSelector.
+	// Cannot be set with any other selector except PodSelector or
+	// ExternalEntitySelector.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Select ExternalEntities matched by this selector. If set with
+	// NamespaceSelector, ExternalEntities are matched from Namespaces matched
+	// by the NamespaceSelector; otherwise, ExternalEntities are matched from
+	// all Namespaces.
+	// Cannot be set with any other selector except NamespaceSelector.
+	// +optional
+	ExternalEntitySelector *metav1.LabelSelector `json:"externalEntitySelector,omitempty"`
+	// IPBlocks describes the IPAddresses/IPBlocks that are part of this
+	// group. A group with IPBlocks cannot be used in the AppliedTo field.
+	// Cannot be set with any selector or ChildGroups.
+	// +optional
+	IPBlocks []secv1alpha1.IPBlock `json:"ipBlocks,omitempty"`
+	// ChildGroups is a list of the names of the ClusterGroups whose members
+	// are members of this group.
+	// Cannot be set with any selector or IPBlocks.
+	// +optional
+	ChildGroups []string `json:"childGroups,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ClusterGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterGroup `json:"items,omitempty"`
+}
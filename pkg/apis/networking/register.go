@@ -53,6 +53,9 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&AddressGroupList{},
 		&NetworkPolicy{},
 		&NetworkPolicyList{},
+		&NetworkPolicyStatus{},
+		&NodeStatsSummary{},
+		&ClusterGroupMembers{},
 	)
 	return nil
 }
@@ -114,3 +114,92 @@ func (s GroupMemberPodSet) IsSuperset(o GroupMemberPodSet) bool {
 func (s GroupMemberPodSet) Equal(o GroupMemberPodSet) bool {
 	return len(s) == len(o) && s.IsSuperset(o)
 }
+
+// groupMemberHash is used to uniquely identify GroupMember. Only Pod,
+// ExternalEntity and the IPs of the Endpoints are included as unique
+// identifiers.
+type groupMemberHash string
+
+// GroupMemberSet is a set of GroupMembers.
+type GroupMemberSet map[groupMemberHash]*GroupMember
+
+// hashGroupMember uses the spew library which follows pointers and prints
+// actual values of the nested objects to ensure the hash does not change when
+// a pointer changes.
+func hashGroupMember(member *GroupMember) groupMemberHash {
+	hasher := md5.New()
+	hashObj := GroupMember{Pod: member.Pod, ExternalEntity: member.ExternalEntity}
+	for _, ep := range member.Endpoints {
+		hashObj.Endpoints = append(hashObj.Endpoints, Endpoint{IP: ep.IP})
+	}
+	printer.Fprintf(hasher, "%#v", hashObj)
+	return groupMemberHash(hex.EncodeToString(hasher.Sum(nil)[0:]))
+}
+
+// NewGroupMemberSet builds a GroupMemberSet from a list of GroupMember.
+func NewGroupMemberSet(items ...*GroupMember) GroupMemberSet {
+	m := GroupMemberSet{}
+	m.Insert(items...)
+	return m
+}
+
+// Insert adds items to the set.
+func (s GroupMemberSet) Insert(items ...*GroupMember) {
+	for _, item := range items {
+		s[hashGroupMember(item)] = item
+	}
+}
+
+// Delete removes all items from the set.
+func (s GroupMemberSet) Delete(items ...*GroupMember) {
+	for _, item := range items {
+		delete(s, hashGroupMember(item))
+	}
+}
+
+// Has returns true if and only if item is contained in the set.
+func (s GroupMemberSet) Has(item *GroupMember) bool {
+	_, contained := s[hashGroupMember(item)]
+	return contained
+}
+
+// Difference returns a set of GroupMembers that are not in o.
+func (s GroupMemberSet) Difference(o GroupMemberSet) GroupMemberSet {
+	result := GroupMemberSet{}
+	for key, item := range s {
+		if _, contained := o[key]; !contained {
+			result[key] = item
+		}
+	}
+	return result
+}
+
+// Union returns a new set which includes items in either m or o.
+func (s GroupMemberSet) Union(o GroupMemberSet) GroupMemberSet {
+	result := GroupMemberSet{}
+	for key, item := range s {
+		result[key] = item
+	}
+	for key, item := range o {
+		result[key] = item
+	}
+	return result
+}
+
+// IsSuperset returns true if and only if s1 is a superset of s2.
+func (s GroupMemberSet) IsSuperset(o GroupMemberSet) bool {
+	for key := range o {
+		_, contained := s[key]
+		if !contained {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns true if and only if s1 is equal (as a set) to s2.
+// Two sets are equal if their membership is identical.
+// (In practice, this means same elements, order doesn't matter)
+func (s GroupMemberSet) Equal(o GroupMemberSet) bool {
+	return len(s) == len(o) && s.IsSuperset(o)
+}
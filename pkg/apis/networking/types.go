@@ -40,6 +40,14 @@ type PodReference struct {
 	Namespace string
 }
 
+// ServiceReference represents a reference to a Service.
+type ServiceReference struct {
+	// The name of this Service.
+	Name string
+	// The Namespace of this Service.
+	Namespace string
+}
+
 // NamedPort represents a Port with a name on Pod.
 type NamedPort struct {
 	// Port represents the Port number.
@@ -158,6 +166,13 @@ type NetworkPolicy struct {
 	// Priority represents the relative priority of this Network Policy as compared to
 	// other Network Policies. Priority will be unset (nil) for K8s Network Policy.
 	Priority *float64
+	// TierPriority represents the priority of the Tier associated with this Network
+	// Policy. The TierPriority will remain nil for K8s NetworkPolicy.
+	TierPriority *int32
+	// Generation is the generation of the original Antrea-native policy this
+	// NetworkPolicy is translated from. It is used by Nodes to report the
+	// realization status of the policy. It will remain 0 for K8s NetworkPolicy.
+	Generation int64
 }
 
 // Direction defines traffic direction of NetworkPolicyRule.
@@ -183,10 +198,13 @@ type NetworkPolicyRule struct {
 	// Priority defines the priority of the Rule as compared to other rules in the
 	// NetworkPolicy.
 	Priority int32
-	// Action specifies the action to be applied on the rule. i.e. Allow/Drop. An empty
+	// Action specifies the action to be applied on the rule. i.e. Allow/Drop/Pass/Reject. An empty
 	// action “nil” defaults to Allow action, which would be the case for rules created for
 	// K8s Network Policy.
 	Action *secv1alpha1.RuleAction
+	// EnableLogging indicates whether or not to generate audit logs for the
+	// traffic which matches this rule.
+	EnableLogging bool
 }
 
 // Protocol defines network protocols supported for things like container ports.
@@ -199,17 +217,35 @@ const (
 	ProtocolUDP Protocol = "UDP"
 	// ProtocolSCTP is the SCTP protocol.
 	ProtocolSCTP Protocol = "SCTP"
+	// ProtocolICMP is the ICMP protocol.
+	ProtocolICMP Protocol = "ICMP"
 )
 
 // Service describes a port to allow traffic on.
 type Service struct {
-	// The protocol (TCP, UDP, or SCTP) which traffic must match. If not specified, this
-	// field defaults to TCP.
+	// The protocol (TCP, UDP, SCTP, or ICMP) which traffic must match. If neither this
+	// field nor IPProtocol is specified, this field defaults to TCP.
 	// +optional
 	Protocol *Protocol
 	// The port name or number on the given protocol. If not specified, this matches all port numbers.
 	// +optional
 	Port *intstr.IntOrString
+	// EndPort defines the end of the port range, being the end included within the range.
+	// It can only be specified when a numerical `port` is specified.
+	// +optional
+	EndPort *int32
+	// ICMPType is the type of the ICMP messages to match. It can only be specified when
+	// the protocol is ICMP. If not specified, this matches all ICMP types.
+	// +optional
+	ICMPType *int32
+	// ICMPCode is the code of the ICMP messages to match. It can only be specified when
+	// the protocol is ICMP. If not specified, this matches all ICMP codes.
+	// +optional
+	ICMPCode *int32
+	// IPProtocol is the number of the IP protocol which traffic must match, e.g. 47
+	// for GRE. It cannot be specified along with the protocol or the ports.
+	// +optional
+	IPProtocol *int32
 }
 
 // NetworkPolicyPeer describes a peer of NetworkPolicyRules.
@@ -219,6 +255,13 @@ type NetworkPolicyPeer struct {
 	AddressGroups []string
 	// A list of IPBlock.
 	IPBlocks []IPBlock
+	// A list of FQDNs, which can be exact names or wildcards like
+	// "*.example.com". Only used in egress rules of Antrea-native policies.
+	FQDNs []string
+	// A list of Services referenced by the peer. The AddressGroups of the peer
+	// contain the ClusterIPs and the Endpoints of these Services, each with its
+	// own ports. Only used in egress rules of Antrea-native policies.
+	ToServices []ServiceReference
 }
 
 // IPBlock describes a particular CIDR (Ex. "192.168.1.1/24"). The except entry describes CIDRs that should
@@ -239,3 +282,85 @@ type NetworkPolicyList struct {
 	metav1.ListMeta
 	Items []NetworkPolicy
 }
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// NetworkPolicyStatus is the status of a NetworkPolicy reported by Nodes. Its
+// name and namespace are the ones of the NetworkPolicy.
+type NetworkPolicyStatus struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+	// Nodes contains statuses produced on a list of Nodes.
+	Nodes []NetworkPolicyNodeStatus
+}
+
+// NetworkPolicyNodeStatus is the status of a NetworkPolicy on a Node.
+type NetworkPolicyNodeStatus struct {
+	// The name of the Node that produces the status.
+	NodeName string
+	// The generation realized by the Node.
+	Generation int64
+	// The error encountered when realizing the generation, if any.
+	Error string
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// ClusterGroupMembers is the list of the members of a ClusterGroup, as
+// computed by antrea-controller. Its name is the one of the ClusterGroup.
+type ClusterGroupMembers struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+	// Pods is a list of Pods selected by the group.
+	Pods []GroupMemberPod
+	// GroupMembers is a list of GroupMember selected by the group.
+	GroupMembers []GroupMember
+	// IPBlocks is a list of IPBlocks of the group.
+	IPBlocks []IPBlock
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// NodeStatsSummary contains the stats produced on a Node. It is used by the
+// antrea-agents to report stats to the antrea-controller. Its name is the one
+// of the Node.
+type NodeStatsSummary struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+	// The TrafficStats of K8s NetworkPolicies collected from the Node.
+	NetworkPolicies []NetworkPolicyStats
+	// The TrafficStats of Antrea ClusterNetworkPolicies collected from the Node.
+	ClusterNetworkPolicies []NetworkPolicyStats
+	// The TrafficStats of Antrea NetworkPolicies collected from the Node.
+	AntreaNetworkPolicies []NetworkPolicyStats
+}
+
+// NetworkPolicyStats contains the traffic stats of a NetworkPolicy.
+type NetworkPolicyStats struct {
+	// The name of the NetworkPolicy.
+	Name string
+	// The namespace of the NetworkPolicy, empty for ClusterNetworkPolicies.
+	Namespace string
+	// The traffic stats of the NetworkPolicy.
+	TrafficStats TrafficStats
+	// The traffic stats of each rule of the NetworkPolicy, only collected
+	// for Antrea-native policies.
+	RuleTrafficStats []RuleTrafficStats
+}
+
+// RuleTrafficStats contains the traffic stats of a NetworkPolicy rule.
+type RuleTrafficStats struct {
+	// The direction of the rule.
+	Direction Direction
+	// The index of the rule among the rules of the same direction.
+	Priority int32
+	// The traffic stats of the rule.
+	TrafficStats TrafficStats
+}
+
+// TrafficStats contains the traffic stats of a NetworkPolicy or rule.
+type TrafficStats struct {
+	// Packets is the packets count hit by the NetworkPolicy or rule.
+	Packets int64
+	// Bytes is the bytes count hit by the NetworkPolicy or rule.
+	Bytes int64
+	// Sessions is the sessions count hit by the NetworkPolicy or rule.
+	Sessions int64
+}
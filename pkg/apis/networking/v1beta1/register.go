@@ -39,6 +39,10 @@ var (
 		Group:    SchemeGroupVersion.Group,
 		Version:  SchemeGroupVersion.Version,
 		Resource: "networkpolicies"}
+	ClusterGroupMembersVersionResource = schema.GroupVersionResource{
+		Group:    SchemeGroupVersion.Group,
+		Version:  SchemeGroupVersion.Version,
+		Resource: "clustergroupmembers"}
 )
 
 // Resource takes an unqualified resource and returns a Group qualified GroupResource.
@@ -63,6 +67,9 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&AddressGroupList{},
 		&NetworkPolicy{},
 		&NetworkPolicyList{},
+		&NetworkPolicyStatus{},
+		&NodeStatsSummary{},
+		&ClusterGroupMembers{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -43,6 +43,14 @@ type PodReference struct {
 	Namespace string `json:"namespace,omitempty" protobuf:"bytes,2,opt,name=namespace"`
 }
 
+// ServiceReference represents a reference to a Service.
+type ServiceReference struct {
+	// The name of this Service.
+	Name string `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
+	// The Namespace of this Service.
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,2,opt,name=namespace"`
+}
+
 // NamedPort represents a Port with a name on Pod.
 type NamedPort struct {
 	// Port represents the Port number.
@@ -165,6 +173,13 @@ type NetworkPolicy struct {
 	// Priority represents the relative priority of this Network Policy as compared to
 	// other Network Policies. Priority will be unset (nil) for K8s Network Policy.
 	Priority *float64 `json:"priority,omitempty" protobuf:"fixed64,4,opt,name=priority"`
+	// TierPriority represents the priority of the Tier associated with this Network
+	// Policy. The TierPriority will remain nil for K8s NetworkPolicy.
+	TierPriority *int32 `json:"tierPriority,omitempty" protobuf:"varint,5,opt,name=tierPriority"`
+	// Generation is the generation of the original Antrea-native policy this
+	// NetworkPolicy is translated from. It is used by Nodes to report the
+	// realization status of the policy. It will remain 0 for K8s NetworkPolicy.
+	Generation int64 `json:"generation,omitempty" protobuf:"varint,6,opt,name=generation"`
 }
 
 // Direction defines traffic direction of NetworkPolicyRule.
@@ -190,10 +205,13 @@ type NetworkPolicyRule struct {
 	// Priority defines the priority of the Rule as compared to other rules in the
 	// NetworkPolicy.
 	Priority int32 `json:"priority,omitempty" protobuf:"varint,5,opt,name=priority"`
-	// Action specifies the action to be applied on the rule. i.e. Allow/Drop. An empty
+	// Action specifies the action to be applied on the rule. i.e. Allow/Drop/Pass/Reject. An empty
 	// action “nil” defaults to Allow action, which would be the case for rules created for
 	// K8s Network Policy.
 	Action *secv1alpha1.RuleAction `json:"action,omitempty" protobuf:"bytes,6,opt,name=action,casttype=github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1.RuleAction"`
+	// EnableLogging indicates whether or not to generate audit logs for the
+	// traffic which matches this rule.
+	EnableLogging bool `json:"enableLogging,omitempty" protobuf:"varint,7,opt,name=enableLogging"`
 }
 
 // Protocol defines network protocols supported for things like container ports.
@@ -206,17 +224,35 @@ const (
 	ProtocolUDP Protocol = "UDP"
 	// ProtocolSCTP is the SCTP protocol.
 	ProtocolSCTP Protocol = "SCTP"
+	// ProtocolICMP is the ICMP protocol.
+	ProtocolICMP Protocol = "ICMP"
 )
 
 // Service describes a port to allow traffic on.
 type Service struct {
-	// The protocol (TCP, UDP, or SCTP) which traffic must match. If not specified, this
-	// field defaults to TCP.
+	// The protocol (TCP, UDP, SCTP, or ICMP) which traffic must match. If neither this
+	// field nor IPProtocol is specified, this field defaults to TCP.
 	// +optional
 	Protocol *Protocol `json:"protocol,omitempty" protobuf:"bytes,1,opt,name=protocol"`
 	// The port name or number on the given protocol. If not specified, this matches all port numbers.
 	// +optional
 	Port *intstr.IntOrString `json:"port,omitempty" protobuf:"bytes,2,opt,name=port"`
+	// EndPort defines the end of the port range, being the end included within the range.
+	// It can only be specified when a numerical `port` is specified.
+	// +optional
+	EndPort *int32 `json:"endPort,omitempty" protobuf:"varint,3,opt,name=endPort"`
+	// ICMPType is the type of the ICMP messages to match. It can only be specified when
+	// the protocol is ICMP. If not specified, this matches all ICMP types.
+	// +optional
+	ICMPType *int32 `json:"icmpType,omitempty" protobuf:"varint,4,opt,name=icmpType"`
+	// ICMPCode is the code of the ICMP messages to match. It can only be specified when
+	// the protocol is ICMP. If not specified, this matches all ICMP codes.
+	// +optional
+	ICMPCode *int32 `json:"icmpCode,omitempty" protobuf:"varint,5,opt,name=icmpCode"`
+	// IPProtocol is the number of the IP protocol which traffic must match, e.g. 47
+	// for GRE. It cannot be specified along with the protocol or the ports.
+	// +optional
+	IPProtocol *int32 `json:"ipProtocol,omitempty" protobuf:"varint,6,opt,name=ipProtocol"`
 }
 
 // NetworkPolicyPeer describes a peer of NetworkPolicyRules.
@@ -226,6 +262,13 @@ type NetworkPolicyPeer struct {
 	AddressGroups []string `json:"addressGroups,omitempty" protobuf:"bytes,1,rep,name=addressGroups"`
 	// A list of IPBlock.
 	IPBlocks []IPBlock `json:"ipBlocks,omitempty" protobuf:"bytes,2,rep,name=ipBlocks"`
+	// A list of FQDNs, which can be exact names or wildcards like
+	// "*.example.com". Only used in egress rules of Antrea-native policies.
+	FQDNs []string `json:"fqdns,omitempty" protobuf:"bytes,3,rep,name=fqdns"`
+	// A list of Services referenced by the peer. The AddressGroups of the peer
+	// contain the ClusterIPs and the Endpoints of these Services, each with its
+	// own ports. Only used in egress rules of Antrea-native policies.
+	ToServices []ServiceReference `json:"toServices,omitempty" protobuf:"bytes,4,rep,name=toServices"`
 }
 
 // IPBlock describes a particular CIDR (Ex. "192.168.1.1/24"). The except entry describes CIDRs that should
@@ -246,3 +289,94 @@ type NetworkPolicyList struct {
 	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
 	Items           []NetworkPolicy `json:"items" protobuf:"bytes,2,rep,name=items"`
 }
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:onlyVerbs=create
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// NetworkPolicyStatus is the status of a NetworkPolicy reported by Nodes. Its
+// name and namespace are the ones of the NetworkPolicy.
+type NetworkPolicyStatus struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// Nodes contains statuses produced on a list of Nodes.
+	Nodes []NetworkPolicyNodeStatus `json:"nodes,omitempty" protobuf:"bytes,2,rep,name=nodes"`
+}
+
+// NetworkPolicyNodeStatus is the status of a NetworkPolicy on a Node.
+type NetworkPolicyNodeStatus struct {
+	// The name of the Node that produces the status.
+	NodeName string `json:"nodeName,omitempty" protobuf:"bytes,1,opt,name=nodeName"`
+	// The generation realized by the Node.
+	Generation int64 `json:"generation,omitempty" protobuf:"varint,2,opt,name=generation"`
+	// The error encountered when realizing the generation, if any.
+	Error string `json:"error,omitempty" protobuf:"bytes,3,opt,name=error"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:onlyVerbs=get
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// ClusterGroupMembers is the list of the members of a ClusterGroup, as
+// computed by antrea-controller. Its name is the one of the ClusterGroup.
+type ClusterGroupMembers struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// Pods is a list of Pods selected by the group.
+	Pods []GroupMemberPod `json:"pods,omitempty" protobuf:"bytes,2,rep,name=pods"`
+	// GroupMembers is a list of GroupMember selected by the group.
+	GroupMembers []GroupMember `json:"groupMembers,omitempty" protobuf:"bytes,3,rep,name=groupMembers"`
+	// IPBlocks is a list of IPBlocks of the group.
+	IPBlocks []IPBlock `json:"ipBlocks,omitempty" protobuf:"bytes,4,rep,name=ipBlocks"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:onlyVerbs=create
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// NodeStatsSummary contains the stats produced on a Node. It is used by the
+// antrea-agents to report stats to the antrea-controller. Its name is the one
+// of the Node.
+type NodeStatsSummary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// The TrafficStats of K8s NetworkPolicies collected from the Node.
+	NetworkPolicies []NetworkPolicyStats `json:"networkPolicies,omitempty" protobuf:"bytes,2,rep,name=networkPolicies"`
+	// The TrafficStats of Antrea ClusterNetworkPolicies collected from the Node.
+	ClusterNetworkPolicies []NetworkPolicyStats `json:"clusterNetworkPolicies,omitempty" protobuf:"bytes,3,rep,name=clusterNetworkPolicies"`
+	// The TrafficStats of Antrea NetworkPolicies collected from the Node.
+	AntreaNetworkPolicies []NetworkPolicyStats `json:"antreaNetworkPolicies,omitempty" protobuf:"bytes,4,rep,name=antreaNetworkPolicies"`
+}
+
+// NetworkPolicyStats contains the traffic stats of a NetworkPolicy.
+type NetworkPolicyStats struct {
+	// The name of the NetworkPolicy.
+	Name string `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
+	// The namespace of the NetworkPolicy, empty for ClusterNetworkPolicies.
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,2,opt,name=namespace"`
+	// The traffic stats of the NetworkPolicy.
+	TrafficStats TrafficStats `json:"trafficStats" protobuf:"bytes,3,opt,name=trafficStats"`
+	// The traffic stats of each rule of the NetworkPolicy, only collected
+	// for Antrea-native policies.
+	RuleTrafficStats []RuleTrafficStats `json:"ruleTrafficStats,omitempty" protobuf:"bytes,4,rep,name=ruleTrafficStats"`
+}
+
+// RuleTrafficStats contains the traffic stats of a NetworkPolicy rule.
+type RuleTrafficStats struct {
+	// The direction of the rule.
+	Direction Direction `json:"direction,omitempty" protobuf:"bytes,1,opt,name=direction"`
+	// The index of the rule among the rules of the same direction.
+	Priority int32 `json:"priority,omitempty" protobuf:"varint,2,opt,name=priority"`
+	// The traffic stats of the rule.
+	TrafficStats TrafficStats `json:"trafficStats" protobuf:"bytes,3,opt,name=trafficStats"`
+}
+
+// TrafficStats contains the traffic stats of a NetworkPolicy or rule.
+type TrafficStats struct {
+	// Packets is the packets count hit by the NetworkPolicy or rule.
+	Packets int64 `json:"packets,omitempty" protobuf:"varint,1,opt,name=packets"`
+	// Bytes is the bytes count hit by the NetworkPolicy or rule.
+	Bytes int64 `json:"bytes,omitempty" protobuf:"varint,2,opt,name=bytes"`
+	// Sessions is the sessions count hit by the NetworkPolicy or rule.
+	Sessions int64 `json:"sessions,omitempty" protobuf:"varint,3,opt,name=sessions"`
+}
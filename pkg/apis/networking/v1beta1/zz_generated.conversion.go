@@ -95,6 +95,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ClusterGroupMembers)(nil), (*networking.ClusterGroupMembers)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ClusterGroupMembers_To_networking_ClusterGroupMembers(a.(*ClusterGroupMembers), b.(*networking.ClusterGroupMembers), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.ClusterGroupMembers)(nil), (*ClusterGroupMembers)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_ClusterGroupMembers_To_v1beta1_ClusterGroupMembers(a.(*networking.ClusterGroupMembers), b.(*ClusterGroupMembers), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Endpoint)(nil), (*networking.Endpoint)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_Endpoint_To_networking_Endpoint(a.(*Endpoint), b.(*networking.Endpoint), scope)
 	}); err != nil {
@@ -185,6 +195,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NetworkPolicyNodeStatus)(nil), (*networking.NetworkPolicyNodeStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NetworkPolicyNodeStatus_To_networking_NetworkPolicyNodeStatus(a.(*NetworkPolicyNodeStatus), b.(*networking.NetworkPolicyNodeStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.NetworkPolicyNodeStatus)(nil), (*NetworkPolicyNodeStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_NetworkPolicyNodeStatus_To_v1beta1_NetworkPolicyNodeStatus(a.(*networking.NetworkPolicyNodeStatus), b.(*NetworkPolicyNodeStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NetworkPolicyPeer)(nil), (*networking.NetworkPolicyPeer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_NetworkPolicyPeer_To_networking_NetworkPolicyPeer(a.(*NetworkPolicyPeer), b.(*networking.NetworkPolicyPeer), scope)
 	}); err != nil {
@@ -205,6 +225,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NetworkPolicyStats)(nil), (*networking.NetworkPolicyStats)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NetworkPolicyStats_To_networking_NetworkPolicyStats(a.(*NetworkPolicyStats), b.(*networking.NetworkPolicyStats), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.NetworkPolicyStats)(nil), (*NetworkPolicyStats)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_NetworkPolicyStats_To_v1beta1_NetworkPolicyStats(a.(*networking.NetworkPolicyStats), b.(*NetworkPolicyStats), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkPolicyStatus)(nil), (*networking.NetworkPolicyStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NetworkPolicyStatus_To_networking_NetworkPolicyStatus(a.(*NetworkPolicyStatus), b.(*networking.NetworkPolicyStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.NetworkPolicyStatus)(nil), (*NetworkPolicyStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_NetworkPolicyStatus_To_v1beta1_NetworkPolicyStatus(a.(*networking.NetworkPolicyStatus), b.(*NetworkPolicyStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeStatsSummary)(nil), (*networking.NodeStatsSummary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NodeStatsSummary_To_networking_NodeStatsSummary(a.(*NodeStatsSummary), b.(*networking.NodeStatsSummary), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.NodeStatsSummary)(nil), (*NodeStatsSummary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_NodeStatsSummary_To_v1beta1_NodeStatsSummary(a.(*networking.NodeStatsSummary), b.(*NodeStatsSummary), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PodReference)(nil), (*networking.PodReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_PodReference_To_networking_PodReference(a.(*PodReference), b.(*networking.PodReference), scope)
 	}); err != nil {
@@ -215,6 +265,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RuleTrafficStats)(nil), (*networking.RuleTrafficStats)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RuleTrafficStats_To_networking_RuleTrafficStats(a.(*RuleTrafficStats), b.(*networking.RuleTrafficStats), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.RuleTrafficStats)(nil), (*RuleTrafficStats)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_RuleTrafficStats_To_v1beta1_RuleTrafficStats(a.(*networking.RuleTrafficStats), b.(*RuleTrafficStats), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Service)(nil), (*networking.Service)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_Service_To_networking_Service(a.(*Service), b.(*networking.Service), scope)
 	}); err != nil {
@@ -225,6 +285,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ServiceReference)(nil), (*networking.ServiceReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ServiceReference_To_networking_ServiceReference(a.(*ServiceReference), b.(*networking.ServiceReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.ServiceReference)(nil), (*ServiceReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_ServiceReference_To_v1beta1_ServiceReference(a.(*networking.ServiceReference), b.(*ServiceReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TrafficStats)(nil), (*networking.TrafficStats)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_TrafficStats_To_networking_TrafficStats(a.(*TrafficStats), b.(*networking.TrafficStats), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*networking.TrafficStats)(nil), (*TrafficStats)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_networking_TrafficStats_To_v1beta1_TrafficStats(a.(*networking.TrafficStats), b.(*TrafficStats), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -376,6 +456,32 @@ func Convert_networking_AppliedToGroupPatch_To_v1beta1_AppliedToGroupPatch(in *n
 	return autoConvert_networking_AppliedToGroupPatch_To_v1beta1_AppliedToGroupPatch(in, out, s)
 }
 
+func autoConvert_v1beta1_ClusterGroupMembers_To_networking_ClusterGroupMembers(in *ClusterGroupMembers, out *networking.ClusterGroupMembers, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.Pods = *(*[]networking.GroupMemberPod)(unsafe.Pointer(&in.Pods))
+	out.GroupMembers = *(*[]networking.GroupMember)(unsafe.Pointer(&in.GroupMembers))
+	out.IPBlocks = *(*[]networking.IPBlock)(unsafe.Pointer(&in.IPBlocks))
+	return nil
+}
+
+// Convert_v1beta1_ClusterGroupMembers_To_networking_ClusterGroupMembers is an autogenerated conversion function.
+func Convert_v1beta1_ClusterGroupMembers_To_networking_ClusterGroupMembers(in *ClusterGroupMembers, out *networking.ClusterGroupMembers, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterGroupMembers_To_networking_ClusterGroupMembers(in, out, s)
+}
+
+func autoConvert_networking_ClusterGroupMembers_To_v1beta1_ClusterGroupMembers(in *networking.ClusterGroupMembers, out *ClusterGroupMembers, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.Pods = *(*[]GroupMemberPod)(unsafe.Pointer(&in.Pods))
+	out.GroupMembers = *(*[]GroupMember)(unsafe.Pointer(&in.GroupMembers))
+	out.IPBlocks = *(*[]IPBlock)(unsafe.Pointer(&in.IPBlocks))
+	return nil
+}
+
+// Convert_networking_ClusterGroupMembers_To_v1beta1_ClusterGroupMembers is an autogenerated conversion function.
+func Convert_networking_ClusterGroupMembers_To_v1beta1_ClusterGroupMembers(in *networking.ClusterGroupMembers, out *ClusterGroupMembers, s conversion.Scope) error {
+	return autoConvert_networking_ClusterGroupMembers_To_v1beta1_ClusterGroupMembers(in, out, s)
+}
+
 func autoConvert_v1beta1_Endpoint_To_networking_Endpoint(in *Endpoint, out *networking.Endpoint, s conversion.Scope) error {
 	out.IP = *(*networking.IPAddress)(unsafe.Pointer(&in.IP))
 	out.Ports = *(*[]networking.NamedPort)(unsafe.Pointer(&in.Ports))
@@ -545,6 +651,8 @@ func autoConvert_v1beta1_NetworkPolicy_To_networking_NetworkPolicy(in *NetworkPo
 	out.Rules = *(*[]networking.NetworkPolicyRule)(unsafe.Pointer(&in.Rules))
 	out.AppliedToGroups = *(*[]string)(unsafe.Pointer(&in.AppliedToGroups))
 	out.Priority = (*float64)(unsafe.Pointer(in.Priority))
+	out.TierPriority = (*int32)(unsafe.Pointer(in.TierPriority))
+	out.Generation = in.Generation
 	return nil
 }
 
@@ -558,6 +666,8 @@ func autoConvert_networking_NetworkPolicy_To_v1beta1_NetworkPolicy(in *networkin
 	out.Rules = *(*[]NetworkPolicyRule)(unsafe.Pointer(&in.Rules))
 	out.AppliedToGroups = *(*[]string)(unsafe.Pointer(&in.AppliedToGroups))
 	out.Priority = (*float64)(unsafe.Pointer(in.Priority))
+	out.TierPriority = (*int32)(unsafe.Pointer(in.TierPriority))
+	out.Generation = in.Generation
 	return nil
 }
 
@@ -588,9 +698,35 @@ func Convert_networking_NetworkPolicyList_To_v1beta1_NetworkPolicyList(in *netwo
 	return autoConvert_networking_NetworkPolicyList_To_v1beta1_NetworkPolicyList(in, out, s)
 }
 
+func autoConvert_v1beta1_NetworkPolicyNodeStatus_To_networking_NetworkPolicyNodeStatus(in *NetworkPolicyNodeStatus, out *networking.NetworkPolicyNodeStatus, s conversion.Scope) error {
+	out.NodeName = in.NodeName
+	out.Generation = in.Generation
+	out.Error = in.Error
+	return nil
+}
+
+// Convert_v1beta1_NetworkPolicyNodeStatus_To_networking_NetworkPolicyNodeStatus is an autogenerated conversion function.
+func Convert_v1beta1_NetworkPolicyNodeStatus_To_networking_NetworkPolicyNodeStatus(in *NetworkPolicyNodeStatus, out *networking.NetworkPolicyNodeStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_NetworkPolicyNodeStatus_To_networking_NetworkPolicyNodeStatus(in, out, s)
+}
+
+func autoConvert_networking_NetworkPolicyNodeStatus_To_v1beta1_NetworkPolicyNodeStatus(in *networking.NetworkPolicyNodeStatus, out *NetworkPolicyNodeStatus, s conversion.Scope) error {
+	out.NodeName = in.NodeName
+	out.Generation = in.Generation
+	out.Error = in.Error
+	return nil
+}
+
+// Convert_networking_NetworkPolicyNodeStatus_To_v1beta1_NetworkPolicyNodeStatus is an autogenerated conversion function.
+func Convert_networking_NetworkPolicyNodeStatus_To_v1beta1_NetworkPolicyNodeStatus(in *networking.NetworkPolicyNodeStatus, out *NetworkPolicyNodeStatus, s conversion.Scope) error {
+	return autoConvert_networking_NetworkPolicyNodeStatus_To_v1beta1_NetworkPolicyNodeStatus(in, out, s)
+}
+
 func autoConvert_v1beta1_NetworkPolicyPeer_To_networking_NetworkPolicyPeer(in *NetworkPolicyPeer, out *networking.NetworkPolicyPeer, s conversion.Scope) error {
 	out.AddressGroups = *(*[]string)(unsafe.Pointer(&in.AddressGroups))
 	out.IPBlocks = *(*[]networking.IPBlock)(unsafe.Pointer(&in.IPBlocks))
+	out.FQDNs = *(*[]string)(unsafe.Pointer(&in.FQDNs))
+	out.ToServices = *(*[]networking.ServiceReference)(unsafe.Pointer(&in.ToServices))
 	return nil
 }
 
@@ -602,6 +738,8 @@ func Convert_v1beta1_NetworkPolicyPeer_To_networking_NetworkPolicyPeer(in *Netwo
 func autoConvert_networking_NetworkPolicyPeer_To_v1beta1_NetworkPolicyPeer(in *networking.NetworkPolicyPeer, out *NetworkPolicyPeer, s conversion.Scope) error {
 	out.AddressGroups = *(*[]string)(unsafe.Pointer(&in.AddressGroups))
 	out.IPBlocks = *(*[]IPBlock)(unsafe.Pointer(&in.IPBlocks))
+	out.FQDNs = *(*[]string)(unsafe.Pointer(&in.FQDNs))
+	out.ToServices = *(*[]ServiceReference)(unsafe.Pointer(&in.ToServices))
 	return nil
 }
 
@@ -621,6 +759,7 @@ func autoConvert_v1beta1_NetworkPolicyRule_To_networking_NetworkPolicyRule(in *N
 	out.Services = *(*[]networking.Service)(unsafe.Pointer(&in.Services))
 	out.Priority = in.Priority
 	out.Action = (*v1alpha1.RuleAction)(unsafe.Pointer(in.Action))
+	out.EnableLogging = in.EnableLogging
 	return nil
 }
 
@@ -640,6 +779,7 @@ func autoConvert_networking_NetworkPolicyRule_To_v1beta1_NetworkPolicyRule(in *n
 	out.Services = *(*[]Service)(unsafe.Pointer(&in.Services))
 	out.Priority = in.Priority
 	out.Action = (*v1alpha1.RuleAction)(unsafe.Pointer(in.Action))
+	out.EnableLogging = in.EnableLogging
 	return nil
 }
 
@@ -648,6 +788,84 @@ func Convert_networking_NetworkPolicyRule_To_v1beta1_NetworkPolicyRule(in *netwo
 	return autoConvert_networking_NetworkPolicyRule_To_v1beta1_NetworkPolicyRule(in, out, s)
 }
 
+func autoConvert_v1beta1_NetworkPolicyStats_To_networking_NetworkPolicyStats(in *NetworkPolicyStats, out *networking.NetworkPolicyStats, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	if err := Convert_v1beta1_TrafficStats_To_networking_TrafficStats(&in.TrafficStats, &out.TrafficStats, s); err != nil {
+		return err
+	}
+	out.RuleTrafficStats = *(*[]networking.RuleTrafficStats)(unsafe.Pointer(&in.RuleTrafficStats))
+	return nil
+}
+
+// Convert_v1beta1_NetworkPolicyStats_To_networking_NetworkPolicyStats is an autogenerated conversion function.
+func Convert_v1beta1_NetworkPolicyStats_To_networking_NetworkPolicyStats(in *NetworkPolicyStats, out *networking.NetworkPolicyStats, s conversion.Scope) error {
+	return autoConvert_v1beta1_NetworkPolicyStats_To_networking_NetworkPolicyStats(in, out, s)
+}
+
+func autoConvert_networking_NetworkPolicyStats_To_v1beta1_NetworkPolicyStats(in *networking.NetworkPolicyStats, out *NetworkPolicyStats, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	if err := Convert_networking_TrafficStats_To_v1beta1_TrafficStats(&in.TrafficStats, &out.TrafficStats, s); err != nil {
+		return err
+	}
+	out.RuleTrafficStats = *(*[]RuleTrafficStats)(unsafe.Pointer(&in.RuleTrafficStats))
+	return nil
+}
+
+// Convert_networking_NetworkPolicyStats_To_v1beta1_NetworkPolicyStats is an autogenerated conversion function.
+func Convert_networking_NetworkPolicyStats_To_v1beta1_NetworkPolicyStats(in *networking.NetworkPolicyStats, out *NetworkPolicyStats, s conversion.Scope) error {
+	return autoConvert_networking_NetworkPolicyStats_To_v1beta1_NetworkPolicyStats(in, out, s)
+}
+
+func autoConvert_v1beta1_NetworkPolicyStatus_To_networking_NetworkPolicyStatus(in *NetworkPolicyStatus, out *networking.NetworkPolicyStatus, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.Nodes = *(*[]networking.NetworkPolicyNodeStatus)(unsafe.Pointer(&in.Nodes))
+	return nil
+}
+
+// Convert_v1beta1_NetworkPolicyStatus_To_networking_NetworkPolicyStatus is an autogenerated conversion function.
+func Convert_v1beta1_NetworkPolicyStatus_To_networking_NetworkPolicyStatus(in *NetworkPolicyStatus, out *networking.NetworkPolicyStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_NetworkPolicyStatus_To_networking_NetworkPolicyStatus(in, out, s)
+}
+
+func autoConvert_networking_NetworkPolicyStatus_To_v1beta1_NetworkPolicyStatus(in *networking.NetworkPolicyStatus, out *NetworkPolicyStatus, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.Nodes = *(*[]NetworkPolicyNodeStatus)(unsafe.Pointer(&in.Nodes))
+	return nil
+}
+
+// Convert_networking_NetworkPolicyStatus_To_v1beta1_NetworkPolicyStatus is an autogenerated conversion function.
+func Convert_networking_NetworkPolicyStatus_To_v1beta1_NetworkPolicyStatus(in *networking.NetworkPolicyStatus, out *NetworkPolicyStatus, s conversion.Scope) error {
+	return autoConvert_networking_NetworkPolicyStatus_To_v1beta1_NetworkPolicyStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_NodeStatsSummary_To_networking_NodeStatsSummary(in *NodeStatsSummary, out *networking.NodeStatsSummary, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.NetworkPolicies = *(*[]networking.NetworkPolicyStats)(unsafe.Pointer(&in.NetworkPolicies))
+	out.ClusterNetworkPolicies = *(*[]networking.NetworkPolicyStats)(unsafe.Pointer(&in.ClusterNetworkPolicies))
+	out.AntreaNetworkPolicies = *(*[]networking.NetworkPolicyStats)(unsafe.Pointer(&in.AntreaNetworkPolicies))
+	return nil
+}
+
+// Convert_v1beta1_NodeStatsSummary_To_networking_NodeStatsSummary is an autogenerated conversion function.
+func Convert_v1beta1_NodeStatsSummary_To_networking_NodeStatsSummary(in *NodeStatsSummary, out *networking.NodeStatsSummary, s conversion.Scope) error {
+	return autoConvert_v1beta1_NodeStatsSummary_To_networking_NodeStatsSummary(in, out, s)
+}
+
+func autoConvert_networking_NodeStatsSummary_To_v1beta1_NodeStatsSummary(in *networking.NodeStatsSummary, out *NodeStatsSummary, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.NetworkPolicies = *(*[]NetworkPolicyStats)(unsafe.Pointer(&in.NetworkPolicies))
+	out.ClusterNetworkPolicies = *(*[]NetworkPolicyStats)(unsafe.Pointer(&in.ClusterNetworkPolicies))
+	out.AntreaNetworkPolicies = *(*[]NetworkPolicyStats)(unsafe.Pointer(&in.AntreaNetworkPolicies))
+	return nil
+}
+
+// Convert_networking_NodeStatsSummary_To_v1beta1_NodeStatsSummary is an autogenerated conversion function.
+func Convert_networking_NodeStatsSummary_To_v1beta1_NodeStatsSummary(in *networking.NodeStatsSummary, out *NodeStatsSummary, s conversion.Scope) error {
+	return autoConvert_networking_NodeStatsSummary_To_v1beta1_NodeStatsSummary(in, out, s)
+}
+
 func autoConvert_v1beta1_PodReference_To_networking_PodReference(in *PodReference, out *networking.PodReference, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Namespace = in.Namespace
@@ -670,9 +888,41 @@ func Convert_networking_PodReference_To_v1beta1_PodReference(in *networking.PodR
 	return autoConvert_networking_PodReference_To_v1beta1_PodReference(in, out, s)
 }
 
+func autoConvert_v1beta1_RuleTrafficStats_To_networking_RuleTrafficStats(in *RuleTrafficStats, out *networking.RuleTrafficStats, s conversion.Scope) error {
+	out.Direction = networking.Direction(in.Direction)
+	out.Priority = in.Priority
+	if err := Convert_v1beta1_TrafficStats_To_networking_TrafficStats(&in.TrafficStats, &out.TrafficStats, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_RuleTrafficStats_To_networking_RuleTrafficStats is an autogenerated conversion function.
+func Convert_v1beta1_RuleTrafficStats_To_networking_RuleTrafficStats(in *RuleTrafficStats, out *networking.RuleTrafficStats, s conversion.Scope) error {
+	return autoConvert_v1beta1_RuleTrafficStats_To_networking_RuleTrafficStats(in, out, s)
+}
+
+func autoConvert_networking_RuleTrafficStats_To_v1beta1_RuleTrafficStats(in *networking.RuleTrafficStats, out *RuleTrafficStats, s conversion.Scope) error {
+	out.Direction = Direction(in.Direction)
+	out.Priority = in.Priority
+	if err := Convert_networking_TrafficStats_To_v1beta1_TrafficStats(&in.TrafficStats, &out.TrafficStats, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_networking_RuleTrafficStats_To_v1beta1_RuleTrafficStats is an autogenerated conversion function.
+func Convert_networking_RuleTrafficStats_To_v1beta1_RuleTrafficStats(in *networking.RuleTrafficStats, out *RuleTrafficStats, s conversion.Scope) error {
+	return autoConvert_networking_RuleTrafficStats_To_v1beta1_RuleTrafficStats(in, out, s)
+}
+
 func autoConvert_v1beta1_Service_To_networking_Service(in *Service, out *networking.Service, s conversion.Scope) error {
 	out.Protocol = (*networking.Protocol)(unsafe.Pointer(in.Protocol))
 	out.Port = (*intstr.IntOrString)(unsafe.Pointer(in.Port))
+	out.EndPort = (*int32)(unsafe.Pointer(in.EndPort))
+	out.ICMPType = (*int32)(unsafe.Pointer(in.ICMPType))
+	out.ICMPCode = (*int32)(unsafe.Pointer(in.ICMPCode))
+	out.IPProtocol = (*int32)(unsafe.Pointer(in.IPProtocol))
 	return nil
 }
 
@@ -684,6 +934,10 @@ func Convert_v1beta1_Service_To_networking_Service(in *Service, out *networking.
 func autoConvert_networking_Service_To_v1beta1_Service(in *networking.Service, out *Service, s conversion.Scope) error {
 	out.Protocol = (*Protocol)(unsafe.Pointer(in.Protocol))
 	out.Port = (*intstr.IntOrString)(unsafe.Pointer(in.Port))
+	out.EndPort = (*int32)(unsafe.Pointer(in.EndPort))
+	out.ICMPType = (*int32)(unsafe.Pointer(in.ICMPType))
+	out.ICMPCode = (*int32)(unsafe.Pointer(in.ICMPCode))
+	out.IPProtocol = (*int32)(unsafe.Pointer(in.IPProtocol))
 	return nil
 }
 
@@ -691,3 +945,49 @@ func autoConvert_networking_Service_To_v1beta1_Service(in *networking.Service, o
 func Convert_networking_Service_To_v1beta1_Service(in *networking.Service, out *Service, s conversion.Scope) error {
 	return autoConvert_networking_Service_To_v1beta1_Service(in, out, s)
 }
+
+func autoConvert_v1beta1_ServiceReference_To_networking_ServiceReference(in *ServiceReference, out *networking.ServiceReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_v1beta1_ServiceReference_To_networking_ServiceReference is an autogenerated conversion function.
+func Convert_v1beta1_ServiceReference_To_networking_ServiceReference(in *ServiceReference, out *networking.ServiceReference, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceReference_To_networking_ServiceReference(in, out, s)
+}
+
+func autoConvert_networking_ServiceReference_To_v1beta1_ServiceReference(in *networking.ServiceReference, out *ServiceReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_networking_ServiceReference_To_v1beta1_ServiceReference is an autogenerated conversion function.
+func Convert_networking_ServiceReference_To_v1beta1_ServiceReference(in *networking.ServiceReference, out *ServiceReference, s conversion.Scope) error {
+	return autoConvert_networking_ServiceReference_To_v1beta1_ServiceReference(in, out, s)
+}
+
+func autoConvert_v1beta1_TrafficStats_To_networking_TrafficStats(in *TrafficStats, out *networking.TrafficStats, s conversion.Scope) error {
+	out.Packets = in.Packets
+	out.Bytes = in.Bytes
+	out.Sessions = in.Sessions
+	return nil
+}
+
+// Convert_v1beta1_TrafficStats_To_networking_TrafficStats is an autogenerated conversion function.
+func Convert_v1beta1_TrafficStats_To_networking_TrafficStats(in *TrafficStats, out *networking.TrafficStats, s conversion.Scope) error {
+	return autoConvert_v1beta1_TrafficStats_To_networking_TrafficStats(in, out, s)
+}
+
+func autoConvert_networking_TrafficStats_To_v1beta1_TrafficStats(in *networking.TrafficStats, out *TrafficStats, s conversion.Scope) error {
+	out.Packets = in.Packets
+	out.Bytes = in.Bytes
+	out.Sessions = in.Sessions
+	return nil
+}
+
+// Convert_networking_TrafficStats_To_v1beta1_TrafficStats is an autogenerated conversion function.
+func Convert_networking_TrafficStats_To_v1beta1_TrafficStats(in *networking.TrafficStats, out *TrafficStats, s conversion.Scope) error {
+	return autoConvert_networking_TrafficStats_To_v1beta1_TrafficStats(in, out, s)
+}
@@ -278,6 +278,53 @@ func (in *AppliedToGroupPatch) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterGroupMembers) DeepCopyInto(out *ClusterGroupMembers) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]GroupMemberPod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GroupMembers != nil {
+		in, out := &in.GroupMembers, &out.GroupMembers
+		*out = make([]GroupMember, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IPBlocks != nil {
+		in, out := &in.IPBlocks, &out.IPBlocks
+		*out = make([]IPBlock, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupMembers.
+func (in *ClusterGroupMembers) DeepCopy() *ClusterGroupMembers {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterGroupMembers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterGroupMembers) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Endpoint) DeepCopyInto(out *Endpoint) {
 	*out = *in
@@ -517,6 +564,11 @@ func (in *NetworkPolicy) DeepCopyInto(out *NetworkPolicy) {
 		*out = new(float64)
 		**out = **in
 	}
+	if in.TierPriority != nil {
+		in, out := &in.TierPriority, &out.TierPriority
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -571,6 +623,22 @@ func (in *NetworkPolicyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyNodeStatus) DeepCopyInto(out *NetworkPolicyNodeStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyNodeStatus.
+func (in *NetworkPolicyNodeStatus) DeepCopy() *NetworkPolicyNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkPolicyPeer) DeepCopyInto(out *NetworkPolicyPeer) {
 	*out = *in
@@ -586,6 +654,16 @@ func (in *NetworkPolicyPeer) DeepCopyInto(out *NetworkPolicyPeer) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FQDNs != nil {
+		in, out := &in.FQDNs, &out.FQDNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ToServices != nil {
+		in, out := &in.ToServices, &out.ToServices
+		*out = make([]ServiceReference, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -629,6 +707,106 @@ func (in *NetworkPolicyRule) DeepCopy() *NetworkPolicyRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyStats) DeepCopyInto(out *NetworkPolicyStats) {
+	*out = *in
+	out.TrafficStats = in.TrafficStats
+	if in.RuleTrafficStats != nil {
+		in, out := &in.RuleTrafficStats, &out.RuleTrafficStats
+		*out = make([]RuleTrafficStats, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyStats.
+func (in *NetworkPolicyStats) DeepCopy() *NetworkPolicyStats {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyStatus) DeepCopyInto(out *NetworkPolicyStatus) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NetworkPolicyNodeStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyStatus.
+func (in *NetworkPolicyStatus) DeepCopy() *NetworkPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkPolicyStatus) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeStatsSummary) DeepCopyInto(out *NodeStatsSummary) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.NetworkPolicies != nil {
+		in, out := &in.NetworkPolicies, &out.NetworkPolicies
+		*out = make([]NetworkPolicyStats, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClusterNetworkPolicies != nil {
+		in, out := &in.ClusterNetworkPolicies, &out.ClusterNetworkPolicies
+		*out = make([]NetworkPolicyStats, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AntreaNetworkPolicies != nil {
+		in, out := &in.AntreaNetworkPolicies, &out.AntreaNetworkPolicies
+		*out = make([]NetworkPolicyStats, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatsSummary.
+func (in *NodeStatsSummary) DeepCopy() *NodeStatsSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeStatsSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeStatsSummary) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodReference) DeepCopyInto(out *PodReference) {
 	*out = *in
@@ -645,6 +823,23 @@ func (in *PodReference) DeepCopy() *PodReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleTrafficStats) DeepCopyInto(out *RuleTrafficStats) {
+	*out = *in
+	out.TrafficStats = in.TrafficStats
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleTrafficStats.
+func (in *RuleTrafficStats) DeepCopy() *RuleTrafficStats {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleTrafficStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Service) DeepCopyInto(out *Service) {
 	*out = *in
@@ -658,6 +853,26 @@ func (in *Service) DeepCopyInto(out *Service) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.EndPort != nil {
+		in, out := &in.EndPort, &out.EndPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ICMPType != nil {
+		in, out := &in.ICMPType, &out.ICMPType
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ICMPCode != nil {
+		in, out := &in.ICMPCode, &out.ICMPCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IPProtocol != nil {
+		in, out := &in.IPProtocol, &out.IPProtocol
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -670,3 +885,35 @@ func (in *Service) DeepCopy() *Service {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceReference) DeepCopyInto(out *ServiceReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceReference.
+func (in *ServiceReference) DeepCopy() *ServiceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficStats) DeepCopyInto(out *TrafficStats) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficStats.
+func (in *TrafficStats) DeepCopy() *TrafficStats {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficStats)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -44,6 +44,10 @@ const (
 	Received  TraceflowAction = "Received"
 	Forwarded TraceflowAction = "Forwarded"
 	Dropped   TraceflowAction = "Dropped"
+	Rejected  TraceflowAction = "Rejected"
+	// Passed indicates that the packet was passed by an Antrea-native policy
+	// rule to the K8s NetworkPolicies.
+	Passed TraceflowAction = "Passed"
 )
 
 // +genclient
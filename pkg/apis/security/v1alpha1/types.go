@@ -21,7 +21,6 @@ import (
 )
 
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 type NetworkPolicy struct {
@@ -31,10 +30,18 @@ type NetworkPolicy struct {
 
 	// Specification of the desired behavior of NetworkPolicy.
 	Spec NetworkPolicySpec `json:"spec"`
+	// Most recently observed status of the NetworkPolicy.
+	Status NetworkPolicyStatus `json:"status"`
 }
 
 // NetworkPolicySpec defines the desired state for NetworkPolicy.
 type NetworkPolicySpec struct {
+	// Tier specifies the tier to which this NetworkPolicy belongs to.
+	// The NetworkPolicy order will be determined based on the combination of the
+	// Tier's Priority and the NetworkPolicy's own Priority. If not specified,
+	// this policy will be created in the Application Tier.
+	// +optional
+	Tier string `json:"tier,omitempty"`
 	// Priority specfies the order of the NetworkPolicy relative to other
 	// NetworkPolicies.
 	Priority float64 `json:"priority"`
@@ -52,6 +59,36 @@ type NetworkPolicySpec struct {
 	Egress []Rule `json:"egress"`
 }
 
+// NetworkPolicyPhase defines the phase in which a NetworkPolicy is.
+type NetworkPolicyPhase string
+
+// These are the valid values for NetworkPolicyPhase.
+const (
+	// NetworkPolicyPending means the NetworkPolicy has been accepted by the
+	// system, but it has not been processed by Antrea.
+	NetworkPolicyPending NetworkPolicyPhase = "Pending"
+	// NetworkPolicyRealizing means the NetworkPolicy has been observed by
+	// Antrea and is being realized.
+	NetworkPolicyRealizing NetworkPolicyPhase = "Realizing"
+	// NetworkPolicyRealized means the NetworkPolicy has been enforced on all
+	// Nodes it applies to.
+	NetworkPolicyRealized NetworkPolicyPhase = "Realized"
+)
+
+// NetworkPolicyStatus represents information about the status of a
+// NetworkPolicy.
+type NetworkPolicyStatus struct {
+	// The phase of a NetworkPolicy is a simple, high-level summary of the
+	// NetworkPolicy's status.
+	Phase NetworkPolicyPhase `json:"phase"`
+	// The generation observed by Antrea.
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// The number of Nodes that have realized the NetworkPolicy.
+	CurrentNodesRealized int32 `json:"currentNodesRealized"`
+	// The total number of Nodes that should realize the NetworkPolicy.
+	DesiredNodesRealized int32 `json:"desiredNodesRealized"`
+}
+
 // Rule describes the traffic allowed to/from the workloads selected by
 // Spec.AppliedTo. Based on the action specified in the rule, traffic is either
 // allowed or denied which exactly match the specified ports and protocol.
@@ -71,6 +108,17 @@ type Rule struct {
 	// destinations.
 	// +optional
 	To []NetworkPolicyPeer `json:"to"`
+	// Rule is matched if traffic is intended for the Services referenced by
+	// this field, either to their ClusterIPs and ports before DNAT or to their
+	// Endpoints and target ports after DNAT. ToServices can only be set in
+	// egress rules and cannot be set with To or Ports.
+	// +optional
+	ToServices []ServiceReference `json:"toServices,omitempty"`
+	// EnableLogging is used to indicate if audit logs should be generated
+	// by the agent for the traffic which matches this rule. Defaults to
+	// false.
+	// +optional
+	EnableLogging bool `json:"enableLogging"`
 }
 
 // NetworkPolicyPeer describes the grouping selector of workloads.
@@ -99,6 +147,57 @@ type NetworkPolicyPeer struct {
 	// NamespaceSelector.
 	// Cannot be set with any other selector except NamespaceSelector.
 	ExternalEntitySelector *metav1.LabelSelector `json:"externalEntitySelector,omitempty"`
+	// Select Nodes matched by this selector, as workloads in To/From fields.
+	// The InternalIPs of the Nodes and the gateway IPs of their Pod CIDRs are
+	// matched, i.e. the traffic from or to the host network of the Nodes.
+	// NodeSelector cannot be set as part of the AppliedTo field.
+	// Cannot be set with any other selector.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+	// Select the Pods running with the ServiceAccounts matched by this
+	// selector, as workloads in AppliedTo/To/From fields.
+	// Cannot be set with any other selector.
+	// +optional
+	ServiceAccount *ServiceAccountSelector `json:"serviceAccount,omitempty"`
+	// Select the destinations by their fully qualified domain name. It can be
+	// an exact name like "www.example.com" or a wildcard like "*.example.com",
+	// which matches all the subdomains of "example.com". FQDN can only be set
+	// in the To field of egress rules.
+	// Cannot be set with any other selector.
+	// +optional
+	FQDN string `json:"fqdn,omitempty"`
+	// Select the workloads and IPBlocks of the ClusterGroup with this name.
+	// Group can only be set in ClusterNetworkPolicies.
+	// Cannot be set with any other selector.
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
+// ServiceAccountSelector selects ServiceAccounts either by name or by labels.
+type ServiceAccountSelector struct {
+	// Name of the ServiceAccount. Cannot be set with Selector.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Namespace of the ServiceAccounts. In Antrea NetworkPolicies, it
+	// defaults to the Namespace of the policy and cannot be set to another
+	// Namespace. In ClusterNetworkPolicies, it must be set with Name, and
+	// ServiceAccounts are selected from all Namespaces if it is not set
+	// with Selector.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Select ServiceAccounts by their labels. Cannot be set with Name.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// ServiceReference refers to a Service by its Namespace and name.
+type ServiceReference struct {
+	// Name of the Service.
+	Name string `json:"name"`
+	// Namespace of the Service. It defaults to the Namespace of the Antrea
+	// NetworkPolicy and must be set in ClusterNetworkPolicies.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // IPBlock describes a particular CIDR (Ex. "192.168.1.1/24") that is allowed
@@ -107,20 +206,47 @@ type IPBlock struct {
 	// CIDR is a string representing the IP Block
 	// Valid examples are "192.168.1.1/24".
 	CIDR string `json:"cidr"`
+	// Except is a slice of CIDRs that should not be included within an IP
+	// Block. Valid examples are "192.168.1.1/24". The traffic from/to the
+	// Except CIDRs is not matched by the rule, whatever its Action: it is
+	// evaluated by the subsequent rules. Except values must be contained in
+	// the CIDR, and are rejected otherwise.
+	// +optional
+	Except []string `json:"except,omitempty"`
 }
 
 // NetworkPolicyPort describes the port and protocol to match in a rule.
 type NetworkPolicyPort struct {
-	// The protocol (TCP, UDP, or SCTP) which traffic must match.
-	// If not specified, this field defaults to TCP.
+	// The protocol (TCP, UDP, SCTP, or ICMP) which traffic must match.
+	// If neither this field nor ipProtocol is specified, this field
+	// defaults to TCP.
 	// +optional
 	Protocol *v1.Protocol `json:"protocol"`
 	// The port on the given protocol. This can either be a numerical
 	// or named port on a Pod. If this field is not provided, this
 	// matches all port names and numbers.
-	// TODO: extend it to include Port Range.
 	// +optional
 	Port *intstr.IntOrString `json:"port"`
+	// EndPort defines the end of the port range, being the end included
+	// within the range. It can only be specified when a numerical `port`
+	// is specified, and must be greater than or equal to `port`.
+	// +optional
+	EndPort *int32 `json:"endPort,omitempty"`
+	// ICMPType is the type of the ICMP messages to match. It can only be
+	// specified when the protocol is ICMP. If not specified, this matches
+	// all ICMP types.
+	// +optional
+	ICMPType *int32 `json:"icmpType,omitempty"`
+	// ICMPCode is the code of the ICMP messages to match. It can only be
+	// specified when the protocol is ICMP. If not specified, this matches
+	// all ICMP codes.
+	// +optional
+	ICMPCode *int32 `json:"icmpCode,omitempty"`
+	// IPProtocol is the number of the IP protocol which traffic must
+	// match, e.g. 47 for GRE or 50 for ESP. It cannot be specified along
+	// with the protocol or the ports.
+	// +optional
+	IPProtocol *int32 `json:"ipProtocol,omitempty"`
 }
 
 // RuleAction describes the action to be applied on traffic matching a rule.
@@ -131,6 +257,14 @@ const (
 	RuleActionAllow RuleAction = "Allow"
 	// RuleActionDrop describes that rule matching traffic must be dropped.
 	RuleActionDrop RuleAction = "Drop"
+	// RuleActionPass describes that rule matching traffic must skip the
+	// remaining Antrea-native policy rules and be evaluated by the K8s
+	// NetworkPolicies.
+	RuleActionPass RuleAction = "Pass"
+	// RuleActionReject describes that rule matching traffic must be rejected,
+	// i.e. answered with a TCP RST packet or an ICMP destination unreachable
+	// packet.
+	RuleActionReject RuleAction = "Reject"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -145,7 +279,6 @@ type NetworkPolicyList struct {
 
 // +genclient
 // +genclient:nonNamespaced
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 type ClusterNetworkPolicy struct {
@@ -155,10 +288,19 @@ type ClusterNetworkPolicy struct {
 
 	// Specification of the desired behavior of ClusterNetworkPolicy.
 	Spec ClusterNetworkPolicySpec `json:"spec"`
+	// Most recently observed status of the ClusterNetworkPolicy.
+	Status NetworkPolicyStatus `json:"status"`
 }
 
 // ClusterNetworkPolicySpec defines the desired state for ClusterNetworkPolicy.
 type ClusterNetworkPolicySpec struct {
+	// Tier specifies the tier to which this ClusterNetworkPolicy belongs to.
+	// The ClusterNetworkPolicy order will be determined based on the
+	// combination of the Tier's Priority and the ClusterNetworkPolicy's own
+	// Priority. If not specified, this policy will be created in the
+	// Application Tier.
+	// +optional
+	Tier string `json:"tier,omitempty"`
 	// Priority specfies the order of the ClusterNetworkPolicy relative to
 	// other ClusterNetworkPolicies.
 	Priority float64 `json:"priority"`
@@ -185,3 +327,40 @@ type ClusterNetworkPolicyList struct {
 
 	Items []ClusterNetworkPolicy `json:"items"`
 }
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Tier groups Antrea-native policies and determines the order in which
+// they are evaluated relative to the policies of other Tiers.
+type Tier struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard metadata of the object.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of Tier.
+	Spec TierSpec `json:"spec"`
+}
+
+// TierSpec defines the desired state for Tier.
+type TierSpec struct {
+	// Priority specfies the order of the Tier relative to other Tiers. A
+	// lower value indicates higher precedence.
+	Priority int32 `json:"priority"`
+	// Description is an optional field to add more information regarding
+	// the purpose of this Tier.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type TierList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Tier `json:"items"`
+}
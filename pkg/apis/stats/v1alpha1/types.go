@@ -0,0 +1,122 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// +genclient
+// +genclient:onlyVerbs=get,list
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NetworkPolicyStats is the statistics of a K8s NetworkPolicy.
+type NetworkPolicyStats struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard metadata of the object.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// The traffic stats of the K8s NetworkPolicy.
+	TrafficStats TrafficStats `json:"trafficStats"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NetworkPolicyStatsList is a list of NetworkPolicyStats.
+type NetworkPolicyStatsList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// List of NetworkPolicyStats.
+	Items []NetworkPolicyStats `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:onlyVerbs=get,list
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterNetworkPolicyStats is the statistics of an Antrea ClusterNetworkPolicy.
+type ClusterNetworkPolicyStats struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard metadata of the object.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// The traffic stats of the Antrea ClusterNetworkPolicy.
+	TrafficStats TrafficStats `json:"trafficStats"`
+	// The traffic stats of each rule of the Antrea ClusterNetworkPolicy.
+	RuleTrafficStats []RuleTrafficStats `json:"ruleTrafficStats,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterNetworkPolicyStatsList is a list of ClusterNetworkPolicyStats.
+type ClusterNetworkPolicyStatsList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// List of ClusterNetworkPolicyStats.
+	Items []ClusterNetworkPolicyStats `json:"items"`
+}
+
+// +genclient
+// +genclient:onlyVerbs=get,list
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AntreaNetworkPolicyStats is the statistics of an Antrea NetworkPolicy.
+type AntreaNetworkPolicyStats struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard metadata of the object.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// The traffic stats of the Antrea NetworkPolicy.
+	TrafficStats TrafficStats `json:"trafficStats"`
+	// The traffic stats of each rule of the Antrea NetworkPolicy.
+	RuleTrafficStats []RuleTrafficStats `json:"ruleTrafficStats,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AntreaNetworkPolicyStatsList is a list of AntreaNetworkPolicyStats.
+type AntreaNetworkPolicyStatsList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// List of AntreaNetworkPolicyStats.
+	Items []AntreaNetworkPolicyStats `json:"items"`
+}
+
+// TrafficStats contains the traffic stats of a NetworkPolicy or of one of its
+// rules.
+type TrafficStats struct {
+	// Packets is the packets count hit by the NetworkPolicy or rule.
+	Packets int64 `json:"packets"`
+	// Bytes is the bytes count hit by the NetworkPolicy or rule.
+	Bytes int64 `json:"bytes"`
+	// Sessions is the sessions count hit by the NetworkPolicy or rule.
+	Sessions int64 `json:"sessions"`
+}
+
+// RuleTrafficStats contains the traffic stats of a rule of an Antrea-native
+// policy.
+type RuleTrafficStats struct {
+	// Direction of the rule, either "Ingress" or "Egress".
+	Direction string `json:"direction"`
+	// Index of the rule among the rules of the same direction in the policy.
+	Index int32 `json:"index"`
+	// The traffic stats of the rule.
+	TrafficStats TrafficStats `json:"trafficStats"`
+}
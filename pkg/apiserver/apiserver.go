@@ -26,16 +26,29 @@ import (
 
 	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
 	networkinginstall "github.com/vmware-tanzu/antrea/pkg/apis/networking/install"
+	statsinstall "github.com/vmware-tanzu/antrea/pkg/apis/stats/install"
+	statsv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1"
 	systeminstall "github.com/vmware-tanzu/antrea/pkg/apis/system/install"
 	system "github.com/vmware-tanzu/antrea/pkg/apis/system/v1beta1"
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/certificate"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/handlers/endpointpair"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/handlers/policyconflict"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/handlers/webhook"
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/networkpolicy/addressgroup"
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/networkpolicy/appliedtogroup"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/networkpolicy/clustergroupmember"
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/networkpolicy/networkpolicy"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/networkpolicy/networkpolicystatus"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/networkpolicy/nodestatssummary"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/stats/antreanetworkpolicystats"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/stats/clusternetworkpolicystats"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/stats/networkpolicystats"
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/system/controllerinfo"
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/registry/system/supportbundle"
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/storage"
+	controllernetworkpolicy "github.com/vmware-tanzu/antrea/pkg/controller/networkpolicy"
 	"github.com/vmware-tanzu/antrea/pkg/controller/querier"
+	"github.com/vmware-tanzu/antrea/pkg/controller/stats"
 )
 
 var (
@@ -50,6 +63,7 @@ var (
 func init() {
 	networkinginstall.Install(Scheme)
 	systeminstall.Install(Scheme)
+	statsinstall.Install(Scheme)
 	// We need to add the options to empty v1, see sample-apiserver/pkg/apiserver/apiserver.go.
 	metav1.AddToGroupVersion(Scheme, schema.GroupVersion{Version: "v1"})
 }
@@ -61,6 +75,23 @@ type ExtraConfig struct {
 	networkPolicyStore  storage.Interface
 	controllerQuerier   querier.ControllerQuerier
 	caCertController    *certificate.CACertController
+	// networkPolicyValidator validates the admission requests sent to the
+	// validating webhooks of Antrea-native policy resources.
+	networkPolicyValidator *controllernetworkpolicy.NetworkPolicyValidator
+	// networkPolicyStatusController aggregates the NetworkPolicy statuses
+	// reported by Nodes.
+	networkPolicyStatusController networkpolicystatus.StatusController
+	// clusterGroupMembershipQuerier computes the members of ClusterGroups.
+	clusterGroupMembershipQuerier clustergroupmember.GroupMembershipQuerier
+	// endpointPairQuerier evaluates the policies applied to the traffic
+	// between two endpoints.
+	endpointPairQuerier endpointpair.Querier
+	// policyConflictAnalyzer finds the conflicts between the rules of
+	// Antrea-native policies.
+	policyConflictAnalyzer policyconflict.Analyzer
+	// statsAggregator aggregates the NetworkPolicy stats reported by Nodes.
+	// It is nil if the NetworkPolicyStats feature is disabled.
+	statsAggregator *stats.Aggregator
 }
 
 // Config defines the config for Antrea apiserver.
@@ -94,15 +125,27 @@ func NewConfig(
 	genericConfig *genericapiserver.Config,
 	addressGroupStore, appliedToGroupStore, networkPolicyStore storage.Interface,
 	caCertController *certificate.CACertController,
-	controllerQuerier querier.ControllerQuerier) *Config {
+	controllerQuerier querier.ControllerQuerier,
+	networkPolicyValidator *controllernetworkpolicy.NetworkPolicyValidator,
+	networkPolicyStatusController networkpolicystatus.StatusController,
+	clusterGroupMembershipQuerier clustergroupmember.GroupMembershipQuerier,
+	endpointPairQuerier endpointpair.Querier,
+	policyConflictAnalyzer policyconflict.Analyzer,
+	statsAggregator *stats.Aggregator) *Config {
 	return &Config{
 		genericConfig: genericConfig,
 		extraConfig: ExtraConfig{
-			addressGroupStore:   addressGroupStore,
-			appliedToGroupStore: appliedToGroupStore,
-			networkPolicyStore:  networkPolicyStore,
-			caCertController:    caCertController,
-			controllerQuerier:   controllerQuerier,
+			addressGroupStore:             addressGroupStore,
+			appliedToGroupStore:           appliedToGroupStore,
+			networkPolicyStore:            networkPolicyStore,
+			caCertController:              caCertController,
+			controllerQuerier:             controllerQuerier,
+			networkPolicyValidator:        networkPolicyValidator,
+			networkPolicyStatusController: networkPolicyStatusController,
+			clusterGroupMembershipQuerier: clusterGroupMembershipQuerier,
+			endpointPairQuerier:           endpointPairQuerier,
+			policyConflictAnalyzer:        policyConflictAnalyzer,
+			statsAggregator:               statsAggregator,
 		},
 	}
 }
@@ -127,6 +170,11 @@ func (c completedConfig) New() (*APIServer, error) {
 	networkingStorage["addressgroups"] = addressgroup.NewREST(c.extraConfig.addressGroupStore)
 	networkingStorage["appliedtogroups"] = appliedtogroup.NewREST(c.extraConfig.appliedToGroupStore)
 	networkingStorage["networkpolicies"] = networkpolicy.NewREST(c.extraConfig.networkPolicyStore)
+	networkingStorage["networkpolicystatuses"] = networkpolicystatus.NewREST(c.extraConfig.networkPolicyStatusController)
+	networkingStorage["clustergroupmembers"] = clustergroupmember.NewREST(c.extraConfig.clusterGroupMembershipQuerier)
+	if c.extraConfig.statsAggregator != nil {
+		networkingStorage["nodestatssummaries"] = nodestatssummary.NewREST(c.extraConfig.statsAggregator)
+	}
 	networkingGroup.VersionedResourcesStorageMap["v1beta1"] = networkingStorage
 
 	systemGroup := genericapiserver.NewDefaultAPIGroupInfo(system.GroupName, Scheme, metav1.ParameterCodec, Codecs)
@@ -137,12 +185,41 @@ func (c completedConfig) New() (*APIServer, error) {
 	systemStorage["supportbundles/download"] = bundleStorage.Download
 	systemGroup.VersionedResourcesStorageMap["v1beta1"] = systemStorage
 
-	groups := []*genericapiserver.APIGroupInfo{&networkingGroup, &systemGroup}
+	// The stats group is always installed so that its APIService is available,
+	// its resources return an error when the NetworkPolicyStats feature is
+	// disabled, in which case statsAggregator is nil.
+	statsGroup := genericapiserver.NewDefaultAPIGroupInfo(statsv1alpha1.GroupName, Scheme, metav1.ParameterCodec, Codecs)
+	statsStorage := map[string]rest.Storage{}
+	statsStorage["networkpolicystats"] = networkpolicystats.NewREST(c.extraConfig.statsAggregator)
+	statsStorage["clusternetworkpolicystats"] = clusternetworkpolicystats.NewREST(c.extraConfig.statsAggregator)
+	statsStorage["antreanetworkpolicystats"] = antreanetworkpolicystats.NewREST(c.extraConfig.statsAggregator)
+	statsGroup.VersionedResourcesStorageMap["v1alpha1"] = statsStorage
+
+	groups := []*genericapiserver.APIGroupInfo{&networkingGroup, &systemGroup, &statsGroup}
 	for _, apiGroupInfo := range groups {
 		if err := s.GenericAPIServer.InstallAPIGroup(apiGroupInfo); err != nil {
 			return nil, err
 		}
 	}
 
+	installHandlers(c.extraConfig, s.GenericAPIServer)
+
 	return s, nil
 }
+
+func installHandlers(c *ExtraConfig, s *genericapiserver.GenericAPIServer) {
+	// Install the handler of the validating webhook for Tiers.
+	s.Handler.NonGoRestfulMux.HandleFunc("/validate/tier", webhook.HandlerForValidateFunc(c.networkPolicyValidator.Validate))
+	// Install the handlers of the validating and the mutating webhooks for
+	// Antrea-native policies.
+	s.Handler.NonGoRestfulMux.HandleFunc("/validate/acnp", webhook.HandlerForValidateFunc(c.networkPolicyValidator.Validate))
+	s.Handler.NonGoRestfulMux.HandleFunc("/validate/anp", webhook.HandlerForValidateFunc(c.networkPolicyValidator.Validate))
+	s.Handler.NonGoRestfulMux.HandleFunc("/mutate/acnp", webhook.HandlerForMutateFunc(controllernetworkpolicy.Mutate))
+	s.Handler.NonGoRestfulMux.HandleFunc("/mutate/anp", webhook.HandlerForMutateFunc(controllernetworkpolicy.Mutate))
+	// Install the handler evaluating the policies applied to the traffic
+	// between two endpoints.
+	s.Handler.NonGoRestfulMux.HandleFunc("/endpointpair", endpointpair.HandleFunc(c.endpointPairQuerier))
+	// Install the handler reporting the conflicts between the rules of
+	// Antrea-native policies.
+	s.Handler.NonGoRestfulMux.HandleFunc("/policyconflicts", policyconflict.HandleFunc(c.policyConflictAnalyzer))
+}
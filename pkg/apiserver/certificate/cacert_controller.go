@@ -43,6 +43,15 @@ var (
 	apiServiceNames = []string{
 		"v1beta1.networking.antrea.tanzu.vmware.com",
 		"v1beta1.system.antrea.tanzu.vmware.com",
+		"v1alpha1.stats.antrea.io",
+	}
+	// validatingWebhooks contains all the ValidatingWebhookConfigurations backed by antrea-controller.
+	validatingWebhooks = []string{
+		"crdvalidator.antrea.tanzu.vmware.com",
+	}
+	// mutatingWebhooks contains all the MutatingWebhookConfigurations backed by antrea-controller.
+	mutatingWebhooks = []string{
+		"crdmutator.antrea.tanzu.vmware.com",
 	}
 )
 
@@ -102,6 +111,66 @@ func (c *CACertController) syncCACert() error {
 	if err := c.syncAPIServices(caCert); err != nil {
 		return err
 	}
+
+	if err := c.syncValidatingWebhooks(caCert); err != nil {
+		return err
+	}
+
+	if err := c.syncMutatingWebhooks(caCert); err != nil {
+		return err
+	}
+	return nil
+}
+
+// syncMutatingWebhooks updates the CABundle of the MutatingWebhookConfiguration backed by antrea-controller.
+func (c *CACertController) syncMutatingWebhooks(caCert []byte) error {
+	klog.Info("Syncing CA certificate with MutatingWebhookConfigurations")
+	for _, name := range mutatingWebhooks {
+		mWebhook, err := c.client.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(context.TODO(), name, v1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("error getting MutatingWebhookConfiguration %s: %v", name, err)
+		}
+		updated := false
+		for idx, webhook := range mWebhook.Webhooks {
+			if bytes.Equal(webhook.ClientConfig.CABundle, caCert) {
+				continue
+			}
+			mWebhook.Webhooks[idx].ClientConfig.CABundle = caCert
+			updated = true
+		}
+		if !updated {
+			continue
+		}
+		if _, err := c.client.AdmissionregistrationV1().MutatingWebhookConfigurations().Update(context.TODO(), mWebhook, v1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("error updating antrea CA cert of MutatingWebhookConfiguration %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// syncValidatingWebhooks updates the CABundle of the ValidatingWebhookConfiguration backed by antrea-controller.
+func (c *CACertController) syncValidatingWebhooks(caCert []byte) error {
+	klog.Info("Syncing CA certificate with ValidatingWebhookConfigurations")
+	for _, name := range validatingWebhooks {
+		vWebhook, err := c.client.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(context.TODO(), name, v1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("error getting ValidatingWebhookConfiguration %s: %v", name, err)
+		}
+		updated := false
+		for idx, webhook := range vWebhook.Webhooks {
+			if bytes.Equal(webhook.ClientConfig.CABundle, caCert) {
+				continue
+			}
+			vWebhook.Webhooks[idx].ClientConfig.CABundle = caCert
+			updated = true
+		}
+		if !updated {
+			continue
+		}
+		if _, err := c.client.AdmissionregistrationV1().ValidatingWebhookConfigurations().Update(context.TODO(), vWebhook, v1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("error updating antrea CA cert of ValidatingWebhookConfiguration %s: %v", name, err)
+		}
+	}
 	return nil
 }
 
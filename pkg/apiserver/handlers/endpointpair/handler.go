@@ -0,0 +1,108 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpointpair
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"k8s.io/klog"
+)
+
+// Request is the request struct of the endpoint-pair query.
+type Request struct {
+	// Source of the traffic, either a Pod ("Namespace/name") or an IP address.
+	Source string `json:"source"`
+	// Destination of the traffic, either a Pod ("Namespace/name") or an IP
+	// address.
+	Destination string `json:"destination"`
+	// Protocol of the traffic (TCP, UDP or SCTP). It defaults to TCP.
+	Protocol string `json:"protocol,omitempty"`
+	// Destination port of the traffic. If not set, only the rules which don't
+	// restrict the ports match the traffic.
+	Port int32 `json:"port,omitempty"`
+	// Policy is the YAML or JSON manifest of a candidate NetworkPolicy,
+	// ClusterNetworkPolicy or Antrea NetworkPolicy. If set, the traffic is
+	// evaluated as if the candidate policy was applied, replacing the policy
+	// with the same kind, Namespace and name if any.
+	Policy string `json:"policy,omitempty"`
+}
+
+// Rule is a policy rule matching the queried traffic.
+type Rule struct {
+	// PolicyType is one of K8sNetworkPolicy, ClusterNetworkPolicy and
+	// AntreaNetworkPolicy.
+	PolicyType string `json:"policyType"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	// Direction is either Ingress or Egress.
+	Direction string `json:"direction"`
+	// Index of the rule among the rules of the policy in the same direction.
+	Index  int32  `json:"index"`
+	Action string `json:"action"`
+	// Candidate is true if the rule belongs to the candidate policy.
+	Candidate bool `json:"candidate,omitempty"`
+	// Effective is true if the rule decides the action of the traffic in its
+	// direction.
+	Effective bool `json:"effective,omitempty"`
+}
+
+// Response is the response struct of the endpoint-pair query.
+type Response struct {
+	// Rules are the rules matching the traffic in the order they are
+	// evaluated: the egress rules applied to the source first, then the
+	// ingress rules applied to the destination.
+	Rules []Rule `json:"rules"`
+	// Action is the effective action of the traffic: Allow, Drop or Reject.
+	Action string `json:"action"`
+	// Reason explains how the action was decided.
+	Reason string `json:"reason"`
+}
+
+// Querier evaluates the policies applied to the traffic between two
+// endpoints.
+type Querier interface {
+	QueryEndpointPair(req *Request) (*Response, error)
+}
+
+// HandleFunc returns the function which can handle API requests to
+// "/endpointpair".
+func HandleFunc(q Querier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Source == "" || req.Destination == "" {
+			http.Error(w, "source and destination must be specified", http.StatusBadRequest)
+			return
+		}
+		resp, err := q.QueryEndpointPair(&req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			klog.Errorf("Failed to encode endpoint-pair response: %v", err)
+			http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		}
+	}
+}
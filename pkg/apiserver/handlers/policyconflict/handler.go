@@ -0,0 +1,118 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyconflict
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/antctl/transform/common"
+)
+
+// ConflictType is the type of a conflict between two policy rules.
+type ConflictType string
+
+const (
+	// Shadowed means that the rule can never match any traffic because all
+	// the traffic it selects is matched by a rule evaluated before it, with a
+	// different action.
+	Shadowed ConflictType = "Shadowed"
+	// Redundant means that the rule can never match any traffic because all
+	// the traffic it selects is matched by a rule evaluated before it, with
+	// the same action. Removing it doesn't change the enforced policy.
+	Redundant ConflictType = "Redundant"
+	// Overlap means that the two rules select the same workloads, peers and
+	// ports, but one allows the traffic while the other drops or rejects it.
+	Overlap ConflictType = "Overlap"
+)
+
+// Rule identifies a rule of an Antrea-native policy.
+type Rule struct {
+	// PolicyType is either ClusterNetworkPolicy or AntreaNetworkPolicy.
+	PolicyType string `json:"policyType"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	// Direction is either Ingress or Egress.
+	Direction string `json:"direction"`
+	// Index of the rule among the rules of the policy in the same direction.
+	Index  int32  `json:"index"`
+	Action string `json:"action"`
+}
+
+// Response describes a conflict between two rules of Antrea-native policies.
+type Response struct {
+	Type ConflictType `json:"type"`
+	// Rule is the offending rule, which never takes effect if Type is
+	// Shadowed or Redundant.
+	Rule Rule `json:"rule"`
+	// By is the rule Rule conflicts with, which is evaluated before Rule.
+	By      Rule   `json:"by"`
+	Message string `json:"message"`
+}
+
+// Analyzer analyzes the rules of the Antrea-native policies to find the
+// conflicts between them.
+type Analyzer interface {
+	AnalyzePolicyConflicts() []Response
+}
+
+// HandleFunc returns the function which can handle API requests to
+// "/policyconflicts". The conflicts can be filtered by type with the "type"
+// query parameter.
+func HandleFunc(a Analyzer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conflictType := r.URL.Query().Get("type")
+		switch ConflictType(conflictType) {
+		case "", Shadowed, Redundant, Overlap:
+		default:
+			http.Error(w, "type must be one of Shadowed, Redundant and Overlap", http.StatusBadRequest)
+			return
+		}
+		conflicts := []Response{}
+		for _, c := range a.AnalyzePolicyConflicts() {
+			if conflictType == "" || string(c.Type) == conflictType {
+				conflicts = append(conflicts, c)
+			}
+		}
+		if err := json.NewEncoder(w).Encode(conflicts); err != nil {
+			klog.Errorf("Failed to encode policy conflicts: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
+
+var _ common.TableOutput = new(Response)
+
+func (r Response) GetTableHeader() []string {
+	return []string{"TYPE", "RULE", "ACTION", "BY", "BY-ACTION"}
+}
+
+func (r Rule) String() string {
+	name := r.Name
+	if r.Namespace != "" {
+		name = r.Namespace + "/" + r.Name
+	}
+	return r.PolicyType + " " + name + " " + r.Direction + "[" + common.Int32ToString(r.Index) + "]"
+}
+
+func (r Response) GetTableRow(maxColumnLength int) []string {
+	return []string{string(r.Type), r.Rule.String(), r.Rule.Action, r.By.String(), r.By.Action}
+}
+
+func (r Response) SortRows() bool {
+	return true
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	admv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+)
+
+// admitFunc validates or mutates the request of an AdmissionReview and returns
+// the response to be sent back to the K8s apiserver.
+type admitFunc func(*admv1.AdmissionReview) *admv1.AdmissionResponse
+
+// HandlerForValidateFunc returns the function which can handle the
+// AdmissionReview requests sent by the K8s apiserver to a validating webhook.
+func HandlerForValidateFunc(validate admitFunc) http.HandlerFunc {
+	return handlerForAdmitFunc(validate)
+}
+
+// HandlerForMutateFunc returns the function which can handle the
+// AdmissionReview requests sent by the K8s apiserver to a mutating webhook.
+// The response of the mutate function carries the JSON patch to apply.
+func HandlerForMutateFunc(mutate admitFunc) http.HandlerFunc {
+	return handlerForAdmitFunc(mutate)
+}
+
+func handlerForAdmitFunc(admit admitFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var reqBody []byte
+		if r.Body != nil {
+			reqBody, _ = ioutil.ReadAll(r.Body)
+		}
+		if len(reqBody) == 0 {
+			klog.Errorf("Admission webhook received empty request body")
+			http.Error(w, "empty request body", http.StatusBadRequest)
+			return
+		}
+		// Verify the content type is accurate.
+		if contentType := r.Header.Get("Content-Type"); contentType != "application/json" {
+			klog.Errorf("Invalid Content-Type %s, expected application/json", contentType)
+			http.Error(w, "invalid Content-Type, expected `application/json`", http.StatusUnsupportedMediaType)
+			return
+		}
+		var admissionResponse *admv1.AdmissionResponse
+		ar := admv1.AdmissionReview{}
+		if err := json.Unmarshal(reqBody, &ar); err != nil || ar.Request == nil {
+			if err == nil {
+				err = fmt.Errorf("AdmissionReview does not contain a request")
+			}
+			klog.Errorf("Failed to decode AdmissionReview: %v", err)
+			admissionResponse = &admv1.AdmissionResponse{
+				Result: &metav1.Status{
+					Message: err.Error(),
+				},
+			}
+		} else {
+			admissionResponse = admit(&ar)
+			admissionResponse.UID = ar.Request.UID
+		}
+		admissionReview := admv1.AdmissionReview{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: admv1.SchemeGroupVersion.String(),
+				Kind:       "AdmissionReview",
+			},
+			Response: admissionResponse,
+		}
+		resp, err := json.Marshal(admissionReview)
+		if err != nil {
+			klog.Errorf("Failed to encode AdmissionReview response: %v", err)
+			http.Error(w, fmt.Sprintf("could not encode response: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(resp); err != nil {
+			klog.Errorf("Failed to write AdmissionReview response: %v", err)
+		}
+	}
+}
@@ -43,6 +43,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.AppliedToGroup":                      schema_pkg_apis_networking_v1beta1_AppliedToGroup(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.AppliedToGroupList":                  schema_pkg_apis_networking_v1beta1_AppliedToGroupList(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.AppliedToGroupPatch":                 schema_pkg_apis_networking_v1beta1_AppliedToGroupPatch(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ClusterGroupMembers":                 schema_pkg_apis_networking_v1beta1_ClusterGroupMembers(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.Endpoint":                            schema_pkg_apis_networking_v1beta1_Endpoint(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ExternalEntityReference":             schema_pkg_apis_networking_v1beta1_ExternalEntityReference(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.GroupMember":                         schema_pkg_apis_networking_v1beta1_GroupMember(ref),
@@ -52,10 +53,25 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NamedPort":                           schema_pkg_apis_networking_v1beta1_NamedPort(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicy":                       schema_pkg_apis_networking_v1beta1_NetworkPolicy(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyList":                   schema_pkg_apis_networking_v1beta1_NetworkPolicyList(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyNodeStatus":             schema_pkg_apis_networking_v1beta1_NetworkPolicyNodeStatus(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyPeer":                   schema_pkg_apis_networking_v1beta1_NetworkPolicyPeer(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyRule":                   schema_pkg_apis_networking_v1beta1_NetworkPolicyRule(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyStats":                  schema_pkg_apis_networking_v1beta1_NetworkPolicyStats(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyStatus":                 schema_pkg_apis_networking_v1beta1_NetworkPolicyStatus(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NodeStatsSummary":                    schema_pkg_apis_networking_v1beta1_NodeStatsSummary(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.PodReference":                        schema_pkg_apis_networking_v1beta1_PodReference(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.RuleTrafficStats":                    schema_pkg_apis_networking_v1beta1_RuleTrafficStats(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.Service":                             schema_pkg_apis_networking_v1beta1_Service(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ServiceReference":                    schema_pkg_apis_networking_v1beta1_ServiceReference(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.TrafficStats":                        schema_pkg_apis_networking_v1beta1_TrafficStats(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.AntreaNetworkPolicyStats":                schema_pkg_apis_stats_v1alpha1_AntreaNetworkPolicyStats(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.AntreaNetworkPolicyStatsList":            schema_pkg_apis_stats_v1alpha1_AntreaNetworkPolicyStatsList(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.ClusterNetworkPolicyStats":               schema_pkg_apis_stats_v1alpha1_ClusterNetworkPolicyStats(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.ClusterNetworkPolicyStatsList":           schema_pkg_apis_stats_v1alpha1_ClusterNetworkPolicyStatsList(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.NetworkPolicyStats":                      schema_pkg_apis_stats_v1alpha1_NetworkPolicyStats(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.NetworkPolicyStatsList":                  schema_pkg_apis_stats_v1alpha1_NetworkPolicyStatsList(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.RuleTrafficStats":                        schema_pkg_apis_stats_v1alpha1_RuleTrafficStats(ref),
+		"github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.TrafficStats":                            schema_pkg_apis_stats_v1alpha1_TrafficStats(ref),
 		"github.com/vmware-tanzu/antrea/pkg/apis/system/v1beta1.SupportBundle":                           schema_pkg_apis_system_v1beta1_SupportBundle(ref),
 		"k8s.io/api/core/v1.AWSElasticBlockStoreVolumeSource":                                            schema_k8sio_api_core_v1_AWSElasticBlockStoreVolumeSource(ref),
 		"k8s.io/api/core/v1.Affinity":                                    schema_k8sio_api_core_v1_Affinity(ref),
@@ -1140,6 +1156,79 @@ func schema_pkg_apis_networking_v1beta1_AppliedToGroupPatch(ref common.Reference
 	}
 }
 
+func schema_pkg_apis_networking_v1beta1_ClusterGroupMembers(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterGroupMembers is the list of the members of a ClusterGroup, as computed by antrea-controller. Its name is the one of the ClusterGroup.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"pods": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Pods is a list of Pods selected by the group.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.GroupMemberPod"),
+									},
+								},
+							},
+						},
+					},
+					"groupMembers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GroupMembers is a list of GroupMember selected by the group.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.GroupMember"),
+									},
+								},
+							},
+						},
+					},
+					"ipBlocks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IPBlocks is a list of IPBlocks of the group.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.IPBlock"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.GroupMember", "github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.GroupMemberPod", "github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.IPBlock", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
 func schema_pkg_apis_networking_v1beta1_Endpoint(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1437,6 +1526,13 @@ func schema_pkg_apis_networking_v1beta1_NetworkPolicy(ref common.ReferenceCallba
 							Format:      "double",
 						},
 					},
+					"generation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Generation is the generation of the original Antrea-native policy this NetworkPolicy is translated from. It is used by Nodes to report the realization status of the policy. It will remain 0 for K8s NetworkPolicy.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 				},
 			},
 		},
@@ -1492,6 +1588,40 @@ func schema_pkg_apis_networking_v1beta1_NetworkPolicyList(ref common.ReferenceCa
 	}
 }
 
+func schema_pkg_apis_networking_v1beta1_NetworkPolicyNodeStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NetworkPolicyNodeStatus is the status of a NetworkPolicy on a Node.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"nodeName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The name of the Node that produces the status.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"generation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The generation realized by the Node.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"error": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The error encountered when realizing the generation, if any.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_networking_v1beta1_NetworkPolicyPeer(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1526,11 +1656,38 @@ func schema_pkg_apis_networking_v1beta1_NetworkPolicyPeer(ref common.ReferenceCa
 							},
 						},
 					},
+					"fqdns": {
+						SchemaProps: spec.SchemaProps{
+							Description: "A list of FQDNs, which can be exact names or wildcards like \"*.example.com\". Only used in egress rules of Antrea-native policies.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"toServices": {
+						SchemaProps: spec.SchemaProps{
+							Description: "A list of Services referenced by the peer. The AddressGroups of the peer contain the ClusterIPs and the Endpoints of these Services, each with its own ports. Only used in egress rules of Antrea-native policies.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ServiceReference"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.IPBlock"},
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.IPBlock", "github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.ServiceReference"},
 	}
 }
 
@@ -1582,11 +1739,18 @@ func schema_pkg_apis_networking_v1beta1_NetworkPolicyRule(ref common.ReferenceCa
 					},
 					"action": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Action specifies the action to be applied on the rule. i.e. Allow/Drop. An empty action “nil” defaults to Allow action, which would be the case for rules created for K8s Network Policy.",
+							Description: "Action specifies the action to be applied on the rule. i.e. Allow/Drop/Pass/Reject. An empty action “nil” defaults to Allow action, which would be the case for rules created for K8s Network Policy.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"enableLogging": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnableLogging indicates whether or not to generate audit logs for the traffic which matches this rule.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -1595,58 +1759,722 @@ func schema_pkg_apis_networking_v1beta1_NetworkPolicyRule(ref common.ReferenceCa
 	}
 }
 
-func schema_pkg_apis_networking_v1beta1_PodReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_networking_v1beta1_NetworkPolicyStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PodReference represents a Pod Reference.",
+				Description: "NetworkPolicyStats contains the traffic stats of a NetworkPolicy.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The name of this pod.",
+							Description: "The name of the NetworkPolicy.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 					"namespace": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The namespace of this pod.",
+							Description: "The namespace of the NetworkPolicy, empty for ClusterNetworkPolicies.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"trafficStats": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The traffic stats of the NetworkPolicy.",
+							Ref:         ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.TrafficStats"),
+						},
+					},
+					"ruleTrafficStats": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The traffic stats of each rule of the NetworkPolicy, only collected for Antrea-native policies.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.RuleTrafficStats"),
+									},
+								},
+							},
+						},
+					},
 				},
+				Required: []string{"trafficStats"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.RuleTrafficStats", "github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.TrafficStats"},
 	}
 }
 
-func schema_pkg_apis_networking_v1beta1_Service(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_networking_v1beta1_NetworkPolicyStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "Service describes a port to allow traffic on.",
+				Description: "NetworkPolicyStatus is the status of a NetworkPolicy reported by Nodes. Its name and namespace are the ones of the NetworkPolicy.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"protocol": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The protocol (TCP, UDP, or SCTP) which traffic must match. If not specified, this field defaults to TCP.",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"port": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The port name or number on the given protocol. If not specified, this matches all port numbers.",
-							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"nodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Nodes contains statuses produced on a list of Nodes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyNodeStatus"),
+									},
+								},
+							},
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyNodeStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_networking_v1beta1_NodeStatsSummary(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NodeStatsSummary contains the stats produced on a Node. It is used by the antrea-agents to report stats to the antrea-controller. Its name is the one of the Node.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"networkPolicies": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The TrafficStats of K8s NetworkPolicies collected from the Node.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyStats"),
+									},
+								},
+							},
+						},
+					},
+					"clusterNetworkPolicies": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The TrafficStats of Antrea ClusterNetworkPolicies collected from the Node.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyStats"),
+									},
+								},
+							},
+						},
+					},
+					"antreaNetworkPolicies": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The TrafficStats of Antrea NetworkPolicies collected from the Node.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyStats"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.NetworkPolicyStats", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_networking_v1beta1_PodReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PodReference represents a Pod Reference.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The name of this pod.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The namespace of this pod.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_networking_v1beta1_RuleTrafficStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RuleTrafficStats contains the traffic stats of a NetworkPolicy rule.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"direction": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The direction of the rule.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"priority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The index of the rule among the rules of the same direction.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"trafficStats": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The traffic stats of the rule.",
+							Ref:         ref("github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.TrafficStats"),
+						},
+					},
+				},
+				Required: []string{"trafficStats"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/networking/v1beta1.TrafficStats"},
+	}
+}
+
+func schema_pkg_apis_networking_v1beta1_Service(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Service describes a port to allow traffic on.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"protocol": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The protocol (TCP, UDP, SCTP, or ICMP) which traffic must match. If neither this field nor IPProtocol is specified, this field defaults to TCP.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The port name or number on the given protocol. If not specified, this matches all port numbers.",
+							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
+						},
+					},
+					"endPort": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EndPort defines the end of the port range, being the end included within the range. It can only be specified when a numerical `port` is specified.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"icmpType": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ICMPType is the type of the ICMP messages to match. It can only be specified when the protocol is ICMP. If not specified, this matches all ICMP types.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"icmpCode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ICMPCode is the code of the ICMP messages to match. It can only be specified when the protocol is ICMP. If not specified, this matches all ICMP codes.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"ipProtocol": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IPProtocol is the number of the IP protocol which traffic must match, e.g. 47 for GRE. It cannot be specified along with the protocol or the ports.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
+	}
+}
+
+func schema_pkg_apis_networking_v1beta1_ServiceReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceReference represents a reference to a Service.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The name of this Service.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The Namespace of this Service.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_networking_v1beta1_TrafficStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TrafficStats contains the traffic stats of a NetworkPolicy or rule.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"packets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Packets is the packets count hit by the NetworkPolicy or rule.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"bytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Bytes is the bytes count hit by the NetworkPolicy or rule.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"sessions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Sessions is the sessions count hit by the NetworkPolicy or rule.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_stats_v1alpha1_AntreaNetworkPolicyStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AntreaNetworkPolicyStats is the statistics of an Antrea NetworkPolicy.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard metadata of the object.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"trafficStats": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The traffic stats of the Antrea NetworkPolicy.",
+							Ref:         ref("github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.TrafficStats"),
+						},
+					},
+					"ruleTrafficStats": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The traffic stats of each rule of the Antrea NetworkPolicy.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.RuleTrafficStats"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"trafficStats"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.RuleTrafficStats", "github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.TrafficStats", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_stats_v1alpha1_AntreaNetworkPolicyStatsList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AntreaNetworkPolicyStatsList is a list of AntreaNetworkPolicyStats.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard list metadata.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Description: "List of AntreaNetworkPolicyStats.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.AntreaNetworkPolicyStats"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.AntreaNetworkPolicyStats", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_stats_v1alpha1_ClusterNetworkPolicyStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterNetworkPolicyStats is the statistics of an Antrea ClusterNetworkPolicy.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard metadata of the object.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"trafficStats": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The traffic stats of the Antrea ClusterNetworkPolicy.",
+							Ref:         ref("github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.TrafficStats"),
+						},
+					},
+					"ruleTrafficStats": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The traffic stats of each rule of the Antrea ClusterNetworkPolicy.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.RuleTrafficStats"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"trafficStats"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.RuleTrafficStats", "github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.TrafficStats", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_stats_v1alpha1_ClusterNetworkPolicyStatsList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterNetworkPolicyStatsList is a list of ClusterNetworkPolicyStats.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard list metadata.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Description: "List of ClusterNetworkPolicyStats.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.ClusterNetworkPolicyStats"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.ClusterNetworkPolicyStats", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_stats_v1alpha1_NetworkPolicyStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NetworkPolicyStats is the statistics of a K8s NetworkPolicy.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard metadata of the object.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"trafficStats": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The traffic stats of the K8s NetworkPolicy.",
+							Ref:         ref("github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.TrafficStats"),
+						},
+					},
+				},
+				Required: []string{"trafficStats"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.TrafficStats", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_stats_v1alpha1_NetworkPolicyStatsList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NetworkPolicyStatsList is a list of NetworkPolicyStats.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Standard list metadata.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Description: "List of NetworkPolicyStats.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.NetworkPolicyStats"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.NetworkPolicyStats", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_stats_v1alpha1_RuleTrafficStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RuleTrafficStats contains the traffic stats of a rule of an Antrea-native policy.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"direction": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Direction of the rule, either \"Ingress\" or \"Egress\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"index": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Index of the rule among the rules of the same direction in the policy.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"trafficStats": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The traffic stats of the rule.",
+							Ref:         ref("github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.TrafficStats"),
+						},
+					},
+				},
+				Required: []string{"direction", "index", "trafficStats"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1.TrafficStats"},
+	}
+}
+
+func schema_pkg_apis_stats_v1alpha1_TrafficStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TrafficStats contains the traffic stats of a NetworkPolicy or of one of its rules.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"packets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Packets is the packets count hit by the NetworkPolicy or rule.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"bytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Bytes is the bytes count hit by the NetworkPolicy or rule.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"sessions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Sessions is the sessions count hit by the NetworkPolicy or rule.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"packets", "bytes", "sessions"},
+			},
+		},
 	}
 }
 
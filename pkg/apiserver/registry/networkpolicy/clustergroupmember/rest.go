@@ -0,0 +1,66 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustergroupmember
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+)
+
+// GroupMembershipQuerier is the interface of the controller which computes the
+// members of ClusterGroups.
+type GroupMembershipQuerier interface {
+	GetClusterGroupMembers(name string) (*networking.ClusterGroupMembers, bool)
+}
+
+// REST implements rest.Storage for ClusterGroupMembers.
+type REST struct {
+	querier GroupMembershipQuerier
+}
+
+var (
+	_ rest.Storage = &REST{}
+	_ rest.Scoper  = &REST{}
+	_ rest.Getter  = &REST{}
+)
+
+// NewREST returns a REST object that will work against API services.
+func NewREST(querier GroupMembershipQuerier) *REST {
+	return &REST{querier}
+}
+
+func (r *REST) New() runtime.Object {
+	return &networking.ClusterGroupMembers{}
+}
+
+// Get computes the members of the ClusterGroup with the given name. Nothing
+// is persisted.
+func (r *REST) Get(ctx context.Context, name string, options *v1.GetOptions) (runtime.Object, error) {
+	members, found := r.querier.GetClusterGroupMembers(name)
+	if !found {
+		return nil, errors.NewNotFound(networking.Resource("clustergroup"), name)
+	}
+	return members, nil
+}
+
+func (r *REST) NamespaceScoped() bool {
+	return false
+}
@@ -64,11 +64,19 @@ func (r *REST) Get(ctx context.Context, name string, options *v1.GetOptions) (ru
 	if !ok || len(ns) == 0 {
 		return nil, errors.NewBadRequest("Namespace parameter required.")
 	}
-	key := k8s.NamespacedName(ns, name)
-	networkPolicy, exists, err := r.networkPolicyStore.Get(key)
+	// The K8s NetworkPolicy takes precedence over the Antrea NetworkPolicy
+	// with the same Namespace and name, which are both returned by List and
+	// Watch.
+	networkPolicy, exists, err := r.networkPolicyStore.Get(k8s.NamespacedName(ns, name))
 	if err != nil {
 		return nil, errors.NewInternalError(err)
 	}
+	if !exists {
+		networkPolicy, exists, err = r.networkPolicyStore.Get(store.AntreaPolicyKey(ns, name))
+		if err != nil {
+			return nil, errors.NewInternalError(err)
+		}
+	}
 	if !exists {
 		return nil, errors.NewNotFound(networking.Resource("networkpolicy"), name)
 	}
@@ -102,6 +110,8 @@ func (r *REST) Watch(ctx context.Context, options *internalversion.ListOptions)
 		if !ok || len(ns) == 0 {
 			return nil, errors.NewBadRequest("Namespace parameter required.")
 		}
+		// The namespaced name selects both the K8s NetworkPolicy and the
+		// Antrea NetworkPolicy with this Namespace and name.
 		key = k8s.NamespacedName(ns, key)
 	}
 	return r.networkPolicyStore.Watch(ctx, key, label, field)
@@ -0,0 +1,87 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	"k8s.io/apimachinery/pkg/fields"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	"github.com/vmware-tanzu/antrea/pkg/controller/networkpolicy/store"
+	"github.com/vmware-tanzu/antrea/pkg/controller/types"
+)
+
+func TestRESTGetAndWatchByName(t *testing.T) {
+	priority := float64(1)
+	k8sNP := &types.NetworkPolicy{UID: "uid1", Namespace: "ns1", Name: "np1"}
+	antreaNP := &types.NetworkPolicy{UID: "uid2", Namespace: "ns1", Name: "np1", Priority: &priority}
+	otherAntreaNP := &types.NetworkPolicy{UID: "uid3", Namespace: "ns1", Name: "np2", Priority: &priority}
+	npStore := store.NewNetworkPolicyStore()
+	for _, policy := range []*types.NetworkPolicy{k8sNP, antreaNP, otherAntreaNP} {
+		require.NoError(t, npStore.Create(policy))
+	}
+	r := NewREST(npStore)
+	ctx := request.WithNamespace(context.Background(), "ns1")
+
+	// The Antrea NetworkPolicy can be fetched by name.
+	obj, err := r.Get(ctx, "np2", nil)
+	require.NoError(t, err)
+	assert.Equal(t, k8stypes.UID("uid3"), obj.(*networking.NetworkPolicy).UID)
+	// The K8s NetworkPolicy takes precedence over the Antrea NetworkPolicy
+	// with the same name.
+	obj, err = r.Get(ctx, "np1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, k8stypes.UID("uid1"), obj.(*networking.NetworkPolicy).UID)
+	_, err = r.Get(ctx, "np3", nil)
+	assert.True(t, errors.IsNotFound(err))
+
+	// Both NetworkPolicies with the same name are watched by name.
+	w, err := r.Watch(ctx, &internalversion.ListOptions{FieldSelector: fields.OneTermEqualSelector("metadata.name", "np1")})
+	require.NoError(t, err)
+	defer w.Stop()
+	uids := map[k8stypes.UID]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case event := <-w.ResultChan():
+			require.Equal(t, watch.Added, event.Type)
+			uids[event.Object.(*networking.NetworkPolicy).UID] = true
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timeout when waiting for NetworkPolicy events")
+		}
+	}
+	assert.Equal(t, map[k8stypes.UID]bool{"uid1": true, "uid2": true}, uids)
+
+	// Updates of the Antrea NetworkPolicy are delivered to the watcher.
+	updatedAntreaNP := *antreaNP
+	updatedAntreaNP.Generation = 1
+	require.NoError(t, npStore.Update(&updatedAntreaNP))
+	select {
+	case event := <-w.ResultChan():
+		assert.Equal(t, watch.Modified, event.Type)
+		assert.Equal(t, k8stypes.UID("uid2"), event.Object.(*networking.NetworkPolicy).UID)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timeout when waiting for NetworkPolicy event")
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicystatus
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+)
+
+// StatusController is the interface of the controller which aggregates the
+// realization statuses of NetworkPolicies reported by Nodes.
+type StatusController interface {
+	UpdateStatus(status *networking.NetworkPolicyStatus) error
+}
+
+// REST implements rest.Storage for NetworkPolicyStatuses.
+type REST struct {
+	statusController StatusController
+}
+
+var (
+	_ rest.Storage = &REST{}
+	_ rest.Scoper  = &REST{}
+	_ rest.Creater = &REST{}
+)
+
+// NewREST returns a REST object that will work against API services.
+func NewREST(statusController StatusController) *REST {
+	return &REST{statusController}
+}
+
+func (r *REST) New() runtime.Object {
+	return &networking.NetworkPolicyStatus{}
+}
+
+// Create passes the NetworkPolicyStatus reported by a Node to the
+// StatusController. Nothing is persisted.
+func (r *REST) Create(ctx context.Context, obj runtime.Object, _ rest.ValidateObjectFunc, _ *v1.CreateOptions) (runtime.Object, error) {
+	status, ok := obj.(*networking.NetworkPolicyStatus)
+	if !ok {
+		return nil, errors.NewBadRequest("not a NetworkPolicyStatus object")
+	}
+	if err := r.statusController.UpdateStatus(status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+func (r *REST) NamespaceScoped() bool {
+	return false
+}
@@ -0,0 +1,86 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusternetworkpolicystats
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	statsv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/features"
+)
+
+// StatsProvider provides the traffic stats of Antrea ClusterNetworkPolicies.
+type StatsProvider interface {
+	GetClusterNetworkPolicyStats(name string) (*statsv1alpha1.ClusterNetworkPolicyStats, bool)
+	ListClusterNetworkPolicyStats() []statsv1alpha1.ClusterNetworkPolicyStats
+}
+
+// REST implements rest.Storage for ClusterNetworkPolicyStats.
+type REST struct {
+	statsProvider StatsProvider
+}
+
+var (
+	_ rest.Scoper = &REST{}
+	_ rest.Getter = &REST{}
+	_ rest.Lister = &REST{}
+)
+
+// NewREST returns a REST object that will work against API services.
+func NewREST(statsProvider StatsProvider) *REST {
+	return &REST{statsProvider}
+}
+
+func (r *REST) New() runtime.Object {
+	return &statsv1alpha1.ClusterNetworkPolicyStats{}
+}
+
+func (r *REST) Get(ctx context.Context, name string, options *v1.GetOptions) (runtime.Object, error) {
+	if !features.DefaultFeatureGate.Enabled(features.NetworkPolicyStats) {
+		return nil, errors.NewBadRequest("feature NetworkPolicyStats disabled")
+	}
+	stats, exists := r.statsProvider.GetClusterNetworkPolicyStats(name)
+	if !exists {
+		return nil, errors.NewNotFound(statsv1alpha1.Resource("clusternetworkpolicystats"), name)
+	}
+	return stats, nil
+}
+
+func (r *REST) NewList() runtime.Object {
+	return &statsv1alpha1.ClusterNetworkPolicyStatsList{}
+}
+
+func (r *REST) List(ctx context.Context, options *internalversion.ListOptions) (runtime.Object, error) {
+	if !features.DefaultFeatureGate.Enabled(features.NetworkPolicyStats) {
+		return nil, errors.NewBadRequest("feature NetworkPolicyStats disabled")
+	}
+	list := new(statsv1alpha1.ClusterNetworkPolicyStatsList)
+	list.Items = r.statsProvider.ListClusterNetworkPolicyStats()
+	return list, nil
+}
+
+func (r *REST) NamespaceScoped() bool {
+	return false
+}
+
+func (r *REST) ConvertToTable(ctx context.Context, obj runtime.Object, tableOptions runtime.Object) (*v1.Table, error) {
+	return rest.NewDefaultTableConvertor(statsv1alpha1.Resource("clusternetworkpolicystats")).ConvertToTable(ctx, obj, tableOptions)
+}
@@ -0,0 +1,39 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicystats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/vmware-tanzu/antrea/pkg/features"
+)
+
+func TestRESTFeatureDisabled(t *testing.T) {
+	if features.DefaultFeatureGate.Enabled(features.NetworkPolicyStats) {
+		t.Skipf("Feature NetworkPolicyStats is enabled by default")
+	}
+	// The stats are not provided when the feature is disabled.
+	r := NewREST(nil)
+	ctx := request.WithNamespace(context.Background(), "ns1")
+	_, err := r.Get(ctx, "np1", nil)
+	assert.True(t, errors.IsBadRequest(err))
+	_, err = r.List(ctx, nil)
+	assert.True(t, errors.IsBadRequest(err))
+}
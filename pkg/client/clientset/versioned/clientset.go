@@ -24,6 +24,7 @@ import (
 	networkingv1beta1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/networking/v1beta1"
 	opsv1alpha1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/ops/v1alpha1"
 	securityv1alpha1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/security/v1alpha1"
+	statsv1alpha1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/stats/v1alpha1"
 	systemv1beta1 "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/typed/system/v1beta1"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
@@ -37,6 +38,7 @@ type Interface interface {
 	NetworkingV1beta1() networkingv1beta1.NetworkingV1beta1Interface
 	OpsV1alpha1() opsv1alpha1.OpsV1alpha1Interface
 	SecurityV1alpha1() securityv1alpha1.SecurityV1alpha1Interface
+	StatsV1alpha1() statsv1alpha1.StatsV1alpha1Interface
 	SystemV1beta1() systemv1beta1.SystemV1beta1Interface
 }
 
@@ -49,6 +51,7 @@ type Clientset struct {
 	networkingV1beta1         *networkingv1beta1.NetworkingV1beta1Client
 	opsV1alpha1               *opsv1alpha1.OpsV1alpha1Client
 	securityV1alpha1          *securityv1alpha1.SecurityV1alpha1Client
+	statsV1alpha1             *statsv1alpha1.StatsV1alpha1Client
 	systemV1beta1             *systemv1beta1.SystemV1beta1Client
 }
 
@@ -77,6 +80,11 @@ func (c *Clientset) SecurityV1alpha1() securityv1alpha1.SecurityV1alpha1Interfac
 	return c.securityV1alpha1
 }
 
+// StatsV1alpha1 retrieves the StatsV1alpha1Client
+func (c *Clientset) StatsV1alpha1() statsv1alpha1.StatsV1alpha1Interface {
+	return c.statsV1alpha1
+}
+
 // SystemV1beta1 retrieves the SystemV1beta1Client
 func (c *Clientset) SystemV1beta1() systemv1beta1.SystemV1beta1Interface {
 	return c.systemV1beta1
@@ -123,6 +131,10 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	cs.statsV1alpha1, err = statsv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 	cs.systemV1beta1, err = systemv1beta1.NewForConfig(&configShallowCopy)
 	if err != nil {
 		return nil, err
@@ -144,6 +156,7 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 	cs.networkingV1beta1 = networkingv1beta1.NewForConfigOrDie(c)
 	cs.opsV1alpha1 = opsv1alpha1.NewForConfigOrDie(c)
 	cs.securityV1alpha1 = securityv1alpha1.NewForConfigOrDie(c)
+	cs.statsV1alpha1 = statsv1alpha1.NewForConfigOrDie(c)
 	cs.systemV1beta1 = systemv1beta1.NewForConfigOrDie(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClientForConfigOrDie(c)
@@ -158,6 +171,7 @@ func New(c rest.Interface) *Clientset {
 	cs.networkingV1beta1 = networkingv1beta1.New(c)
 	cs.opsV1alpha1 = opsv1alpha1.New(c)
 	cs.securityV1alpha1 = securityv1alpha1.New(c)
+	cs.statsV1alpha1 = statsv1alpha1.New(c)
 	cs.systemV1beta1 = systemv1beta1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
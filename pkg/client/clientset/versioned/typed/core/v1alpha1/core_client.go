@@ -24,6 +24,7 @@ import (
 
 type CoreV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	ClusterGroupsGetter
 	ExternalEntitiesGetter
 }
 
@@ -32,6 +33,10 @@ type CoreV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *CoreV1alpha1Client) ClusterGroups() ClusterGroupInterface {
+	return newClusterGroups(c)
+}
+
 func (c *CoreV1alpha1Client) ExternalEntities(namespace string) ExternalEntityInterface {
 	return newExternalEntities(c, namespace)
 }
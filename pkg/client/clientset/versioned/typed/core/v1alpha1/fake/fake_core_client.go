@@ -26,6 +26,10 @@ type FakeCoreV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeCoreV1alpha1) ClusterGroups() v1alpha1.ClusterGroupInterface {
+	return &FakeClusterGroups{c}
+}
+
 func (c *FakeCoreV1alpha1) ExternalEntities(namespace string) v1alpha1.ExternalEntityInterface {
 	return &FakeExternalEntities{c, namespace}
 }
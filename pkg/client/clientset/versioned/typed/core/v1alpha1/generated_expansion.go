@@ -16,4 +16,6 @@
 
 package v1alpha1
 
+type ClusterGroupExpansion interface{}
+
 type ExternalEntityExpansion interface{}
@@ -34,10 +34,22 @@ func (c *FakeNetworkingV1beta1) AppliedToGroups() v1beta1.AppliedToGroupInterfac
 	return &FakeAppliedToGroups{c}
 }
 
+func (c *FakeNetworkingV1beta1) ClusterGroupMembers() v1beta1.ClusterGroupMembersInterface {
+	return &FakeClusterGroupMembers{c}
+}
+
 func (c *FakeNetworkingV1beta1) NetworkPolicies(namespace string) v1beta1.NetworkPolicyInterface {
 	return &FakeNetworkPolicies{c, namespace}
 }
 
+func (c *FakeNetworkingV1beta1) NetworkPolicyStatuses() v1beta1.NetworkPolicyStatusInterface {
+	return &FakeNetworkPolicyStatuses{c}
+}
+
+func (c *FakeNetworkingV1beta1) NodeStatsSummaries() v1beta1.NodeStatsSummaryInterface {
+	return &FakeNodeStatsSummaries{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeNetworkingV1beta1) RESTClient() rest.Interface {
@@ -26,7 +26,10 @@ type NetworkingV1beta1Interface interface {
 	RESTClient() rest.Interface
 	AddressGroupsGetter
 	AppliedToGroupsGetter
+	ClusterGroupMembersGetter
 	NetworkPoliciesGetter
+	NetworkPolicyStatusesGetter
+	NodeStatsSummariesGetter
 }
 
 // NetworkingV1beta1Client is used to interact with features provided by the networking.antrea.tanzu.vmware.com group.
@@ -42,10 +45,22 @@ func (c *NetworkingV1beta1Client) AppliedToGroups() AppliedToGroupInterface {
 	return newAppliedToGroups(c)
 }
 
+func (c *NetworkingV1beta1Client) ClusterGroupMembers() ClusterGroupMembersInterface {
+	return newClusterGroupMembers(c)
+}
+
 func (c *NetworkingV1beta1Client) NetworkPolicies(namespace string) NetworkPolicyInterface {
 	return newNetworkPolicies(c, namespace)
 }
 
+func (c *NetworkingV1beta1Client) NetworkPolicyStatuses() NetworkPolicyStatusInterface {
+	return newNetworkPolicyStatuses(c)
+}
+
+func (c *NetworkingV1beta1Client) NodeStatsSummaries() NodeStatsSummaryInterface {
+	return newNodeStatsSummaries(c)
+}
+
 // NewForConfig creates a new NetworkingV1beta1Client for the given config.
 func NewForConfig(c *rest.Config) (*NetworkingV1beta1Client, error) {
 	config := *c
@@ -34,6 +34,10 @@ func (c *FakeSecurityV1alpha1) NetworkPolicies(namespace string) v1alpha1.Networ
 	return &FakeNetworkPolicies{c, namespace}
 }
 
+func (c *FakeSecurityV1alpha1) Tiers() v1alpha1.TierInterface {
+	return &FakeTiers{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeSecurityV1alpha1) RESTClient() rest.Interface {
@@ -26,6 +26,7 @@ type SecurityV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	ClusterNetworkPoliciesGetter
 	NetworkPoliciesGetter
+	TiersGetter
 }
 
 // SecurityV1alpha1Client is used to interact with features provided by the security.antrea.tanzu.vmware.com group.
@@ -41,6 +42,10 @@ func (c *SecurityV1alpha1Client) NetworkPolicies(namespace string) NetworkPolicy
 	return newNetworkPolicies(c, namespace)
 }
 
+func (c *SecurityV1alpha1Client) Tiers() TierInterface {
+	return newTiers(c)
+}
+
 // NewForConfig creates a new SecurityV1alpha1Client for the given config.
 func NewForConfig(c *rest.Config) (*SecurityV1alpha1Client, error) {
 	config := *c
@@ -0,0 +1,192 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/controller/networkpolicy/store"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+)
+
+// addANP receives Antrea NetworkPolicy ADD events and creates resources
+// which can be consumed by agents to configure corresponding rules on the Nodes.
+func (n *NetworkPolicyController) addANP(obj interface{}) {
+	defer n.heartbeat("addANP")
+	np := obj.(*secv1alpha1.NetworkPolicy)
+	klog.Infof("Processing Antrea NetworkPolicy %s/%s ADD event", np.Namespace, np.Name)
+	// Create an internal NetworkPolicy object corresponding to this
+	// NetworkPolicy and enqueue task to internal NetworkPolicy Workqueue.
+	internalNP := n.processAntreaNetworkPolicy(np)
+	klog.Infof("Creating new internal NetworkPolicy %#v", internalNP)
+	n.internalNetworkPolicyStore.Create(internalNP)
+	key := store.AntreaPolicyKey(np.Namespace, np.Name)
+	n.enqueueInternalNetworkPolicy(key)
+}
+
+// updateANP receives Antrea NetworkPolicy UPDATE events and updates resources
+// which can be consumed by agents to configure corresponding rules on the Nodes.
+func (n *NetworkPolicyController) updateANP(old, cur interface{}) {
+	defer n.heartbeat("updateANP")
+	curNP := cur.(*secv1alpha1.NetworkPolicy)
+	// Retrieve old secv1alpha1.NetworkPolicy object.
+	oldNP := old.(*secv1alpha1.NetworkPolicy)
+	// Only the status or metadata was updated, e.g. when the status is
+	// reported by the controller itself. Tier updates call this function with
+	// identical objects, so they are still processed.
+	if oldNP.ResourceVersion != curNP.ResourceVersion && oldNP.Generation == curNP.Generation {
+		klog.V(4).Infof("Spec of Antrea NetworkPolicy %s/%s was not updated, skipping", curNP.Namespace, curNP.Name)
+		return
+	}
+	klog.Infof("Processing Antrea NetworkPolicy %s/%s UPDATE event", curNP.Namespace, curNP.Name)
+	// Update an internal NetworkPolicy, corresponding to this NetworkPolicy and
+	// enqueue task to internal NetworkPolicy Workqueue.
+	curInternalNP := n.processAntreaNetworkPolicy(curNP)
+	klog.V(2).Infof("Updating existing internal NetworkPolicy %s/%s", curInternalNP.Namespace, curInternalNP.Name)
+	// Old and current NetworkPolicy share the same key.
+	key := store.AntreaPolicyKey(oldNP.Namespace, oldNP.Name)
+	// Lock access to internal NetworkPolicy store such that concurrent access
+	// to an internal NetworkPolicy is not allowed. This will avoid the
+	// case in which an Update to an internal NetworkPolicy object may
+	// cause the SpanMeta member to be overridden with stale SpanMeta members
+	// from an older internal NetworkPolicy.
+	n.internalNetworkPolicyMutex.Lock()
+	oldInternalNPObj, _, _ := n.internalNetworkPolicyStore.Get(key)
+	oldInternalNP := oldInternalNPObj.(*antreatypes.NetworkPolicy)
+	// Must preserve old internal NetworkPolicy Span.
+	curInternalNP.SpanMeta = oldInternalNP.SpanMeta
+	n.internalNetworkPolicyStore.Update(curInternalNP)
+	// Unlock the internal NetworkPolicy store.
+	n.internalNetworkPolicyMutex.Unlock()
+	// Enqueue addressGroup keys to update their Node span.
+	for _, rule := range curInternalNP.Rules {
+		for _, addrGroupName := range rule.From.AddressGroups {
+			n.enqueueAddressGroup(addrGroupName)
+		}
+		for _, addrGroupName := range rule.To.AddressGroups {
+			n.enqueueAddressGroup(addrGroupName)
+		}
+	}
+	n.enqueueInternalNetworkPolicy(key)
+	for _, atg := range oldInternalNP.AppliedToGroups {
+		// Delete the old AppliedToGroup object if it is not referenced
+		// by any internal NetworkPolicy.
+		n.deleteDereferencedAppliedToGroup(atg)
+	}
+	n.deleteDereferencedAddressGroups(oldInternalNP)
+}
+
+// deleteANP receives Antrea NetworkPolicy DELETED events and deletes resources
+// which can be consumed by agents to delete corresponding rules on the Nodes.
+func (n *NetworkPolicyController) deleteANP(old interface{}) {
+	np, ok := old.(*secv1alpha1.NetworkPolicy)
+	if !ok {
+		tombstone, ok := old.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Error decoding object when deleting Antrea NetworkPolicy, invalid type: %v", old)
+			return
+		}
+		np, ok = tombstone.Obj.(*secv1alpha1.NetworkPolicy)
+		if !ok {
+			klog.Errorf("Error decoding object tombstone when deleting Antrea NetworkPolicy, invalid type: %v", tombstone.Obj)
+			return
+		}
+	}
+	defer n.heartbeat("deleteANP")
+	klog.Infof("Processing Antrea NetworkPolicy %s/%s DELETE event", np.Namespace, np.Name)
+	key := store.AntreaPolicyKey(np.Namespace, np.Name)
+	oldInternalNPObj, _, _ := n.internalNetworkPolicyStore.Get(key)
+	oldInternalNP := oldInternalNPObj.(*antreatypes.NetworkPolicy)
+	klog.Infof("Old internal NetworkPolicy %#v", oldInternalNP)
+	err := n.internalNetworkPolicyStore.Delete(key)
+	if err != nil {
+		klog.Errorf("Error deleting internal NetworkPolicy during Antrea NetworkPolicy %s/%s delete: %v", np.Namespace, np.Name, err)
+		return
+	}
+	for _, atg := range oldInternalNP.AppliedToGroups {
+		n.deleteDereferencedAppliedToGroup(atg)
+	}
+	n.deleteDereferencedAddressGroups(oldInternalNP)
+	// Clean up the statuses reported by Nodes for this policy.
+	n.enqueueNetworkPolicyStatus(key)
+}
+
+// processAntreaNetworkPolicy creates an internal NetworkPolicy instance
+// corresponding to the secv1alpha1.NetworkPolicy object. This method
+// does not commit the internal NetworkPolicy in store, instead returns an
+// instance to the caller wherein, it will be either stored as a new Object
+// in case of ADD event or modified and store the updated instance, in case
+// of an UPDATE event.
+func (n *NetworkPolicyController) processAntreaNetworkPolicy(np *secv1alpha1.NetworkPolicy) *antreatypes.NetworkPolicy {
+	appliedToGroupNames := make([]string, 0, len(np.Spec.AppliedTo))
+	// Create AppliedToGroup for each AppliedTo present in Antrea
+	// NetworkPolicy spec. The AppliedTo of a Namespaced policy can only
+	// select Pods or ExternalEntities from the policy's own Namespace.
+	for _, at := range np.Spec.AppliedTo {
+		if at.ServiceAccount != nil {
+			appliedToGroupUID, err := n.createAppliedToGroupForServiceAccount(at.ServiceAccount, np)
+			if err != nil {
+				klog.Errorf("Ignoring ServiceAccount AppliedTo of Antrea NetworkPolicy %s/%s: %v", np.Namespace, np.Name, err)
+				continue
+			}
+			appliedToGroupNames = append(appliedToGroupNames, appliedToGroupUID)
+			continue
+		}
+		if at.PodSelector == nil && at.ExternalEntitySelector == nil {
+			klog.Warningf("Ignoring AppliedTo of Antrea NetworkPolicy %s/%s without podSelector, externalEntitySelector or serviceAccount", np.Namespace, np.Name)
+			continue
+		}
+		appliedToGroupNames = append(appliedToGroupNames, n.createAppliedToGroup(np.Namespace, at.PodSelector, nil, at.ExternalEntitySelector))
+	}
+	rules := make([]networking.NetworkPolicyRule, 0, len(np.Spec.Ingress)+len(np.Spec.Egress))
+	// Compute NetworkPolicyRule for Ingress Rule.
+	for idx, ingressRule := range np.Spec.Ingress {
+		rules = append(rules, networking.NetworkPolicyRule{
+			Direction:     networking.DirectionIn,
+			From:          *n.toAntreaPeerForCRD(ingressRule.From, np, networking.DirectionIn),
+			Services:      toAntreaServicesForCRD(ingressRule.Ports),
+			Action:        ingressRule.Action,
+			Priority:      int32(idx),
+			EnableLogging: ingressRule.EnableLogging,
+		})
+	}
+	// Compute NetworkPolicyRule for Egress Rule.
+	for idx, egressRule := range np.Spec.Egress {
+		to, services := n.toAntreaEgressPeerForCRD(egressRule, np)
+		rules = append(rules, networking.NetworkPolicyRule{
+			Direction:     networking.DirectionOut,
+			To:            *to,
+			Services:      services,
+			Action:        egressRule.Action,
+			Priority:      int32(idx),
+			EnableLogging: egressRule.EnableLogging,
+		})
+	}
+	tierPriority := n.getTierPriority(np.Spec.Tier)
+	internalNetworkPolicy := &antreatypes.NetworkPolicy{
+		Name:            np.Name,
+		Namespace:       np.Namespace,
+		UID:             np.UID,
+		AppliedToGroups: appliedToGroupNames,
+		Rules:           rules,
+		Priority:        &np.Spec.Priority,
+		TierPriority:    &tierPriority,
+		Generation:      np.Generation,
+	}
+	return internalNetworkPolicy
+}
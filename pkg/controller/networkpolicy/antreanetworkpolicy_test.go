@@ -0,0 +1,413 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/controller/networkpolicy/store"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+)
+
+func TestProcessAntreaNetworkPolicy(t *testing.T) {
+	p10 := float64(10)
+	defaultTierPriority := DefaultTierPriority
+	allowAction := secv1alpha1.RuleActionAllow
+	dropAction := secv1alpha1.RuleActionDrop
+	passAction := secv1alpha1.RuleActionPass
+	rejectAction := secv1alpha1.RuleActionReject
+	protocolTCP := networking.ProtocolTCP
+	intstr80, intstr81 := intstr.FromInt(80), intstr.FromInt(81)
+	selectorA := metav1.LabelSelector{MatchLabels: map[string]string{"foo1": "bar1"}}
+	selectorB := metav1.LabelSelector{MatchLabels: map[string]string{"foo2": "bar2"}}
+	selectorC := metav1.LabelSelector{MatchLabels: map[string]string{"foo3": "bar3"}}
+	tests := []struct {
+		name                    string
+		inputPolicy             *secv1alpha1.NetworkPolicy
+		expectedPolicy          *antreatypes.NetworkPolicy
+		expectedAppliedToGroups int
+		expectedAddressGroups   int
+	}{
+		{
+			name: "rules-with-same-selectors",
+			inputPolicy: &secv1alpha1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "npA", UID: "uidA"},
+				Spec: secv1alpha1.NetworkPolicySpec{
+					AppliedTo: []secv1alpha1.NetworkPolicyPeer{
+						{PodSelector: &selectorA},
+					},
+					Priority: p10,
+					Ingress: []secv1alpha1.Rule{
+						{
+							Ports: []secv1alpha1.NetworkPolicyPort{
+								{
+									Port: &intstr80,
+								},
+							},
+							From: []secv1alpha1.NetworkPolicyPeer{
+								{
+									PodSelector: &selectorB,
+								},
+							},
+							Action: &allowAction,
+						},
+					},
+					Egress: []secv1alpha1.Rule{
+						{
+							Ports: []secv1alpha1.NetworkPolicyPort{
+								{
+									Port: &intstr81,
+								},
+							},
+							To: []secv1alpha1.NetworkPolicyPeer{
+								{
+									PodSelector: &selectorB,
+								},
+							},
+							Action: &dropAction,
+						},
+					},
+				},
+			},
+			expectedPolicy: &antreatypes.NetworkPolicy{
+				UID:          "uidA",
+				Name:         "npA",
+				Namespace:    "ns1",
+				Priority:     &p10,
+				TierPriority: &defaultTierPriority,
+				Rules: []networking.NetworkPolicyRule{
+					{
+						Direction: networking.DirectionIn,
+						From: networking.NetworkPolicyPeer{
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("ns1", &selectorB, nil, nil).NormalizedName)},
+						},
+						Services: []networking.Service{
+							{
+								Protocol: &protocolTCP,
+								Port:     &intstr80,
+							},
+						},
+						Priority: 0,
+						Action:   &allowAction,
+					},
+					{
+						Direction: networking.DirectionOut,
+						To: networking.NetworkPolicyPeer{
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("ns1", &selectorB, nil, nil).NormalizedName)},
+						},
+						Services: []networking.Service{
+							{
+								Protocol: &protocolTCP,
+								Port:     &intstr81,
+							},
+						},
+						Priority: 0,
+						Action:   &dropAction,
+					},
+				},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("ns1", &selectorA, nil, nil).NormalizedName)},
+			},
+			expectedAppliedToGroups: 1,
+			expectedAddressGroups:   1,
+		},
+		{
+			name: "rules-with-different-selectors",
+			inputPolicy: &secv1alpha1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "npB", UID: "uidB"},
+				Spec: secv1alpha1.NetworkPolicySpec{
+					AppliedTo: []secv1alpha1.NetworkPolicyPeer{
+						{PodSelector: &selectorA},
+					},
+					Priority: p10,
+					Ingress: []secv1alpha1.Rule{
+						{
+							Ports: []secv1alpha1.NetworkPolicyPort{
+								{
+									Port: &intstr80,
+								},
+							},
+							From: []secv1alpha1.NetworkPolicyPeer{
+								{
+									PodSelector: &selectorB,
+								},
+							},
+							Action: &allowAction,
+						},
+						{
+							Ports: []secv1alpha1.NetworkPolicyPort{
+								{
+									Port: &intstr81,
+								},
+							},
+							From: []secv1alpha1.NetworkPolicyPeer{
+								{
+									NamespaceSelector: &selectorC,
+								},
+							},
+							Action: &dropAction,
+						},
+					},
+				},
+			},
+			expectedPolicy: &antreatypes.NetworkPolicy{
+				UID:          "uidB",
+				Name:         "npB",
+				Namespace:    "ns2",
+				Priority:     &p10,
+				TierPriority: &defaultTierPriority,
+				Rules: []networking.NetworkPolicyRule{
+					{
+						Direction: networking.DirectionIn,
+						From: networking.NetworkPolicyPeer{
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("ns2", &selectorB, nil, nil).NormalizedName)},
+						},
+						Services: []networking.Service{
+							{
+								Protocol: &protocolTCP,
+								Port:     &intstr80,
+							},
+						},
+						Priority: 0,
+						Action:   &allowAction,
+					},
+					{
+						Direction: networking.DirectionIn,
+						From: networking.NetworkPolicyPeer{
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("ns2", nil, &selectorC, nil).NormalizedName)},
+						},
+						Services: []networking.Service{
+							{
+								Protocol: &protocolTCP,
+								Port:     &intstr81,
+							},
+						},
+						Priority: 1,
+						Action:   &dropAction,
+					},
+				},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("ns2", &selectorA, nil, nil).NormalizedName)},
+			},
+			expectedAppliedToGroups: 1,
+			expectedAddressGroups:   2,
+		},
+		{
+			name: "rules-with-pass-and-reject-actions",
+			inputPolicy: &secv1alpha1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns3", Name: "npC", UID: "uidC"},
+				Spec: secv1alpha1.NetworkPolicySpec{
+					AppliedTo: []secv1alpha1.NetworkPolicyPeer{
+						{PodSelector: &selectorA},
+					},
+					Priority: p10,
+					Ingress: []secv1alpha1.Rule{
+						{
+							Ports: []secv1alpha1.NetworkPolicyPort{
+								{
+									Port: &intstr80,
+								},
+							},
+							From: []secv1alpha1.NetworkPolicyPeer{
+								{
+									PodSelector: &selectorB,
+								},
+							},
+							Action: &passAction,
+						},
+					},
+					Egress: []secv1alpha1.Rule{
+						{
+							Ports: []secv1alpha1.NetworkPolicyPort{
+								{
+									Port: &intstr81,
+								},
+							},
+							To: []secv1alpha1.NetworkPolicyPeer{
+								{
+									PodSelector: &selectorB,
+								},
+							},
+							Action: &rejectAction,
+						},
+					},
+				},
+			},
+			expectedPolicy: &antreatypes.NetworkPolicy{
+				UID:          "uidC",
+				Name:         "npC",
+				Namespace:    "ns3",
+				Priority:     &p10,
+				TierPriority: &defaultTierPriority,
+				Rules: []networking.NetworkPolicyRule{
+					{
+						Direction: networking.DirectionIn,
+						From: networking.NetworkPolicyPeer{
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("ns3", &selectorB, nil, nil).NormalizedName)},
+						},
+						Services: []networking.Service{
+							{
+								Protocol: &protocolTCP,
+								Port:     &intstr80,
+							},
+						},
+						Priority: 0,
+						Action:   &passAction,
+					},
+					{
+						Direction: networking.DirectionOut,
+						To: networking.NetworkPolicyPeer{
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("ns3", &selectorB, nil, nil).NormalizedName)},
+						},
+						Services: []networking.Service{
+							{
+								Protocol: &protocolTCP,
+								Port:     &intstr81,
+							},
+						},
+						Priority: 0,
+						Action:   &rejectAction,
+					},
+				},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("ns3", &selectorA, nil, nil).NormalizedName)},
+			},
+			expectedAppliedToGroups: 1,
+			expectedAddressGroups:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, c := newController()
+
+			if actualPolicy := c.processAntreaNetworkPolicy(tt.inputPolicy); !reflect.DeepEqual(actualPolicy, tt.expectedPolicy) {
+				t.Errorf("processAntreaNetworkPolicy() got %v, want %v", actualPolicy, tt.expectedPolicy)
+			}
+
+			if actualAddressGroups := len(c.addressGroupStore.List()); actualAddressGroups != tt.expectedAddressGroups {
+				t.Errorf("len(addressGroupStore.List()) got %v, want %v", actualAddressGroups, tt.expectedAddressGroups)
+			}
+
+			if actualAppliedToGroups := len(c.appliedToGroupStore.List()); actualAppliedToGroups != tt.expectedAppliedToGroups {
+				t.Errorf("len(appliedToGroupStore.List()) got %v, want %v", actualAppliedToGroups, tt.expectedAppliedToGroups)
+			}
+		})
+	}
+}
+
+func TestAddANP(t *testing.T) {
+	anpObj := getANP()
+	_, npc := newController()
+	npc.addANP(anpObj)
+	key := store.AntreaPolicyKey(anpObj.Namespace, anpObj.Name)
+	actualPolicyObj, found, _ := npc.internalNetworkPolicyStore.Get(key)
+	assert.True(t, found, "expected internal NetworkPolicy to be created")
+	actualPolicy := actualPolicyObj.(*antreatypes.NetworkPolicy)
+	assert.Equal(t, anpObj.Namespace, actualPolicy.Namespace)
+	assert.Equal(t, &anpObj.Spec.Priority, actualPolicy.Priority)
+	assert.Len(t, actualPolicy.Rules, 2)
+	assert.Equal(t, 1, npc.GetNetworkPolicyNum(), "expected networkPolicy number is 1")
+	assert.Equal(t, 2, npc.GetAddressGroupNum(), "expected addressGroup number is 2")
+	assert.Equal(t, 1, npc.GetAppliedToGroupNum(), "appliedToGroup number is 1")
+}
+
+func TestDeleteANP(t *testing.T) {
+	anpObj := getANP()
+	apgID := getNormalizedUID(toGroupSelector(anpObj.Namespace, anpObj.Spec.AppliedTo[0].PodSelector, nil, nil).NormalizedName)
+	_, npc := newController()
+	npc.addANP(anpObj)
+	npc.deleteANP(anpObj)
+	_, found, _ := npc.appliedToGroupStore.Get(apgID)
+	assert.False(t, found, "expected AppliedToGroup to be deleted")
+	adgs := npc.addressGroupStore.List()
+	assert.Len(t, adgs, 0, "expected empty AddressGroup list")
+	key := store.AntreaPolicyKey(anpObj.Namespace, anpObj.Name)
+	_, found, _ = npc.internalNetworkPolicyStore.Get(key)
+	assert.False(t, found, "expected internal NetworkPolicy to be deleted")
+}
+
+func TestANPAndK8sNetworkPolicyWithSameName(t *testing.T) {
+	anpObj := getANP()
+	npObj := getK8sNetworkPolicyObj()
+	npObj.Namespace, npObj.Name = anpObj.Namespace, anpObj.Name
+	_, npc := newController()
+	npc.addNetworkPolicy(npObj)
+	npc.addANP(anpObj)
+	assert.Equal(t, 2, npc.GetNetworkPolicyNum(), "expected both internal NetworkPolicies to exist")
+
+	npKey, _ := keyFunc(npObj)
+	anpKey := store.AntreaPolicyKey(anpObj.Namespace, anpObj.Name)
+	// The statuses reported by Nodes are stored for the Antrea NetworkPolicy.
+	require.NoError(t, npc.UpdateStatus(&networking.NetworkPolicyStatus{
+		ObjectMeta: metav1.ObjectMeta{Namespace: anpObj.Namespace, Name: anpObj.Name},
+		Nodes:      []networking.NetworkPolicyNodeStatus{{NodeName: "node1", Generation: anpObj.Generation}},
+	}))
+	assert.Contains(t, npc.networkPolicyStatuses, anpKey)
+	assert.NotContains(t, npc.networkPolicyStatuses, npKey)
+
+	npc.deleteANP(anpObj)
+	_, found, _ := npc.internalNetworkPolicyStore.Get(anpKey)
+	assert.False(t, found, "expected internal NetworkPolicy of the Antrea NetworkPolicy to be deleted")
+	actualPolicyObj, found, _ := npc.internalNetworkPolicyStore.Get(npKey)
+	require.True(t, found, "expected internal NetworkPolicy of the K8s NetworkPolicy to be kept")
+	assert.Nil(t, actualPolicyObj.(*antreatypes.NetworkPolicy).Priority)
+}
+
+// util functions for testing.
+
+func getANP() *secv1alpha1.NetworkPolicy {
+	p10 := float64(10)
+	allowAction := secv1alpha1.RuleActionAllow
+	selectorA := metav1.LabelSelector{MatchLabels: map[string]string{"foo1": "bar1"}}
+	selectorB := metav1.LabelSelector{MatchLabels: map[string]string{"foo2": "bar2"}}
+	selectorC := metav1.LabelSelector{MatchLabels: map[string]string{"foo3": "bar3"}}
+	ingressRules := []secv1alpha1.Rule{
+		{
+			From: []secv1alpha1.NetworkPolicyPeer{
+				{
+					NamespaceSelector: &selectorB,
+				},
+			},
+			Action: &allowAction,
+		},
+	}
+	egressRules := []secv1alpha1.Rule{
+		{
+			To: []secv1alpha1.NetworkPolicyPeer{
+				{
+					PodSelector: &selectorC,
+				},
+			},
+			Action: &allowAction,
+		},
+	}
+	npObj := &secv1alpha1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "test-anp"},
+		Spec: secv1alpha1.NetworkPolicySpec{
+			AppliedTo: []secv1alpha1.NetworkPolicyPeer{
+				{PodSelector: &selectorA},
+			},
+			Priority: p10,
+			Ingress:  ingressRules,
+			Egress:   egressRules,
+		},
+	}
+	return npObj
+}
@@ -0,0 +1,231 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+
+	corev1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/core/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+)
+
+// addClusterGroup receives ClusterGroup ADD events and re-processes the
+// ClusterNetworkPolicies which refer to it, as they may have been processed
+// before the ClusterGroup was created.
+func (n *NetworkPolicyController) addClusterGroup(obj interface{}) {
+	defer n.heartbeat("addClusterGroup")
+	cg := obj.(*corev1alpha1.ClusterGroup)
+	klog.V(2).Infof("Processing ClusterGroup %s ADD event", cg.Name)
+	n.reprocessCNPsForClusterGroup(cg.Name)
+}
+
+// updateClusterGroup receives ClusterGroup UPDATE events and re-processes the
+// ClusterNetworkPolicies which refer to it if its spec was updated.
+func (n *NetworkPolicyController) updateClusterGroup(old, cur interface{}) {
+	defer n.heartbeat("updateClusterGroup")
+	oldCG := old.(*corev1alpha1.ClusterGroup)
+	curCG := cur.(*corev1alpha1.ClusterGroup)
+	if reflect.DeepEqual(oldCG.Spec, curCG.Spec) {
+		klog.V(4).Infof("Spec of ClusterGroup %s was not updated, skipping", curCG.Name)
+		return
+	}
+	klog.V(2).Infof("Processing ClusterGroup %s UPDATE event", curCG.Name)
+	n.reprocessCNPsForClusterGroup(curCG.Name)
+}
+
+// deleteClusterGroup receives ClusterGroup DELETED events and re-processes
+// the ClusterNetworkPolicies which refer to it.
+func (n *NetworkPolicyController) deleteClusterGroup(old interface{}) {
+	cg, ok := old.(*corev1alpha1.ClusterGroup)
+	if !ok {
+		tombstone, ok := old.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Error decoding object when deleting ClusterGroup, invalid type: %v", old)
+			return
+		}
+		cg, ok = tombstone.Obj.(*corev1alpha1.ClusterGroup)
+		if !ok {
+			klog.Errorf("Error decoding object tombstone when deleting ClusterGroup, invalid type: %v", tombstone.Obj)
+			return
+		}
+	}
+	defer n.heartbeat("deleteClusterGroup")
+	klog.V(2).Infof("Processing ClusterGroup %s DELETE event", cg.Name)
+	n.reprocessCNPsForClusterGroup(cg.Name)
+}
+
+// reprocessCNPsForClusterGroup re-processes the ClusterNetworkPolicies which
+// refer to the ClusterGroup with the given name, either directly or through
+// the ChildGroups of another ClusterGroup.
+func (n *NetworkPolicyController) reprocessCNPsForClusterGroup(name string) {
+	cnps, _ := n.cnpLister.List(labels.Everything())
+	for _, cnp := range cnps {
+		if !n.isProcessed(cnp) || !n.cnpRefersToClusterGroup(cnp, name) {
+			continue
+		}
+		n.updateCNP(cnp, cnp)
+	}
+}
+
+// cnpRefersToClusterGroup returns true if the ClusterNetworkPolicy refers to
+// the ClusterGroup with the given name, which may not exist.
+func (n *NetworkPolicyController) cnpRefersToClusterGroup(cnp *secv1alpha1.ClusterNetworkPolicy, name string) bool {
+	var peers []secv1alpha1.NetworkPolicyPeer
+	peers = append(peers, cnp.Spec.AppliedTo...)
+	for _, rule := range cnp.Spec.Ingress {
+		peers = append(peers, rule.From...)
+	}
+	for _, rule := range cnp.Spec.Egress {
+		peers = append(peers, rule.To...)
+	}
+	for _, peer := range peers {
+		if peer.Group == "" {
+			continue
+		}
+		if _, names := n.resolveClusterGroup(peer.Group); names.Has(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveClusterGroup returns the ClusterGroup with the given name and all the
+// ClusterGroups reachable through its ChildGroups, each of them only once in
+// case of cycles. It also returns the names of all the visited ClusterGroups,
+// including the ones which do not exist.
+func (n *NetworkPolicyController) resolveClusterGroup(name string) ([]*corev1alpha1.ClusterGroup, sets.String) {
+	var groups []*corev1alpha1.ClusterGroup
+	names := sets.NewString(name)
+	pending := []string{name}
+	for len(pending) > 0 {
+		cgName := pending[0]
+		pending = pending[1:]
+		cg, err := n.cgLister.Get(cgName)
+		if err != nil {
+			klog.V(2).Infof("Failed to retrieve ClusterGroup %s: %v", cgName, err)
+			continue
+		}
+		groups = append(groups, cg)
+		for _, child := range cg.Spec.ChildGroups {
+			if names.Has(child) {
+				continue
+			}
+			names.Insert(child)
+			pending = append(pending, child)
+		}
+	}
+	return groups, names
+}
+
+// clusterGroupHasSelector returns true if the ClusterGroup selects Pods or
+// ExternalEntities.
+func clusterGroupHasSelector(cg *corev1alpha1.ClusterGroup) bool {
+	return cg.Spec.PodSelector != nil || cg.Spec.NamespaceSelector != nil || cg.Spec.ExternalEntitySelector != nil
+}
+
+// toAntreaPeerForClusterGroup computes the AddressGroups and IPBlocks of the
+// ClusterGroup with the given name referred to by the ClusterNetworkPolicy.
+func (n *NetworkPolicyController) toAntreaPeerForClusterGroup(name string, cnp metav1.Object) ([]string, []networking.IPBlock) {
+	var addressGroups []string
+	var ipBlocks []networking.IPBlock
+	groups, _ := n.resolveClusterGroup(name)
+	for _, cg := range groups {
+		for i := range cg.Spec.IPBlocks {
+			ipBlock, err := toAntreaIPBlockForCRD(&cg.Spec.IPBlocks[i])
+			if err != nil {
+				klog.Errorf("Failure processing ClusterGroup %s IPBlock %v: %v", cg.Name, cg.Spec.IPBlocks[i], err)
+				continue
+			}
+			ipBlocks = append(ipBlocks, *ipBlock)
+		}
+		if clusterGroupHasSelector(cg) {
+			peer := secv1alpha1.NetworkPolicyPeer{
+				PodSelector:            cg.Spec.PodSelector,
+				NamespaceSelector:      cg.Spec.NamespaceSelector,
+				ExternalEntitySelector: cg.Spec.ExternalEntitySelector,
+			}
+			addressGroups = append(addressGroups, n.createAddressGroupForCRD(peer, cnp))
+		}
+	}
+	return addressGroups, ipBlocks
+}
+
+// createAppliedToGroupsForClusterGroup creates an AppliedToGroup for each
+// ClusterGroup selecting Pods or ExternalEntities among the ClusterGroup with
+// the given name and its ChildGroups, and returns their names.
+func (n *NetworkPolicyController) createAppliedToGroupsForClusterGroup(name string) []string {
+	var appliedToGroupNames []string
+	groups, _ := n.resolveClusterGroup(name)
+	for _, cg := range groups {
+		if len(cg.Spec.IPBlocks) > 0 {
+			klog.Errorf("Ignoring IPBlocks of ClusterGroup %s: IPBlocks cannot be used in AppliedTo", cg.Name)
+		}
+		if clusterGroupHasSelector(cg) {
+			appliedToGroupNames = append(appliedToGroupNames, n.createAppliedToGroup("", cg.Spec.PodSelector, cg.Spec.NamespaceSelector, cg.Spec.ExternalEntitySelector))
+		}
+	}
+	return appliedToGroupNames
+}
+
+// GetClusterGroupMembers computes the Pods, ExternalEntities and IPBlocks of
+// the ClusterGroup with the given name and its ChildGroups. It returns false
+// if the ClusterGroup doesn't exist.
+func (n *NetworkPolicyController) GetClusterGroupMembers(name string) (*networking.ClusterGroupMembers, bool) {
+	if n.cgLister == nil {
+		// ClusterGroups are not watched when the ClusterNetworkPolicy
+		// feature is disabled.
+		return nil, false
+	}
+	if _, err := n.cgLister.Get(name); err != nil {
+		return nil, false
+	}
+	podSet := networking.GroupMemberPodSet{}
+	memberSet := networking.GroupMemberSet{}
+	members := &networking.ClusterGroupMembers{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	groups, _ := n.resolveClusterGroup(name)
+	for _, cg := range groups {
+		for i := range cg.Spec.IPBlocks {
+			ipBlock, err := toAntreaIPBlockForCRD(&cg.Spec.IPBlocks[i])
+			if err != nil {
+				continue
+			}
+			members.IPBlocks = append(members.IPBlocks, *ipBlock)
+		}
+		if !clusterGroupHasSelector(cg) {
+			continue
+		}
+		pods, externalEntities := n.processSelector(*toGroupSelector("", cg.Spec.PodSelector, cg.Spec.NamespaceSelector, cg.Spec.ExternalEntitySelector))
+		for _, pod := range pods {
+			podSet.Insert(podToMemberPod(pod, true, true))
+		}
+		for _, entity := range externalEntities {
+			memberSet.Insert(externalEntityToGroupMember(entity))
+		}
+	}
+	for _, pod := range podSet {
+		members.Pods = append(members.Pods, *pod)
+	}
+	for _, member := range memberSet {
+		members.GroupMembers = append(members.GroupMembers, *member)
+	}
+	return members, true
+}
@@ -0,0 +1,168 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/core/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+)
+
+func getClusterGroup(name string, podSelector *metav1.LabelSelector, cidrs []string, childGroups ...string) *corev1alpha1.ClusterGroup {
+	cg := &corev1alpha1.ClusterGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1alpha1.GroupSpec{
+			PodSelector: podSelector,
+			ChildGroups: childGroups,
+		},
+	}
+	for _, cidr := range cidrs {
+		cg.Spec.IPBlocks = append(cg.Spec.IPBlocks, secv1alpha1.IPBlock{CIDR: cidr})
+	}
+	return cg
+}
+
+func TestResolveClusterGroup(t *testing.T) {
+	_, npc := newController()
+	// cg1 and cg2 refer to each other, and cg2 refers to a missing group.
+	cg1 := getClusterGroup("cg1", nil, nil, "cg2")
+	cg2 := getClusterGroup("cg2", nil, nil, "cg1", "missing")
+	npc.cgStore.Add(cg1)
+	npc.cgStore.Add(cg2)
+
+	groups, names := npc.resolveClusterGroup("cg1")
+	assert.Equal(t, []*corev1alpha1.ClusterGroup{cg1, cg2}, groups)
+	assert.Equal(t, []string{"cg1", "cg2", "missing"}, names.List())
+
+	groups, names = npc.resolveClusterGroup("missing")
+	assert.Empty(t, groups)
+	assert.Equal(t, []string{"missing"}, names.List())
+}
+
+func TestToAntreaPeerForClusterGroup(t *testing.T) {
+	selectorA := metav1.LabelSelector{MatchLabels: map[string]string{"foo1": "bar1"}}
+	selectorB := metav1.LabelSelector{MatchLabels: map[string]string{"foo2": "bar2"}}
+	cidr := "10.0.0.0/16"
+	cidrIPNet, _ := cidrStrToIPNet(cidr)
+	cnp := &secv1alpha1.ClusterNetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cnpA"}}
+	anp := &secv1alpha1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "anpA", Namespace: "ns1"}}
+	peers := []secv1alpha1.NetworkPolicyPeer{{Group: "cg1"}}
+
+	_, npc := newController()
+	npc.cgStore.Add(getClusterGroup("cg1", &selectorA, []string{cidr}, "cg2"))
+	npc.cgStore.Add(getClusterGroup("cg2", &selectorB, nil))
+
+	peer := npc.toAntreaPeerForCRD(peers, cnp, networking.DirectionIn)
+	assert.Equal(t, []string{
+		getNormalizedUID(toGroupSelector("", &selectorA, nil, nil).NormalizedName),
+		getNormalizedUID(toGroupSelector("", &selectorB, nil, nil).NormalizedName),
+	}, peer.AddressGroups)
+	require.Len(t, peer.IPBlocks, 1)
+	assert.True(t, compareIPBlocks(&networking.IPBlock{CIDR: *cidrIPNet}, &peer.IPBlocks[0]))
+
+	// ClusterGroups cannot be referred to by Antrea NetworkPolicies.
+	peer = npc.toAntreaPeerForCRD(peers, anp, networking.DirectionIn)
+	assert.Empty(t, peer.AddressGroups)
+	assert.Empty(t, peer.IPBlocks)
+
+	// A missing ClusterGroup selects nothing.
+	peer = npc.toAntreaPeerForCRD([]secv1alpha1.NetworkPolicyPeer{{Group: "missing"}}, cnp, networking.DirectionIn)
+	assert.Empty(t, peer.AddressGroups)
+	assert.Empty(t, peer.IPBlocks)
+}
+
+func TestAddClusterGroupReprocessesCNP(t *testing.T) {
+	selectorA := metav1.LabelSelector{MatchLabels: map[string]string{"foo1": "bar1"}}
+	selectorB := metav1.LabelSelector{MatchLabels: map[string]string{"foo2": "bar2"}}
+	cnp := getCNP()
+	cnp.Spec.AppliedTo = []secv1alpha1.NetworkPolicyPeer{{Group: "cg-applied"}}
+	cnp.Spec.Ingress[0].From = []secv1alpha1.NetworkPolicyPeer{{Group: "cg-parent"}}
+	_, npc := newController()
+	npc.cnpStore.Add(cnp)
+	npc.addCNP(cnp)
+
+	key, _ := keyFunc(cnp)
+	getInternalPolicy := func() *antreatypes.NetworkPolicy {
+		obj, found, _ := npc.internalNetworkPolicyStore.Get(key)
+		require.True(t, found)
+		return obj.(*antreatypes.NetworkPolicy)
+	}
+	assert.Empty(t, getInternalPolicy().AppliedToGroups)
+	assert.Empty(t, getInternalPolicy().Rules[0].From.AddressGroups)
+
+	// Creating the ClusterGroups, including a child group, updates the policy.
+	cgApplied := getClusterGroup("cg-applied", &selectorA, nil)
+	npc.cgStore.Add(cgApplied)
+	npc.addClusterGroup(cgApplied)
+	cgParent := getClusterGroup("cg-parent", nil, nil, "cg-child")
+	npc.cgStore.Add(cgParent)
+	npc.addClusterGroup(cgParent)
+	cgChild := getClusterGroup("cg-child", &selectorB, nil)
+	npc.cgStore.Add(cgChild)
+	npc.addClusterGroup(cgChild)
+	assert.Equal(t, []string{getNormalizedUID(toGroupSelector("", &selectorA, nil, nil).NormalizedName)}, getInternalPolicy().AppliedToGroups)
+	childGroupUID := getNormalizedUID(toGroupSelector("", &selectorB, nil, nil).NormalizedName)
+	assert.Equal(t, []string{childGroupUID}, getInternalPolicy().Rules[0].From.AddressGroups)
+
+	// Deleting the child group removes its AddressGroup.
+	npc.cgStore.Delete(cgChild)
+	npc.deleteClusterGroup(cgChild)
+	assert.Empty(t, getInternalPolicy().Rules[0].From.AddressGroups)
+	_, found, _ := npc.addressGroupStore.Get(childGroupUID)
+	assert.False(t, found, "expected AddressGroup of the deleted ClusterGroup to be deleted")
+}
+
+func TestGetClusterGroupMembers(t *testing.T) {
+	selectorA := metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	cidr := "10.0.0.0/16"
+	cidrIPNet, _ := cidrStrToIPNet(cidr)
+	pod1 := getPod("pod1", "ns1", "node1", "1.1.1.1", false)
+	pod1.Labels = map[string]string{"app": "web"}
+	pod2 := getPod("pod2", "ns2", "node1", "1.1.1.2", false)
+	pod2.Labels = map[string]string{"app": "web"}
+	pod3 := getPod("pod3", "ns1", "node1", "1.1.1.3", false)
+	_, npc := newController()
+	npc.podStore.Add(pod1)
+	npc.podStore.Add(pod2)
+	npc.podStore.Add(pod3)
+	npc.cgStore.Add(getClusterGroup("cg1", nil, []string{cidr}, "cg2"))
+	npc.cgStore.Add(getClusterGroup("cg2", &selectorA, nil, "cg1"))
+
+	members, found := npc.GetClusterGroupMembers("cg1")
+	require.True(t, found)
+	assert.Equal(t, "cg1", members.Name)
+	assert.Equal(t, networking.NewGroupMemberPodSet(podToMemberPod(pod1, true, true), podToMemberPod(pod2, true, true)), networking.NewGroupMemberPodSet(toPodPointers(members.Pods)...))
+	assert.Empty(t, members.GroupMembers)
+	require.Len(t, members.IPBlocks, 1)
+	assert.True(t, compareIPBlocks(&networking.IPBlock{CIDR: *cidrIPNet}, &members.IPBlocks[0]))
+
+	_, found = npc.GetClusterGroupMembers("missing")
+	assert.False(t, found)
+}
+
+func toPodPointers(pods []networking.GroupMemberPod) []*networking.GroupMemberPod {
+	var pointers []*networking.GroupMemberPod
+	for i := range pods {
+		pointers = append(pointers, &pods[i])
+	}
+	return pointers
+}
@@ -15,14 +15,20 @@
 package networkpolicy
 
 import (
+	"fmt"
+	"net"
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog"
 
 	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
 	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
 	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+	"github.com/vmware-tanzu/antrea/pkg/k8s"
 )
 
 var (
@@ -53,13 +59,20 @@ func (n *NetworkPolicyController) addCNP(obj interface{}) {
 func (n *NetworkPolicyController) updateCNP(old, cur interface{}) {
 	defer n.heartbeat("updateCNP")
 	curCNP := cur.(*secv1alpha1.ClusterNetworkPolicy)
+	// Retrieve old secv1alpha1.ClusterNetworkPolicy object.
+	oldCNP := old.(*secv1alpha1.ClusterNetworkPolicy)
+	// Only the status or metadata was updated, e.g. when the status is
+	// reported by the controller itself. Tier updates call this function with
+	// identical objects, so they are still processed.
+	if oldCNP.ResourceVersion != curCNP.ResourceVersion && oldCNP.Generation == curCNP.Generation {
+		klog.V(4).Infof("Spec of ClusterNetworkPolicy %s was not updated, skipping", curCNP.Name)
+		return
+	}
 	klog.Infof("Processing ClusterNetworkPolicy %s UPDATE event", curCNP.Name)
 	// Update an internal NetworkPolicy, corresponding to this NetworkPolicy and
 	// enqueue task to internal NetworkPolicy Workqueue.
 	curInternalNP := n.processClusterNetworkPolicy(curCNP)
 	klog.V(2).Infof("Updating existing internal NetworkPolicy %s", curInternalNP.Name)
-	// Retrieve old secv1alpha1.NetworkPolicy object.
-	oldCNP := old.(*secv1alpha1.ClusterNetworkPolicy)
 	// Old and current NetworkPolicy share the same key.
 	key, _ := keyFunc(oldCNP)
 	// Lock access to internal NetworkPolicy store such that concurrent access
@@ -124,6 +137,8 @@ func (n *NetworkPolicyController) deleteCNP(old interface{}) {
 		n.deleteDereferencedAppliedToGroup(atg)
 	}
 	n.deleteDereferencedAddressGroups(oldInternalNP)
+	// Clean up the statuses reported by Nodes for this policy.
+	n.enqueueNetworkPolicyStatus(key)
 }
 
 // toAntreaServicesForCRD converts a secv1alpha1.NetworkPolicyPort object to an
@@ -132,8 +147,16 @@ func toAntreaServicesForCRD(npPorts []secv1alpha1.NetworkPolicyPort) []networkin
 	var antreaServices []networking.Service
 	for _, npPort := range npPorts {
 		antreaService := networking.Service{
-			Protocol: toAntreaProtocol(npPort.Protocol),
-			Port:     npPort.Port,
+			Port:       npPort.Port,
+			EndPort:    npPort.EndPort,
+			ICMPType:   npPort.ICMPType,
+			ICMPCode:   npPort.ICMPCode,
+			IPProtocol: npPort.IPProtocol,
+		}
+		// The protocol does not default to TCP when the IP protocol number
+		// is specified, as they cannot be matched together.
+		if npPort.IPProtocol == nil || npPort.Protocol != nil {
+			antreaService.Protocol = toAntreaProtocol(npPort.Protocol)
 		}
 		antreaServices = append(antreaServices, antreaService)
 	}
@@ -141,21 +164,48 @@ func toAntreaServicesForCRD(npPorts []secv1alpha1.NetworkPolicyPort) []networkin
 }
 
 // toAntreaIPBlockForCRD converts a secv1alpha1.IPBlock to an Antrea IPBlock.
+// An error is returned if any of the Except CIDRs is not contained in the CIDR.
 func toAntreaIPBlockForCRD(ipBlock *secv1alpha1.IPBlock) (*networking.IPBlock, error) {
 	// Convert the allowed IPBlock to networkpolicy.IPNet.
 	ipNet, err := cidrStrToIPNet(ipBlock.CIDR)
 	if err != nil {
 		return nil, err
 	}
+	exceptNets := []networking.IPNet{}
+	for _, exc := range ipBlock.Except {
+		// Convert the except IPBlock to networkpolicy.IPNet.
+		exceptNet, err := cidrStrToIPNet(exc)
+		if err != nil {
+			return nil, err
+		}
+		if exceptNet.PrefixLength < ipNet.PrefixLength || !ipNetContains(ipNet, net.IP(exceptNet.IP)) {
+			return nil, fmt.Errorf("except %s is not contained in CIDR %s", exc, ipBlock.CIDR)
+		}
+		exceptNets = append(exceptNets, *exceptNet)
+	}
 	antreaIPBlock := &networking.IPBlock{
-		CIDR: *ipNet,
-		// secv1alpha.IPBlock does not have the Except slices.
-		Except: []networking.IPNet{},
+		CIDR:   *ipNet,
+		Except: exceptNets,
 	}
 	return antreaIPBlock, nil
 }
 
-func (n *NetworkPolicyController) toAntreaPeerForCRD(peers []secv1alpha1.NetworkPolicyPeer, cnp *secv1alpha1.ClusterNetworkPolicy, dir networking.Direction) *networking.NetworkPolicyPeer {
+// toAntreaFQDNForCRD validates the FQDN of a secv1alpha1.NetworkPolicyPeer
+// and returns its canonical form, i.e. lower case without the trailing dot.
+// The wildcard "*" is only allowed as the leftmost label.
+func toAntreaFQDNForCRD(fqdn string) (string, error) {
+	name := strings.TrimSuffix(strings.ToLower(fqdn), ".")
+	if errs := validation.IsDNS1123Subdomain(strings.TrimPrefix(name, "*.")); len(errs) > 0 {
+		return "", fmt.Errorf("invalid FQDN: %s", strings.Join(errs, "; "))
+	}
+	return name, nil
+}
+
+// toAntreaPeerForCRD converts the secv1alpha1.NetworkPolicyPeers of an Antrea
+// policy to an Antrea NetworkPolicyPeer. np can either be a
+// secv1alpha1.ClusterNetworkPolicy or a secv1alpha1.NetworkPolicy, the latter
+// restricting Pods selected by a podSelector-only peer to its own Namespace.
+func (n *NetworkPolicyController) toAntreaPeerForCRD(peers []secv1alpha1.NetworkPolicyPeer, np metav1.Object, dir networking.Direction) *networking.NetworkPolicyPeer {
 	var addressGroups []string
 	// Empty NetworkPolicyPeer is supposed to match all addresses.
 	// It's treated as an IPBlock "0.0.0.0/0".
@@ -169,37 +219,82 @@ func (n *NetworkPolicyController) toAntreaPeerForCRD(peers []secv1alpha1.Network
 		// For an egress Peer, create an AddressGroup matching all Pods in all
 		// Namespaces such that it can be used to resolve named Ports. This
 		// AddressGroup is set in the NetworkPolicyPeer of matchAllPeer.
-		allPodsGroupUID := n.createAddressGroupForCRD(matchAllPodsPeerCrd, cnp)
+		allPodsGroupUID := n.createAddressGroupForCRD(matchAllPodsPeerCrd, np)
 		podsPeer := matchAllPeer
 		addressGroups = append(addressGroups, allPodsGroupUID)
 		podsPeer.AddressGroups = addressGroups
 		return &podsPeer
 	}
 	var ipBlocks []networking.IPBlock
+	var fqdns []string
 	for _, peer := range peers {
-		// A secv1alpha1.NetworkPolicyPeer will either have an IPBlock or a
-		// podSelector and/or namespaceSelector set.
-		if peer.IPBlock != nil {
+		// A secv1alpha1.NetworkPolicyPeer will either have a Group, an IPBlock,
+		// a FQDN, a nodeSelector, a serviceAccount or a podSelector or
+		// externalEntitySelector and/or namespaceSelector set.
+		if peer.Group != "" {
+			if np.GetNamespace() != "" {
+				klog.Errorf("Ignoring ClusterGroup %s of Antrea policy %s: ClusterGroups can only be referred to by ClusterNetworkPolicies", peer.Group, k8s.NamespacedName(np.GetNamespace(), np.GetName()))
+				continue
+			}
+			groupAddressGroups, groupIPBlocks := n.toAntreaPeerForClusterGroup(peer.Group, np)
+			addressGroups = append(addressGroups, groupAddressGroups...)
+			ipBlocks = append(ipBlocks, groupIPBlocks...)
+		} else if peer.FQDN != "" {
+			if dir != networking.DirectionOut {
+				klog.Errorf("Ignoring FQDN %s of Antrea policy %s: FQDN can only be used in egress rules", peer.FQDN, k8s.NamespacedName(np.GetNamespace(), np.GetName()))
+				continue
+			}
+			fqdn, err := toAntreaFQDNForCRD(peer.FQDN)
+			if err != nil {
+				klog.Errorf("Failure processing Antrea policy %s FQDN %s: %v", k8s.NamespacedName(np.GetNamespace(), np.GetName()), peer.FQDN, err)
+				continue
+			}
+			fqdns = append(fqdns, fqdn)
+		} else if peer.IPBlock != nil {
 			ipBlock, err := toAntreaIPBlockForCRD(peer.IPBlock)
 			if err != nil {
-				klog.Errorf("Failure processing ClusterNetworkPolicy %s IPBlock %v: %v", cnp.Name, peer.IPBlock, err)
+				klog.Errorf("Failure processing Antrea policy %s IPBlock %v: %v", k8s.NamespacedName(np.GetNamespace(), np.GetName()), peer.IPBlock, err)
 				continue
 			}
 			ipBlocks = append(ipBlocks, *ipBlock)
-		} else {
-			normalizedUID := n.createAddressGroupForCRD(peer, cnp)
+		} else if peer.NodeSelector != nil {
+			addressGroups = append(addressGroups, n.createAddressGroupForNodeSelector(peer.NodeSelector))
+		} else if peer.ServiceAccount != nil {
+			normalizedUID, err := n.createAddressGroupForServiceAccount(peer.ServiceAccount, np)
+			if err != nil {
+				klog.Errorf("Failure processing Antrea policy %s ServiceAccount %v: %v", k8s.NamespacedName(np.GetNamespace(), np.GetName()), peer.ServiceAccount, err)
+				continue
+			}
+			addressGroups = append(addressGroups, normalizedUID)
+		} else if peer.PodSelector != nil || peer.NamespaceSelector != nil || peer.ExternalEntitySelector != nil {
+			normalizedUID := n.createAddressGroupForCRD(peer, np)
 			addressGroups = append(addressGroups, normalizedUID)
 		}
 	}
-	return &networking.NetworkPolicyPeer{AddressGroups: addressGroups, IPBlocks: ipBlocks}
+	return &networking.NetworkPolicyPeer{AddressGroups: addressGroups, IPBlocks: ipBlocks, FQDNs: fqdns}
+}
+
+// toAntreaEgressPeerForCRD converts the destinations and the ports of an
+// egress rule of an Antrea-native policy. If the rule refers to Services, the
+// destinations are the AddressGroups of the Services, whose members are matched
+// with their own ports, thus no services are returned.
+func (n *NetworkPolicyController) toAntreaEgressPeerForCRD(rule secv1alpha1.Rule, np metav1.Object) (*networking.NetworkPolicyPeer, []networking.Service) {
+	if len(rule.ToServices) == 0 {
+		return n.toAntreaPeerForCRD(rule.To, np, networking.DirectionOut), toAntreaServicesForCRD(rule.Ports)
+	}
+	if len(rule.To) > 0 || len(rule.Ports) > 0 {
+		klog.Errorf("Ignoring To and Ports of an egress rule of Antrea policy %s: they cannot be set with ToServices", k8s.NamespacedName(np.GetNamespace(), np.GetName()))
+	}
+	return n.toAntreaPeerForServices(rule.ToServices, np), nil
 }
 
 // createAddressGroupForCRD creates an AddressGroup object corresponding to a
-// secv1alpha1.NetworkPolicyPeer object in Cluster NetworkPolicyRule. This
+// secv1alpha1.NetworkPolicyPeer object in an Antrea policy rule. This
 // function simply creates the object without actually populating the
-// PodAddresses as the affected Pods are calculated during sync process.
-func (n *NetworkPolicyController) createAddressGroupForCRD(peer secv1alpha1.NetworkPolicyPeer, np *secv1alpha1.ClusterNetworkPolicy) string {
-	groupSelector := toGroupSelector("", peer.PodSelector, peer.NamespaceSelector)
+// PodAddresses and GroupMembers as the affected Pods and ExternalEntities are
+// calculated during sync process.
+func (n *NetworkPolicyController) createAddressGroupForCRD(peer secv1alpha1.NetworkPolicyPeer, np metav1.Object) string {
+	groupSelector := toGroupSelector(np.GetNamespace(), peer.PodSelector, peer.NamespaceSelector, peer.ExternalEntitySelector)
 	normalizedUID := getNormalizedUID(groupSelector.NormalizedName)
 	// Get or create an AddressGroup for the generated UID.
 	_, found, _ := n.addressGroupStore.Get(normalizedUID)
@@ -228,31 +323,48 @@ func (n *NetworkPolicyController) processClusterNetworkPolicy(cnp *secv1alpha1.C
 	// Create AppliedToGroup for each AppliedTo present in
 	// ClusterNetworkPolicy spec.
 	for _, at := range cnp.Spec.AppliedTo {
-		appliedToGroupNames = append(appliedToGroupNames, n.createAppliedToGroup("", at.PodSelector, at.NamespaceSelector))
+		if at.Group != "" {
+			appliedToGroupNames = append(appliedToGroupNames, n.createAppliedToGroupsForClusterGroup(at.Group)...)
+			continue
+		}
+		if at.ServiceAccount != nil {
+			appliedToGroupUID, err := n.createAppliedToGroupForServiceAccount(at.ServiceAccount, cnp)
+			if err != nil {
+				klog.Errorf("Ignoring ServiceAccount AppliedTo of ClusterNetworkPolicy %s: %v", cnp.Name, err)
+				continue
+			}
+			appliedToGroupNames = append(appliedToGroupNames, appliedToGroupUID)
+			continue
+		}
+		appliedToGroupNames = append(appliedToGroupNames, n.createAppliedToGroup("", at.PodSelector, at.NamespaceSelector, at.ExternalEntitySelector))
 	}
 	rules := make([]networking.NetworkPolicyRule, 0, len(cnp.Spec.Ingress)+len(cnp.Spec.Egress))
 	// Compute NetworkPolicyRule for Egress Rule.
 	for idx, ingressRule := range cnp.Spec.Ingress {
 		// Set default action to ALLOW to allow traffic.
 		rules = append(rules, networking.NetworkPolicyRule{
-			Direction: networking.DirectionIn,
-			From:      *n.toAntreaPeerForCRD(ingressRule.From, cnp, networking.DirectionIn),
-			Services:  toAntreaServicesForCRD(ingressRule.Ports),
-			Action:    ingressRule.Action,
-			Priority:  int32(idx),
+			Direction:     networking.DirectionIn,
+			From:          *n.toAntreaPeerForCRD(ingressRule.From, cnp, networking.DirectionIn),
+			Services:      toAntreaServicesForCRD(ingressRule.Ports),
+			Action:        ingressRule.Action,
+			Priority:      int32(idx),
+			EnableLogging: ingressRule.EnableLogging,
 		})
 	}
 	// Compute NetworkPolicyRule for Egress Rule.
 	for idx, egressRule := range cnp.Spec.Egress {
 		// Set default action to ALLOW to allow traffic.
+		to, services := n.toAntreaEgressPeerForCRD(egressRule, cnp)
 		rules = append(rules, networking.NetworkPolicyRule{
-			Direction: networking.DirectionOut,
-			To:        *n.toAntreaPeerForCRD(egressRule.To, cnp, networking.DirectionOut),
-			Services:  toAntreaServicesForCRD(egressRule.Ports),
-			Action:    egressRule.Action,
-			Priority:  int32(idx),
+			Direction:     networking.DirectionOut,
+			To:            *to,
+			Services:      services,
+			Action:        egressRule.Action,
+			Priority:      int32(idx),
+			EnableLogging: egressRule.EnableLogging,
 		})
 	}
+	tierPriority := n.getTierPriority(cnp.Spec.Tier)
 	internalNetworkPolicy := &antreatypes.NetworkPolicy{
 		Name:            cnp.Name,
 		Namespace:       "",
@@ -260,6 +372,8 @@ func (n *NetworkPolicyController) processClusterNetworkPolicy(cnp *secv1alpha1.C
 		AppliedToGroups: appliedToGroupNames,
 		Rules:           rules,
 		Priority:        &cnp.Spec.Priority,
+		TierPriority:    &tierPriority,
+		Generation:      cnp.Generation,
 	}
 	return internalNetworkPolicy
 }
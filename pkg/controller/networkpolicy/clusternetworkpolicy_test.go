@@ -33,6 +33,12 @@ import (
 func TestToAntreaServicesForCRD(t *testing.T) {
 	tcpProto := v1.ProtocolTCP
 	portNum := intstr.FromInt(80)
+	portName := intstr.FromString("http")
+	endPort := int32(90)
+	icmpProto := v1.Protocol("ICMP")
+	icmpType := int32(8)
+	icmpCode := int32(0)
+	greProtocol := int32(47)
 	tables := []struct {
 		ports     []secv1alpha1.NetworkPolicyPort
 		expValues []networking.Service
@@ -46,16 +52,52 @@ func TestToAntreaServicesForCRD(t *testing.T) {
 				},
 			},
 		},
+		{
+			// Named ports are kept as is and resolved by antrea-agent for
+			// each member of the groups.
+			[]secv1alpha1.NetworkPolicyPort{{Port: &portName}},
+			[]networking.Service{
+				{
+					Protocol: toAntreaProtocol(nil),
+					Port:     &portName,
+				},
+			},
+		},
+		{
+			[]secv1alpha1.NetworkPolicyPort{{Protocol: &tcpProto, Port: &portNum, EndPort: &endPort}},
+			[]networking.Service{
+				{
+					Protocol: toAntreaProtocol(&tcpProto),
+					Port:     &portNum,
+					EndPort:  &endPort,
+				},
+			},
+		},
+		{
+			[]secv1alpha1.NetworkPolicyPort{{Protocol: &icmpProto, ICMPType: &icmpType, ICMPCode: &icmpCode}},
+			[]networking.Service{
+				{
+					Protocol: toAntreaProtocol(&icmpProto),
+					ICMPType: &icmpType,
+					ICMPCode: &icmpCode,
+				},
+			},
+		},
+		{
+			// The protocol must not default to TCP when the IP protocol
+			// number is specified.
+			[]secv1alpha1.NetworkPolicyPort{{IPProtocol: &greProtocol}},
+			[]networking.Service{
+				{
+					IPProtocol: &greProtocol,
+				},
+			},
+		},
 	}
 	for _, table := range tables {
 		services := toAntreaServicesForCRD(table.ports)
-		service := services[0]
-		expValue := table.expValues[0]
-		if *service.Protocol != *expValue.Protocol {
-			t.Errorf("Unexpected Antrea Protocol in Antrea Service. Expected %v, got %v", *expValue.Protocol, *service.Protocol)
-		}
-		if *service.Port != *expValue.Port {
-			t.Errorf("Unexpected Antrea Port in Antrea Service. Expected %v, got %v", *expValue.Port, *service.Port)
+		if !reflect.DeepEqual(services, table.expValues) {
+			t.Errorf("Unexpected Antrea Services. Expected %v, got %v", table.expValues, services)
 		}
 	}
 }
@@ -86,6 +128,33 @@ func TestToAntreaIPBlockForCRD(t *testing.T) {
 			networking.IPBlock{},
 			fmt.Errorf("invalid format for IPBlock CIDR: 10.0.0.0"),
 		},
+		{
+			&secv1alpha1.IPBlock{
+				CIDR:   "10.0.0.0/24",
+				Except: []string{"10.0.0.128/25"},
+			},
+			networking.IPBlock{
+				CIDR:   expIPNet,
+				Except: []networking.IPNet{{IP: ipStrToIPAddress("10.0.0.128"), PrefixLength: 25}},
+			},
+			nil,
+		},
+		{
+			&secv1alpha1.IPBlock{
+				CIDR:   "10.0.0.0/24",
+				Except: []string{"10.0.1.0/25"},
+			},
+			networking.IPBlock{},
+			fmt.Errorf("except 10.0.1.0/25 is not contained in CIDR 10.0.0.0/24"),
+		},
+		{
+			&secv1alpha1.IPBlock{
+				CIDR:   "10.0.0.0/24",
+				Except: []string{"10.0.0.0/16"},
+			},
+			networking.IPBlock{},
+			fmt.Errorf("except 10.0.0.0/16 is not contained in CIDR 10.0.0.0/24"),
+		},
 	}
 	for _, table := range tables {
 		antreaIPBlock, err := toAntreaIPBlockForCRD(table.ipBlock)
@@ -104,6 +173,15 @@ func TestToAntreaIPBlockForCRD(t *testing.T) {
 		if table.expValue.CIDR.PrefixLength != ipNet.PrefixLength {
 			t.Errorf("Unexpected PrefixLength in Antrea IPBlock conversion. Expected %v, got %v", table.expValue.CIDR.PrefixLength, ipNet.PrefixLength)
 		}
+		if len(table.expValue.Except) != len(antreaIPBlock.Except) {
+			t.Errorf("Unexpected Except in Antrea IPBlock conversion. Expected %v, got %v", table.expValue.Except, antreaIPBlock.Except)
+			continue
+		}
+		for i, exceptNet := range antreaIPBlock.Except {
+			if bytes.Compare(exceptNet.IP, table.expValue.Except[i].IP) != 0 || exceptNet.PrefixLength != table.expValue.Except[i].PrefixLength {
+				t.Errorf("Unexpected Except in Antrea IPBlock conversion. Expected %v, got %v", table.expValue.Except[i], exceptNet)
+			}
+		}
 	}
 }
 
@@ -121,7 +199,7 @@ func TestToAntreaPeerForCRD(t *testing.T) {
 	selectorC := metav1.LabelSelector{MatchLabels: map[string]string{"foo3": "bar3"}}
 	selectorAll := metav1.LabelSelector{}
 	matchAllPodsPeer := matchAllPeer
-	matchAllPodsPeer.AddressGroups = []string{getNormalizedUID(toGroupSelector("", nil, &selectorAll).NormalizedName)}
+	matchAllPodsPeer.AddressGroups = []string{getNormalizedUID(toGroupSelector("", nil, &selectorAll, nil).NormalizedName)}
 	tests := []struct {
 		name      string
 		inPeers   []secv1alpha1.NetworkPolicyPeer
@@ -141,8 +219,8 @@ func TestToAntreaPeerForCRD(t *testing.T) {
 			},
 			outPeer: networking.NetworkPolicyPeer{
 				AddressGroups: []string{
-					getNormalizedUID(toGroupSelector("", &selectorA, &selectorB).NormalizedName),
-					getNormalizedUID(toGroupSelector("", &selectorC, nil).NormalizedName),
+					getNormalizedUID(toGroupSelector("", &selectorA, &selectorB, nil).NormalizedName),
+					getNormalizedUID(toGroupSelector("", &selectorC, nil, nil).NormalizedName),
 				},
 			},
 			direction: networking.DirectionIn,
@@ -160,12 +238,24 @@ func TestToAntreaPeerForCRD(t *testing.T) {
 			},
 			outPeer: networking.NetworkPolicyPeer{
 				AddressGroups: []string{
-					getNormalizedUID(toGroupSelector("", &selectorA, &selectorB).NormalizedName),
-					getNormalizedUID(toGroupSelector("", &selectorC, nil).NormalizedName),
+					getNormalizedUID(toGroupSelector("", &selectorA, &selectorB, nil).NormalizedName),
+					getNormalizedUID(toGroupSelector("", &selectorC, nil, nil).NormalizedName),
 				},
 			},
 			direction: networking.DirectionOut,
 		},
+		{
+			name: "node-selector-peer-ingress",
+			inPeers: []secv1alpha1.NetworkPolicyPeer{
+				{
+					NodeSelector: &selectorA,
+				},
+			},
+			outPeer: networking.NetworkPolicyPeer{
+				AddressGroups: []string{getNormalizedUID(toNodeGroupSelector(&selectorA).NormalizedName)},
+			},
+			direction: networking.DirectionIn,
+		},
 		{
 			name: "ipblock-selector-peer-ingress",
 			inPeers: []secv1alpha1.NetworkPolicyPeer{
@@ -198,6 +288,34 @@ func TestToAntreaPeerForCRD(t *testing.T) {
 			},
 			direction: networking.DirectionOut,
 		},
+		{
+			name: "fqdn-peer-egress",
+			inPeers: []secv1alpha1.NetworkPolicyPeer{
+				{
+					FQDN: "www.Example.com.",
+				},
+				{
+					FQDN: "*.example.com",
+				},
+				{
+					FQDN: "invalid_name.example.com",
+				},
+			},
+			outPeer: networking.NetworkPolicyPeer{
+				FQDNs: []string{"www.example.com", "*.example.com"},
+			},
+			direction: networking.DirectionOut,
+		},
+		{
+			name: "fqdn-peer-ingress",
+			inPeers: []secv1alpha1.NetworkPolicyPeer{
+				{
+					FQDN: "www.example.com",
+				},
+			},
+			outPeer:   networking.NetworkPolicyPeer{},
+			direction: networking.DirectionIn,
+		},
 		{
 			name:      "empty-peer-ingress",
 			inPeers:   []secv1alpha1.NetworkPolicyPeer{},
@@ -226,12 +344,14 @@ func TestToAntreaPeerForCRD(t *testing.T) {
 					t.Errorf("Unexpected IPBlocks in Antrea Peer conversion. Expected %v, got %v", tt.outPeer.IPBlocks[i], (*actualPeer).IPBlocks[i])
 				}
 			}
+			assert.Equal(t, tt.outPeer.FQDNs, (*actualPeer).FQDNs)
 		})
 	}
 }
 
 func TestProcessClusterNetworkPolicy(t *testing.T) {
 	p10 := float64(10)
+	defaultTierPriority := DefaultTierPriority
 	allowAction := secv1alpha1.RuleActionAllow
 	protocolTCP := networking.ProtocolTCP
 	intstr80, intstr81 := intstr.FromInt(80), intstr.FromInt(81)
@@ -289,15 +409,16 @@ func TestProcessClusterNetworkPolicy(t *testing.T) {
 				},
 			},
 			expectedPolicy: &antreatypes.NetworkPolicy{
-				UID:       "uidA",
-				Name:      "cnpA",
-				Namespace: "",
-				Priority:  &p10,
+				UID:          "uidA",
+				Name:         "cnpA",
+				Namespace:    "",
+				Priority:     &p10,
+				TierPriority: &defaultTierPriority,
 				Rules: []networking.NetworkPolicyRule{
 					{
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("", &selectorB, &selectorC).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("", &selectorB, &selectorC, nil).NormalizedName)},
 						},
 						Services: []networking.Service{
 							{
@@ -311,7 +432,7 @@ func TestProcessClusterNetworkPolicy(t *testing.T) {
 					{
 						Direction: networking.DirectionOut,
 						To: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("", &selectorB, &selectorC).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("", &selectorB, &selectorC, nil).NormalizedName)},
 						},
 						Services: []networking.Service{
 							{
@@ -323,7 +444,7 @@ func TestProcessClusterNetworkPolicy(t *testing.T) {
 						Action:   &allowAction,
 					},
 				},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("", &selectorA, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("", &selectorA, nil, nil).NormalizedName)},
 			},
 			expectedAppliedToGroups: 1,
 			expectedAddressGroups:   1,
@@ -368,15 +489,16 @@ func TestProcessClusterNetworkPolicy(t *testing.T) {
 				},
 			},
 			expectedPolicy: &antreatypes.NetworkPolicy{
-				UID:       "uidA",
-				Name:      "cnpA",
-				Namespace: "",
-				Priority:  &p10,
+				UID:          "uidA",
+				Name:         "cnpA",
+				Namespace:    "",
+				Priority:     &p10,
+				TierPriority: &defaultTierPriority,
 				Rules: []networking.NetworkPolicyRule{
 					{
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("", &selectorB, nil).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("", &selectorB, nil, nil).NormalizedName)},
 						},
 						Services: []networking.Service{
 							{
@@ -390,7 +512,7 @@ func TestProcessClusterNetworkPolicy(t *testing.T) {
 					{
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("", nil, &selectorC).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("", nil, &selectorC, nil).NormalizedName)},
 						},
 						Services: []networking.Service{
 							{
@@ -402,7 +524,7 @@ func TestProcessClusterNetworkPolicy(t *testing.T) {
 						Action:   &allowAction,
 					},
 				},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("", &selectorA, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("", &selectorA, nil, nil).NormalizedName)},
 			},
 			expectedAppliedToGroups: 1,
 			expectedAddressGroups:   2,
@@ -429,6 +551,7 @@ func TestProcessClusterNetworkPolicy(t *testing.T) {
 
 func TestAddCNP(t *testing.T) {
 	p10 := float64(10)
+	defaultTierPriority := DefaultTierPriority
 	allowAction := secv1alpha1.RuleActionAllow
 	protocolTCP := networking.ProtocolTCP
 	intstr80, intstr81 := intstr.FromInt(80), intstr.FromInt(81)
@@ -438,7 +561,7 @@ func TestAddCNP(t *testing.T) {
 	selectorC := metav1.LabelSelector{MatchLabels: map[string]string{"foo3": "bar3"}}
 	selectorAll := metav1.LabelSelector{}
 	matchAllPeerEgress := matchAllPeer
-	matchAllPeerEgress.AddressGroups = []string{getNormalizedUID(toGroupSelector("", nil, &selectorAll).NormalizedName)}
+	matchAllPeerEgress.AddressGroups = []string{getNormalizedUID(toGroupSelector("", nil, &selectorAll, nil).NormalizedName)}
 	tests := []struct {
 		name               string
 		inputPolicy        *secv1alpha1.ClusterNetworkPolicy
@@ -490,15 +613,16 @@ func TestAddCNP(t *testing.T) {
 				},
 			},
 			expPolicy: &antreatypes.NetworkPolicy{
-				UID:       "uidE",
-				Name:      "npE",
-				Namespace: "",
-				Priority:  &p10,
+				UID:          "uidE",
+				Name:         "npE",
+				Namespace:    "",
+				Priority:     &p10,
+				TierPriority: &defaultTierPriority,
 				Rules: []networking.NetworkPolicyRule{
 					{
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("", &selectorB, &selectorC).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("", &selectorB, &selectorC, nil).NormalizedName)},
 						},
 						Services: []networking.Service{
 							{
@@ -512,7 +636,7 @@ func TestAddCNP(t *testing.T) {
 					{
 						Direction: networking.DirectionOut,
 						To: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("", &selectorB, &selectorC).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("", &selectorB, &selectorC, nil).NormalizedName)},
 						},
 						Services: []networking.Service{
 							{
@@ -524,7 +648,7 @@ func TestAddCNP(t *testing.T) {
 						Action:   &allowAction,
 					},
 				},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("", &selectorA, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("", &selectorA, nil, nil).NormalizedName)},
 			},
 			expAppliedToGroups: 1,
 			expAddressGroups:   1,
@@ -569,15 +693,16 @@ func TestAddCNP(t *testing.T) {
 				},
 			},
 			expPolicy: &antreatypes.NetworkPolicy{
-				UID:       "uidF",
-				Name:      "npF",
-				Namespace: "",
-				Priority:  &p10,
+				UID:          "uidF",
+				Name:         "npF",
+				Namespace:    "",
+				Priority:     &p10,
+				TierPriority: &defaultTierPriority,
 				Rules: []networking.NetworkPolicyRule{
 					{
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("", &selectorB, nil).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("", &selectorB, nil, nil).NormalizedName)},
 						},
 						Services: []networking.Service{
 							{
@@ -591,7 +716,7 @@ func TestAddCNP(t *testing.T) {
 					{
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("", nil, &selectorC).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("", nil, &selectorC, nil).NormalizedName)},
 						},
 						Services: []networking.Service{
 							{
@@ -603,7 +728,7 @@ func TestAddCNP(t *testing.T) {
 						Action:   &allowAction,
 					},
 				},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("", &selectorA, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("", &selectorA, nil, nil).NormalizedName)},
 			},
 			expAppliedToGroups: 1,
 			expAddressGroups:   2,
@@ -641,7 +766,7 @@ func TestAddCNP(t *testing.T) {
 func TestDeleteCNP(t *testing.T) {
 	selectorA := metav1.LabelSelector{MatchLabels: map[string]string{"foo1": "bar1"}}
 	cnpObj := getCNP()
-	apgID := getNormalizedUID(toGroupSelector("", &selectorA, nil).NormalizedName)
+	apgID := getNormalizedUID(toGroupSelector("", &selectorA, nil, nil).NormalizedName)
 	_, npc := newController()
 	npc.addCNP(cnpObj)
 	npc.deleteCNP(cnpObj)
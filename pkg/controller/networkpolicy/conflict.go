@@ -0,0 +1,378 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/handlers/policyconflict"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+)
+
+const (
+	// policyConflictReportInterval is the interval at which the conflicts
+	// between Antrea-native policies are analyzed and reported as Events.
+	policyConflictReportInterval = time.Minute
+	policyConflictEventComponent = "antrea-controller"
+)
+
+// policyConflictEventReasons maps the types of conflicts to the reasons of the
+// Events reporting them.
+var policyConflictEventReasons = map[policyconflict.ConflictType]string{
+	policyconflict.Shadowed:  "ShadowedRule",
+	policyconflict.Redundant: "RedundantRule",
+	policyconflict.Overlap:   "ConflictingRules",
+}
+
+// policyConflict is a conflict between two rules of Antrea-native policies.
+// by is evaluated before rule.
+type policyConflict struct {
+	conflictType policyconflict.ConflictType
+	rule         *queryRule
+	by           *queryRule
+	message      string
+}
+
+func (c *policyConflict) toResponse() policyconflict.Response {
+	return policyconflict.Response{
+		Type:    c.conflictType,
+		Rule:    c.rule.toConflictRule(),
+		By:      c.by.toConflictRule(),
+		Message: c.message,
+	}
+}
+
+func (r *queryRule) toConflictRule() policyconflict.Rule {
+	return policyconflict.Rule{
+		PolicyType: r.policy.policyType(),
+		Namespace:  r.policy.Namespace,
+		Name:       r.policy.Name,
+		Direction:  directionNames[r.rule.Direction],
+		Index:      r.index,
+		Action:     string(r.action()),
+	}
+}
+
+// AnalyzePolicyConflicts analyzes the rules of the internal Antrea-native
+// policies and returns the rules which never take effect because the traffic
+// they select is matched first by another rule, and the rules which select
+// the same traffic as another rule with an opposite action.
+func (n *NetworkPolicyController) AnalyzePolicyConflicts() []policyconflict.Response {
+	conflicts := n.analyzePolicyConflicts()
+	responses := make([]policyconflict.Response, 0, len(conflicts))
+	for _, c := range conflicts {
+		responses = append(responses, c.toResponse())
+	}
+	return responses
+}
+
+// analyzePolicyConflicts compares each rule of the Antrea-native policies with
+// the rules of the same direction evaluated before it, and returns at most
+// one conflict per rule: the one with the first rule covering all of its
+// traffic. The analysis relies on the selectors of the rules rather than on
+// the current members of the groups, so that the conflicts don't depend on the
+// workloads running at the time of the analysis. As a consequence, two rules
+// selecting the same workloads with different selectors are not considered to
+// be conflicting. K8s NetworkPolicies are not analyzed as their rules don't
+// have priorities.
+func (n *NetworkPolicyController) analyzePolicyConflicts() []*policyConflict {
+	rulesByDirection := map[networking.Direction][]*queryRule{}
+	for _, obj := range n.internalNetworkPolicyStore.List() {
+		internalNP := obj.(*antreatypes.NetworkPolicy)
+		if internalNP.Priority == nil {
+			continue
+		}
+		policy := &queryPolicy{
+			NetworkPolicy:       internalNP,
+			addressGroupStore:   n.addressGroupStore,
+			appliedToGroupStore: n.appliedToGroupStore,
+		}
+		for i := range internalNP.Rules {
+			rule := &internalNP.Rules[i]
+			rulesByDirection[rule.Direction] = append(rulesByDirection[rule.Direction], &queryRule{policy: policy, rule: rule, index: rule.Priority})
+		}
+	}
+	var conflicts []*policyConflict
+	for _, direction := range []networking.Direction{networking.DirectionIn, networking.DirectionOut} {
+		rules := rulesByDirection[direction]
+		sortAntreaQueryRules(rules)
+		for i, rule := range rules {
+			for _, by := range rules[:i] {
+				if c := getPolicyConflict(rule, by); c != nil {
+					conflicts = append(conflicts, c)
+					break
+				}
+			}
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].message < conflicts[j].message
+	})
+	return conflicts
+}
+
+// getPolicyConflict returns the conflict between rule and by, which is
+// evaluated before rule, or nil if by doesn't cover all the traffic selected
+// by rule.
+func getPolicyConflict(rule, by *queryRule) *policyConflict {
+	if !queryRuleCovers(by, rule) {
+		return nil
+	}
+	c := &policyConflict{rule: rule, by: by}
+	action, byAction := rule.action(), by.action()
+	switch {
+	case action == byAction:
+		c.conflictType = policyconflict.Redundant
+		c.message = fmt.Sprintf("%s is redundant as all its traffic is matched first by %s with the same action", rule, by)
+	case isAllowDropPair(action, byAction) && queryRuleCovers(rule, by):
+		c.conflictType = policyconflict.Overlap
+		c.message = fmt.Sprintf("%s and %s select the same traffic with opposite actions, %s takes precedence", rule, by, by)
+	default:
+		c.conflictType = policyconflict.Shadowed
+		c.message = fmt.Sprintf("%s is shadowed as all its traffic is matched first by %s, which %s it", rule, by, actionVerbs[byAction])
+	}
+	return c
+}
+
+// isAllowDropPair returns true if one of the actions allows the traffic and
+// the other one drops or rejects it.
+func isAllowDropPair(a, b secv1alpha1.RuleAction) bool {
+	isDrop := func(action secv1alpha1.RuleAction) bool {
+		return action == secv1alpha1.RuleActionDrop || action == secv1alpha1.RuleActionReject
+	}
+	return (a == secv1alpha1.RuleActionAllow && isDrop(b)) || (b == secv1alpha1.RuleActionAllow && isDrop(a))
+}
+
+// queryRuleCovers returns true if all the traffic selected by rule b is also
+// selected by rule a, i.e. if a applies to all the workloads b applies to, and
+// if the peers and the services of a include the ones of b.
+func queryRuleCovers(a, b *queryRule) bool {
+	if !sets.NewString(a.policy.AppliedToGroups...).IsSuperset(sets.NewString(b.policy.AppliedToGroups...)) {
+		return false
+	}
+	aPeer, bPeer := &a.rule.From, &b.rule.From
+	if a.rule.Direction == networking.DirectionOut {
+		aPeer, bPeer = &a.rule.To, &b.rule.To
+	}
+	return peerCovers(aPeer, bPeer) && servicesCover(a.rule.Services, b.rule.Services)
+}
+
+// peerCovers returns true if all the addresses selected by peer b are also
+// selected by peer a. A peer with an IPBlock matching all IPv4 addresses
+// covers any other peer.
+func peerCovers(a, b *networking.NetworkPolicyPeer) bool {
+	for i := range a.IPBlocks {
+		if a.IPBlocks[i].CIDR.PrefixLength == 0 && len(a.IPBlocks[i].Except) == 0 && net.IP(a.IPBlocks[i].CIDR.IP).To4() != nil {
+			return true
+		}
+	}
+	if !sets.NewString(a.AddressGroups...).IsSuperset(sets.NewString(b.AddressGroups...)) {
+		return false
+	}
+	if !sets.NewString(a.FQDNs...).IsSuperset(sets.NewString(b.FQDNs...)) {
+		return false
+	}
+	for i := range b.IPBlocks {
+		covered := false
+		for j := range a.IPBlocks {
+			if ipBlockCovers(&a.IPBlocks[j], &b.IPBlocks[i]) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// ipBlockCovers returns true if all the addresses of IPBlock b are also
+// addresses of IPBlock a. Each Except CIDR of a overlapping with the CIDR of b
+// must be contained in an Except CIDR of b.
+func ipBlockCovers(a, b *networking.IPBlock) bool {
+	if !ipNetContainsIPNet(&a.CIDR, &b.CIDR) {
+		return false
+	}
+	for i := range a.Except {
+		aExcept := &a.Except[i]
+		if !ipNetContainsIPNet(&b.CIDR, aExcept) && !ipNetContainsIPNet(aExcept, &b.CIDR) {
+			continue
+		}
+		excepted := false
+		for j := range b.Except {
+			if ipNetContainsIPNet(&b.Except[j], aExcept) {
+				excepted = true
+				break
+			}
+		}
+		if !excepted {
+			return false
+		}
+	}
+	return true
+}
+
+// ipNetContainsIPNet returns true if the CIDR inner is contained in the CIDR
+// outer.
+func ipNetContainsIPNet(outer, inner *networking.IPNet) bool {
+	return outer.PrefixLength <= inner.PrefixLength && ipNetContains(outer, net.IP(inner.IP))
+}
+
+// servicesCover returns true if all the traffic matched by the services b is
+// also matched by the services a. No service means all the traffic.
+func servicesCover(a, b []networking.Service) bool {
+	if len(a) == 0 {
+		return true
+	}
+	if len(b) == 0 {
+		return false
+	}
+	for i := range b {
+		covered := false
+		for j := range a {
+			if serviceCovers(&a[j], &b[i]) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// serviceCovers returns true if all the traffic matched by service b is also
+// matched by service a.
+func serviceCovers(a, b *networking.Service) bool {
+	if (a.IPProtocol == nil) != (b.IPProtocol == nil) {
+		return false
+	}
+	if a.IPProtocol != nil {
+		return *a.IPProtocol == *b.IPProtocol
+	}
+	if serviceProtocol(a) != serviceProtocol(b) {
+		return false
+	}
+	if a.ICMPType != nil && (b.ICMPType == nil || *a.ICMPType != *b.ICMPType) {
+		return false
+	}
+	if a.ICMPCode != nil && (b.ICMPCode == nil || *a.ICMPCode != *b.ICMPCode) {
+		return false
+	}
+	if a.Port == nil {
+		return true
+	}
+	if b.Port == nil || a.Port.Type != b.Port.Type {
+		return false
+	}
+	if a.Port.Type == intstr.String {
+		return a.Port.StrVal == b.Port.StrVal
+	}
+	aStart, aEnd := portRange(a)
+	bStart, bEnd := portRange(b)
+	return aStart <= bStart && bEnd <= aEnd
+}
+
+func serviceProtocol(s *networking.Service) networking.Protocol {
+	if s.Protocol == nil {
+		return networking.ProtocolTCP
+	}
+	return *s.Protocol
+}
+
+// portRange returns the range of the numerical port of a service.
+func portRange(s *networking.Service) (int32, int32) {
+	if s.EndPort == nil {
+		return s.Port.IntVal, s.Port.IntVal
+	}
+	return s.Port.IntVal, *s.EndPort
+}
+
+// PolicyConflictReporter periodically analyzes the rules of the Antrea-native
+// policies and reports the conflicts as Events on the policies of the
+// offending rules.
+type PolicyConflictReporter struct {
+	networkPolicyController *NetworkPolicyController
+	recorder                record.EventRecorder
+	// reported contains the messages of the conflicts which have been
+	// reported and still exist, so that each conflict generates a single
+	// Event. A conflict which disappears is reported again if it comes back.
+	reported sets.String
+}
+
+// NewPolicyConflictReporter returns a new *PolicyConflictReporter.
+func NewPolicyConflictReporter(kubeClient kubernetes.Interface, networkPolicyController *NetworkPolicyController) *PolicyConflictReporter {
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: policyConflictEventComponent})
+	return &PolicyConflictReporter{
+		networkPolicyController: networkPolicyController,
+		recorder:                recorder,
+		reported:                sets.NewString(),
+	}
+}
+
+// Run analyzes and reports the conflicts between the rules of the
+// Antrea-native policies periodically until stopCh is closed.
+func (r *PolicyConflictReporter) Run(stopCh <-chan struct{}) {
+	klog.Info("Starting policy conflict reporter")
+	defer klog.Info("Shutting down policy conflict reporter")
+	wait.Until(r.report, policyConflictReportInterval, stopCh)
+}
+
+func (r *PolicyConflictReporter) report() {
+	current := sets.NewString()
+	for _, c := range r.networkPolicyController.analyzePolicyConflicts() {
+		current.Insert(c.message)
+		if r.reported.Has(c.message) {
+			continue
+		}
+		r.recorder.Event(policyObjectReference(c.rule.policy), v1.EventTypeWarning, policyConflictEventReasons[c.conflictType], c.message)
+	}
+	r.reported = current
+}
+
+// policyObjectReference returns the reference to the Antrea-native policy an
+// internal NetworkPolicy is created for.
+func policyObjectReference(policy *queryPolicy) *v1.ObjectReference {
+	kind := "NetworkPolicy"
+	if policy.policyType() == clusterNetworkPolicyType {
+		kind = "ClusterNetworkPolicy"
+	}
+	return &v1.ObjectReference{
+		Kind:       kind,
+		APIVersion: secv1alpha1.SchemeGroupVersion.String(),
+		Namespace:  policy.Namespace,
+		Name:       policy.Name,
+		UID:        policy.UID,
+	}
+}
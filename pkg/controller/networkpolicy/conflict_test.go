@@ -0,0 +1,144 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/handlers/policyconflict"
+)
+
+func TestAnalyzePolicyConflicts(t *testing.T) {
+	_, npc := newController()
+	allowAction := secv1alpha1.RuleActionAllow
+	dropAction := secv1alpha1.RuleActionDrop
+	protocolTCP := v1.ProtocolTCP
+	int80 := intstr.FromInt(80)
+	int5432 := intstr.FromInt(5432)
+	appliedTo := []secv1alpha1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}}}
+	dbPeer := []secv1alpha1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}}}
+	npc.addCNP(&secv1alpha1.ClusterNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cnp1", UID: "uid1"},
+		Spec: secv1alpha1.ClusterNetworkPolicySpec{
+			Priority:  1,
+			AppliedTo: appliedTo,
+			Ingress: []secv1alpha1.Rule{{
+				Action: &dropAction,
+				From:   []secv1alpha1.NetworkPolicyPeer{{IPBlock: &secv1alpha1.IPBlock{CIDR: "10.0.0.0/16"}}},
+			}},
+			Egress: []secv1alpha1.Rule{{
+				Action: &allowAction,
+				To:     dbPeer,
+				Ports:  []secv1alpha1.NetworkPolicyPort{{Protocol: &protocolTCP, Port: &int5432}},
+			}},
+		},
+	})
+	npc.addCNP(&secv1alpha1.ClusterNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cnp2", UID: "uid2"},
+		Spec: secv1alpha1.ClusterNetworkPolicySpec{
+			Priority:  5,
+			AppliedTo: appliedTo,
+			Ingress: []secv1alpha1.Rule{
+				{
+					Action: &allowAction,
+					From:   []secv1alpha1.NetworkPolicyPeer{{IPBlock: &secv1alpha1.IPBlock{CIDR: "10.0.1.0/24"}}},
+					Ports:  []secv1alpha1.NetworkPolicyPort{{Protocol: &protocolTCP, Port: &int80}},
+				},
+				{
+					Action: &dropAction,
+					From:   []secv1alpha1.NetworkPolicyPeer{{IPBlock: &secv1alpha1.IPBlock{CIDR: "10.0.0.0/16"}}},
+				},
+				{
+					// Not covered as 10.1.0.0/24 is not contained in 10.0.0.0/16.
+					Action: &allowAction,
+					From:   []secv1alpha1.NetworkPolicyPeer{{IPBlock: &secv1alpha1.IPBlock{CIDR: "10.1.0.0/24"}}},
+				},
+			},
+			Egress: []secv1alpha1.Rule{{
+				Action: &dropAction,
+				To:     dbPeer,
+				Ports:  []secv1alpha1.NetworkPolicyPort{{Protocol: &protocolTCP, Port: &int5432}},
+			}},
+		},
+	})
+
+	cnp1Ingress := policyconflict.Rule{PolicyType: clusterNetworkPolicyType, Name: "cnp1", Direction: "Ingress", Index: 0, Action: "Drop"}
+	cnp1Egress := policyconflict.Rule{PolicyType: clusterNetworkPolicyType, Name: "cnp1", Direction: "Egress", Index: 0, Action: "Allow"}
+	conflicts := npc.AnalyzePolicyConflicts()
+	require.Len(t, conflicts, 3)
+	var types []policyconflict.ConflictType
+	for _, c := range conflicts {
+		types = append(types, c.Type)
+		switch c.Type {
+		case policyconflict.Shadowed:
+			assert.Equal(t, policyconflict.Rule{PolicyType: clusterNetworkPolicyType, Name: "cnp2", Direction: "Ingress", Index: 0, Action: "Allow"}, c.Rule)
+			assert.Equal(t, cnp1Ingress, c.By)
+		case policyconflict.Redundant:
+			assert.Equal(t, policyconflict.Rule{PolicyType: clusterNetworkPolicyType, Name: "cnp2", Direction: "Ingress", Index: 1, Action: "Drop"}, c.Rule)
+			assert.Equal(t, cnp1Ingress, c.By)
+		case policyconflict.Overlap:
+			assert.Equal(t, policyconflict.Rule{PolicyType: clusterNetworkPolicyType, Name: "cnp2", Direction: "Egress", Index: 0, Action: "Drop"}, c.Rule)
+			assert.Equal(t, cnp1Egress, c.By)
+		}
+	}
+	assert.ElementsMatch(t, []policyconflict.ConflictType{policyconflict.Shadowed, policyconflict.Redundant, policyconflict.Overlap}, types)
+
+	// Each conflict is reported once, as long as it exists.
+	recorder := record.NewFakeRecorder(10)
+	reporter := &PolicyConflictReporter{networkPolicyController: npc, recorder: recorder, reported: sets.NewString()}
+	reporter.report()
+	assert.Len(t, recorder.Events, 3)
+	reporter.report()
+	assert.Len(t, recorder.Events, 3)
+}
+
+func TestServiceCovers(t *testing.T) {
+	protocolTCP := networking.ProtocolTCP
+	protocolUDP := networking.ProtocolUDP
+	int80 := intstr.FromInt(80)
+	int85 := intstr.FromInt(85)
+	int90 := int32(90)
+	int100 := int32(100)
+	http := intstr.FromString("http")
+	tests := []struct {
+		name     string
+		a        networking.Service
+		b        networking.Service
+		expected bool
+	}{
+		{"any-port", networking.Service{Protocol: &protocolTCP}, networking.Service{Port: &int80}, true},
+		{"different-protocol", networking.Service{Protocol: &protocolUDP}, networking.Service{Port: &int80}, false},
+		{"port-in-range", networking.Service{Port: &int80, EndPort: &int90}, networking.Service{Port: &int85}, true},
+		{"range-not-contained", networking.Service{Port: &int80, EndPort: &int90}, networking.Service{Port: &int85, EndPort: &int100}, false},
+		{"named-port", networking.Service{Port: &http}, networking.Service{Port: &http}, true},
+		{"named-and-numbered-port", networking.Service{Port: &http}, networking.Service{Port: &int80}, false},
+		{"port-and-any-port", networking.Service{Port: &int80}, networking.Service{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, serviceCovers(&tt.a, &tt.b))
+		})
+	}
+}
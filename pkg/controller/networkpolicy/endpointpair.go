@@ -0,0 +1,579 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/handlers/endpointpair"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/storage"
+	"github.com/vmware-tanzu/antrea/pkg/controller/metrics"
+	"github.com/vmware-tanzu/antrea/pkg/controller/networkpolicy/store"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+	"github.com/vmware-tanzu/antrea/pkg/k8s"
+)
+
+const (
+	k8sNetworkPolicyType     = "K8sNetworkPolicy"
+	clusterNetworkPolicyType = "ClusterNetworkPolicy"
+	antreaNetworkPolicyType  = "AntreaNetworkPolicy"
+)
+
+var (
+	directionNames = map[networking.Direction]string{
+		networking.DirectionIn:  "Ingress",
+		networking.DirectionOut: "Egress",
+	}
+	actionVerbs = map[secv1alpha1.RuleAction]string{
+		secv1alpha1.RuleActionAllow:  "allows",
+		secv1alpha1.RuleActionDrop:   "drops",
+		secv1alpha1.RuleActionReject: "rejects",
+		secv1alpha1.RuleActionPass:   "passes",
+	}
+	// ipProtocolNumbers maps the protocols of the queried traffic to their IP
+	// protocol numbers.
+	ipProtocolNumbers = map[v1.Protocol]int32{
+		v1.ProtocolTCP:  6,
+		v1.ProtocolUDP:  17,
+		v1.ProtocolSCTP: 132,
+	}
+)
+
+// queryEndpoint is an endpoint of the queried traffic. pod is nil if the
+// endpoint is an IP address which doesn't belong to any Pod.
+type queryEndpoint struct {
+	pod       *v1.Pod
+	namespace *v1.Namespace
+	ip        net.IP
+}
+
+func (e *queryEndpoint) String() string {
+	if e.pod != nil {
+		return "Pod " + k8s.NamespacedName(e.pod.Namespace, e.pod.Name)
+	}
+	return e.ip.String()
+}
+
+// queryPolicy is an internal NetworkPolicy evaluated by the query, along with
+// the stores holding the groups it refers to.
+type queryPolicy struct {
+	*antreatypes.NetworkPolicy
+	addressGroupStore   storage.Interface
+	appliedToGroupStore storage.Interface
+	candidate           bool
+}
+
+func (p *queryPolicy) policyType() string {
+	if p.Priority == nil {
+		return k8sNetworkPolicyType
+	}
+	if p.Namespace == "" {
+		return clusterNetworkPolicyType
+	}
+	return antreaNetworkPolicyType
+}
+
+// queryRule is a rule of a queryPolicy matching the queried traffic.
+type queryRule struct {
+	policy *queryPolicy
+	rule   *networking.NetworkPolicyRule
+	// index is the index of the rule among the rules of the policy in the
+	// same direction.
+	index int32
+}
+
+func (r *queryRule) String() string {
+	return fmt.Sprintf("%s rule %d of %s %s", directionNames[r.rule.Direction], r.index, r.policy.policyType(), k8s.NamespacedName(r.policy.Namespace, r.policy.Name))
+}
+
+func (r *queryRule) action() secv1alpha1.RuleAction {
+	if r.rule.Action == nil {
+		return defaultAction
+	}
+	return *r.rule.Action
+}
+
+func (r *queryRule) toResponseRule() endpointpair.Rule {
+	return endpointpair.Rule{
+		PolicyType: r.policy.policyType(),
+		Namespace:  r.policy.Namespace,
+		Name:       r.policy.Name,
+		Direction:  directionNames[r.rule.Direction],
+		Index:      r.index,
+		Action:     string(r.action()),
+		Candidate:  r.policy.candidate,
+	}
+}
+
+// QueryEndpointPair evaluates the internal NetworkPolicies applied to the
+// traffic described by the request, without generating any packet. The
+// egress rules applied to the source are evaluated first, then the ingress
+// rules applied to the destination if the traffic is allowed by the former.
+// FQDN peers are never matched as they are only resolved by the agents.
+func (n *NetworkPolicyController) QueryEndpointPair(req *endpointpair.Request) (*endpointpair.Response, error) {
+	protocol := v1.ProtocolTCP
+	if req.Protocol != "" {
+		protocol = v1.Protocol(strings.ToUpper(req.Protocol))
+		if protocol != v1.ProtocolTCP && protocol != v1.ProtocolUDP && protocol != v1.ProtocolSCTP {
+			return nil, fmt.Errorf("unsupported protocol %s", req.Protocol)
+		}
+	}
+	if req.Port < 0 || req.Port > 65535 {
+		return nil, fmt.Errorf("invalid port %d", req.Port)
+	}
+	src, err := n.resolveQueryEndpoint(req.Source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source: %v", err)
+	}
+	dst, err := n.resolveQueryEndpoint(req.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination: %v", err)
+	}
+	if src.pod == nil && dst.pod == nil {
+		return nil, fmt.Errorf("at least one of the source and the destination must be a Pod")
+	}
+	policies, err := n.getQueryPolicies(req.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &endpointpair.Response{Rules: []endpointpair.Rule{}}
+	action, egressReason := n.evaluateQueryDirection(policies, networking.DirectionOut, src, dst, protocol, req.Port, resp)
+	reasons := []string{egressReason}
+	if action == secv1alpha1.RuleActionAllow {
+		var ingressReason string
+		action, ingressReason = n.evaluateQueryDirection(policies, networking.DirectionIn, dst, src, protocol, req.Port, resp)
+		reasons = append(reasons, ingressReason)
+	}
+	resp.Action = string(action)
+	resp.Reason = strings.Join(reasons, "; ")
+	return resp, nil
+}
+
+// resolveQueryEndpoint resolves a Pod reference ("Namespace/name") or an IP
+// address to a queryEndpoint. An IP address is resolved to the Pod it is
+// assigned to if any, as policies select Pods by their labels.
+func (n *NetworkPolicyController) resolveQueryEndpoint(str string) (*queryEndpoint, error) {
+	if parts := strings.Split(str, "/"); len(parts) == 2 {
+		pod, err := n.podLister.Pods(parts[0]).Get(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Pod %s: %v", str, err)
+		}
+		return n.podToQueryEndpoint(pod), nil
+	}
+	ip := net.ParseIP(str)
+	if ip == nil {
+		return nil, fmt.Errorf("%s is neither a Pod reference (Namespace/name) nor an IP address", str)
+	}
+	pods, _ := n.podLister.List(labels.Everything())
+	for _, pod := range pods {
+		if !pod.Spec.HostNetwork && pod.Status.PodIP != "" && net.ParseIP(pod.Status.PodIP).Equal(ip) {
+			return n.podToQueryEndpoint(pod), nil
+		}
+	}
+	return &queryEndpoint{ip: ip}, nil
+}
+
+func (n *NetworkPolicyController) podToQueryEndpoint(pod *v1.Pod) *queryEndpoint {
+	// The Namespace may not be found, in which case only the selectors not
+	// involving a namespaceSelector can select the Pod.
+	namespace, _ := n.namespaceLister.Get(pod.Namespace)
+	return &queryEndpoint{pod: pod, namespace: namespace, ip: net.ParseIP(pod.Status.PodIP)}
+}
+
+// getQueryPolicies returns the internal NetworkPolicies to evaluate. If a
+// candidate policy is provided, it replaces the existing policy with the same
+// kind, Namespace and name.
+func (n *NetworkPolicyController) getQueryPolicies(manifest string) ([]*queryPolicy, error) {
+	var candidate *queryPolicy
+	if manifest != "" {
+		var err error
+		if candidate, err = n.processCandidatePolicy(manifest); err != nil {
+			return nil, err
+		}
+	}
+	var policies []*queryPolicy
+	for _, obj := range n.internalNetworkPolicyStore.List() {
+		policy := &queryPolicy{
+			NetworkPolicy:       obj.(*antreatypes.NetworkPolicy),
+			addressGroupStore:   n.addressGroupStore,
+			appliedToGroupStore: n.appliedToGroupStore,
+		}
+		if candidate != nil && candidate.policyType() == policy.policyType() && candidate.Namespace == policy.Namespace && candidate.Name == policy.Name {
+			continue
+		}
+		policies = append(policies, policy)
+	}
+	if candidate != nil {
+		policies = append(policies, candidate)
+	}
+	return policies, nil
+}
+
+// processCandidatePolicy translates the manifest of a candidate policy to an
+// internal NetworkPolicy. The AddressGroups and AppliedToGroups it refers to
+// are created in scratch stores instead of the stores of the controller, so
+// that evaluating the candidate policy doesn't affect the realized policies.
+func (n *NetworkPolicyController) processCandidatePolicy(manifest string) (*queryPolicy, error) {
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal([]byte(manifest), &typeMeta); err != nil {
+		return nil, fmt.Errorf("invalid candidate policy: %v", err)
+	}
+	scratch := &NetworkPolicyController{
+		podLister:            n.podLister,
+		namespaceLister:      n.namespaceLister,
+		externalEntityLister: n.externalEntityLister,
+		tierLister:           n.tierLister,
+		cgLister:             n.cgLister,
+		addressGroupStore:    store.NewAddressGroupStore(),
+		appliedToGroupStore:  store.NewAppliedToGroupStore(),
+		appliedToGroupQueue:  workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+	defer func() {
+		scratch.appliedToGroupQueue.ShutDown()
+		// Enqueuing the scratch AppliedToGroups overrode the queue length
+		// reported for the controller.
+		metrics.LengthAppliedToGroupQueue.Set(float64(n.appliedToGroupQueue.Len()))
+	}()
+
+	var internalNP *antreatypes.NetworkPolicy
+	switch typeMeta.GroupVersionKind() {
+	case networkingv1.SchemeGroupVersion.WithKind("NetworkPolicy"):
+		var np networkingv1.NetworkPolicy
+		if err := yaml.Unmarshal([]byte(manifest), &np); err != nil {
+			return nil, fmt.Errorf("invalid candidate NetworkPolicy: %v", err)
+		}
+		if np.Namespace == "" {
+			np.Namespace = metav1.NamespaceDefault
+		}
+		internalNP = scratch.processNetworkPolicy(&np)
+	case secv1alpha1.SchemeGroupVersion.WithKind("ClusterNetworkPolicy"):
+		if n.cnpLister == nil {
+			return nil, fmt.Errorf("ClusterNetworkPolicy feature is disabled")
+		}
+		var cnp secv1alpha1.ClusterNetworkPolicy
+		if err := yaml.Unmarshal([]byte(manifest), &cnp); err != nil {
+			return nil, fmt.Errorf("invalid candidate ClusterNetworkPolicy: %v", err)
+		}
+		internalNP = scratch.processClusterNetworkPolicy(&cnp)
+	case secv1alpha1.SchemeGroupVersion.WithKind("NetworkPolicy"):
+		if n.anpLister == nil {
+			return nil, fmt.Errorf("AntreaNetworkPolicy feature is disabled")
+		}
+		var anp secv1alpha1.NetworkPolicy
+		if err := yaml.Unmarshal([]byte(manifest), &anp); err != nil {
+			return nil, fmt.Errorf("invalid candidate Antrea NetworkPolicy: %v", err)
+		}
+		if anp.Namespace == "" {
+			anp.Namespace = metav1.NamespaceDefault
+		}
+		internalNP = scratch.processAntreaNetworkPolicy(&anp)
+	default:
+		return nil, fmt.Errorf("unsupported candidate policy %s %s", typeMeta.APIVersion, typeMeta.Kind)
+	}
+	return &queryPolicy{
+		NetworkPolicy:       internalNP,
+		addressGroupStore:   scratch.addressGroupStore,
+		appliedToGroupStore: scratch.appliedToGroupStore,
+		candidate:           true,
+	}, nil
+}
+
+// evaluateQueryDirection evaluates the rules of the policies applied to
+// appliedTo in the provided direction, peer being the other endpoint of the
+// traffic. The matching rules are appended to resp in the order they are
+// evaluated. It returns the action decided in this direction and a
+// description of how it was decided.
+func (n *NetworkPolicyController) evaluateQueryDirection(policies []*queryPolicy, direction networking.Direction, appliedTo, peer *queryEndpoint, protocol v1.Protocol, port int32, resp *endpointpair.Response) (secv1alpha1.RuleAction, string) {
+	dirName := strings.ToLower(directionNames[direction])
+	if appliedTo.pod == nil {
+		return secv1alpha1.RuleActionAllow, fmt.Sprintf("no policy applies to the %s traffic of %s", dirName, appliedTo)
+	}
+	// The destination of the traffic is used to resolve named ports.
+	dst := appliedTo
+	if direction == networking.DirectionOut {
+		dst = peer
+	}
+	var antreaRules, k8sRules []*queryRule
+	var isolatingPolicies []string
+	for _, policy := range policies {
+		if !n.queryPolicyAppliesTo(policy, appliedTo) {
+			continue
+		}
+		isK8sPolicy := policy.Priority == nil
+		var k8sIndex int32
+		for i := range policy.Rules {
+			rule := &policy.Rules[i]
+			if rule.Direction != direction {
+				continue
+			}
+			qr := &queryRule{policy: policy, rule: rule, index: rule.Priority}
+			if isK8sPolicy {
+				// Rules of K8s NetworkPolicies don't have priorities. A K8s
+				// NetworkPolicy with any rule in this direction, including the
+				// deny-all rule, isolates the Pod in this direction.
+				if k8sIndex == 0 {
+					isolatingPolicies = append(isolatingPolicies, k8s.NamespacedName(policy.Namespace, policy.Name))
+				}
+				qr.index = k8sIndex
+				k8sIndex++
+			}
+			var rulePeer *networking.NetworkPolicyPeer
+			if direction == networking.DirectionIn {
+				rulePeer = &rule.From
+			} else {
+				rulePeer = &rule.To
+			}
+			if !n.queryPeerMatches(policy, rulePeer, peer, protocol, port) || !servicesMatchQuery(rule.Services, protocol, port, dst) {
+				continue
+			}
+			if isK8sPolicy {
+				k8sRules = append(k8sRules, qr)
+			} else {
+				antreaRules = append(antreaRules, qr)
+			}
+		}
+	}
+	sortAntreaQueryRules(antreaRules)
+	sort.SliceStable(k8sRules, func(i, j int) bool {
+		a, b := k8sRules[i], k8sRules[j]
+		if a.policy.Namespace != b.policy.Namespace {
+			return a.policy.Namespace < b.policy.Namespace
+		}
+		if a.policy.Name != b.policy.Name {
+			return a.policy.Name < b.policy.Name
+		}
+		return a.index < b.index
+	})
+
+	var action secv1alpha1.RuleAction
+	var reason string
+	var passedBy *queryRule
+	for _, qr := range antreaRules {
+		rule := qr.toResponseRule()
+		if action == "" && passedBy == nil {
+			if qr.action() == secv1alpha1.RuleActionPass {
+				// The remaining Antrea-native policy rules are skipped.
+				passedBy = qr
+			} else {
+				action = qr.action()
+				reason = fmt.Sprintf("%s %s the %s traffic of %s", qr, actionVerbs[action], dirName, appliedTo)
+				rule.Effective = true
+			}
+		}
+		resp.Rules = append(resp.Rules, rule)
+	}
+	for _, qr := range k8sRules {
+		rule := qr.toResponseRule()
+		if action == "" {
+			action = secv1alpha1.RuleActionAllow
+			reason = fmt.Sprintf("%s allows the %s traffic of %s", qr, dirName, appliedTo)
+			rule.Effective = true
+		}
+		resp.Rules = append(resp.Rules, rule)
+	}
+	if action == "" {
+		if len(isolatingPolicies) > 0 {
+			action = secv1alpha1.RuleActionDrop
+			reason = fmt.Sprintf("the %s traffic of %s is isolated by %s %s and not allowed by any of their rules", dirName, appliedTo, k8sNetworkPolicyType, strings.Join(isolatingPolicies, ", "))
+		} else {
+			action = secv1alpha1.RuleActionAllow
+			reason = fmt.Sprintf("no policy isolates the %s traffic of %s", dirName, appliedTo)
+		}
+	}
+	if passedBy != nil {
+		reason = fmt.Sprintf("%s passes the %s traffic of %s to %ss, %s", passedBy, dirName, appliedTo, k8sNetworkPolicyType, reason)
+	}
+	return action, reason
+}
+
+// sortAntreaQueryRules sorts the rules of Antrea-native policies in the order
+// they are evaluated: by the priorities of their Tiers first, then by the
+// priorities of their policies, then by their own priorities.
+func sortAntreaQueryRules(rules []*queryRule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		a, b := rules[i], rules[j]
+		if *a.policy.TierPriority != *b.policy.TierPriority {
+			return *a.policy.TierPriority < *b.policy.TierPriority
+		}
+		if *a.policy.Priority != *b.policy.Priority {
+			return *a.policy.Priority < *b.policy.Priority
+		}
+		return a.index < b.index
+	})
+}
+
+// queryPolicyAppliesTo returns true if any AppliedToGroup of the policy
+// selects the endpoint.
+func (n *NetworkPolicyController) queryPolicyAppliesTo(policy *queryPolicy, endpoint *queryEndpoint) bool {
+	for _, name := range policy.AppliedToGroups {
+		obj, found, _ := policy.appliedToGroupStore.Get(name)
+		if !found {
+			continue
+		}
+		if n.labelsMatchGroupSelector(endpoint.pod, endpoint.namespace, &obj.(*antreatypes.AppliedToGroup).Selector) {
+			return true
+		}
+	}
+	return false
+}
+
+// queryPeerMatches returns true if any AddressGroup of the peer selects the
+// endpoint, or if any IPBlock of the peer contains the IP of the endpoint. The
+// AddressGroups of Nodes and Services hold IPs instead of selecting Pods, the
+// endpoint must be one of them, and for Services the queried traffic must also
+// match its ports.
+func (n *NetworkPolicyController) queryPeerMatches(policy *queryPolicy, peer *networking.NetworkPolicyPeer, endpoint *queryEndpoint, protocol v1.Protocol, port int32) bool {
+	for _, name := range peer.AddressGroups {
+		obj, found, _ := policy.addressGroupStore.Get(name)
+		if !found {
+			continue
+		}
+		selector := &obj.(*antreatypes.AddressGroup).Selector
+		if selector.NodeSelector != nil {
+			if endpoint.ip != nil && groupMembersMatchQuery(n.nodeSelectorToMemberPods(selector.NodeSelector), endpoint.ip, false, protocol, port) {
+				return true
+			}
+			continue
+		}
+		if selector.ServiceReference != nil {
+			if endpoint.ip != nil && groupMembersMatchQuery(n.serviceToMemberPods(selector.ServiceReference), endpoint.ip, true, protocol, port) {
+				return true
+			}
+			continue
+		}
+		if endpoint.pod != nil && n.labelsMatchGroupSelector(endpoint.pod, endpoint.namespace, selector) {
+			return true
+		}
+	}
+	if endpoint.ip != nil {
+		for i := range peer.IPBlocks {
+			if ipBlockContains(&peer.IPBlocks[i], endpoint.ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// groupMembersMatchQuery returns true if any member has the IP and, if
+// matchPorts is true, a port matching the protocol and the port of the queried
+// traffic.
+func groupMembersMatchQuery(members networking.GroupMemberPodSet, ip net.IP, matchPorts bool, protocol v1.Protocol, port int32) bool {
+	for _, member := range members {
+		if !net.IP(member.IP).Equal(ip) {
+			continue
+		}
+		if !matchPorts {
+			return true
+		}
+		for _, memberPort := range member.Ports {
+			if string(memberPort.Protocol) == string(protocol) && memberPort.Port == port {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func ipBlockContains(ipBlock *networking.IPBlock, ip net.IP) bool {
+	if !ipNetContains(&ipBlock.CIDR, ip) {
+		return false
+	}
+	for i := range ipBlock.Except {
+		if ipNetContains(&ipBlock.Except[i], ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func ipNetContains(ipNet *networking.IPNet, ip net.IP) bool {
+	netIP := net.IP(ipNet.IP)
+	bits := 8 * net.IPv6len
+	if netIP.To4() != nil {
+		bits = 8 * net.IPv4len
+	}
+	return (&net.IPNet{IP: netIP, Mask: net.CIDRMask(int(ipNet.PrefixLength), bits)}).Contains(ip)
+}
+
+// servicesMatchQuery returns true if the services match the protocol and the
+// port of the queried traffic. Named ports are resolved using the container
+// ports of the destination Pod.
+func servicesMatchQuery(services []networking.Service, protocol v1.Protocol, port int32, dst *queryEndpoint) bool {
+	if len(services) == 0 {
+		return true
+	}
+	for _, service := range services {
+		if service.Protocol == nil {
+			// The service matches an IP protocol number.
+			if service.IPProtocol != nil && *service.IPProtocol == ipProtocolNumbers[protocol] {
+				return true
+			}
+			continue
+		}
+		if string(*service.Protocol) != string(protocol) {
+			continue
+		}
+		if service.Port == nil {
+			return true
+		}
+		if port == 0 {
+			continue
+		}
+		if service.Port.Type == intstr.Int {
+			endPort := service.Port.IntVal
+			if service.EndPort != nil {
+				endPort = *service.EndPort
+			}
+			if port >= service.Port.IntVal && port <= endPort {
+				return true
+			}
+			continue
+		}
+		if dst.pod != nil && podHasNamedPort(dst.pod, service.Port.StrVal, protocol, port) {
+			return true
+		}
+	}
+	return false
+}
+
+func podHasNamedPort(pod *v1.Pod, name string, protocol v1.Protocol, port int32) bool {
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			portProtocol := containerPort.Protocol
+			if portProtocol == "" {
+				portProtocol = v1.ProtocolTCP
+			}
+			if containerPort.Name == name && portProtocol == protocol && containerPort.ContainerPort == port {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,240 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/apiserver/handlers/endpointpair"
+)
+
+func newQueryPod(name, ip string, labels map[string]string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: name, Labels: labels},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name:  "container-1",
+				Ports: []v1.ContainerPort{{Name: "http", ContainerPort: 80, Protocol: v1.ProtocolTCP}},
+			}},
+		},
+		Status: v1.PodStatus{PodIP: ip},
+	}
+}
+
+const candidateCNP = `
+apiVersion: security.antrea.tanzu.vmware.com/v1alpha1
+kind: ClusterNetworkPolicy
+metadata:
+  name: cnp1
+spec:
+  priority: 1
+  appliedTo:
+  - podSelector:
+      matchLabels:
+        app: server
+  ingress:
+  - action: Pass
+    from:
+    - podSelector:
+        matchLabels:
+          app: client
+`
+
+func TestQueryEndpointPair(t *testing.T) {
+	_, npc := newController()
+	npc.namespaceStore.Add(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1"}})
+	npc.podStore.Add(newQueryPod("client", "10.0.0.1", map[string]string{"app": "client"}))
+	npc.podStore.Add(newQueryPod("server", "10.0.0.2", map[string]string{"app": "server"}))
+
+	httpPort := intstr.FromString("http")
+	npc.addNetworkPolicy(&networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "np1", UID: "uid1"},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "server"}},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From:  []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "client"}}}},
+				Ports: []networkingv1.NetworkPolicyPort{{Port: &httpPort}},
+			}},
+		},
+	})
+	np1Rule := endpointpair.Rule{PolicyType: k8sNetworkPolicyType, Namespace: "ns1", Name: "np1", Direction: "Ingress", Index: 0, Action: "Allow"}
+
+	resp, err := npc.QueryEndpointPair(&endpointpair.Request{Source: "ns1/client", Destination: "ns1/server", Port: 80})
+	require.NoError(t, err)
+	effectiveNP1Rule := np1Rule
+	effectiveNP1Rule.Effective = true
+	assert.Equal(t, []endpointpair.Rule{effectiveNP1Rule}, resp.Rules)
+	assert.Equal(t, "Allow", resp.Action)
+
+	// The named port is not resolved to 81.
+	resp, err = npc.QueryEndpointPair(&endpointpair.Request{Source: "ns1/client", Destination: "ns1/server", Port: 81})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Rules)
+	assert.Equal(t, "Drop", resp.Action)
+
+	// The IP of a Pod is resolved to the Pod, while the other IPs are not
+	// selected by the podSelector.
+	resp, err = npc.QueryEndpointPair(&endpointpair.Request{Source: "10.0.0.1", Destination: "ns1/server", Port: 80})
+	require.NoError(t, err)
+	assert.Equal(t, "Allow", resp.Action)
+	resp, err = npc.QueryEndpointPair(&endpointpair.Request{Source: "10.0.0.3", Destination: "ns1/server", Port: 80})
+	require.NoError(t, err)
+	assert.Equal(t, "Drop", resp.Action)
+
+	dropAction := secv1alpha1.RuleActionDrop
+	npc.addCNP(&secv1alpha1.ClusterNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cnp1", UID: "uid2"},
+		Spec: secv1alpha1.ClusterNetworkPolicySpec{
+			Priority:  1,
+			AppliedTo: []secv1alpha1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "server"}}}},
+			Ingress: []secv1alpha1.Rule{{
+				Action: &dropAction,
+				From:   []secv1alpha1.NetworkPolicyPeer{{IPBlock: &secv1alpha1.IPBlock{CIDR: "10.0.0.0/24"}}},
+			}},
+		},
+	})
+	resp, err = npc.QueryEndpointPair(&endpointpair.Request{Source: "ns1/client", Destination: "ns1/server", Port: 80})
+	require.NoError(t, err)
+	assert.Equal(t, []endpointpair.Rule{
+		{PolicyType: clusterNetworkPolicyType, Name: "cnp1", Direction: "Ingress", Index: 0, Action: "Drop", Effective: true},
+		np1Rule,
+	}, resp.Rules)
+	assert.Equal(t, "Drop", resp.Action)
+
+	// The candidate policy replaces the existing one.
+	resp, err = npc.QueryEndpointPair(&endpointpair.Request{Source: "ns1/client", Destination: "ns1/server", Port: 80, Policy: candidateCNP})
+	require.NoError(t, err)
+	assert.Equal(t, []endpointpair.Rule{
+		{PolicyType: clusterNetworkPolicyType, Name: "cnp1", Direction: "Ingress", Index: 0, Action: "Pass", Candidate: true},
+		effectiveNP1Rule,
+	}, resp.Rules)
+	assert.Equal(t, "Allow", resp.Action)
+	// Evaluating the candidate policy doesn't create any group.
+	assert.Len(t, npc.appliedToGroupStore.List(), 2)
+	assert.Len(t, npc.addressGroupStore.List(), 1)
+
+	_, err = npc.QueryEndpointPair(&endpointpair.Request{Source: "ns1/unknown", Destination: "ns1/server"})
+	assert.Error(t, err)
+	_, err = npc.QueryEndpointPair(&endpointpair.Request{Source: "10.0.0.3", Destination: "10.0.0.4"})
+	assert.Error(t, err)
+	_, err = npc.QueryEndpointPair(&endpointpair.Request{Source: "ns1/client", Destination: "ns1/server", Protocol: "ICMP"})
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointPairToServices(t *testing.T) {
+	_, npc := newController()
+	npc.namespaceStore.Add(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1"}})
+	npc.podStore.Add(newQueryPod("client", "10.0.0.1", map[string]string{"app": "client"}))
+	npc.podStore.Add(newQueryPod("server", "10.0.0.2", map[string]string{"app": "server"}))
+	npc.serviceStore.Add(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "svc1"},
+		Spec: v1.ServiceSpec{
+			ClusterIP: "10.96.0.10",
+			Ports:     []v1.ServicePort{{Name: "http", Port: 80, Protocol: v1.ProtocolTCP}},
+		},
+	})
+	npc.endpointsStore.Add(&v1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "svc1"},
+		Subsets: []v1.EndpointSubset{{
+			Addresses: []v1.EndpointAddress{{IP: "10.0.0.2"}},
+			Ports:     []v1.EndpointPort{{Name: "http", Port: 8080, Protocol: v1.ProtocolTCP}},
+		}},
+	})
+
+	dropAction := secv1alpha1.RuleActionDrop
+	npc.addCNP(&secv1alpha1.ClusterNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cnp1", UID: "uid1"},
+		Spec: secv1alpha1.ClusterNetworkPolicySpec{
+			Priority:  1,
+			AppliedTo: []secv1alpha1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "client"}}}},
+			Egress: []secv1alpha1.Rule{{
+				Action:     &dropAction,
+				ToServices: []secv1alpha1.ServiceReference{{Name: "svc1", Namespace: "ns1"}},
+			}},
+		},
+	})
+	cnp1Rule := endpointpair.Rule{PolicyType: clusterNetworkPolicyType, Name: "cnp1", Direction: "Egress", Index: 0, Action: "Drop", Effective: true}
+
+	// Both the ClusterIP and the Endpoints of the Service are matched with
+	// their own ports.
+	resp, err := npc.QueryEndpointPair(&endpointpair.Request{Source: "ns1/client", Destination: "10.96.0.10", Port: 80})
+	require.NoError(t, err)
+	assert.Equal(t, []endpointpair.Rule{cnp1Rule}, resp.Rules)
+	assert.Equal(t, "Drop", resp.Action)
+	resp, err = npc.QueryEndpointPair(&endpointpair.Request{Source: "ns1/client", Destination: "ns1/server", Port: 8080})
+	require.NoError(t, err)
+	assert.Equal(t, []endpointpair.Rule{cnp1Rule}, resp.Rules)
+	assert.Equal(t, "Drop", resp.Action)
+
+	// Other ports and protocols of the Service IPs are not matched.
+	resp, err = npc.QueryEndpointPair(&endpointpair.Request{Source: "ns1/client", Destination: "10.96.0.10", Port: 8080})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Rules)
+	assert.Equal(t, "Allow", resp.Action)
+	resp, err = npc.QueryEndpointPair(&endpointpair.Request{Source: "ns1/client", Destination: "ns1/server", Port: 80})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Rules)
+	assert.Equal(t, "Allow", resp.Action)
+	resp, err = npc.QueryEndpointPair(&endpointpair.Request{Source: "ns1/client", Destination: "ns1/server", Port: 8080, Protocol: "UDP"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Rules)
+	assert.Equal(t, "Allow", resp.Action)
+}
+
+func TestQueryEndpointPairNodeSelector(t *testing.T) {
+	_, npc := newController()
+	npc.namespaceStore.Add(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1"}})
+	npc.podStore.Add(newQueryPod("server", "10.10.0.2", map[string]string{"app": "server"}))
+	controlPlaneLabels := map[string]string{"node-role.kubernetes.io/master": ""}
+	npc.nodeStore.Add(newNode("node1", "172.16.0.1", "10.10.0.0/24", controlPlaneLabels))
+	npc.nodeStore.Add(newNode("node2", "172.16.0.2", "10.10.1.0/24", nil))
+
+	dropAction := secv1alpha1.RuleActionDrop
+	npc.addCNP(&secv1alpha1.ClusterNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cnp1", UID: "uid1"},
+		Spec: secv1alpha1.ClusterNetworkPolicySpec{
+			Priority:  1,
+			AppliedTo: []secv1alpha1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "server"}}}},
+			Ingress: []secv1alpha1.Rule{{
+				Action: &dropAction,
+				From:   []secv1alpha1.NetworkPolicyPeer{{NodeSelector: &metav1.LabelSelector{MatchLabels: controlPlaneLabels}}},
+			}},
+		},
+	})
+	cnp1Rule := endpointpair.Rule{PolicyType: clusterNetworkPolicyType, Name: "cnp1", Direction: "Ingress", Index: 0, Action: "Drop", Effective: true}
+
+	// Both the InternalIP and the gateway IP of the selected Node are matched.
+	for _, src := range []string{"172.16.0.1", "10.10.0.1"} {
+		resp, err := npc.QueryEndpointPair(&endpointpair.Request{Source: src, Destination: "ns1/server", Port: 80})
+		require.NoError(t, err)
+		assert.Equal(t, []endpointpair.Rule{cnp1Rule}, resp.Rules)
+		assert.Equal(t, "Drop", resp.Action)
+	}
+	// The IPs of the other Node are not matched.
+	for _, src := range []string{"172.16.0.2", "10.10.1.1"} {
+		resp, err := npc.QueryEndpointPair(&endpointpair.Request{Source: src, Destination: "ns1/server", Port: 80})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Rules)
+		assert.Equal(t, "Allow", resp.Action)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/core/v1alpha1"
+)
+
+// addExternalEntity retrieves all AddressGroups and AppliedToGroups which match
+// the ExternalEntity's labels and enqueues the groups key for further processing.
+func (n *NetworkPolicyController) addExternalEntity(obj interface{}) {
+	defer n.heartbeat("addExternalEntity")
+	ee := obj.(*v1alpha1.ExternalEntity)
+	klog.V(2).Infof("Processing ExternalEntity %s/%s ADD event, labels: %v", ee.Namespace, ee.Name, ee.Labels)
+	// Find all AppliedToGroup keys which match the ExternalEntity's labels.
+	appliedToGroupKeySet := n.filterAppliedToGroupsForPodOrExternalEntity(ee)
+	// Find all AddressGroup keys which match the ExternalEntity's labels.
+	addressGroupKeySet := n.filterAddressGroupsForPodOrExternalEntity(ee)
+	// Enqueue groups to their respective queues for group processing.
+	for group := range appliedToGroupKeySet {
+		n.enqueueAppliedToGroup(group)
+	}
+	for group := range addressGroupKeySet {
+		n.enqueueAddressGroup(group)
+	}
+}
+
+// updateExternalEntity retrieves all AddressGroups and AppliedToGroups which
+// match the updated and old ExternalEntity's labels and enqueues the group keys
+// for further processing.
+func (n *NetworkPolicyController) updateExternalEntity(oldObj, curObj interface{}) {
+	defer n.heartbeat("updateExternalEntity")
+	oldEE := oldObj.(*v1alpha1.ExternalEntity)
+	curEE := curObj.(*v1alpha1.ExternalEntity)
+	klog.V(2).Infof("Processing ExternalEntity %s/%s UPDATE event, labels: %v", curEE.Namespace, curEE.Name, curEE.Labels)
+	// No need to trigger processing of groups if there is no change in the
+	// ExternalEntity labels or spec.
+	labelsEqual := labels.Equals(labels.Set(oldEE.Labels), labels.Set(curEE.Labels))
+	specEqual := reflect.DeepEqual(oldEE.Spec, curEE.Spec)
+	if labelsEqual && specEqual {
+		klog.V(4).Infof("No change in ExternalEntity %s/%s. Skipping NetworkPolicy evaluation.", curEE.Namespace, curEE.Name)
+		return
+	}
+	// Find groups matching the old ExternalEntity's labels.
+	oldAddressGroupKeySet := n.filterAddressGroupsForPodOrExternalEntity(oldEE)
+	oldAppliedToGroupKeySet := n.filterAppliedToGroupsForPodOrExternalEntity(oldEE)
+	// Find groups matching the new ExternalEntity's labels.
+	curAppliedToGroupKeySet := n.filterAppliedToGroupsForPodOrExternalEntity(curEE)
+	curAddressGroupKeySet := n.filterAddressGroupsForPodOrExternalEntity(curEE)
+	// Create set to hold the group keys to enqueue.
+	var appliedToGroupKeys sets.String
+	var addressGroupKeys sets.String
+	if !specEqual {
+		// The Endpoints or the ExternalNode of the ExternalEntity have changed,
+		// all the groups must be updated.
+		appliedToGroupKeys = oldAppliedToGroupKeySet.Union(curAppliedToGroupKeySet)
+		addressGroupKeys = oldAddressGroupKeySet.Union(curAddressGroupKeySet)
+	} else {
+		// No need to enqueue common groups as they already have latest
+		// ExternalEntity information.
+		appliedToGroupKeys = oldAppliedToGroupKeySet.Difference(curAppliedToGroupKeySet).Union(curAppliedToGroupKeySet.Difference(oldAppliedToGroupKeySet))
+		addressGroupKeys = oldAddressGroupKeySet.Difference(curAddressGroupKeySet).Union(curAddressGroupKeySet.Difference(oldAddressGroupKeySet))
+	}
+	for group := range appliedToGroupKeys {
+		n.enqueueAppliedToGroup(group)
+	}
+	for group := range addressGroupKeys {
+		n.enqueueAddressGroup(group)
+	}
+}
+
+// deleteExternalEntity retrieves all AddressGroups and AppliedToGroups which
+// match the ExternalEntity's labels and enqueues the groups key for further
+// processing.
+func (n *NetworkPolicyController) deleteExternalEntity(old interface{}) {
+	ee, ok := old.(*v1alpha1.ExternalEntity)
+	if !ok {
+		tombstone, ok := old.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Error decoding object when deleting ExternalEntity, invalid type: %v", old)
+			return
+		}
+		ee, ok = tombstone.Obj.(*v1alpha1.ExternalEntity)
+		if !ok {
+			klog.Errorf("Error decoding object tombstone when deleting ExternalEntity, invalid type: %v", tombstone.Obj)
+			return
+		}
+	}
+	defer n.heartbeat("deleteExternalEntity")
+
+	klog.V(2).Infof("Processing ExternalEntity %s/%s DELETE event, labels: %v", ee.Namespace, ee.Name, ee.Labels)
+	// Find all AppliedToGroup keys which match the ExternalEntity's labels.
+	appliedToGroupKeys := n.filterAppliedToGroupsForPodOrExternalEntity(ee)
+	// Find all AddressGroup keys which match the ExternalEntity's labels.
+	addressGroupKeys := n.filterAddressGroupsForPodOrExternalEntity(ee)
+	// Enqueue groups to their respective queues for group processing.
+	for group := range appliedToGroupKeys {
+		n.enqueueAppliedToGroup(group)
+	}
+	for group := range addressGroupKeys {
+		n.enqueueAddressGroup(group)
+	}
+}
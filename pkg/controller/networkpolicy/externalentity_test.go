@@ -0,0 +1,196 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/core/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+)
+
+func TestExternalEntityToGroupMember(t *testing.T) {
+	ee := getExternalEntity("ee1", "ns1", "vm1", "10.0.0.1", nil)
+	ee.Spec.Endpoints[0].Ports = []v1alpha1.NamedPort{
+		{Name: "http", Port: 80, Protocol: v1.ProtocolTCP},
+		{Name: "dns", Port: 53, Protocol: v1.ProtocolUDP},
+		// The protocol defaults to TCP if not specified.
+		{Name: "https", Port: 443},
+	}
+	expMember := &networking.GroupMember{
+		ExternalEntity: &networking.ExternalEntityReference{Name: "ee1", Namespace: "ns1"},
+		Endpoints: []networking.Endpoint{
+			{
+				IP: ipStrToIPAddress("10.0.0.1"),
+				Ports: []networking.NamedPort{
+					{Name: "http", Port: 80, Protocol: networking.ProtocolTCP},
+					{Name: "dns", Port: 53, Protocol: networking.ProtocolUDP},
+					{Name: "https", Port: 443, Protocol: networking.ProtocolTCP},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expMember, externalEntityToGroupMember(ee))
+}
+
+func TestSyncGroupsForExternalEntities(t *testing.T) {
+	ns := "ns1"
+	appliedToLabels := map[string]string{"app": "db"}
+	addressLabels := map[string]string{"app": "web"}
+	anp := getANPForExternalEntities(ns, appliedToLabels, addressLabels)
+	_, npc := newController()
+	npc.addANP(anp)
+
+	appliedToEE := getExternalEntity("db", ns, "vm1", "10.0.0.1", appliedToLabels)
+	// ExternalEntity without ExternalNode cannot be in the span of the policy.
+	unhandledEE := getExternalEntity("db-unhandled", ns, "", "10.0.0.2", appliedToLabels)
+	addressEE := getExternalEntity("web", ns, "vm2", "10.0.0.3", addressLabels)
+	// ExternalEntity from another Namespace must not be selected by an Antrea
+	// NetworkPolicy.
+	otherNSEE := getExternalEntity("web", "ns2", "vm3", "10.0.0.4", addressLabels)
+	// Pod with the same labels must not be selected by an externalEntitySelector.
+	pod := getPod("web", ns, "node1", "1.1.1.1", false)
+	pod.Labels = addressLabels
+	for _, ee := range []*v1alpha1.ExternalEntity{appliedToEE, unhandledEE, addressEE, otherNSEE} {
+		npc.externalEntityStore.Add(ee)
+	}
+	npc.podStore.Add(pod)
+
+	appliedToGroupUID := getNormalizedUID(toGroupSelector(ns, nil, nil, &metav1.LabelSelector{MatchLabels: appliedToLabels}).NormalizedName)
+	addressGroupUID := getNormalizedUID(toGroupSelector(ns, nil, nil, &metav1.LabelSelector{MatchLabels: addressLabels}).NormalizedName)
+	assert.Equal(t, []string{appliedToGroupUID}, npc.filterAppliedToGroupsForPodOrExternalEntity(appliedToEE).List())
+	assert.Equal(t, []string{addressGroupUID}, npc.filterAddressGroupsForPodOrExternalEntity(addressEE).List())
+	assert.Empty(t, npc.filterAddressGroupsForPodOrExternalEntity(otherNSEE))
+	assert.Empty(t, npc.filterAddressGroupsForPodOrExternalEntity(pod))
+
+	require.NoError(t, npc.syncAppliedToGroup(appliedToGroupUID))
+	appGroupObj, found, _ := npc.appliedToGroupStore.Get(appliedToGroupUID)
+	require.True(t, found)
+	appGroup := appGroupObj.(*antreatypes.AppliedToGroup)
+	assert.Equal(t, []string{"vm1"}, appGroup.SpanMeta.NodeNames.List())
+	assert.Empty(t, appGroup.PodsByNode)
+	require.Len(t, appGroup.GroupMemberByNode["vm1"], 1)
+	assert.True(t, appGroup.GroupMemberByNode["vm1"].Has(externalEntityToGroupMember(appliedToEE)))
+
+	require.NoError(t, npc.syncAddressGroup(addressGroupUID))
+	addrGroupObj, found, _ := npc.addressGroupStore.Get(addressGroupUID)
+	require.True(t, found)
+	addrGroup := addrGroupObj.(*antreatypes.AddressGroup)
+	assert.Empty(t, addrGroup.Pods)
+	assert.Equal(t, networking.NewGroupMemberSet(externalEntityToGroupMember(addressEE)), addrGroup.GroupMembers)
+
+	// Deleting the ExternalEntity removes it from the AddressGroup.
+	npc.externalEntityStore.Delete(addressEE)
+	require.NoError(t, npc.syncAddressGroup(addressGroupUID))
+	addrGroupObj, _, _ = npc.addressGroupStore.Get(addressGroupUID)
+	assert.Empty(t, addrGroupObj.(*antreatypes.AddressGroup).GroupMembers)
+}
+
+func TestLabelsMatchGroupSelectorForExternalEntity(t *testing.T) {
+	eeLabels := map[string]string{"app": "web"}
+	ee := getExternalEntity("ee1", "ns1", "vm1", "10.0.0.1", eeLabels)
+	pod := getPod("pod1", "ns1", "node1", "1.1.1.1", false)
+	pod.Labels = eeLabels
+	ns := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1", Labels: map[string]string{"env": "prod"}}}
+	selector := &metav1.LabelSelector{MatchLabels: eeLabels}
+	nsSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}
+	tests := []struct {
+		name        string
+		sel         *antreatypes.GroupSelector
+		expEEMatch  bool
+		expPodMatch bool
+	}{
+		{
+			name:       "ee-selector-in-namespace",
+			sel:        toGroupSelector("ns1", nil, nil, selector),
+			expEEMatch: true,
+		},
+		{
+			name: "ee-selector-in-other-namespace",
+			sel:  toGroupSelector("ns2", nil, nil, selector),
+		},
+		{
+			name:       "ee-selector-with-ns-selector",
+			sel:        toGroupSelector("", nil, nsSelector, selector),
+			expEEMatch: true,
+		},
+		{
+			name:       "cluster-scoped-ee-selector",
+			sel:        toGroupSelector("", nil, nil, selector),
+			expEEMatch: true,
+		},
+		{
+			name:        "pod-selector",
+			sel:         toGroupSelector("ns1", selector, nil, nil),
+			expPodMatch: true,
+		},
+		{
+			name:        "ns-selector",
+			sel:         toGroupSelector("", nil, nsSelector, nil),
+			expPodMatch: true,
+		},
+	}
+	_, npc := newController()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expEEMatch, npc.labelsMatchGroupSelector(ee, ns, tt.sel))
+			assert.Equal(t, tt.expPodMatch, npc.labelsMatchGroupSelector(pod, ns, tt.sel))
+		})
+	}
+}
+
+// util functions for testing.
+
+func getExternalEntity(name, ns, externalNode, ip string, labels map[string]string) *v1alpha1.ExternalEntity {
+	return &v1alpha1.ExternalEntity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+			Labels:    labels,
+		},
+		Spec: v1alpha1.ExternalEntitySpec{
+			Endpoints:    []v1alpha1.Endpoint{{IP: ip}},
+			ExternalNode: externalNode,
+		},
+	}
+}
+
+func getANPForExternalEntities(ns string, appliedToLabels, addressLabels map[string]string) *secv1alpha1.NetworkPolicy {
+	allowAction := secv1alpha1.RuleActionAllow
+	return &secv1alpha1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "anp-ee", UID: "uid-ee"},
+		Spec: secv1alpha1.NetworkPolicySpec{
+			AppliedTo: []secv1alpha1.NetworkPolicyPeer{
+				{ExternalEntitySelector: &metav1.LabelSelector{MatchLabels: appliedToLabels}},
+			},
+			Priority: float64(10),
+			Ingress: []secv1alpha1.Rule{
+				{
+					From: []secv1alpha1.NetworkPolicyPeer{
+						{ExternalEntitySelector: &metav1.LabelSelector{MatchLabels: addressLabels}},
+					},
+					Action: &allowAction,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"encoding/json"
+	"fmt"
+
+	admv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog"
+
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+)
+
+// jsonPatchOperation is an operation of a JSON patch, see RFC 6902.
+type jsonPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Mutate mutates the request of the given AdmissionReview to set the default
+// values of the fields left unset in an Antrea-native policy, so that the
+// stored policy reflects how it is enforced.
+func Mutate(ar *admv1.AdmissionReview) *admv1.AdmissionResponse {
+	klog.V(2).Infof("Mutating %s %s for resource %s", ar.Request.Operation, ar.Request.Kind.Kind, ar.Request.Name)
+	if ar.Request.Object.Raw == nil {
+		return &admv1.AdmissionResponse{Allowed: true}
+	}
+	var patch []jsonPatchOperation
+	switch ar.Request.Kind.Kind {
+	case "ClusterNetworkPolicy":
+		var cnp secv1alpha1.ClusterNetworkPolicy
+		if err := json.Unmarshal(ar.Request.Object.Raw, &cnp); err != nil {
+			klog.Errorf("Error de-serializing current ClusterNetworkPolicy: %v", err)
+			return getAdmissionResponseForErr(err)
+		}
+		patch = getDefaultingPatch(cnp.Spec.Tier, cnp.Spec.Ingress, cnp.Spec.Egress, "")
+	case "NetworkPolicy":
+		var anp secv1alpha1.NetworkPolicy
+		if err := json.Unmarshal(ar.Request.Object.Raw, &anp); err != nil {
+			klog.Errorf("Error de-serializing current Antrea NetworkPolicy: %v", err)
+			return getAdmissionResponseForErr(err)
+		}
+		// The Namespace of the request is set even if the object doesn't
+		// specify it.
+		patch = getDefaultingPatch(anp.Spec.Tier, anp.Spec.Ingress, anp.Spec.Egress, ar.Request.Namespace)
+	}
+	if len(patch) == 0 {
+		return &admv1.AdmissionResponse{Allowed: true}
+	}
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		return getAdmissionResponseForErr(err)
+	}
+	patchType := admv1.PatchTypeJSONPatch
+	return &admv1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patchBytes,
+		PatchType: &patchType,
+	}
+}
+
+// getDefaultingPatch returns the JSON patch setting the default values of an
+// Antrea-native policy. The Tier defaults to the application Tier, the
+// protocol of the ports defaults to TCP unless the IP protocol number is set,
+// and the Namespace of the Services referenced by an Antrea NetworkPolicy
+// defaults to the Namespace of the policy. The namespace is empty for
+// ClusterNetworkPolicies.
+func getDefaultingPatch(tier string, ingress, egress []secv1alpha1.Rule, namespace string) []jsonPatchOperation {
+	var patch []jsonPatchOperation
+	if tier == "" {
+		patch = append(patch, jsonPatchOperation{Op: "add", Path: "/spec/tier", Value: DefaultTierName})
+	}
+	for _, d := range []struct {
+		direction string
+		rules     []secv1alpha1.Rule
+	}{{"ingress", ingress}, {"egress", egress}} {
+		direction := d.direction
+		for i, rule := range d.rules {
+			for j, port := range rule.Ports {
+				if port.Protocol == nil && port.IPProtocol == nil {
+					patch = append(patch, jsonPatchOperation{Op: "add", Path: fmt.Sprintf("/spec/%s/%d/ports/%d/protocol", direction, i, j), Value: v1.ProtocolTCP})
+				}
+			}
+			for j, serviceRef := range rule.ToServices {
+				if serviceRef.Namespace == "" && namespace != "" {
+					patch = append(patch, jsonPatchOperation{Op: "add", Path: fmt.Sprintf("/spec/%s/%d/toServices/%d/namespace", direction, i, j), Value: namespace})
+				}
+			}
+		}
+	}
+	return patch
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+)
+
+func TestMutate(t *testing.T) {
+	allowAction := secv1alpha1.RuleActionAllow
+	protocolUDP := v1.ProtocolUDP
+	ipProtocol := int32(47)
+	selectorA := metav1.LabelSelector{MatchLabels: map[string]string{"foo1": "bar1"}}
+	tests := []struct {
+		name          string
+		kind          string
+		object        interface{}
+		expectedPatch string
+	}{
+		{
+			name: "cnp-defaults",
+			kind: "ClusterNetworkPolicy",
+			object: &secv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "cnp-a"},
+				Spec: secv1alpha1.ClusterNetworkPolicySpec{
+					AppliedTo: []secv1alpha1.NetworkPolicyPeer{{PodSelector: &selectorA}},
+					Priority:  10,
+					Ingress: []secv1alpha1.Rule{
+						{
+							Action: &allowAction,
+							Ports: []secv1alpha1.NetworkPolicyPort{
+								{Protocol: &protocolUDP},
+								{},
+								{IPProtocol: &ipProtocol},
+							},
+						},
+					},
+				},
+			},
+			expectedPatch: `[{"op":"add","path":"/spec/tier","value":"application"},{"op":"add","path":"/spec/ingress/0/ports/1/protocol","value":"TCP"}]`,
+		},
+		{
+			name: "anp-service-namespace",
+			kind: "NetworkPolicy",
+			object: &secv1alpha1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "anp-a"},
+				Spec: secv1alpha1.NetworkPolicySpec{
+					Tier:      "securityops",
+					AppliedTo: []secv1alpha1.NetworkPolicyPeer{{PodSelector: &selectorA}},
+					Priority:  10,
+					Egress: []secv1alpha1.Rule{
+						{
+							Action:     &allowAction,
+							ToServices: []secv1alpha1.ServiceReference{{Namespace: "ns2", Name: "svc-a"}, {Name: "svc-b"}},
+						},
+					},
+				},
+			},
+			expectedPatch: `[{"op":"add","path":"/spec/egress/0/toServices/1/namespace","value":"ns1"}]`,
+		},
+		{
+			name: "no-defaults",
+			kind: "ClusterNetworkPolicy",
+			object: &secv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "cnp-b"},
+				Spec: secv1alpha1.ClusterNetworkPolicySpec{
+					Tier:      "emergency",
+					AppliedTo: []secv1alpha1.NetworkPolicyPeer{{PodSelector: &selectorA}},
+					Priority:  10,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, _ := json.Marshal(tt.object)
+			ar := &admv1.AdmissionReview{
+				Request: &admv1.AdmissionRequest{
+					Kind:      metav1.GroupVersionKind{Group: "security.antrea.tanzu.vmware.com", Version: "v1alpha1", Kind: tt.kind},
+					Namespace: tt.object.(metav1.Object).GetNamespace(),
+					Name:      tt.object.(metav1.Object).GetName(),
+					Operation: admv1.Create,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			}
+			response := Mutate(ar)
+			assert.True(t, response.Allowed)
+			if tt.expectedPatch == "" {
+				assert.Nil(t, response.Patch)
+				return
+			}
+			assert.JSONEq(t, tt.expectedPatch, string(response.Patch))
+			assert.Equal(t, admv1.PatchTypeJSONPatch, *response.PatchType)
+		})
+	}
+}
@@ -44,11 +44,14 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog"
 
+	"github.com/vmware-tanzu/antrea/pkg/apis/core/v1alpha1"
 	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
 	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
 	"github.com/vmware-tanzu/antrea/pkg/apiserver/storage"
 	"github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned"
+	corev1a1informers "github.com/vmware-tanzu/antrea/pkg/client/informers/externalversions/core/v1alpha1"
 	secinformers "github.com/vmware-tanzu/antrea/pkg/client/informers/externalversions/security/v1alpha1"
+	corev1a1listers "github.com/vmware-tanzu/antrea/pkg/client/listers/core/v1alpha1"
 	seclisters "github.com/vmware-tanzu/antrea/pkg/client/listers/security/v1alpha1"
 	"github.com/vmware-tanzu/antrea/pkg/controller/metrics"
 	"github.com/vmware-tanzu/antrea/pkg/controller/networkpolicy/store"
@@ -118,6 +121,38 @@ type NetworkPolicyController struct {
 	// namespaceListerSynced is a function which returns true if the Namespace shared informer has been synced at least once.
 	namespaceListerSynced cache.InformerSynced
 
+	serviceInformer coreinformers.ServiceInformer
+	// serviceLister is able to list/get Services and is populated by the shared informer passed to
+	// NewNetworkPolicyController.
+	serviceLister corelisters.ServiceLister
+	// serviceListerSynced is a function which returns true if the Service shared informer has been synced at least once.
+	serviceListerSynced cache.InformerSynced
+
+	endpointsInformer coreinformers.EndpointsInformer
+	// endpointsLister is able to list/get Endpoints and is populated by the shared informer passed to
+	// NewNetworkPolicyController.
+	endpointsLister corelisters.EndpointsLister
+	// endpointsListerSynced is a function which returns true if the Endpoints shared informer has been synced at least once.
+	endpointsListerSynced cache.InformerSynced
+
+	nodeInformer coreinformers.NodeInformer
+	// nodeLister is able to list/get Nodes and is populated by the shared informer passed to
+	// NewNetworkPolicyController.
+	nodeLister corelisters.NodeLister
+	// nodeListerSynced is a function which returns true if the Node shared informer has been synced at least once.
+	nodeListerSynced cache.InformerSynced
+
+	serviceAccountInformer coreinformers.ServiceAccountInformer
+	// serviceAccountLister is able to list/get ServiceAccounts and is populated by the shared informer passed to
+	// NewNetworkPolicyController.
+	serviceAccountLister corelisters.ServiceAccountLister
+	// serviceAccountListerSynced is a function which returns true if the ServiceAccount shared informer has been
+	// synced at least once.
+	serviceAccountListerSynced cache.InformerSynced
+	// podIndexer indexes the Pods by their ServiceAccounts, so that the Pods running with a ServiceAccount can be
+	// retrieved efficiently. It is only set when the Pods can be selected by their ServiceAccounts.
+	podIndexer cache.Indexer
+
 	networkPolicyInformer networkinginformers.NetworkPolicyInformer
 	// networkPolicyLister is able to list/get Network Policies and is populated by the shared informer passed to
 	// NewNetworkPolicyController.
@@ -132,6 +167,34 @@ type NetworkPolicyController struct {
 	// cnpListerSynced is a function which returns true if the ClusterNetworkPolicies shared informer has been synced at least once.
 	cnpListerSynced cache.InformerSynced
 
+	anpInformer secinformers.NetworkPolicyInformer
+	// anpLister is able to list/get Antrea NetworkPolicies and is populated by the shared informer passed to
+	// NewNetworkPolicyController.
+	anpLister seclisters.NetworkPolicyLister
+	// anpListerSynced is a function which returns true if the Antrea NetworkPolicies shared informer has been synced at least once.
+	anpListerSynced cache.InformerSynced
+
+	tierInformer secinformers.TierInformer
+	// tierLister is able to list/get Tiers and is populated by the shared informer passed to
+	// NewNetworkPolicyController.
+	tierLister seclisters.TierLister
+	// tierListerSynced is a function which returns true if the Tiers shared informer has been synced at least once.
+	tierListerSynced cache.InformerSynced
+
+	externalEntityInformer corev1a1informers.ExternalEntityInformer
+	// externalEntityLister is able to list/get ExternalEntities and is populated by the shared informer passed to
+	// NewNetworkPolicyController.
+	externalEntityLister corev1a1listers.ExternalEntityLister
+	// externalEntityListerSynced is a function which returns true if the ExternalEntities shared informer has been synced at least once.
+	externalEntityListerSynced cache.InformerSynced
+
+	cgInformer corev1a1informers.ClusterGroupInformer
+	// cgLister is able to list/get ClusterGroups and is populated by the shared informer passed to
+	// NewNetworkPolicyController.
+	cgLister corev1a1listers.ClusterGroupLister
+	// cgListerSynced is a function which returns true if the ClusterGroups shared informer has been synced at least once.
+	cgListerSynced cache.InformerSynced
+
 	// addressGroupStore is the storage where the populated Address Groups are stored.
 	addressGroupStore storage.Interface
 	// appliedToGroupStore is the storage where the populated AppliedTo Groups are stored.
@@ -153,6 +216,15 @@ type NetworkPolicyController struct {
 	// concurrent access during updates to the internal NetworkPolicy object.
 	internalNetworkPolicyMutex sync.RWMutex
 
+	// networkPolicyStatusQueue maintains the keys of the Antrea-native
+	// policies whose status need to be synced.
+	networkPolicyStatusQueue workqueue.RateLimitingInterface
+	// networkPolicyStatuses stores the realization statuses reported by Nodes,
+	// indexed by NetworkPolicy key and then by Node name.
+	networkPolicyStatuses map[string]map[string]*networking.NetworkPolicyNodeStatus
+	// networkPolicyStatusesMutex protects networkPolicyStatuses.
+	networkPolicyStatusesMutex sync.RWMutex
+
 	// heartbeatCh is an internal channel for testing. It's used to know whether all tasks have been
 	// processed, and to count executions of each function.
 	heartbeatCh chan heartbeat
@@ -168,8 +240,16 @@ func NewNetworkPolicyController(kubeClient clientset.Interface,
 	crdClient versioned.Interface,
 	podInformer coreinformers.PodInformer,
 	namespaceInformer coreinformers.NamespaceInformer,
+	serviceInformer coreinformers.ServiceInformer,
+	endpointsInformer coreinformers.EndpointsInformer,
+	nodeInformer coreinformers.NodeInformer,
+	serviceAccountInformer coreinformers.ServiceAccountInformer,
 	networkPolicyInformer networkinginformers.NetworkPolicyInformer,
 	cnpInformer secinformers.ClusterNetworkPolicyInformer,
+	anpInformer secinformers.NetworkPolicyInformer,
+	tierInformer secinformers.TierInformer,
+	externalEntityInformer corev1a1informers.ExternalEntityInformer,
+	cgInformer corev1a1informers.ClusterGroupInformer,
 	addressGroupStore storage.Interface,
 	appliedToGroupStore storage.Interface,
 	internalNetworkPolicyStore storage.Interface) *NetworkPolicyController {
@@ -191,6 +271,8 @@ func NewNetworkPolicyController(kubeClient clientset.Interface,
 		appliedToGroupQueue:        workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "appliedToGroup"),
 		addressGroupQueue:          workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "addressGroup"),
 		internalNetworkPolicyQueue: workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "internalNetworkPolicy"),
+		networkPolicyStatusQueue:   workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "networkPolicyStatus"),
+		networkPolicyStatuses:      map[string]map[string]*networking.NetworkPolicyNodeStatus{},
 	}
 	// Add handlers for Pod events.
 	podInformer.Informer().AddEventHandlerWithResyncPeriod(
@@ -219,6 +301,81 @@ func NewNetworkPolicyController(kubeClient clientset.Interface,
 		},
 		resyncPeriod,
 	)
+	// Register Informer and add handlers for Tier events only if one of the
+	// Antrea-native policy features is enabled.
+	if features.DefaultFeatureGate.Enabled(features.ClusterNetworkPolicy) || features.DefaultFeatureGate.Enabled(features.AntreaNetworkPolicy) {
+		n.tierInformer = tierInformer
+		n.tierLister = tierInformer.Lister()
+		n.tierListerSynced = tierInformer.Informer().HasSynced
+		tierInformer.Informer().AddEventHandlerWithResyncPeriod(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc: n.addTier,
+			},
+			resyncPeriod,
+		)
+	}
+	// Register Informers and add handlers for Service and Endpoints events only
+	// if one of the Antrea-native policy features is enabled, as Services can
+	// only be referenced by the egress rules of Antrea-native policies.
+	if features.DefaultFeatureGate.Enabled(features.ClusterNetworkPolicy) || features.DefaultFeatureGate.Enabled(features.AntreaNetworkPolicy) {
+		n.serviceInformer = serviceInformer
+		n.serviceLister = serviceInformer.Lister()
+		n.serviceListerSynced = serviceInformer.Informer().HasSynced
+		serviceInformer.Informer().AddEventHandlerWithResyncPeriod(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc:    n.addService,
+				UpdateFunc: n.updateService,
+				DeleteFunc: n.deleteService,
+			},
+			resyncPeriod,
+		)
+		n.endpointsInformer = endpointsInformer
+		n.endpointsLister = endpointsInformer.Lister()
+		n.endpointsListerSynced = endpointsInformer.Informer().HasSynced
+		endpointsInformer.Informer().AddEventHandlerWithResyncPeriod(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc:    n.addEndpoints,
+				UpdateFunc: n.updateEndpoints,
+				DeleteFunc: n.deleteEndpoints,
+			},
+			resyncPeriod,
+		)
+	}
+	// Register Informer and add handlers for Node events only if one of the
+	// Antrea-native policy features is enabled, as Nodes can only be selected
+	// by the peers of Antrea-native policies.
+	if features.DefaultFeatureGate.Enabled(features.ClusterNetworkPolicy) || features.DefaultFeatureGate.Enabled(features.AntreaNetworkPolicy) {
+		n.nodeInformer = nodeInformer
+		n.nodeLister = nodeInformer.Lister()
+		n.nodeListerSynced = nodeInformer.Informer().HasSynced
+		nodeInformer.Informer().AddEventHandlerWithResyncPeriod(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc:    n.addNode,
+				UpdateFunc: n.updateNode,
+				DeleteFunc: n.deleteNode,
+			},
+			resyncPeriod,
+		)
+	}
+	// Register Informer and add handlers for ServiceAccount events, and index
+	// Pods by their ServiceAccounts, only if one of the Antrea-native policy
+	// features is enabled, as Pods can only be selected by their
+	// ServiceAccounts in Antrea-native policies.
+	if features.DefaultFeatureGate.Enabled(features.ClusterNetworkPolicy) || features.DefaultFeatureGate.Enabled(features.AntreaNetworkPolicy) {
+		n.serviceAccountInformer = serviceAccountInformer
+		n.serviceAccountLister = serviceAccountInformer.Lister()
+		n.serviceAccountListerSynced = serviceAccountInformer.Informer().HasSynced
+		serviceAccountInformer.Informer().AddEventHandlerWithResyncPeriod(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc:    n.addServiceAccount,
+				UpdateFunc: n.updateServiceAccount,
+				DeleteFunc: n.deleteServiceAccount,
+			},
+			resyncPeriod,
+		)
+		podInformer.Informer().AddIndexers(cache.Indexers{serviceAccountIndex: podServiceAccountIndexFunc})
+		n.podIndexer = podInformer.Informer().GetIndexer()
+	}
 	// Register Informer and add handlers for ClusterNetworkPolicy events only if the feature is enabled.
 	if features.DefaultFeatureGate.Enabled(features.ClusterNetworkPolicy) {
 		n.cnpInformer = cnpInformer
@@ -232,6 +389,48 @@ func NewNetworkPolicyController(kubeClient clientset.Interface,
 			},
 			resyncPeriod,
 		)
+		// ClusterGroups can only be referenced by ClusterNetworkPolicies.
+		n.cgInformer = cgInformer
+		n.cgLister = cgInformer.Lister()
+		n.cgListerSynced = cgInformer.Informer().HasSynced
+		cgInformer.Informer().AddEventHandlerWithResyncPeriod(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc:    n.addClusterGroup,
+				UpdateFunc: n.updateClusterGroup,
+				DeleteFunc: n.deleteClusterGroup,
+			},
+			resyncPeriod,
+		)
+	}
+	// Register Informer and add handlers for Antrea NetworkPolicy events only if the feature is enabled.
+	if features.DefaultFeatureGate.Enabled(features.AntreaNetworkPolicy) {
+		n.anpInformer = anpInformer
+		n.anpLister = anpInformer.Lister()
+		n.anpListerSynced = anpInformer.Informer().HasSynced
+		anpInformer.Informer().AddEventHandlerWithResyncPeriod(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc:    n.addANP,
+				UpdateFunc: n.updateANP,
+				DeleteFunc: n.deleteANP,
+			},
+			resyncPeriod,
+		)
+	}
+	// Register Informer and add handlers for ExternalEntity events only if one
+	// of the Antrea-native policy features is enabled, as ExternalEntities can
+	// only be selected by Antrea-native policies.
+	if features.DefaultFeatureGate.Enabled(features.ClusterNetworkPolicy) || features.DefaultFeatureGate.Enabled(features.AntreaNetworkPolicy) {
+		n.externalEntityInformer = externalEntityInformer
+		n.externalEntityLister = externalEntityInformer.Lister()
+		n.externalEntityListerSynced = externalEntityInformer.Informer().HasSynced
+		externalEntityInformer.Informer().AddEventHandlerWithResyncPeriod(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc:    n.addExternalEntity,
+				UpdateFunc: n.updateExternalEntity,
+				DeleteFunc: n.deleteExternalEntity,
+			},
+			resyncPeriod,
+		)
 	}
 	return n
 }
@@ -265,14 +464,18 @@ func (n *NetworkPolicyController) GetConnectedAgentNum() int {
 	return n.internalNetworkPolicyStore.GetWatchersNum()
 }
 
-// toGroupSelector converts the podSelector and namespaceSelector
+// toGroupSelector converts the podSelector, namespaceSelector, externalEntitySelector
 // and NetworkPolicy Namespace to a networkpolicy.GroupSelector object.
-func toGroupSelector(namespace string, podSelector, nsSelector *metav1.LabelSelector) *antreatypes.GroupSelector {
+func toGroupSelector(namespace string, podSelector, nsSelector, extEntitySelector *metav1.LabelSelector) *antreatypes.GroupSelector {
 	groupSelector := antreatypes.GroupSelector{}
 	if podSelector != nil {
 		pSelector, _ := metav1.LabelSelectorAsSelector(podSelector)
 		groupSelector.PodSelector = pSelector
 	}
+	if extEntitySelector != nil {
+		eSelector, _ := metav1.LabelSelectorAsSelector(extEntitySelector)
+		groupSelector.ExternalEntitySelector = eSelector
+	}
 	if nsSelector == nil {
 		// No namespaceSelector indicates that the pods must be selected within
 		// the NetworkPolicy's Namespace.
@@ -281,7 +484,7 @@ func toGroupSelector(namespace string, podSelector, nsSelector *metav1.LabelSele
 		nSelector, _ := metav1.LabelSelectorAsSelector(nsSelector)
 		groupSelector.NamespaceSelector = nSelector
 	}
-	name := generateNormalizedName(groupSelector.Namespace, groupSelector.PodSelector, groupSelector.NamespaceSelector)
+	name := generateNormalizedName(groupSelector.Namespace, groupSelector.PodSelector, groupSelector.NamespaceSelector, groupSelector.ExternalEntitySelector)
 	groupSelector.NormalizedName = name
 	return &groupSelector
 }
@@ -297,7 +500,7 @@ func getNormalizedUID(name string) string {
 // the following format: "namespace=NamespaceName And podSelector=normalizedPodSelector".
 // Note: Namespace and nsSelector may or may not be set depending on the
 // selector. However, they cannot be set simultaneously.
-func generateNormalizedName(namespace string, podSelector, nsSelector labels.Selector) string {
+func generateNormalizedName(namespace string, podSelector, nsSelector, eeSelector labels.Selector) string {
 	normalizedName := []string{}
 	if nsSelector != nil {
 		normalizedName = append(normalizedName, fmt.Sprintf("namespaceSelector=%s", nsSelector.String()))
@@ -307,13 +510,16 @@ func generateNormalizedName(namespace string, podSelector, nsSelector labels.Sel
 	if podSelector != nil {
 		normalizedName = append(normalizedName, fmt.Sprintf("podSelector=%s", podSelector.String()))
 	}
+	if eeSelector != nil {
+		normalizedName = append(normalizedName, fmt.Sprintf("externalEntitySelector=%s", eeSelector.String()))
+	}
 	sort.Strings(normalizedName)
 	return strings.Join(normalizedName, " And ")
 }
 
 // createAppliedToGroup creates an AppliedToGroup object in store if it is not created already.
-func (n *NetworkPolicyController) createAppliedToGroup(npNsName string, pSel, nSel *metav1.LabelSelector) string {
-	groupSelector := toGroupSelector(npNsName, pSel, nSel)
+func (n *NetworkPolicyController) createAppliedToGroup(npNsName string, pSel, nSel, eSel *metav1.LabelSelector) string {
+	groupSelector := toGroupSelector(npNsName, pSel, nSel, eSel)
 	appliedToGroupUID := getNormalizedUID(groupSelector.NormalizedName)
 	// Get or create a AppliedToGroup for the generated UID.
 	_, found, _ := n.appliedToGroupStore.Get(appliedToGroupUID)
@@ -332,51 +538,72 @@ func (n *NetworkPolicyController) createAppliedToGroup(npNsName string, pSel, nS
 	return appliedToGroupUID
 }
 
-// labelsMatchGroupSelector matches a Pod's labels to the
-// GroupSelector object and returns true, if and only if the labels
+// labelsMatchGroupSelector matches a Pod's or an ExternalEntity's labels to
+// the GroupSelector object and returns true, if and only if the labels
 // match any of the selector criteria present in the GroupSelector.
-func (n *NetworkPolicyController) labelsMatchGroupSelector(pod *v1.Pod, podNS *v1.Namespace, sel *antreatypes.GroupSelector) bool {
+func (n *NetworkPolicyController) labelsMatchGroupSelector(obj metav1.Object, ns *v1.Namespace, sel *antreatypes.GroupSelector) bool {
+	if sel.ServiceAccountSelector != nil {
+		// Only Pods can be selected by their ServiceAccounts.
+		pod, ok := obj.(*v1.Pod)
+		return ok && n.podMatchesServiceAccountSelector(pod, sel)
+	}
+	var objSelector labels.Selector
+	if _, ok := obj.(*v1alpha1.ExternalEntity); ok {
+		if sel.ExternalEntitySelector == nil {
+			// ExternalEntities can only be selected by an externalEntitySelector.
+			return false
+		}
+		objSelector = sel.ExternalEntitySelector
+	} else {
+		if sel.ExternalEntitySelector != nil {
+			// Pods cannot be selected by a group which selects ExternalEntities.
+			return false
+		}
+		objSelector = sel.PodSelector
+	}
+	objLabels := labels.Set(obj.GetLabels())
 	if sel.Namespace != "" {
-		if sel.Namespace != pod.Namespace {
-			// Pods must be matched within the same Namespace.
+		if sel.Namespace != obj.GetNamespace() {
+			// Pods or ExternalEntities must be matched within the same Namespace.
 			return false
 		}
-		if !sel.PodSelector.Matches(labels.Set(pod.Labels)) {
-			// podSelector does not match the Pod's labels.
+		if !objSelector.Matches(objLabels) {
+			// podSelector or externalEntitySelector does not match the labels.
 			return false
 		}
-		// podSelector matches the Pod's labels.
+		// podSelector or externalEntitySelector matches the labels.
 		return true
-	} else if sel.NamespaceSelector != nil && sel.PodSelector != nil {
-		// Pod event may arrive before Pod's Namespace event. In this case, we must
-		// ensure that the Pod Namespace is not nil.
-		if podNS == nil || !sel.NamespaceSelector.Matches(labels.Set(podNS.Labels)) {
-			// Pod's Namespace do not match namespaceSelector.
+	} else if sel.NamespaceSelector != nil && objSelector != nil {
+		// Pod or ExternalEntity event may arrive before its Namespace event.
+		// In this case, we must ensure that the Namespace is not nil.
+		if ns == nil || !sel.NamespaceSelector.Matches(labels.Set(ns.Labels)) {
+			// Namespace do not match namespaceSelector.
 			return false
 		}
-		if !sel.PodSelector.Matches(labels.Set(pod.Labels)) {
-			// Pod's Namespace matches namespaceSelector but Pod's labels do not match
-			// the podSelector.
+		if !objSelector.Matches(objLabels) {
+			// Namespace matches namespaceSelector but labels do not match the
+			// podSelector or externalEntitySelector.
 			return false
 		}
-		// Pod's Namespace matches namespaceSelector and Pod's labels matches
-		// podSelector.
+		// Namespace matches namespaceSelector and labels match
+		// podSelector or externalEntitySelector.
 		return true
 	} else if sel.NamespaceSelector != nil {
 		// Selector only has a NamespaceSelector.
 		// Pod event may arrive before Pod's Namespace event. In this case, we must
 		// ensure that the Pod Namespace is not nil.
-		if podNS == nil || !sel.NamespaceSelector.Matches(labels.Set(podNS.Labels)) {
+		if ns == nil || !sel.NamespaceSelector.Matches(labels.Set(ns.Labels)) {
 			// Namespace labels do not match namespaceSelector.
 			return false
 		}
 		// Namespace labels match namespaceSelector.
 		return true
-	} else if sel.PodSelector != nil {
-		// Selector only has a PodSelector and no sel.Namespace. Pods must be matched
-		// from all Namespaces.
-		if !sel.PodSelector.Matches(labels.Set(pod.Labels)) {
-			// pod labels do not match PodSelector.
+	} else if objSelector != nil {
+		// Selector only has a PodSelector or an ExternalEntitySelector and no
+		// sel.Namespace. Pods or ExternalEntities must be matched from all
+		// Namespaces.
+		if !objSelector.Matches(objLabels) {
+			// labels do not match PodSelector or ExternalEntitySelector.
 			return false
 		}
 		return true
@@ -401,39 +628,39 @@ func (n *NetworkPolicyController) filterAddressGroupsForNamespace(namespace *v1.
 	return matchingKeys
 }
 
-// filterAddressGroupsForPod computes a list of AddressGroup keys which
-// match the Pod's labels.
-func (n *NetworkPolicyController) filterAddressGroupsForPod(pod *v1.Pod) sets.String {
+// filterAddressGroupsForPodOrExternalEntity computes a list of AddressGroup
+// keys which match the Pod's or the ExternalEntity's labels.
+func (n *NetworkPolicyController) filterAddressGroupsForPodOrExternalEntity(obj metav1.Object) sets.String {
 	matchingKeySet := sets.String{}
-	// AddressGroups that are in this namespace or that are cluster scoped can possibly select this Pod.
-	localAddressGroups, _ := n.addressGroupStore.GetByIndex(cache.NamespaceIndex, pod.Namespace)
+	// AddressGroups that are in this namespace or that are cluster scoped can possibly select this Pod or ExternalEntity.
+	localAddressGroups, _ := n.addressGroupStore.GetByIndex(cache.NamespaceIndex, obj.GetNamespace())
 	clusterScopedAddressGroups, _ := n.addressGroupStore.GetByIndex(cache.NamespaceIndex, "")
-	podNS, _ := n.namespaceLister.Get(pod.Namespace)
+	ns, _ := n.namespaceLister.Get(obj.GetNamespace())
 	for _, group := range append(localAddressGroups, clusterScopedAddressGroups...) {
 		addrGroup := group.(*antreatypes.AddressGroup)
-		if n.labelsMatchGroupSelector(pod, podNS, &addrGroup.Selector) {
+		if n.labelsMatchGroupSelector(obj, ns, &addrGroup.Selector) {
 			matchingKeySet.Insert(addrGroup.Name)
-			klog.V(2).Infof("Pod %s/%s matched AddressGroup %s", pod.Namespace, pod.Name, addrGroup.Name)
+			klog.V(2).Infof("%s/%s matched AddressGroup %s", obj.GetNamespace(), obj.GetName(), addrGroup.Name)
 		}
 	}
 	return matchingKeySet
 }
 
-// filterAppliedToGroupsForPod computes a list of AppliedToGroup keys which
-// match the Pod's labels.
-func (n *NetworkPolicyController) filterAppliedToGroupsForPod(pod *v1.Pod) sets.String {
+// filterAppliedToGroupsForPodOrExternalEntity computes a list of
+// AppliedToGroup keys which match the Pod's or the ExternalEntity's labels.
+func (n *NetworkPolicyController) filterAppliedToGroupsForPodOrExternalEntity(obj metav1.Object) sets.String {
 	matchingKeySet := sets.String{}
 	// Get appliedToGroups from the namespace level
-	appliedToGroups, _ := n.appliedToGroupStore.GetByIndex(cache.NamespaceIndex, pod.Namespace)
+	appliedToGroups, _ := n.appliedToGroupStore.GetByIndex(cache.NamespaceIndex, obj.GetNamespace())
 	// Get appliedToGroups from the cluster level
 	clusterATGroups, _ := n.appliedToGroupStore.GetByIndex(cache.NamespaceIndex, "")
 	appliedToGroups = append(appliedToGroups, clusterATGroups...)
-	podNS, _ := n.namespaceLister.Get(pod.Namespace)
+	ns, _ := n.namespaceLister.Get(obj.GetNamespace())
 	for _, group := range appliedToGroups {
 		appGroup := group.(*antreatypes.AppliedToGroup)
-		if n.labelsMatchGroupSelector(pod, podNS, &appGroup.Selector) {
+		if n.labelsMatchGroupSelector(obj, ns, &appGroup.Selector) {
 			matchingKeySet.Insert(appGroup.Name)
-			klog.V(2).Infof("Pod %s/%s matched AppliedToGroup %s", pod.Namespace, pod.Name, appGroup.Name)
+			klog.V(2).Infof("%s/%s matched AppliedToGroup %s", obj.GetNamespace(), obj.GetName(), appGroup.Name)
 		}
 	}
 	return matchingKeySet
@@ -444,7 +671,7 @@ func (n *NetworkPolicyController) filterAppliedToGroupsForPod(pod *v1.Pod) sets.
 // creates the object without actually populating the PodAddresses as the
 // affected Pods are calculated during sync process.
 func (n *NetworkPolicyController) createAddressGroup(peer networkingv1.NetworkPolicyPeer, np *networkingv1.NetworkPolicy) string {
-	groupSelector := toGroupSelector(np.ObjectMeta.Namespace, peer.PodSelector, peer.NamespaceSelector)
+	groupSelector := toGroupSelector(np.ObjectMeta.Namespace, peer.PodSelector, peer.NamespaceSelector, nil)
 	normalizedUID := getNormalizedUID(groupSelector.NormalizedName)
 	// Get or create an AddressGroup for the generated UID.
 	_, found, _ := n.addressGroupStore.Get(normalizedUID)
@@ -515,7 +742,7 @@ func toAntreaIPBlock(ipBlock *networkingv1.IPBlock) (*networking.IPBlock, error)
 // wherein, it will be either stored as a new Object in case of ADD event or
 // modified and store the updated instance, in case of an UPDATE event.
 func (n *NetworkPolicyController) processNetworkPolicy(np *networkingv1.NetworkPolicy) *antreatypes.NetworkPolicy {
-	appliedToGroupKey := n.createAppliedToGroup(np.Namespace, &np.Spec.PodSelector, nil)
+	appliedToGroupKey := n.createAppliedToGroup(np.Namespace, &np.Spec.PodSelector, nil, nil)
 	appliedToGroupNames := []string{appliedToGroupKey}
 	rules := make([]networking.NetworkPolicyRule, 0, len(np.Spec.Ingress)+len(np.Spec.Egress))
 	var ingressRuleExists, egressRuleExists bool
@@ -719,9 +946,9 @@ func (n *NetworkPolicyController) addPod(obj interface{}) {
 	pod := obj.(*v1.Pod)
 	klog.V(2).Infof("Processing Pod %s/%s ADD event, labels: %v", pod.Namespace, pod.Name, pod.Labels)
 	// Find all AppliedToGroup keys which match the Pod's labels.
-	appliedToGroupKeySet := n.filterAppliedToGroupsForPod(pod)
+	appliedToGroupKeySet := n.filterAppliedToGroupsForPodOrExternalEntity(pod)
 	// Find all AddressGroup keys which match the Pod's labels.
-	addressGroupKeySet := n.filterAddressGroupsForPod(pod)
+	addressGroupKeySet := n.filterAddressGroupsForPodOrExternalEntity(pod)
 	// Enqueue groups to their respective queues for group processing.
 	for group := range appliedToGroupKeySet {
 		n.enqueueAppliedToGroup(group)
@@ -747,11 +974,11 @@ func (n *NetworkPolicyController) updatePod(oldObj, curObj interface{}) {
 		return
 	}
 	// Find groups matching the old Pod's labels.
-	oldAddressGroupKeySet := n.filterAddressGroupsForPod(oldPod)
-	oldAppliedToGroupKeySet := n.filterAppliedToGroupsForPod(oldPod)
+	oldAddressGroupKeySet := n.filterAddressGroupsForPodOrExternalEntity(oldPod)
+	oldAppliedToGroupKeySet := n.filterAppliedToGroupsForPodOrExternalEntity(oldPod)
 	// Find groups matching the new Pod's labels.
-	curAppliedToGroupKeySet := n.filterAppliedToGroupsForPod(curPod)
-	curAddressGroupKeySet := n.filterAddressGroupsForPod(curPod)
+	curAppliedToGroupKeySet := n.filterAppliedToGroupsForPodOrExternalEntity(curPod)
+	curAddressGroupKeySet := n.filterAddressGroupsForPodOrExternalEntity(curPod)
 	// Create set to hold the group keys to enqueue.
 	var appliedToGroupKeys sets.String
 	var addressGroupKeys sets.String
@@ -801,9 +1028,9 @@ func (n *NetworkPolicyController) deletePod(old interface{}) {
 
 	klog.V(2).Infof("Processing Pod %s/%s DELETE event, labels: %v", pod.Namespace, pod.Name, pod.Labels)
 	// Find all AppliedToGroup keys which match the Pod's labels.
-	appliedToGroupKeys := n.filterAppliedToGroupsForPod(pod)
+	appliedToGroupKeys := n.filterAppliedToGroupsForPodOrExternalEntity(pod)
 	// Find all AddressGroup keys which match the Pod's labels.
-	addressGroupKeys := n.filterAddressGroupsForPod(pod)
+	addressGroupKeys := n.filterAddressGroupsForPodOrExternalEntity(pod)
 	// Enqueue groups to their respective queues for group processing.
 	for group := range appliedToGroupKeys {
 		n.enqueueAppliedToGroup(group)
@@ -950,6 +1177,7 @@ func (n *NetworkPolicyController) Run(stopCh <-chan struct{}) {
 	defer n.appliedToGroupQueue.ShutDown()
 	defer n.addressGroupQueue.ShutDown()
 	defer n.internalNetworkPolicyQueue.ShutDown()
+	defer n.networkPolicyStatusQueue.ShutDown()
 
 	klog.Info("Starting NetworkPolicy controller")
 	defer klog.Info("Shutting down NetworkPolicy controller")
@@ -959,19 +1187,42 @@ func (n *NetworkPolicyController) Run(stopCh <-chan struct{}) {
 		klog.Error("Unable to sync caches for NetworkPolicy controller")
 		return
 	}
-	// Only wait for CNPListerSynced when ClusterNetworkPolicy feature gate is enabled.
+	// Only wait for CNPListerSynced and CGListerSynced when ClusterNetworkPolicy
+	// feature gate is enabled.
 	if features.DefaultFeatureGate.Enabled(features.ClusterNetworkPolicy) {
-		if !cache.WaitForCacheSync(stopCh, n.cnpListerSynced) {
+		if !cache.WaitForCacheSync(stopCh, n.cnpListerSynced, n.cgListerSynced) {
 			klog.Error("Unable to sync CNP caches for NetworkPolicy controller")
 			return
 		}
 	}
+	// Only wait for ANPListerSynced when AntreaNetworkPolicy feature gate is
+	// enabled.
+	if features.DefaultFeatureGate.Enabled(features.AntreaNetworkPolicy) {
+		if !cache.WaitForCacheSync(stopCh, n.anpListerSynced) {
+			klog.Error("Unable to sync ANP caches for NetworkPolicy controller")
+			return
+		}
+	}
+	// Only wait for TierListerSynced, ServiceListerSynced,
+	// EndpointsListerSynced, NodeListerSynced, ServiceAccountListerSynced and
+	// ExternalEntityListerSynced when one of the Antrea-native policy features
+	// is enabled.
+	if features.DefaultFeatureGate.Enabled(features.ClusterNetworkPolicy) || features.DefaultFeatureGate.Enabled(features.AntreaNetworkPolicy) {
+		if !cache.WaitForCacheSync(stopCh, n.tierListerSynced, n.serviceListerSynced, n.endpointsListerSynced, n.nodeListerSynced, n.serviceAccountListerSynced, n.externalEntityListerSynced) {
+			klog.Error("Unable to sync Tier caches for NetworkPolicy controller")
+			return
+		}
+		// Create the default Tiers once the Tier cache is synced, so that
+		// only the missing ones are created.
+		n.initializeTiers()
+	}
 	klog.Info("Caches are synced for NetworkPolicy controller")
 
 	for i := 0; i < defaultWorkers; i++ {
 		go wait.Until(n.appliedToGroupWorker, time.Second, stopCh)
 		go wait.Until(n.addressGroupWorker, time.Second, stopCh)
 		go wait.Until(n.internalNetworkPolicyWorker, time.Second, stopCh)
+		go wait.Until(n.networkPolicyStatusWorker, time.Second, stopCh)
 	}
 	<-stopCh
 }
@@ -1086,9 +1337,60 @@ func (n *NetworkPolicyController) processNextAppliedToGroupWorkItem() bool {
 	return true
 }
 
+// processSelector retrieves all the Pods and ExternalEntities which match the
+// given GroupSelector. ExternalEntities are only retrieved if the group selects
+// ExternalEntities, in which case no Pod is retrieved.
+func (n *NetworkPolicyController) processSelector(groupSelector antreatypes.GroupSelector) ([]*v1.Pod, []*v1alpha1.ExternalEntity) {
+	var pods []*v1.Pod
+	var externalEntities []*v1alpha1.ExternalEntity
+	if groupSelector.ServiceAccountSelector != nil {
+		return n.serviceAccountSelectorToPods(groupSelector), nil
+	}
+	if groupSelector.ExternalEntitySelector != nil && n.externalEntityLister == nil {
+		// ExternalEntities are not watched when the AntreaNetworkPolicy
+		// feature is disabled.
+		return nil, nil
+	}
+	if groupSelector.Namespace != "" {
+		// Namespace presence indicates Pods or ExternalEntities must be selected from the same Namespace.
+		if groupSelector.ExternalEntitySelector != nil {
+			externalEntities, _ = n.externalEntityLister.ExternalEntities(groupSelector.Namespace).List(groupSelector.ExternalEntitySelector)
+		} else {
+			pods, _ = n.podLister.Pods(groupSelector.Namespace).List(groupSelector.PodSelector)
+		}
+	} else if groupSelector.NamespaceSelector != nil {
+		// Pods or ExternalEntities must be selected from Namespaces matching nsSelector.
+		// All the Pods from these Namespaces are selected if there is neither
+		// podSelector nor externalEntitySelector.
+		namespaces, _ := n.namespaceLister.List(groupSelector.NamespaceSelector)
+		for _, ns := range namespaces {
+			if groupSelector.ExternalEntitySelector != nil {
+				nsExternalEntities, _ := n.externalEntityLister.ExternalEntities(ns.Name).List(groupSelector.ExternalEntitySelector)
+				externalEntities = append(externalEntities, nsExternalEntities...)
+			} else if groupSelector.PodSelector != nil {
+				nsPods, _ := n.podLister.Pods(ns.Name).List(groupSelector.PodSelector)
+				pods = append(pods, nsPods...)
+			} else {
+				nsPods, _ := n.podLister.Pods(ns.Name).List(labels.Everything())
+				pods = append(pods, nsPods...)
+			}
+		}
+	} else if groupSelector.ExternalEntitySelector != nil {
+		// Lack of Namespace and NamespaceSelector indicates ExternalEntities
+		// must be selected from all Namespaces.
+		externalEntities, _ = n.externalEntityLister.ExternalEntities("").List(groupSelector.ExternalEntitySelector)
+	} else if groupSelector.PodSelector != nil {
+		// Lack of Namespace and NamespaceSelector indicates Pods must be selected
+		// from all Namespaces.
+		pods, _ = n.podLister.Pods("").List(groupSelector.PodSelector)
+	}
+	return pods, externalEntities
+}
+
 // syncAddressGroup retrieves all the internal NetworkPolicies which have a
-// reference to this AddressGroup and updates it's Pod IPAddresses set to
-// reflect the current state of affected Pods based on the GroupSelector.
+// reference to this AddressGroup and updates it's Pod IPAddresses set and
+// GroupMembers set to reflect the current state of affected Pods and
+// ExternalEntities based on the GroupSelector.
 func (n *NetworkPolicyController) syncAddressGroup(key string) error {
 	startTime := time.Now()
 	defer func() {
@@ -1108,7 +1410,6 @@ func (n *NetworkPolicyController) syncAddressGroup(key string) error {
 		return nil
 	}
 	addressGroup := addressGroupObj.(*antreatypes.AddressGroup)
-	var pods []*v1.Pod
 	// NodeNames set must be considered immutable once generated and updated
 	// in the store. If any change is needed, the set must be regenerated with
 	// the new NodeNames and the store must be updated.
@@ -1117,46 +1418,37 @@ func (n *NetworkPolicyController) syncAddressGroup(key string) error {
 		internalNP := internalNPObj.(*antreatypes.NetworkPolicy)
 		addrGroupNodeNames = addrGroupNodeNames.Union(internalNP.SpanMeta.NodeNames)
 	}
-	// Find all Pods matching its selectors and update store.
-	groupSelector := addressGroup.Selector
-	if groupSelector.Namespace != "" {
-		// Namespace presence indicates Pods must be selected from the same Namespace.
-		pods, _ = n.podLister.Pods(groupSelector.Namespace).List(groupSelector.PodSelector)
-	} else if groupSelector.NamespaceSelector != nil && groupSelector.PodSelector != nil {
-		// Pods must be selected from Namespaces matching nsSelector.
-		namespaces, _ := n.namespaceLister.List(groupSelector.NamespaceSelector)
-		for _, ns := range namespaces {
-			nsPods, _ := n.podLister.Pods(ns.Name).List(groupSelector.PodSelector)
-			pods = append(pods, nsPods...)
-		}
-	} else if groupSelector.NamespaceSelector != nil {
-		// All the Pods from Namespaces matching the nsSelector must be selected.
-		namespaces, _ := n.namespaceLister.List(groupSelector.NamespaceSelector)
-		for _, ns := range namespaces {
-			nsPods, _ := n.podLister.Pods(ns.Name).List(labels.Everything())
-			pods = append(pods, nsPods...)
-		}
-	} else if groupSelector.PodSelector != nil {
-		// Lack of Namespace and NamespaceSelector indicates Pods must be selected
-		// from all Namespaces.
-		pods, _ = n.podLister.Pods("").List(groupSelector.PodSelector)
-	}
 	podSet := networking.GroupMemberPodSet{}
-	for _, pod := range pods {
-		if pod.Status.PodIP == "" {
-			// No need to insert Pod IPAddress when it is unset.
-			continue
+	memberSet := networking.GroupMemberSet{}
+	if addressGroup.Selector.ServiceReference != nil {
+		// Find the ClusterIP and the Endpoints of the Service and update store.
+		podSet = n.serviceToMemberPods(addressGroup.Selector.ServiceReference)
+	} else if addressGroup.Selector.NodeSelector != nil {
+		// Find the IPs of all Nodes matching its selector and update store.
+		podSet = n.nodeSelectorToMemberPods(addressGroup.Selector.NodeSelector)
+	} else {
+		// Find all Pods and ExternalEntities matching its selectors and update store.
+		pods, externalEntities := n.processSelector(addressGroup.Selector)
+		for _, pod := range pods {
+			if pod.Status.PodIP == "" {
+				// No need to insert Pod IPAddress when it is unset.
+				continue
+			}
+			podSet.Insert(podToMemberPod(pod, true, false))
+		}
+		for _, entity := range externalEntities {
+			memberSet.Insert(externalEntityToGroupMember(entity))
 		}
-		podSet.Insert(podToMemberPod(pod, true, false))
 	}
 	updatedAddressGroup := &antreatypes.AddressGroup{
-		Name:     addressGroup.Name,
-		UID:      addressGroup.UID,
-		Selector: addressGroup.Selector,
-		Pods:     podSet,
-		SpanMeta: antreatypes.SpanMeta{NodeNames: addrGroupNodeNames},
-	}
-	klog.V(2).Infof("Updating existing AddressGroup %s with %d addresses and %d Nodes", key, len(podSet), addrGroupNodeNames.Len())
+		Name:         addressGroup.Name,
+		UID:          addressGroup.UID,
+		Selector:     addressGroup.Selector,
+		Pods:         podSet,
+		GroupMembers: memberSet,
+		SpanMeta:     antreatypes.SpanMeta{NodeNames: addrGroupNodeNames},
+	}
+	klog.V(2).Infof("Updating existing AddressGroup %s with %d addresses, %d GroupMembers and %d Nodes", key, len(podSet), len(memberSet), addrGroupNodeNames.Len())
 	n.addressGroupStore.Update(updatedAddressGroup)
 	return nil
 }
@@ -1194,10 +1486,40 @@ func podToMemberPod(pod *v1.Pod, includeIP, includePodRef bool) *networking.Grou
 	return memberPod
 }
 
+// externalEntityToGroupMember is util function to convert an ExternalEntity to
+// a GroupMember type. A networking.Endpoint item will be set in the GroupMember
+// for each Endpoint of the ExternalEntity.
+func externalEntityToGroupMember(ee *v1alpha1.ExternalEntity) *networking.GroupMember {
+	memberEntity := &networking.GroupMember{
+		ExternalEntity: &networking.ExternalEntityReference{
+			Name:      ee.Name,
+			Namespace: ee.Namespace,
+		},
+	}
+	for _, endpoint := range ee.Spec.Endpoints {
+		ep := networking.Endpoint{IP: ipStrToIPAddress(endpoint.IP)}
+		for _, port := range endpoint.Ports {
+			// The protocol of an ExternalEntity port defaults to TCP, so that
+			// named ports can be resolved the same way as Pod container ports.
+			protocol := networking.ProtocolTCP
+			if port.Protocol != "" {
+				protocol = networking.Protocol(port.Protocol)
+			}
+			ep.Ports = append(ep.Ports, networking.NamedPort{
+				Port:     port.Port,
+				Name:     port.Name,
+				Protocol: protocol,
+			})
+		}
+		memberEntity.Endpoints = append(memberEntity.Endpoints, ep)
+	}
+	return memberEntity
+}
+
 // syncAppliedToGroup enqueues all the internal NetworkPolicy keys that
-// refer this AppliedToGroup and update the AppliedToGroup Pod
-// references by Node to reflect the latest set of affected Pods based
-// on it's GroupSelector.
+// refer this AppliedToGroup and update the AppliedToGroup Pod and
+// GroupMember references by Node to reflect the latest set of affected
+// Pods and ExternalEntities based on it's GroupSelector.
 func (n *NetworkPolicyController) syncAppliedToGroup(key string) error {
 	startTime := time.Now()
 	defer func() {
@@ -1206,7 +1528,7 @@ func (n *NetworkPolicyController) syncAppliedToGroup(key string) error {
 		klog.V(2).Infof("Finished syncing AppliedToGroup %s. (%v)", key, d)
 	}()
 	podSetByNode := make(map[string]networking.GroupMemberPodSet)
-	var pods []*v1.Pod
+	memberSetByNode := make(map[string]networking.GroupMemberSet)
 	appGroupNodeNames := sets.String{}
 	appliedToGroupObj, found, err := n.appliedToGroupStore.Get(key)
 	if !found {
@@ -1214,29 +1536,7 @@ func (n *NetworkPolicyController) syncAppliedToGroup(key string) error {
 		return nil
 	}
 	appliedToGroup := appliedToGroupObj.(*antreatypes.AppliedToGroup)
-	groupSelector := appliedToGroup.Selector
-	if groupSelector.Namespace != "" {
-		// AppliedTo Group was created for k8s networkpolicy and must select pods in its own namespace.
-		pods, _ = n.podLister.Pods(groupSelector.Namespace).List(groupSelector.PodSelector)
-	} else if groupSelector.NamespaceSelector != nil && groupSelector.PodSelector != nil {
-		// Pods must be selected from Namespaces matching nsSelector.
-		namespaces, _ := n.namespaceLister.List(groupSelector.NamespaceSelector)
-		for _, ns := range namespaces {
-			nsPods, _ := n.podLister.Pods(ns.Name).List(groupSelector.PodSelector)
-			pods = append(pods, nsPods...)
-		}
-	} else if groupSelector.NamespaceSelector != nil {
-		// All the Pods from Namespaces matching the nsSelector must be selected.
-		namespaces, _ := n.namespaceLister.List(groupSelector.NamespaceSelector)
-		for _, ns := range namespaces {
-			nsPods, _ := n.podLister.Pods(ns.Name).List(labels.Everything())
-			pods = append(pods, nsPods...)
-		}
-	} else if groupSelector.PodSelector != nil && groupSelector.Namespace == "" {
-		// Lack of Namespace and NamespaceSelector indicates Pods must be selected
-		// from all Namespaces.
-		pods, _ = n.podLister.Pods("").List(groupSelector.PodSelector)
-	}
+	pods, externalEntities := n.processSelector(appliedToGroup.Selector)
 	scheduledPodNum := 0
 	for _, pod := range pods {
 		if pod.Spec.NodeName == "" {
@@ -1254,14 +1554,32 @@ func (n *NetworkPolicyController) syncAppliedToGroup(key string) error {
 		// Update the NodeNames in order to set the SpanMeta for AppliedToGroup.
 		appGroupNodeNames.Insert(pod.Spec.NodeName)
 	}
-	updatedAppliedToGroup := &antreatypes.AppliedToGroup{
-		UID:        appliedToGroup.UID,
-		Name:       appliedToGroup.Name,
-		Selector:   appliedToGroup.Selector,
-		PodsByNode: podSetByNode,
-		SpanMeta:   antreatypes.SpanMeta{NodeNames: appGroupNodeNames},
+	externalEntityNum := 0
+	for _, entity := range externalEntities {
+		if entity.Spec.ExternalNode == "" {
+			// No need to process ExternalEntity when it's not handled by any Node.
+			continue
+		}
+		externalEntityNum++
+		memberSet := memberSetByNode[entity.Spec.ExternalNode]
+		if memberSet == nil {
+			memberSet = networking.GroupMemberSet{}
+		}
+		memberSet.Insert(externalEntityToGroupMember(entity))
+		// Update the GroupMembers by ExternalNode.
+		memberSetByNode[entity.Spec.ExternalNode] = memberSet
+		// Update the NodeNames in order to set the SpanMeta for AppliedToGroup.
+		appGroupNodeNames.Insert(entity.Spec.ExternalNode)
 	}
-	klog.V(2).Infof("Updating existing AppliedToGroup %s with %d Pods and %d Nodes", key, scheduledPodNum, appGroupNodeNames.Len())
+	updatedAppliedToGroup := &antreatypes.AppliedToGroup{
+		UID:               appliedToGroup.UID,
+		Name:              appliedToGroup.Name,
+		Selector:          appliedToGroup.Selector,
+		PodsByNode:        podSetByNode,
+		GroupMemberByNode: memberSetByNode,
+		SpanMeta:          antreatypes.SpanMeta{NodeNames: appGroupNodeNames},
+	}
+	klog.V(2).Infof("Updating existing AppliedToGroup %s with %d Pods, %d ExternalEntities and %d Nodes", key, scheduledPodNum, externalEntityNum, appGroupNodeNames.Len())
 	n.appliedToGroupStore.Update(updatedAppliedToGroup)
 
 	// Get all internal NetworkPolicy objects that refers this AppliedToGroup.
@@ -1324,6 +1642,8 @@ func (n *NetworkPolicyController) syncInternalNetworkPolicy(key string) error {
 		Rules:           internalNP.Rules,
 		AppliedToGroups: internalNP.AppliedToGroups,
 		Priority:        internalNP.Priority,
+		TierPriority:    internalNP.TierPriority,
+		Generation:      internalNP.Generation,
 		SpanMeta:        antreatypes.SpanMeta{NodeNames: nodeNames},
 	}
 	klog.V(4).Infof("Updating internal NetworkPolicy %s with %d Nodes", key, nodeNames.Len())
@@ -1331,6 +1651,11 @@ func (n *NetworkPolicyController) syncInternalNetworkPolicy(key string) error {
 	// Internal NetworkPolicy update is complete. Safe to unlock the
 	// critical section.
 	n.internalNetworkPolicyMutex.Unlock()
+	// The desired Nodes or the generation may have changed, the status of the
+	// Antrea-native policy must be recomputed.
+	if internalNP.Priority != nil {
+		n.enqueueNetworkPolicyStatus(key)
+	}
 	if nodeNames.Equal(oldNodeNames) {
 		// Node span for internal NetworkPolicy was not modified. No need to enqueue
 		// AddressGroups.
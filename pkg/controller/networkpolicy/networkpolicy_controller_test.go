@@ -51,8 +51,16 @@ type networkPolicyController struct {
 	*NetworkPolicyController
 	podStore                   cache.Store
 	namespaceStore             cache.Store
+	serviceStore               cache.Store
+	endpointsStore             cache.Store
+	nodeStore                  cache.Store
+	serviceAccountStore        cache.Store
 	networkPolicyStore         cache.Store
 	cnpStore                   cache.Store
+	anpStore                   cache.Store
+	tierStore                  cache.Store
+	externalEntityStore        cache.Store
+	cgStore                    cache.Store
 	appliedToGroupStore        storage.Interface
 	addressGroupStore          storage.Interface
 	internalNetworkPolicyStore storage.Interface
@@ -73,21 +81,55 @@ func newController(objects ...runtime.Object) (*fake.Clientset, *networkPolicyCo
 		crdClient,
 		informerFactory.Core().V1().Pods(),
 		informerFactory.Core().V1().Namespaces(),
+		informerFactory.Core().V1().Services(),
+		informerFactory.Core().V1().Endpoints(),
+		informerFactory.Core().V1().Nodes(),
+		informerFactory.Core().V1().ServiceAccounts(),
 		informerFactory.Networking().V1().NetworkPolicies(),
 		crdInformerFactory.Security().V1alpha1().ClusterNetworkPolicies(),
+		crdInformerFactory.Security().V1alpha1().NetworkPolicies(),
+		crdInformerFactory.Security().V1alpha1().Tiers(),
+		crdInformerFactory.Core().V1alpha1().ExternalEntities(),
+		crdInformerFactory.Core().V1alpha1().ClusterGroups(),
 		addressGroupStore,
 		appliedToGroupStore,
 		internalNetworkPolicyStore)
 	npController.podListerSynced = alwaysReady
 	npController.namespaceListerSynced = alwaysReady
 	npController.networkPolicyListerSynced = alwaysReady
+	npController.serviceLister = informerFactory.Core().V1().Services().Lister()
+	npController.serviceListerSynced = alwaysReady
+	npController.endpointsLister = informerFactory.Core().V1().Endpoints().Lister()
+	npController.endpointsListerSynced = alwaysReady
+	npController.nodeLister = informerFactory.Core().V1().Nodes().Lister()
+	npController.nodeListerSynced = alwaysReady
+	npController.serviceAccountLister = informerFactory.Core().V1().ServiceAccounts().Lister()
+	npController.serviceAccountListerSynced = alwaysReady
+	informerFactory.Core().V1().Pods().Informer().AddIndexers(cache.Indexers{serviceAccountIndex: podServiceAccountIndexFunc})
+	npController.podIndexer = informerFactory.Core().V1().Pods().Informer().GetIndexer()
+	npController.cnpLister = crdInformerFactory.Security().V1alpha1().ClusterNetworkPolicies().Lister()
 	npController.cnpListerSynced = alwaysReady
+	npController.anpListerSynced = alwaysReady
+	npController.tierLister = crdInformerFactory.Security().V1alpha1().Tiers().Lister()
+	npController.tierListerSynced = alwaysReady
+	npController.externalEntityLister = crdInformerFactory.Core().V1alpha1().ExternalEntities().Lister()
+	npController.externalEntityListerSynced = alwaysReady
+	npController.cgLister = crdInformerFactory.Core().V1alpha1().ClusterGroups().Lister()
+	npController.cgListerSynced = alwaysReady
 	return client, &networkPolicyController{
 		npController,
 		informerFactory.Core().V1().Pods().Informer().GetStore(),
 		informerFactory.Core().V1().Namespaces().Informer().GetStore(),
+		informerFactory.Core().V1().Services().Informer().GetStore(),
+		informerFactory.Core().V1().Endpoints().Informer().GetStore(),
+		informerFactory.Core().V1().Nodes().Informer().GetStore(),
+		informerFactory.Core().V1().ServiceAccounts().Informer().GetStore(),
 		informerFactory.Networking().V1().NetworkPolicies().Informer().GetStore(),
 		crdInformerFactory.Security().V1alpha1().ClusterNetworkPolicies().Informer().GetStore(),
+		crdInformerFactory.Security().V1alpha1().NetworkPolicies().Informer().GetStore(),
+		crdInformerFactory.Security().V1alpha1().Tiers().Informer().GetStore(),
+		crdInformerFactory.Core().V1alpha1().ExternalEntities().Informer().GetStore(),
+		crdInformerFactory.Core().V1alpha1().ClusterGroups().Informer().GetStore(),
 		appliedToGroupStore,
 		addressGroupStore,
 		internalNetworkPolicyStore,
@@ -122,7 +164,7 @@ func TestAddNetworkPolicy(t *testing.T) {
 	selectorC := metav1.LabelSelector{MatchLabels: map[string]string{"foo3": "bar3"}}
 	selectorAll := metav1.LabelSelector{}
 	matchAllPeerEgress := matchAllPeer
-	matchAllPeerEgress.AddressGroups = []string{getNormalizedUID(toGroupSelector("", nil, &selectorAll).NormalizedName)}
+	matchAllPeerEgress.AddressGroups = []string{getNormalizedUID(toGroupSelector("", nil, &selectorAll, nil).NormalizedName)}
 	tests := []struct {
 		name               string
 		inputPolicy        *networkingv1.NetworkPolicy
@@ -151,7 +193,7 @@ func TestAddNetworkPolicy(t *testing.T) {
 					Priority:  defaultRulePriority,
 					Action:    &defaultAction,
 				}},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil, nil).NormalizedName)},
 			},
 			expAppliedToGroups: 1,
 			expAddressGroups:   0,
@@ -177,7 +219,7 @@ func TestAddNetworkPolicy(t *testing.T) {
 					Priority:  defaultRulePriority,
 					Action:    &defaultAction,
 				}},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil, nil).NormalizedName)},
 			},
 			expAppliedToGroups: 1,
 			expAddressGroups:   1,
@@ -198,7 +240,7 @@ func TestAddNetworkPolicy(t *testing.T) {
 				Rules: []networking.NetworkPolicyRule{
 					denyAllIngressRule,
 				},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil, nil).NormalizedName)},
 			},
 			expAppliedToGroups: 1,
 			expAddressGroups:   0,
@@ -219,7 +261,7 @@ func TestAddNetworkPolicy(t *testing.T) {
 				Rules: []networking.NetworkPolicyRule{
 					denyAllEgressRule,
 				},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil, nil).NormalizedName)},
 			},
 			expAppliedToGroups: 1,
 			expAddressGroups:   0,
@@ -270,7 +312,7 @@ func TestAddNetworkPolicy(t *testing.T) {
 					{
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC, nil).NormalizedName)},
 						},
 						Services: []networking.Service{
 							{
@@ -284,7 +326,7 @@ func TestAddNetworkPolicy(t *testing.T) {
 					{
 						Direction: networking.DirectionOut,
 						To: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC, nil).NormalizedName)},
 						},
 						Services: []networking.Service{
 							{
@@ -296,7 +338,7 @@ func TestAddNetworkPolicy(t *testing.T) {
 						Action:   &defaultAction,
 					},
 				},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorA, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorA, nil, nil).NormalizedName)},
 			},
 			expAppliedToGroups: 1,
 			expAddressGroups:   1,
@@ -343,7 +385,7 @@ func TestAddNetworkPolicy(t *testing.T) {
 					{
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, nil).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, nil, nil).NormalizedName)},
 						},
 						Services: []networking.Service{
 							{
@@ -357,7 +399,7 @@ func TestAddNetworkPolicy(t *testing.T) {
 					{
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", nil, &selectorC).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", nil, &selectorC, nil).NormalizedName)},
 						},
 						Services: []networking.Service{
 							{
@@ -369,7 +411,7 @@ func TestAddNetworkPolicy(t *testing.T) {
 						Action:   &defaultAction,
 					},
 				},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorA, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorA, nil, nil).NormalizedName)},
 			},
 			expAppliedToGroups: 1,
 			expAddressGroups:   2,
@@ -409,7 +451,7 @@ func TestDeleteNetworkPolicy(t *testing.T) {
 	ns := npObj.ObjectMeta.Namespace
 	pSelector := npObj.Spec.PodSelector
 	pLabelSelector, _ := metav1.LabelSelectorAsSelector(&pSelector)
-	apgID := getNormalizedUID(generateNormalizedName(ns, pLabelSelector, nil))
+	apgID := getNormalizedUID(generateNormalizedName(ns, pLabelSelector, nil, nil))
 	_, npc := newController()
 	npc.addNetworkPolicy(npObj)
 	npc.deleteNetworkPolicy(npObj)
@@ -493,7 +535,7 @@ func TestUpdateNetworkPolicy(t *testing.T) {
 					{
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC, nil).NormalizedName)},
 						},
 						Priority: defaultRulePriority,
 						Action:   &defaultAction,
@@ -501,13 +543,13 @@ func TestUpdateNetworkPolicy(t *testing.T) {
 					{
 						Direction: networking.DirectionOut,
 						To: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, nil).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, nil, nil).NormalizedName)},
 						},
 						Priority: defaultRulePriority,
 						Action:   &defaultAction,
 					},
 				},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorA, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorA, nil, nil).NormalizedName)},
 			},
 			expAppliedToGroups: 1,
 			expAddressGroups:   2,
@@ -538,13 +580,13 @@ func TestUpdateNetworkPolicy(t *testing.T) {
 					{
 						Direction: networking.DirectionOut,
 						To: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC, nil).NormalizedName)},
 						},
 						Priority: defaultRulePriority,
 						Action:   &defaultAction,
 					},
 				},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil, nil).NormalizedName)},
 			},
 			expAppliedToGroups: 1,
 			expAddressGroups:   1,
@@ -575,13 +617,13 @@ func TestUpdateNetworkPolicy(t *testing.T) {
 					{
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC, nil).NormalizedName)},
 						},
 						Priority: defaultRulePriority,
 						Action:   &defaultAction,
 					},
 				},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil, nil).NormalizedName)},
 			},
 			expAppliedToGroups: 1,
 			expAddressGroups:   1,
@@ -599,7 +641,7 @@ func TestUpdateNetworkPolicy(t *testing.T) {
 				Name:            "npA",
 				Namespace:       "nsA",
 				Rules:           []networking.NetworkPolicyRule{},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil, nil).NormalizedName)},
 			},
 			expAppliedToGroups: 1,
 			expAddressGroups:   0,
@@ -646,7 +688,7 @@ func TestUpdateNetworkPolicy(t *testing.T) {
 					{
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC, nil).NormalizedName)},
 						},
 						Priority: defaultRulePriority,
 						Action:   &defaultAction,
@@ -654,7 +696,7 @@ func TestUpdateNetworkPolicy(t *testing.T) {
 					{
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("", nil, &selectorA).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("", nil, &selectorA, nil).NormalizedName)},
 						},
 						Priority: defaultRulePriority,
 						Action:   &defaultAction,
@@ -662,13 +704,13 @@ func TestUpdateNetworkPolicy(t *testing.T) {
 					{
 						Direction: networking.DirectionOut,
 						To: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, nil).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, nil, nil).NormalizedName)},
 						},
 						Priority: defaultRulePriority,
 						Action:   &defaultAction,
 					},
 				},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil, nil).NormalizedName)},
 			},
 			expAppliedToGroups: 1,
 			expAddressGroups:   3,
@@ -708,7 +750,7 @@ func TestUpdateNetworkPolicy(t *testing.T) {
 					{
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC, nil).NormalizedName)},
 						},
 						Priority: defaultRulePriority,
 						Action:   &defaultAction,
@@ -716,13 +758,13 @@ func TestUpdateNetworkPolicy(t *testing.T) {
 					{
 						Direction: networking.DirectionOut,
 						To: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorA, nil).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorA, nil, nil).NormalizedName)},
 						},
 						Priority: defaultRulePriority,
 						Action:   &defaultAction,
 					},
 				},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil, nil).NormalizedName)},
 			},
 			expAppliedToGroups: 1,
 			expAddressGroups:   2,
@@ -1064,9 +1106,9 @@ func TestAddPod(t *testing.T) {
 			_, npc := newController()
 			npc.addNetworkPolicy(testNPObj)
 			npc.podStore.Add(tt.addedPod)
-			appGroupID := getNormalizedUID(toGroupSelector("nsA", &selectorSpec, nil).NormalizedName)
-			inGroupID := getNormalizedUID(toGroupSelector("nsA", &selectorIn, nil).NormalizedName)
-			outGroupID := getNormalizedUID(toGroupSelector("nsA", &selectorOut, nil).NormalizedName)
+			appGroupID := getNormalizedUID(toGroupSelector("nsA", &selectorSpec, nil, nil).NormalizedName)
+			inGroupID := getNormalizedUID(toGroupSelector("nsA", &selectorIn, nil, nil).NormalizedName)
+			outGroupID := getNormalizedUID(toGroupSelector("nsA", &selectorOut, nil, nil).NormalizedName)
 			npc.syncAppliedToGroup(appGroupID)
 			npc.syncAddressGroup(inGroupID)
 			npc.syncAddressGroup(outGroupID)
@@ -1102,7 +1144,7 @@ func TestDeletePod(t *testing.T) {
 	inPSelector := metav1.LabelSelector{
 		MatchLabels: ruleLabels,
 	}
-	matchAppGID := getNormalizedUID(generateNormalizedName(ns, mLabelSelector, nil))
+	matchAppGID := getNormalizedUID(generateNormalizedName(ns, mLabelSelector, nil, nil))
 	ingressRules := []networkingv1.NetworkPolicyIngressRule{
 		{
 			From: []networkingv1.NetworkPolicyPeer{
@@ -1257,8 +1299,8 @@ func TestAddNamespace(t *testing.T) {
 			p2 := getPod("p2", "nsA", "nodeA", "2.2.3.4", false)
 			npc.podStore.Add(p1)
 			npc.podStore.Add(p2)
-			inGroupID := getNormalizedUID(toGroupSelector("", nil, &selectorIn).NormalizedName)
-			outGroupID := getNormalizedUID(toGroupSelector("", nil, &selectorOut).NormalizedName)
+			inGroupID := getNormalizedUID(toGroupSelector("", nil, &selectorIn, nil).NormalizedName)
+			outGroupID := getNormalizedUID(toGroupSelector("", nil, &selectorOut, nil).NormalizedName)
 			npc.syncAddressGroup(inGroupID)
 			npc.syncAddressGroup(outGroupID)
 			updatedInAddrGroupObj, _, _ := npc.addressGroupStore.Get(inGroupID)
@@ -1374,8 +1416,8 @@ func TestDeleteNamespace(t *testing.T) {
 			npc.podStore.Add(p1)
 			npc.podStore.Add(p2)
 			npc.namespaceStore.Delete(tt.deletedNamespace)
-			inGroupID := getNormalizedUID(toGroupSelector("", nil, &selectorIn).NormalizedName)
-			outGroupID := getNormalizedUID(toGroupSelector("", nil, &selectorOut).NormalizedName)
+			inGroupID := getNormalizedUID(toGroupSelector("", nil, &selectorIn, nil).NormalizedName)
+			outGroupID := getNormalizedUID(toGroupSelector("", nil, &selectorOut, nil).NormalizedName)
 			npc.syncAddressGroup(inGroupID)
 			npc.syncAddressGroup(outGroupID)
 			npc.podStore.Delete(p1)
@@ -1422,7 +1464,7 @@ func TestToGroupSelector(t *testing.T) {
 				Namespace:         "nsName",
 				NamespaceSelector: nil,
 				PodSelector:       pLabelSelector,
-				NormalizedName:    generateNormalizedName("nsName", pLabelSelector, nil),
+				NormalizedName:    generateNormalizedName("nsName", pLabelSelector, nil, nil),
 			},
 		},
 		{
@@ -1434,7 +1476,7 @@ func TestToGroupSelector(t *testing.T) {
 				Namespace:         "",
 				NamespaceSelector: nLabelSelector,
 				PodSelector:       nil,
-				NormalizedName:    generateNormalizedName("", nil, nLabelSelector),
+				NormalizedName:    generateNormalizedName("", nil, nLabelSelector, nil),
 			},
 		},
 		{
@@ -1446,7 +1488,7 @@ func TestToGroupSelector(t *testing.T) {
 				Namespace:         "nsName",
 				NamespaceSelector: nil,
 				PodSelector:       pLabelSelector,
-				NormalizedName:    generateNormalizedName("nsName", pLabelSelector, nil),
+				NormalizedName:    generateNormalizedName("nsName", pLabelSelector, nil, nil),
 			},
 		},
 		{
@@ -1458,13 +1500,13 @@ func TestToGroupSelector(t *testing.T) {
 				Namespace:         "",
 				NamespaceSelector: nLabelSelector,
 				PodSelector:       pLabelSelector,
-				NormalizedName:    generateNormalizedName("", pLabelSelector, nLabelSelector),
+				NormalizedName:    generateNormalizedName("", pLabelSelector, nLabelSelector, nil),
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			group := toGroupSelector(tt.namespace, tt.podSelector, tt.nsSelector)
+			group := toGroupSelector(tt.namespace, tt.podSelector, tt.nsSelector, nil)
 			if group.Namespace != tt.expGroupSelector.Namespace {
 				t.Errorf("Group Namespace incorrectly set. Expected %s, got: %s", tt.expGroupSelector.Namespace, group.Namespace)
 			}
@@ -1512,39 +1554,59 @@ func TestGenerateNormalizedName(t *testing.T) {
 		MatchExpressions: nExprs,
 	}
 	nLabelSelector, _ := metav1.LabelSelectorAsSelector(&nSelector)
+	eLabelSelector, _ := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"app": "vm"}})
 	tables := []struct {
 		namespace string
 		pSelector labels.Selector
 		nSelector labels.Selector
+		eSelector labels.Selector
 		expName   string
 	}{
 		{
 			"nsName",
 			pLabelSelector,
 			nil,
+			nil,
 			fmt.Sprintf("namespace=nsName And podSelector=%s", normalizedPodSelector),
 		},
 		{
 			"nsName",
 			nil,
 			nil,
+			nil,
 			"namespace=nsName",
 		},
 		{
 			"nsName",
 			nil,
 			nLabelSelector,
+			nil,
 			fmt.Sprintf("namespaceSelector=%s", normalizedNSSelector),
 		},
 		{
 			"nsName",
 			pLabelSelector,
 			nLabelSelector,
+			nil,
 			fmt.Sprintf("namespaceSelector=%s And podSelector=%s", normalizedNSSelector, normalizedPodSelector),
 		},
+		{
+			"nsName",
+			nil,
+			nil,
+			eLabelSelector,
+			"externalEntitySelector=app=vm And namespace=nsName",
+		},
+		{
+			"nsName",
+			nil,
+			nLabelSelector,
+			eLabelSelector,
+			fmt.Sprintf("externalEntitySelector=app=vm And namespaceSelector=%s", normalizedNSSelector),
+		},
 	}
 	for _, table := range tables {
-		name := generateNormalizedName(table.namespace, table.pSelector, table.nSelector)
+		name := generateNormalizedName(table.namespace, table.pSelector, table.nSelector, table.eSelector)
 		if table.expName != name {
 			t.Errorf("Unexpected normalized name. Expected %s, got %s", table.expName, name)
 		}
@@ -1671,7 +1733,7 @@ func TestToAntreaPeer(t *testing.T) {
 	selectorC := metav1.LabelSelector{MatchLabels: map[string]string{"foo3": "bar3"}}
 	selectorAll := metav1.LabelSelector{}
 	matchAllPodsPeer := matchAllPeer
-	matchAllPodsPeer.AddressGroups = []string{getNormalizedUID(toGroupSelector("", nil, &selectorAll).NormalizedName)}
+	matchAllPodsPeer.AddressGroups = []string{getNormalizedUID(toGroupSelector("", nil, &selectorAll, nil).NormalizedName)}
 	tests := []struct {
 		name      string
 		inPeers   []networkingv1.NetworkPolicyPeer
@@ -1691,8 +1753,8 @@ func TestToAntreaPeer(t *testing.T) {
 			},
 			outPeer: networking.NetworkPolicyPeer{
 				AddressGroups: []string{
-					getNormalizedUID(toGroupSelector("nsA", &selectorA, &selectorB).NormalizedName),
-					getNormalizedUID(toGroupSelector("nsA", &selectorC, nil).NormalizedName),
+					getNormalizedUID(toGroupSelector("nsA", &selectorA, &selectorB, nil).NormalizedName),
+					getNormalizedUID(toGroupSelector("nsA", &selectorC, nil, nil).NormalizedName),
 				},
 			},
 			direction: networking.DirectionIn,
@@ -1710,8 +1772,8 @@ func TestToAntreaPeer(t *testing.T) {
 			},
 			outPeer: networking.NetworkPolicyPeer{
 				AddressGroups: []string{
-					getNormalizedUID(toGroupSelector("nsA", &selectorA, &selectorB).NormalizedName),
-					getNormalizedUID(toGroupSelector("nsA", &selectorC, nil).NormalizedName),
+					getNormalizedUID(toGroupSelector("nsA", &selectorA, &selectorB, nil).NormalizedName),
+					getNormalizedUID(toGroupSelector("nsA", &selectorC, nil, nil).NormalizedName),
 				},
 			},
 			direction: networking.DirectionOut,
@@ -1848,7 +1910,7 @@ func TestProcessNetworkPolicy(t *testing.T) {
 					Priority:  defaultRulePriority,
 					Action:    &defaultAction,
 				}},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil, nil).NormalizedName)},
 			},
 			expectedAppliedToGroups: 1,
 			expectedAddressGroups:   0,
@@ -1867,7 +1929,7 @@ func TestProcessNetworkPolicy(t *testing.T) {
 				Name:            "npA",
 				Namespace:       "nsA",
 				Rules:           []networking.NetworkPolicyRule{denyAllEgressRule},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &metav1.LabelSelector{}, nil, nil).NormalizedName)},
 			},
 			expectedAppliedToGroups: 1,
 			expectedAddressGroups:   0,
@@ -1918,7 +1980,7 @@ func TestProcessNetworkPolicy(t *testing.T) {
 					{
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC, nil).NormalizedName)},
 						},
 						Services: []networking.Service{
 							{
@@ -1932,7 +1994,7 @@ func TestProcessNetworkPolicy(t *testing.T) {
 					{
 						Direction: networking.DirectionOut,
 						To: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, &selectorC, nil).NormalizedName)},
 						},
 						Services: []networking.Service{
 							{
@@ -1944,7 +2006,7 @@ func TestProcessNetworkPolicy(t *testing.T) {
 						Action:   &defaultAction,
 					},
 				},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorA, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorA, nil, nil).NormalizedName)},
 			},
 			expectedAppliedToGroups: 1,
 			expectedAddressGroups:   1,
@@ -1991,7 +2053,7 @@ func TestProcessNetworkPolicy(t *testing.T) {
 					{
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, nil).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorB, nil, nil).NormalizedName)},
 						},
 						Services: []networking.Service{
 							{
@@ -2005,7 +2067,7 @@ func TestProcessNetworkPolicy(t *testing.T) {
 					{
 						Direction: networking.DirectionIn,
 						From: networking.NetworkPolicyPeer{
-							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", nil, &selectorC).NormalizedName)},
+							AddressGroups: []string{getNormalizedUID(toGroupSelector("nsA", nil, &selectorC, nil).NormalizedName)},
 						},
 						Services: []networking.Service{
 							{
@@ -2017,7 +2079,7 @@ func TestProcessNetworkPolicy(t *testing.T) {
 						Action:   &defaultAction,
 					},
 				},
-				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorA, nil).NormalizedName)},
+				AppliedToGroups: []string{getNormalizedUID(toGroupSelector("nsA", &selectorA, nil, nil).NormalizedName)},
 			},
 			expectedAppliedToGroups: 1,
 			expectedAddressGroups:   2,
@@ -0,0 +1,158 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+
+	"github.com/containernetworking/plugins/pkg/ip"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+)
+
+// addNode enqueues the AddressGroups selecting the Node for further
+// processing.
+func (n *NetworkPolicyController) addNode(obj interface{}) {
+	defer n.heartbeat("addNode")
+	node := obj.(*v1.Node)
+	klog.V(2).Infof("Processing Node %s ADD event, labels: %v", node.Name, node.Labels)
+	n.enqueueAddressGroupsForNode(node)
+}
+
+// updateNode enqueues the AddressGroups selecting the old or the new Node for
+// further processing when the labels or the IPs of the Node have changed.
+func (n *NetworkPolicyController) updateNode(oldObj, curObj interface{}) {
+	defer n.heartbeat("updateNode")
+	oldNode := oldObj.(*v1.Node)
+	curNode := curObj.(*v1.Node)
+	// Nodes are updated periodically by their heartbeat, skip the updates
+	// which don't affect the members of any AddressGroup.
+	if labels.Equals(oldNode.Labels, curNode.Labels) &&
+		oldNode.Spec.PodCIDR == curNode.Spec.PodCIDR &&
+		reflect.DeepEqual(oldNode.Status.Addresses, curNode.Status.Addresses) {
+		return
+	}
+	klog.V(2).Infof("Processing Node %s UPDATE event, labels: %v", curNode.Name, curNode.Labels)
+	n.enqueueAddressGroupsForNode(oldNode, curNode)
+}
+
+// deleteNode enqueues the AddressGroups selecting the Node for further
+// processing.
+func (n *NetworkPolicyController) deleteNode(old interface{}) {
+	node, ok := old.(*v1.Node)
+	if !ok {
+		tombstone, ok := old.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Error decoding object when deleting Node, invalid type: %v", old)
+			return
+		}
+		node, ok = tombstone.Obj.(*v1.Node)
+		if !ok {
+			klog.Errorf("Error decoding object tombstone when deleting Node, invalid type: %v", tombstone.Obj)
+			return
+		}
+	}
+	defer n.heartbeat("deleteNode")
+
+	klog.V(2).Infof("Processing Node %s DELETE event, labels: %v", node.Name, node.Labels)
+	n.enqueueAddressGroupsForNode(node)
+}
+
+// enqueueAddressGroupsForNode enqueues the AddressGroups whose nodeSelector
+// matches the labels of any of the given Nodes.
+func (n *NetworkPolicyController) enqueueAddressGroupsForNode(nodes ...*v1.Node) {
+	// AddressGroups selecting Nodes are cluster scoped.
+	addressGroups, _ := n.addressGroupStore.GetByIndex(cache.NamespaceIndex, "")
+	for _, group := range addressGroups {
+		addrGroup := group.(*antreatypes.AddressGroup)
+		if addrGroup.Selector.NodeSelector == nil {
+			continue
+		}
+		for _, node := range nodes {
+			if addrGroup.Selector.NodeSelector.Matches(labels.Set(node.Labels)) {
+				n.enqueueAddressGroup(addrGroup.Name)
+				break
+			}
+		}
+	}
+}
+
+// toNodeGroupSelector converts a nodeSelector to the GroupSelector of an
+// AddressGroup.
+func toNodeGroupSelector(nodeSelector *metav1.LabelSelector) *antreatypes.GroupSelector {
+	selector, _ := metav1.LabelSelectorAsSelector(nodeSelector)
+	return &antreatypes.GroupSelector{
+		NormalizedName: fmt.Sprintf("nodeSelector=%s", selector.String()),
+		NodeSelector:   selector,
+	}
+}
+
+// createAddressGroupForNodeSelector creates the AddressGroup selecting the
+// Nodes matched by the nodeSelector if it is not created already. Its members
+// are calculated during sync process.
+func (n *NetworkPolicyController) createAddressGroupForNodeSelector(nodeSelector *metav1.LabelSelector) string {
+	groupSelector := toNodeGroupSelector(nodeSelector)
+	normalizedUID := getNormalizedUID(groupSelector.NormalizedName)
+	// Get or create an AddressGroup for the generated UID.
+	_, found, _ := n.addressGroupStore.Get(normalizedUID)
+	if found {
+		return normalizedUID
+	}
+	addressGroup := &antreatypes.AddressGroup{
+		UID:      types.UID(normalizedUID),
+		Name:     normalizedUID,
+		Selector: *groupSelector,
+	}
+	klog.V(2).Infof("Creating new AddressGroup %s with selector (%s)", addressGroup.Name, addressGroup.Selector.NormalizedName)
+	n.addressGroupStore.Create(addressGroup)
+	return normalizedUID
+}
+
+// nodeSelectorToMemberPods computes the members of an AddressGroup selecting
+// Nodes: the InternalIPs of the Nodes, and the gateway IPs of their Pod CIDRs,
+// which are the source IPs of the traffic sent by the host network of a Node
+// to the local Pods.
+func (n *NetworkPolicyController) nodeSelectorToMemberPods(nodeSelector labels.Selector) networking.GroupMemberPodSet {
+	podSet := networking.GroupMemberPodSet{}
+	nodes, _ := n.nodeLister.List(nodeSelector)
+	for _, node := range nodes {
+		for _, address := range node.Status.Addresses {
+			if address.Type == v1.NodeInternalIP {
+				podSet.Insert(&networking.GroupMemberPod{IP: ipStrToIPAddress(address.Address)})
+			}
+		}
+		if node.Spec.PodCIDR == "" {
+			continue
+		}
+		_, podCIDR, err := net.ParseCIDR(node.Spec.PodCIDR)
+		if err != nil {
+			klog.Errorf("Failed to parse the Pod CIDR %s of Node %s: %v", node.Spec.PodCIDR, node.Name, err)
+			continue
+		}
+		// The gateway IP is the first IP of the Pod CIDR.
+		gatewayIP := ip.NextIP(podCIDR.IP)
+		podSet.Insert(&networking.GroupMemberPod{IP: ipStrToIPAddress(gatewayIP.String())})
+	}
+	return podSet
+}
@@ -0,0 +1,90 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+)
+
+func newNode(name, internalIP, podCIDR string, labels map[string]string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec:       v1.NodeSpec{PodCIDR: podCIDR},
+		Status: v1.NodeStatus{
+			Addresses: []v1.NodeAddress{
+				{Type: v1.NodeHostName, Address: name},
+				{Type: v1.NodeInternalIP, Address: internalIP},
+			},
+		},
+	}
+}
+
+func TestSyncAddressGroupForNodeSelector(t *testing.T) {
+	_, npc := newController()
+	controlPlaneLabels := map[string]string{"node-role.kubernetes.io/master": ""}
+	node1 := newNode("node1", "172.16.0.1", "10.10.0.0/24", controlPlaneLabels)
+	node2 := newNode("node2", "172.16.0.2", "10.10.1.0/24", nil)
+	npc.nodeStore.Add(node1)
+	npc.nodeStore.Add(node2)
+	key := npc.createAddressGroupForNodeSelector(&metav1.LabelSelector{MatchLabels: controlPlaneLabels})
+	getAddressGroup := func() *antreatypes.AddressGroup {
+		obj, found, _ := npc.addressGroupStore.Get(key)
+		require.True(t, found)
+		return obj.(*antreatypes.AddressGroup)
+	}
+
+	require.NoError(t, npc.syncAddressGroup(key))
+	expectedPods := networking.NewGroupMemberPodSet(
+		&networking.GroupMemberPod{IP: ipStrToIPAddress("172.16.0.1")},
+		&networking.GroupMemberPod{IP: ipStrToIPAddress("10.10.0.1")},
+	)
+	assert.Equal(t, expectedPods, getAddressGroup().Pods)
+
+	// A Node update which doesn't change its labels or IPs is ignored.
+	updatedNode2 := node2.DeepCopy()
+	updatedNode2.Status.Conditions = []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}}
+	npc.updateNode(node2, updatedNode2)
+	assert.Equal(t, 0, npc.addressGroupQueue.Len())
+
+	// Labeling a Node enqueues the AddressGroup.
+	updatedNode2.Labels = controlPlaneLabels
+	npc.nodeStore.Update(updatedNode2)
+	npc.updateNode(node2, updatedNode2)
+	require.Equal(t, 1, npc.addressGroupQueue.Len())
+	require.NoError(t, npc.syncAddressGroup(key))
+	expectedPods.Insert(
+		&networking.GroupMemberPod{IP: ipStrToIPAddress("172.16.0.2")},
+		&networking.GroupMemberPod{IP: ipStrToIPAddress("10.10.1.1")},
+	)
+	assert.Equal(t, expectedPods, getAddressGroup().Pods)
+
+	// Deleting a Node removes its IPs from the AddressGroup.
+	npc.nodeStore.Delete(node1)
+	npc.deleteNode(node1)
+	require.NoError(t, npc.syncAddressGroup(key))
+	expectedPods = networking.NewGroupMemberPodSet(
+		&networking.GroupMemberPod{IP: ipStrToIPAddress("172.16.0.2")},
+		&networking.GroupMemberPod{IP: ipStrToIPAddress("10.10.1.1")},
+	)
+	assert.Equal(t, expectedPods, getAddressGroup().Pods)
+}
@@ -0,0 +1,251 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+	"github.com/vmware-tanzu/antrea/pkg/k8s"
+)
+
+// addService enqueues the AddressGroup of the Service, if any, for further
+// processing.
+func (n *NetworkPolicyController) addService(obj interface{}) {
+	defer n.heartbeat("addService")
+	service := obj.(*v1.Service)
+	klog.V(2).Infof("Processing Service %s/%s ADD event", service.Namespace, service.Name)
+	n.enqueueAddressGroupForService(service.Namespace, service.Name)
+}
+
+// updateService enqueues the AddressGroup of the Service, if any, for further
+// processing when the ClusterIP or the ports of the Service have changed.
+func (n *NetworkPolicyController) updateService(oldObj, curObj interface{}) {
+	defer n.heartbeat("updateService")
+	oldService := oldObj.(*v1.Service)
+	curService := curObj.(*v1.Service)
+	klog.V(2).Infof("Processing Service %s/%s UPDATE event", curService.Namespace, curService.Name)
+	if oldService.Spec.ClusterIP == curService.Spec.ClusterIP && reflect.DeepEqual(oldService.Spec.Ports, curService.Spec.Ports) {
+		klog.V(4).Infof("No change in the ClusterIP and the ports of Service %s/%s. Skipping NetworkPolicy evaluation.", curService.Namespace, curService.Name)
+		return
+	}
+	n.enqueueAddressGroupForService(curService.Namespace, curService.Name)
+}
+
+// deleteService enqueues the AddressGroup of the Service, if any, for further
+// processing.
+func (n *NetworkPolicyController) deleteService(old interface{}) {
+	service, ok := old.(*v1.Service)
+	if !ok {
+		tombstone, ok := old.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Error decoding object when deleting Service, invalid type: %v", old)
+			return
+		}
+		service, ok = tombstone.Obj.(*v1.Service)
+		if !ok {
+			klog.Errorf("Error decoding object tombstone when deleting Service, invalid type: %v", tombstone.Obj)
+			return
+		}
+	}
+	defer n.heartbeat("deleteService")
+
+	klog.V(2).Infof("Processing Service %s/%s DELETE event", service.Namespace, service.Name)
+	n.enqueueAddressGroupForService(service.Namespace, service.Name)
+}
+
+// addEndpoints enqueues the AddressGroup of the Service of the Endpoints, if
+// any, for further processing.
+func (n *NetworkPolicyController) addEndpoints(obj interface{}) {
+	defer n.heartbeat("addEndpoints")
+	endpoints := obj.(*v1.Endpoints)
+	klog.V(2).Infof("Processing Endpoints %s/%s ADD event", endpoints.Namespace, endpoints.Name)
+	n.enqueueAddressGroupForService(endpoints.Namespace, endpoints.Name)
+}
+
+// updateEndpoints enqueues the AddressGroup of the Service of the Endpoints,
+// if any, for further processing when the subsets of the Endpoints have
+// changed.
+func (n *NetworkPolicyController) updateEndpoints(oldObj, curObj interface{}) {
+	defer n.heartbeat("updateEndpoints")
+	oldEndpoints := oldObj.(*v1.Endpoints)
+	curEndpoints := curObj.(*v1.Endpoints)
+	if reflect.DeepEqual(oldEndpoints.Subsets, curEndpoints.Subsets) {
+		// Endpoints are updated periodically by the leader election of some
+		// components, there is no need to log these events.
+		return
+	}
+	klog.V(2).Infof("Processing Endpoints %s/%s UPDATE event", curEndpoints.Namespace, curEndpoints.Name)
+	n.enqueueAddressGroupForService(curEndpoints.Namespace, curEndpoints.Name)
+}
+
+// deleteEndpoints enqueues the AddressGroup of the Service of the Endpoints,
+// if any, for further processing.
+func (n *NetworkPolicyController) deleteEndpoints(old interface{}) {
+	endpoints, ok := old.(*v1.Endpoints)
+	if !ok {
+		tombstone, ok := old.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Error decoding object when deleting Endpoints, invalid type: %v", old)
+			return
+		}
+		endpoints, ok = tombstone.Obj.(*v1.Endpoints)
+		if !ok {
+			klog.Errorf("Error decoding object tombstone when deleting Endpoints, invalid type: %v", tombstone.Obj)
+			return
+		}
+	}
+	defer n.heartbeat("deleteEndpoints")
+
+	klog.V(2).Infof("Processing Endpoints %s/%s DELETE event", endpoints.Namespace, endpoints.Name)
+	n.enqueueAddressGroupForService(endpoints.Namespace, endpoints.Name)
+}
+
+// enqueueAddressGroupForService enqueues the AddressGroup of the Service with
+// the given Namespace and name if it is referenced by any Antrea-native policy.
+func (n *NetworkPolicyController) enqueueAddressGroupForService(namespace, name string) {
+	key := getNormalizedUID(toServiceGroupSelector(namespace, name).NormalizedName)
+	if _, found, _ := n.addressGroupStore.Get(key); !found {
+		return
+	}
+	n.enqueueAddressGroup(key)
+}
+
+// toServiceGroupSelector returns the GroupSelector of the AddressGroup of the
+// Service with the given Namespace and name.
+func toServiceGroupSelector(namespace, name string) *antreatypes.GroupSelector {
+	return &antreatypes.GroupSelector{
+		NormalizedName:   fmt.Sprintf("service=%s", k8s.NamespacedName(namespace, name)),
+		ServiceReference: &networking.ServiceReference{Name: name, Namespace: namespace},
+	}
+}
+
+// toAntreaPeerForServices converts the Services referenced by an egress rule of
+// an Antrea-native policy to a NetworkPolicyPeer which contains an AddressGroup
+// per Service. The Services default to the Namespace of the policy.
+func (n *NetworkPolicyController) toAntreaPeerForServices(serviceRefs []secv1alpha1.ServiceReference, np metav1.Object) *networking.NetworkPolicyPeer {
+	peer := &networking.NetworkPolicyPeer{}
+	for _, serviceRef := range serviceRefs {
+		namespace := serviceRef.Namespace
+		if namespace == "" {
+			namespace = np.GetNamespace()
+		}
+		if namespace == "" {
+			klog.Errorf("Ignoring Service %s of Antrea policy %s: the Namespace of the Service must be set in ClusterNetworkPolicies", serviceRef.Name, np.GetName())
+			continue
+		}
+		peer.AddressGroups = append(peer.AddressGroups, n.createAddressGroupForService(namespace, serviceRef.Name))
+		peer.ToServices = append(peer.ToServices, networking.ServiceReference{Name: serviceRef.Name, Namespace: namespace})
+	}
+	return peer
+}
+
+// createAddressGroupForService creates the AddressGroup of a Service if it is
+// not created already. Its members are calculated during sync process.
+func (n *NetworkPolicyController) createAddressGroupForService(namespace, name string) string {
+	groupSelector := toServiceGroupSelector(namespace, name)
+	normalizedUID := getNormalizedUID(groupSelector.NormalizedName)
+	// Get or create an AddressGroup for the generated UID.
+	_, found, _ := n.addressGroupStore.Get(normalizedUID)
+	if found {
+		return normalizedUID
+	}
+	addressGroup := &antreatypes.AddressGroup{
+		UID:      types.UID(normalizedUID),
+		Name:     normalizedUID,
+		Selector: *groupSelector,
+	}
+	klog.V(2).Infof("Creating new AddressGroup %s with selector (%s)", addressGroup.Name, addressGroup.Selector.NormalizedName)
+	n.addressGroupStore.Create(addressGroup)
+	return normalizedUID
+}
+
+// serviceToMemberPods computes the members of the AddressGroup of a Service:
+// its ClusterIP with the Service ports, which matches the traffic before it is
+// DNAT'd, and its ready Endpoints with the target ports, which match the
+// traffic after it is DNAT'd by AntreaProxy.
+func (n *NetworkPolicyController) serviceToMemberPods(serviceRef *networking.ServiceReference) networking.GroupMemberPodSet {
+	podSet := networking.GroupMemberPodSet{}
+	service, err := n.serviceLister.Services(serviceRef.Namespace).Get(serviceRef.Name)
+	if err != nil {
+		// The Service doesn't exist or has been deleted, no traffic can be
+		// matched.
+		klog.V(2).Infof("Service %s/%s not found", serviceRef.Namespace, serviceRef.Name)
+		return podSet
+	}
+	if service.Spec.ClusterIP != "" && service.Spec.ClusterIP != v1.ClusterIPNone {
+		memberPod := &networking.GroupMemberPod{IP: ipStrToIPAddress(service.Spec.ClusterIP)}
+		for _, port := range service.Spec.Ports {
+			memberPod.Ports = append(memberPod.Ports, networking.NamedPort{
+				Port:     port.Port,
+				Name:     port.Name,
+				Protocol: networking.Protocol(port.Protocol),
+			})
+		}
+		sortNamedPorts(memberPod.Ports)
+		podSet.Insert(memberPod)
+	}
+	endpoints, err := n.endpointsLister.Endpoints(serviceRef.Namespace).Get(serviceRef.Name)
+	if err != nil {
+		return podSet
+	}
+	// An address may be in several subsets with different ports.
+	memberPods := map[string]*networking.GroupMemberPod{}
+	for _, subset := range endpoints.Subsets {
+		for _, address := range subset.Addresses {
+			memberPod, exists := memberPods[address.IP]
+			if !exists {
+				memberPod = &networking.GroupMemberPod{IP: ipStrToIPAddress(address.IP)}
+				memberPods[address.IP] = memberPod
+			}
+			for _, port := range subset.Ports {
+				memberPod.Ports = append(memberPod.Ports, networking.NamedPort{
+					Port:     port.Port,
+					Name:     port.Name,
+					Protocol: networking.Protocol(port.Protocol),
+				})
+			}
+		}
+	}
+	for _, memberPod := range memberPods {
+		sortNamedPorts(memberPod.Ports)
+		podSet.Insert(memberPod)
+	}
+	return podSet
+}
+
+// sortNamedPorts sorts the ports so that they are compared consistently when
+// generating the AddressGroup patches.
+func sortNamedPorts(ports []networking.NamedPort) {
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].Protocol != ports[j].Protocol {
+			return ports[i].Protocol < ports[j].Protocol
+		}
+		if ports[i].Port != ports[j].Port {
+			return ports[i].Port < ports[j].Port
+		}
+		return ports[i].Name < ports[j].Name
+	})
+}
@@ -0,0 +1,148 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+)
+
+func TestToAntreaEgressPeerForServices(t *testing.T) {
+	anp := &secv1alpha1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "anpA", Namespace: "ns1"}}
+	cnp := &secv1alpha1.ClusterNetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cnpA"}}
+	port := intstr.FromInt(80)
+	rule := secv1alpha1.Rule{
+		ToServices: []secv1alpha1.ServiceReference{{Name: "svc1"}, {Name: "svc2", Namespace: "ns2"}},
+	}
+	_, npc := newController()
+
+	peer, services := npc.toAntreaEgressPeerForCRD(rule, anp)
+	assert.Equal(t, []string{
+		getNormalizedUID(toServiceGroupSelector("ns1", "svc1").NormalizedName),
+		getNormalizedUID(toServiceGroupSelector("ns2", "svc2").NormalizedName),
+	}, peer.AddressGroups)
+	assert.Equal(t, []networking.ServiceReference{{Name: "svc1", Namespace: "ns1"}, {Name: "svc2", Namespace: "ns2"}}, peer.ToServices)
+	assert.Nil(t, services)
+	assert.Len(t, npc.addressGroupStore.List(), 2)
+
+	// The Namespace of the Services must be set in ClusterNetworkPolicies.
+	peer, _ = npc.toAntreaEgressPeerForCRD(rule, cnp)
+	assert.Equal(t, []string{getNormalizedUID(toServiceGroupSelector("ns2", "svc2").NormalizedName)}, peer.AddressGroups)
+	assert.Equal(t, []networking.ServiceReference{{Name: "svc2", Namespace: "ns2"}}, peer.ToServices)
+
+	// To and Ports are ignored when ToServices is set.
+	rule.To = []secv1alpha1.NetworkPolicyPeer{{IPBlock: &secv1alpha1.IPBlock{CIDR: "10.0.0.0/8"}}}
+	rule.Ports = []secv1alpha1.NetworkPolicyPort{{Port: &port}}
+	peer, services = npc.toAntreaEgressPeerForCRD(rule, anp)
+	assert.Len(t, peer.AddressGroups, 2)
+	assert.Empty(t, peer.IPBlocks)
+	assert.Nil(t, services)
+}
+
+func TestSyncAddressGroupForService(t *testing.T) {
+	_, npc := newController()
+	service := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "svc1"},
+		Spec: v1.ServiceSpec{
+			ClusterIP: "10.96.0.10",
+			Ports: []v1.ServicePort{
+				{Name: "https", Port: 443, Protocol: v1.ProtocolTCP},
+				{Name: "dns", Port: 53, Protocol: v1.ProtocolUDP},
+			},
+		},
+	}
+	endpoints := &v1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "svc1"},
+		Subsets: []v1.EndpointSubset{
+			{
+				Addresses: []v1.EndpointAddress{{IP: "1.1.1.1"}, {IP: "2.2.2.2"}},
+				Ports:     []v1.EndpointPort{{Name: "https", Port: 8443, Protocol: v1.ProtocolTCP}},
+			},
+			{
+				Addresses: []v1.EndpointAddress{{IP: "1.1.1.1"}},
+				Ports:     []v1.EndpointPort{{Name: "dns", Port: 5353, Protocol: v1.ProtocolUDP}},
+			},
+		},
+	}
+	npc.serviceStore.Add(service)
+	npc.endpointsStore.Add(endpoints)
+	key := npc.createAddressGroupForService("ns1", "svc1")
+	getAddressGroup := func() *antreatypes.AddressGroup {
+		obj, found, _ := npc.addressGroupStore.Get(key)
+		require.True(t, found)
+		return obj.(*antreatypes.AddressGroup)
+	}
+
+	require.NoError(t, npc.syncAddressGroup(key))
+	expectedPods := networking.NewGroupMemberPodSet(
+		&networking.GroupMemberPod{
+			IP: ipStrToIPAddress("10.96.0.10"),
+			Ports: []networking.NamedPort{
+				{Name: "https", Port: 443, Protocol: networking.ProtocolTCP},
+				{Name: "dns", Port: 53, Protocol: networking.ProtocolUDP},
+			},
+		},
+		&networking.GroupMemberPod{
+			IP: ipStrToIPAddress("1.1.1.1"),
+			Ports: []networking.NamedPort{
+				{Name: "https", Port: 8443, Protocol: networking.ProtocolTCP},
+				{Name: "dns", Port: 5353, Protocol: networking.ProtocolUDP},
+			},
+		},
+		&networking.GroupMemberPod{
+			IP:    ipStrToIPAddress("2.2.2.2"),
+			Ports: []networking.NamedPort{{Name: "https", Port: 8443, Protocol: networking.ProtocolTCP}},
+		},
+	)
+	assert.Equal(t, expectedPods, getAddressGroup().Pods)
+
+	// Updating the Endpoints enqueues the AddressGroup.
+	updatedEndpoints := endpoints.DeepCopy()
+	updatedEndpoints.Subsets = updatedEndpoints.Subsets[:1]
+	updatedEndpoints.Subsets[0].Addresses = []v1.EndpointAddress{{IP: "3.3.3.3"}}
+	npc.endpointsStore.Update(updatedEndpoints)
+	npc.updateEndpoints(endpoints, updatedEndpoints)
+	require.Equal(t, 1, npc.addressGroupQueue.Len())
+	require.NoError(t, npc.syncAddressGroup(key))
+	expectedPods = networking.NewGroupMemberPodSet(
+		&networking.GroupMemberPod{
+			IP: ipStrToIPAddress("10.96.0.10"),
+			Ports: []networking.NamedPort{
+				{Name: "https", Port: 443, Protocol: networking.ProtocolTCP},
+				{Name: "dns", Port: 53, Protocol: networking.ProtocolUDP},
+			},
+		},
+		&networking.GroupMemberPod{
+			IP:    ipStrToIPAddress("3.3.3.3"),
+			Ports: []networking.NamedPort{{Name: "https", Port: 8443, Protocol: networking.ProtocolTCP}},
+		},
+	)
+	assert.Equal(t, expectedPods, getAddressGroup().Pods)
+
+	// Deleting the Service and its Endpoints empties the AddressGroup.
+	npc.serviceStore.Delete(service)
+	npc.endpointsStore.Delete(updatedEndpoints)
+	require.NoError(t, npc.syncAddressGroup(key))
+	assert.Empty(t, getAddressGroup().Pods)
+}
@@ -0,0 +1,276 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+	"github.com/vmware-tanzu/antrea/pkg/k8s"
+)
+
+const (
+	// serviceAccountIndex is the name of the index of the Pods by their
+	// ServiceAccounts.
+	serviceAccountIndex = "serviceAccount"
+	// defaultServiceAccountName is the name of the ServiceAccount used by the
+	// Pods which don't specify any.
+	defaultServiceAccountName = "default"
+)
+
+// podServiceAccountName returns the name of the ServiceAccount the Pod runs
+// with.
+func podServiceAccountName(pod *v1.Pod) string {
+	if pod.Spec.ServiceAccountName == "" {
+		return defaultServiceAccountName
+	}
+	return pod.Spec.ServiceAccountName
+}
+
+// podServiceAccountIndexFunc indexes a Pod by the namespaced name of its
+// ServiceAccount.
+func podServiceAccountIndexFunc(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		return []string{}, nil
+	}
+	return []string{k8s.NamespacedName(pod.Namespace, podServiceAccountName(pod))}, nil
+}
+
+// addServiceAccount enqueues the groups selecting the ServiceAccount by its
+// labels for further processing.
+func (n *NetworkPolicyController) addServiceAccount(obj interface{}) {
+	defer n.heartbeat("addServiceAccount")
+	sa := obj.(*v1.ServiceAccount)
+	klog.V(2).Infof("Processing ServiceAccount %s/%s ADD event, labels: %v", sa.Namespace, sa.Name, sa.Labels)
+	n.enqueueGroupsForServiceAccount(sa)
+}
+
+// updateServiceAccount enqueues the groups selecting the old or the new
+// ServiceAccount by its labels for further processing when its labels have
+// changed.
+func (n *NetworkPolicyController) updateServiceAccount(oldObj, curObj interface{}) {
+	defer n.heartbeat("updateServiceAccount")
+	oldSA := oldObj.(*v1.ServiceAccount)
+	curSA := curObj.(*v1.ServiceAccount)
+	// The secrets of ServiceAccounts may be updated, skip the updates which
+	// don't affect the members of any group.
+	if labels.Equals(oldSA.Labels, curSA.Labels) {
+		return
+	}
+	klog.V(2).Infof("Processing ServiceAccount %s/%s UPDATE event, labels: %v", curSA.Namespace, curSA.Name, curSA.Labels)
+	n.enqueueGroupsForServiceAccount(oldSA, curSA)
+}
+
+// deleteServiceAccount enqueues the groups selecting the ServiceAccount by its
+// labels for further processing.
+func (n *NetworkPolicyController) deleteServiceAccount(old interface{}) {
+	sa, ok := old.(*v1.ServiceAccount)
+	if !ok {
+		tombstone, ok := old.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Error decoding object when deleting ServiceAccount, invalid type: %v", old)
+			return
+		}
+		sa, ok = tombstone.Obj.(*v1.ServiceAccount)
+		if !ok {
+			klog.Errorf("Error decoding object tombstone when deleting ServiceAccount, invalid type: %v", tombstone.Obj)
+			return
+		}
+	}
+	defer n.heartbeat("deleteServiceAccount")
+
+	klog.V(2).Infof("Processing ServiceAccount %s/%s DELETE event, labels: %v", sa.Namespace, sa.Name, sa.Labels)
+	n.enqueueGroupsForServiceAccount(sa)
+}
+
+// enqueueGroupsForServiceAccount enqueues the AddressGroups and the
+// AppliedToGroups whose ServiceAccount label selector matches any of the given
+// ServiceAccounts, which all belong to the same Namespace. The groups
+// selecting a ServiceAccount by name are not affected by the changes of the
+// ServiceAccount as they select Pods by the name of their ServiceAccounts.
+func (n *NetworkPolicyController) enqueueGroupsForServiceAccount(sas ...*v1.ServiceAccount) {
+	namespace := sas[0].Namespace
+	matches := func(sel *antreatypes.GroupSelector) bool {
+		if sel.ServiceAccountSelector == nil || sel.ServiceAccountSelector.Selector == nil {
+			return false
+		}
+		for _, sa := range sas {
+			if sel.ServiceAccountSelector.Selector.Matches(labels.Set(sa.Labels)) {
+				return true
+			}
+		}
+		return false
+	}
+	// The groups in the Namespace of the ServiceAccounts or cluster scoped can
+	// possibly select them.
+	localAddressGroups, _ := n.addressGroupStore.GetByIndex(cache.NamespaceIndex, namespace)
+	clusterScopedAddressGroups, _ := n.addressGroupStore.GetByIndex(cache.NamespaceIndex, "")
+	for _, group := range append(localAddressGroups, clusterScopedAddressGroups...) {
+		addrGroup := group.(*antreatypes.AddressGroup)
+		if matches(&addrGroup.Selector) {
+			n.enqueueAddressGroup(addrGroup.Name)
+		}
+	}
+	localAppliedToGroups, _ := n.appliedToGroupStore.GetByIndex(cache.NamespaceIndex, namespace)
+	clusterScopedAppliedToGroups, _ := n.appliedToGroupStore.GetByIndex(cache.NamespaceIndex, "")
+	for _, group := range append(localAppliedToGroups, clusterScopedAppliedToGroups...) {
+		appGroup := group.(*antreatypes.AppliedToGroup)
+		if matches(&appGroup.Selector) {
+			n.enqueueAppliedToGroup(appGroup.Name)
+		}
+	}
+}
+
+// toServiceAccountGroupSelector converts a secv1alpha1.ServiceAccountSelector
+// to a GroupSelector. namespace is the Namespace of the Antrea NetworkPolicy
+// the selector belongs to, and is empty for ClusterNetworkPolicies.
+func toServiceAccountGroupSelector(namespace string, saSelector *secv1alpha1.ServiceAccountSelector) (*antreatypes.GroupSelector, error) {
+	if saSelector.Name != "" && saSelector.Selector != nil {
+		return nil, fmt.Errorf("name and selector cannot be set together")
+	}
+	if saSelector.Name == "" && saSelector.Selector == nil {
+		return nil, fmt.Errorf("one of name and selector must be set")
+	}
+	if namespace == "" {
+		namespace = saSelector.Namespace
+	} else if saSelector.Namespace != "" && saSelector.Namespace != namespace {
+		return nil, fmt.Errorf("ServiceAccounts can only be selected from the Namespace of the policy")
+	}
+	if saSelector.Name != "" && namespace == "" {
+		return nil, fmt.Errorf("namespace must be set with name")
+	}
+	groupSelector := &antreatypes.GroupSelector{
+		Namespace:              namespace,
+		ServiceAccountSelector: &antreatypes.ServiceAccountSelector{Name: saSelector.Name},
+	}
+	normalizedName := []string{}
+	if namespace != "" {
+		normalizedName = append(normalizedName, fmt.Sprintf("namespace=%s", namespace))
+	}
+	if saSelector.Name != "" {
+		normalizedName = append(normalizedName, fmt.Sprintf("serviceAccount=%s", saSelector.Name))
+	} else {
+		selector, err := metav1.LabelSelectorAsSelector(saSelector.Selector)
+		if err != nil {
+			return nil, err
+		}
+		groupSelector.ServiceAccountSelector.Selector = selector
+		normalizedName = append(normalizedName, fmt.Sprintf("serviceAccountSelector=%s", selector.String()))
+	}
+	sort.Strings(normalizedName)
+	groupSelector.NormalizedName = strings.Join(normalizedName, " And ")
+	return groupSelector, nil
+}
+
+// createAddressGroupForServiceAccount creates the AddressGroup selecting the
+// Pods running with the ServiceAccounts matched by the selector if it is not
+// created already. Its members are calculated during sync process.
+func (n *NetworkPolicyController) createAddressGroupForServiceAccount(saSelector *secv1alpha1.ServiceAccountSelector, np metav1.Object) (string, error) {
+	groupSelector, err := toServiceAccountGroupSelector(np.GetNamespace(), saSelector)
+	if err != nil {
+		return "", err
+	}
+	normalizedUID := getNormalizedUID(groupSelector.NormalizedName)
+	// Get or create an AddressGroup for the generated UID.
+	_, found, _ := n.addressGroupStore.Get(normalizedUID)
+	if found {
+		return normalizedUID, nil
+	}
+	addressGroup := &antreatypes.AddressGroup{
+		UID:      types.UID(normalizedUID),
+		Name:     normalizedUID,
+		Selector: *groupSelector,
+	}
+	klog.V(2).Infof("Creating new AddressGroup %s with selector (%s)", addressGroup.Name, addressGroup.Selector.NormalizedName)
+	n.addressGroupStore.Create(addressGroup)
+	return normalizedUID, nil
+}
+
+// createAppliedToGroupForServiceAccount creates the AppliedToGroup selecting
+// the Pods running with the ServiceAccounts matched by the selector if it is
+// not created already.
+func (n *NetworkPolicyController) createAppliedToGroupForServiceAccount(saSelector *secv1alpha1.ServiceAccountSelector, np metav1.Object) (string, error) {
+	groupSelector, err := toServiceAccountGroupSelector(np.GetNamespace(), saSelector)
+	if err != nil {
+		return "", err
+	}
+	appliedToGroupUID := getNormalizedUID(groupSelector.NormalizedName)
+	// Get or create an AppliedToGroup for the generated UID.
+	_, found, _ := n.appliedToGroupStore.Get(appliedToGroupUID)
+	if found {
+		return appliedToGroupUID, nil
+	}
+	appliedToGroup := &antreatypes.AppliedToGroup{
+		Name:     appliedToGroupUID,
+		UID:      types.UID(appliedToGroupUID),
+		Selector: *groupSelector,
+	}
+	klog.V(2).Infof("Creating new AppliedToGroup %s with selector (%s)", appliedToGroup.Name, appliedToGroup.Selector.NormalizedName)
+	n.appliedToGroupStore.Create(appliedToGroup)
+	n.enqueueAppliedToGroup(appliedToGroupUID)
+	return appliedToGroupUID, nil
+}
+
+// podMatchesServiceAccountSelector returns true if the Pod runs with a
+// ServiceAccount matched by the GroupSelector.
+func (n *NetworkPolicyController) podMatchesServiceAccountSelector(pod *v1.Pod, sel *antreatypes.GroupSelector) bool {
+	if sel.Namespace != "" && sel.Namespace != pod.Namespace {
+		return false
+	}
+	saName := podServiceAccountName(pod)
+	if sel.ServiceAccountSelector.Name != "" {
+		return sel.ServiceAccountSelector.Name == saName
+	}
+	// The Pod event may arrive before its ServiceAccount event, in which case
+	// the Pod is added to the group when the ServiceAccount event arrives.
+	sa, err := n.serviceAccountLister.ServiceAccounts(pod.Namespace).Get(saName)
+	if err != nil {
+		return false
+	}
+	return sel.ServiceAccountSelector.Selector.Matches(labels.Set(sa.Labels))
+}
+
+// serviceAccountSelectorToPods retrieves the Pods running with the
+// ServiceAccounts matched by the GroupSelector.
+func (n *NetworkPolicyController) serviceAccountSelectorToPods(sel antreatypes.GroupSelector) []*v1.Pod {
+	var saNames []string
+	if sel.ServiceAccountSelector.Name != "" {
+		saNames = append(saNames, k8s.NamespacedName(sel.Namespace, sel.ServiceAccountSelector.Name))
+	} else {
+		sas, _ := n.serviceAccountLister.ServiceAccounts(sel.Namespace).List(sel.ServiceAccountSelector.Selector)
+		for _, sa := range sas {
+			saNames = append(saNames, k8s.NamespacedName(sa.Namespace, sa.Name))
+		}
+	}
+	var pods []*v1.Pod
+	for _, saName := range saNames {
+		objs, _ := n.podIndexer.ByIndex(serviceAccountIndex, saName)
+		for _, obj := range objs {
+			pods = append(pods, obj.(*v1.Pod))
+		}
+	}
+	return pods
+}
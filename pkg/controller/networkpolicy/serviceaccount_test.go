@@ -0,0 +1,135 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+)
+
+func newServiceAccount(namespace, name string, labels map[string]string) *v1.ServiceAccount {
+	return &v1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+	}
+}
+
+func newPodWithServiceAccount(name, namespace, podIP, serviceAccount string) *v1.Pod {
+	pod := getPod(name, namespace, "", podIP, false)
+	pod.Spec.ServiceAccountName = serviceAccount
+	return pod
+}
+
+func TestToServiceAccountGroupSelector(t *testing.T) {
+	tests := []struct {
+		name                   string
+		namespace              string
+		saSelector             *secv1alpha1.ServiceAccountSelector
+		expectedNormalizedName string
+		expectedErr            bool
+	}{
+		{
+			name:                   "name-in-anp",
+			namespace:              "ns1",
+			saSelector:             &secv1alpha1.ServiceAccountSelector{Name: "sa1"},
+			expectedNormalizedName: "namespace=ns1 And serviceAccount=sa1",
+		},
+		{
+			name:                   "selector-in-cnp",
+			saSelector:             &secv1alpha1.ServiceAccountSelector{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+			expectedNormalizedName: "serviceAccountSelector=app=web",
+		},
+		{
+			name:        "name-without-namespace-in-cnp",
+			saSelector:  &secv1alpha1.ServiceAccountSelector{Name: "sa1"},
+			expectedErr: true,
+		},
+		{
+			name:        "other-namespace-in-anp",
+			namespace:   "ns1",
+			saSelector:  &secv1alpha1.ServiceAccountSelector{Name: "sa1", Namespace: "ns2"},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groupSelector, err := toServiceAccountGroupSelector(tt.namespace, tt.saSelector)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedNormalizedName, groupSelector.NormalizedName)
+		})
+	}
+}
+
+func TestSyncAddressGroupForServiceAccount(t *testing.T) {
+	_, npc := newController()
+	webLabels := map[string]string{"app": "web"}
+	sa1 := newServiceAccount("ns1", "sa1", webLabels)
+	sa2 := newServiceAccount("ns1", "sa2", nil)
+	npc.serviceAccountStore.Add(sa1)
+	npc.serviceAccountStore.Add(sa2)
+	pod1 := newPodWithServiceAccount("pod1", "ns1", "10.0.0.1", "sa1")
+	pod2 := newPodWithServiceAccount("pod2", "ns1", "10.0.0.2", "sa2")
+	pod3 := newPodWithServiceAccount("pod3", "ns1", "10.0.0.3", "")
+	npc.podStore.Add(pod1)
+	npc.podStore.Add(pod2)
+	npc.podStore.Add(pod3)
+	cnp := &secv1alpha1.ClusterNetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cnp1", UID: "uid1"}}
+	nameKey, err := npc.createAddressGroupForServiceAccount(&secv1alpha1.ServiceAccountSelector{Name: "default", Namespace: "ns1"}, cnp)
+	require.NoError(t, err)
+	selectorKey, err := npc.createAddressGroupForServiceAccount(&secv1alpha1.ServiceAccountSelector{Selector: &metav1.LabelSelector{MatchLabels: webLabels}}, cnp)
+	require.NoError(t, err)
+	getAddressGroup := func(key string) *antreatypes.AddressGroup {
+		obj, found, _ := npc.addressGroupStore.Get(key)
+		require.True(t, found)
+		return obj.(*antreatypes.AddressGroup)
+	}
+
+	// Pods which don't specify any ServiceAccount run with the default one.
+	require.NoError(t, npc.syncAddressGroup(nameKey))
+	assert.Equal(t, networking.NewGroupMemberPodSet(&networking.GroupMemberPod{IP: ipStrToIPAddress("10.0.0.3")}), getAddressGroup(nameKey).Pods)
+	require.NoError(t, npc.syncAddressGroup(selectorKey))
+	assert.Equal(t, networking.NewGroupMemberPodSet(&networking.GroupMemberPod{IP: ipStrToIPAddress("10.0.0.1")}), getAddressGroup(selectorKey).Pods)
+	assert.Equal(t, []string{nameKey}, npc.filterAddressGroupsForPodOrExternalEntity(pod3).List())
+	assert.Equal(t, []string{selectorKey}, npc.filterAddressGroupsForPodOrExternalEntity(pod1).List())
+
+	// An update of a ServiceAccount which doesn't change its labels is ignored.
+	updatedSA2 := sa2.DeepCopy()
+	updatedSA2.Secrets = []v1.ObjectReference{{Name: "sa2-token"}}
+	npc.updateServiceAccount(sa2, updatedSA2)
+	assert.Equal(t, 0, npc.addressGroupQueue.Len())
+
+	// Labeling a ServiceAccount enqueues the AddressGroup selecting it.
+	updatedSA2.Labels = webLabels
+	npc.serviceAccountStore.Update(updatedSA2)
+	npc.updateServiceAccount(sa2, updatedSA2)
+	require.Equal(t, 1, npc.addressGroupQueue.Len())
+	require.NoError(t, npc.syncAddressGroup(selectorKey))
+	assert.Equal(t, networking.NewGroupMemberPodSet(
+		&networking.GroupMemberPod{IP: ipStrToIPAddress("10.0.0.1")},
+		&networking.GroupMemberPod{IP: ipStrToIPAddress("10.0.0.2")},
+	), getAddressGroup(selectorKey).Pods)
+	assert.Equal(t, []string{selectorKey}, npc.filterAddressGroupsForPodOrExternalEntity(pod2).List())
+}
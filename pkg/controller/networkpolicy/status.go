@@ -0,0 +1,176 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/controller/networkpolicy/store"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+)
+
+// UpdateStatus stores the realization status of a NetworkPolicy reported by
+// Nodes and enqueues the NetworkPolicy so that the status of the original
+// Antrea-native policy gets updated. Nodes only report the statuses of
+// Antrea-native policies.
+func (n *NetworkPolicyController) UpdateStatus(status *networking.NetworkPolicyStatus) error {
+	key := store.AntreaPolicyKey(status.Namespace, status.Name)
+	n.networkPolicyStatusesMutex.Lock()
+	nodeStatuses, exists := n.networkPolicyStatuses[key]
+	if !exists {
+		nodeStatuses = map[string]*networking.NetworkPolicyNodeStatus{}
+		n.networkPolicyStatuses[key] = nodeStatuses
+	}
+	for i := range status.Nodes {
+		nodeStatus := status.Nodes[i]
+		nodeStatuses[nodeStatus.NodeName] = &nodeStatus
+	}
+	n.networkPolicyStatusesMutex.Unlock()
+	n.enqueueNetworkPolicyStatus(key)
+	return nil
+}
+
+func (n *NetworkPolicyController) enqueueNetworkPolicyStatus(key string) {
+	klog.V(4).Infof("Adding new key %s to NetworkPolicy status queue", key)
+	n.networkPolicyStatusQueue.Add(key)
+}
+
+func (n *NetworkPolicyController) networkPolicyStatusWorker() {
+	for n.processNextNetworkPolicyStatusWorkItem() {
+	}
+}
+
+// Processes an item in the "networkPolicyStatus" work queue, by calling
+// syncNetworkPolicyStatus after casting the item to a string (NetworkPolicy
+// key). If syncNetworkPolicyStatus returns an error, this function handles it
+// by requeueing the item so that it can be processed again later. This
+// function return false if and only if the work queue was shutdown (no more
+// items will be processed).
+func (n *NetworkPolicyController) processNextNetworkPolicyStatusWorkItem() bool {
+	defer n.heartbeat("processNextNetworkPolicyStatusWorkItem")
+	key, quit := n.networkPolicyStatusQueue.Get()
+	if quit {
+		return false
+	}
+	defer n.networkPolicyStatusQueue.Done(key)
+
+	err := n.syncNetworkPolicyStatus(key.(string))
+	if err != nil {
+		// Put the item back on the workqueue to handle any transient errors.
+		n.networkPolicyStatusQueue.AddRateLimited(key)
+		klog.Errorf("Failed to sync NetworkPolicy status %s: %v", key, err)
+		return true
+	}
+	n.networkPolicyStatusQueue.Forget(key)
+	return true
+}
+
+// syncNetworkPolicyStatus computes the realization status of an Antrea-native
+// policy from the statuses reported by the Nodes which span it, and updates
+// the status of the ClusterNetworkPolicy or Antrea NetworkPolicy if needed.
+func (n *NetworkPolicyController) syncNetworkPolicyStatus(key string) error {
+	internalNPObj, found, _ := n.internalNetworkPolicyStore.Get(key)
+	if !found {
+		// The policy has been deleted, forget the statuses reported for it.
+		n.networkPolicyStatusesMutex.Lock()
+		delete(n.networkPolicyStatuses, key)
+		n.networkPolicyStatusesMutex.Unlock()
+		return nil
+	}
+	internalNP := internalNPObj.(*antreatypes.NetworkPolicy)
+	// Status is only maintained for Antrea-native policies.
+	if internalNP.Priority == nil {
+		return nil
+	}
+	status := n.computeNetworkPolicyStatus(key, internalNP)
+
+	namespace, name := internalNP.Namespace, internalNP.Name
+	if namespace == "" {
+		if n.cnpLister == nil {
+			return nil
+		}
+		cnp, err := n.cnpLister.Get(name)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if cnp.Status == *status {
+			return nil
+		}
+		toUpdate := cnp.DeepCopy()
+		toUpdate.Status = *status
+		klog.V(2).Infof("Updating status of ClusterNetworkPolicy %s: %+v", name, *status)
+		_, err = n.crdClient.SecurityV1alpha1().ClusterNetworkPolicies().UpdateStatus(context.TODO(), toUpdate, metav1.UpdateOptions{})
+		return err
+	}
+	if n.anpLister == nil {
+		return nil
+	}
+	anp, err := n.anpLister.NetworkPolicies(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if anp.Status == *status {
+		return nil
+	}
+	toUpdate := anp.DeepCopy()
+	toUpdate.Status = *status
+	klog.V(2).Infof("Updating status of Antrea NetworkPolicy %s/%s: %+v", namespace, name, *status)
+	_, err = n.crdClient.SecurityV1alpha1().NetworkPolicies(namespace).UpdateStatus(context.TODO(), toUpdate, metav1.UpdateOptions{})
+	return err
+}
+
+// computeNetworkPolicyStatus returns the status of the given internal
+// NetworkPolicy. A Node is considered to have realized the policy only if it
+// reported no error for the current generation of the policy.
+func (n *NetworkPolicyController) computeNetworkPolicyStatus(key string, internalNP *antreatypes.NetworkPolicy) *secv1alpha1.NetworkPolicyStatus {
+	status := &secv1alpha1.NetworkPolicyStatus{
+		ObservedGeneration: internalNP.Generation,
+	}
+	// The span hasn't been computed yet.
+	if internalNP.SpanMeta.NodeNames == nil {
+		status.Phase = secv1alpha1.NetworkPolicyPending
+		return status
+	}
+	status.DesiredNodesRealized = int32(internalNP.SpanMeta.NodeNames.Len())
+
+	n.networkPolicyStatusesMutex.RLock()
+	defer n.networkPolicyStatusesMutex.RUnlock()
+	for nodeName, nodeStatus := range n.networkPolicyStatuses[key] {
+		if !internalNP.SpanMeta.NodeNames.Has(nodeName) {
+			continue
+		}
+		if nodeStatus.Generation == internalNP.Generation && nodeStatus.Error == "" {
+			status.CurrentNodesRealized++
+		}
+	}
+	if status.CurrentNodesRealized == status.DesiredNodesRealized {
+		status.Phase = secv1alpha1.NetworkPolicyRealized
+	} else {
+		status.Phase = secv1alpha1.NetworkPolicyRealizing
+	}
+	return status
+}
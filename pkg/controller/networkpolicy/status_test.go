@@ -0,0 +1,150 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	antreatypes "github.com/vmware-tanzu/antrea/pkg/controller/types"
+)
+
+func TestSyncNetworkPolicyStatus(t *testing.T) {
+	p10 := float64(10)
+	cnp := &secv1alpha1.ClusterNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cnpA", UID: "uidA", Generation: 2},
+		Spec: secv1alpha1.ClusterNetworkPolicySpec{
+			Priority: p10,
+		},
+	}
+	_, npc := newController()
+	_, err := npc.crdClient.SecurityV1alpha1().ClusterNetworkPolicies().Create(context.TODO(), cnp, metav1.CreateOptions{})
+	require.NoError(t, err)
+	npc.cnpStore.Add(cnp)
+	npc.internalNetworkPolicyStore.Create(&antreatypes.NetworkPolicy{
+		UID:        cnp.UID,
+		Name:       cnp.Name,
+		Priority:   &p10,
+		Generation: cnp.Generation,
+		SpanMeta:   antreatypes.SpanMeta{NodeNames: sets.NewString("node1", "node2")},
+	})
+
+	tests := []struct {
+		name           string
+		reportedStatus *networking.NetworkPolicyStatus
+		expectedStatus secv1alpha1.NetworkPolicyStatus
+	}{
+		{
+			name: "no-node-reported",
+			expectedStatus: secv1alpha1.NetworkPolicyStatus{
+				Phase:                secv1alpha1.NetworkPolicyRealizing,
+				ObservedGeneration:   2,
+				CurrentNodesRealized: 0,
+				DesiredNodesRealized: 2,
+			},
+		},
+		{
+			name: "one-node-realized",
+			reportedStatus: &networking.NetworkPolicyStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "cnpA"},
+				Nodes:      []networking.NetworkPolicyNodeStatus{{NodeName: "node1", Generation: 2}},
+			},
+			expectedStatus: secv1alpha1.NetworkPolicyStatus{
+				Phase:                secv1alpha1.NetworkPolicyRealizing,
+				ObservedGeneration:   2,
+				CurrentNodesRealized: 1,
+				DesiredNodesRealized: 2,
+			},
+		},
+		{
+			name: "stale-generation-reported",
+			reportedStatus: &networking.NetworkPolicyStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "cnpA"},
+				Nodes:      []networking.NetworkPolicyNodeStatus{{NodeName: "node2", Generation: 1}},
+			},
+			expectedStatus: secv1alpha1.NetworkPolicyStatus{
+				Phase:                secv1alpha1.NetworkPolicyRealizing,
+				ObservedGeneration:   2,
+				CurrentNodesRealized: 1,
+				DesiredNodesRealized: 2,
+			},
+		},
+		{
+			name: "error-reported",
+			reportedStatus: &networking.NetworkPolicyStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "cnpA"},
+				Nodes:      []networking.NetworkPolicyNodeStatus{{NodeName: "node2", Generation: 2, Error: "failed to install flows"}},
+			},
+			expectedStatus: secv1alpha1.NetworkPolicyStatus{
+				Phase:                secv1alpha1.NetworkPolicyRealizing,
+				ObservedGeneration:   2,
+				CurrentNodesRealized: 1,
+				DesiredNodesRealized: 2,
+			},
+		},
+		{
+			name: "unrelated-node-reported",
+			reportedStatus: &networking.NetworkPolicyStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "cnpA"},
+				Nodes:      []networking.NetworkPolicyNodeStatus{{NodeName: "node3", Generation: 2}},
+			},
+			expectedStatus: secv1alpha1.NetworkPolicyStatus{
+				Phase:                secv1alpha1.NetworkPolicyRealizing,
+				ObservedGeneration:   2,
+				CurrentNodesRealized: 1,
+				DesiredNodesRealized: 2,
+			},
+		},
+		{
+			name: "all-nodes-realized",
+			reportedStatus: &networking.NetworkPolicyStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "cnpA"},
+				Nodes:      []networking.NetworkPolicyNodeStatus{{NodeName: "node2", Generation: 2}},
+			},
+			expectedStatus: secv1alpha1.NetworkPolicyStatus{
+				Phase:                secv1alpha1.NetworkPolicyRealized,
+				ObservedGeneration:   2,
+				CurrentNodesRealized: 2,
+				DesiredNodesRealized: 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.reportedStatus != nil {
+				require.NoError(t, npc.UpdateStatus(tt.reportedStatus))
+			}
+			require.NoError(t, npc.syncNetworkPolicyStatus("cnpA"))
+			updatedCNP, err := npc.crdClient.SecurityV1alpha1().ClusterNetworkPolicies().Get(context.TODO(), "cnpA", metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, updatedCNP.Status)
+			// Keep the lister in sync with the updated object.
+			npc.cnpStore.Update(updatedCNP)
+		})
+	}
+
+	// The statuses of a deleted policy should be forgotten.
+	npc.internalNetworkPolicyStore.Delete("cnpA")
+	require.NoError(t, npc.syncNetworkPolicyStatus("cnpA"))
+	_, exists := npc.networkPolicyStatuses["cnpA"]
+	assert.False(t, exists)
+}
@@ -78,7 +78,7 @@ func (event *addressGroupEvent) GetResourceVersion() uint64 {
 }
 
 // ToAddressGroupMsg converts the stored AddressGroup to its message form.
-// If includeBody is true, IPAddresses will be copied.
+// If includeBody is true, IPAddresses and GroupMembers will be copied.
 func ToAddressGroupMsg(in *types.AddressGroup, out *networking.AddressGroup, includeBody bool) {
 	out.Name = in.Name
 	out.UID = in.UID
@@ -88,6 +88,9 @@ func ToAddressGroupMsg(in *types.AddressGroup, out *networking.AddressGroup, inc
 	for _, p := range in.Pods {
 		out.Pods = append(out.Pods, *p)
 	}
+	for _, m := range in.GroupMembers {
+		out.GroupMembers = append(out.GroupMembers, *m)
+	}
 }
 
 var _ storage.GenEventFunc = genAddressGroupEvent
@@ -120,7 +123,10 @@ func genAddressGroupEvent(key string, prevObj, currObj interface{}, rv uint64) (
 		var addedPods, removedPods []networking.GroupMemberPod
 
 		for podHash, pod := range event.CurrGroup.Pods {
-			if _, exists := event.PrevGroup.Pods[podHash]; !exists {
+			// A Pod whose ports have changed, e.g. a Service port or an
+			// Endpoint, is added again, which overrides the stale one as Pods
+			// are identified by their references and IPs.
+			if prevPod, exists := event.PrevGroup.Pods[podHash]; !exists || !reflect.DeepEqual(prevPod.Ports, pod.Ports) {
 				addedPods = append(addedPods, *pod)
 			}
 		}
@@ -129,13 +135,26 @@ func genAddressGroupEvent(key string, prevObj, currObj interface{}, rv uint64) (
 				removedPods = append(removedPods, *pod)
 			}
 		}
+		var addedMembers, removedMembers []networking.GroupMember
+		for memberHash, member := range event.CurrGroup.GroupMembers {
+			if _, exists := event.PrevGroup.GroupMembers[memberHash]; !exists {
+				addedMembers = append(addedMembers, *member)
+			}
+		}
+		for memberHash, member := range event.PrevGroup.GroupMembers {
+			if _, exists := event.CurrGroup.GroupMembers[memberHash]; !exists {
+				removedMembers = append(removedMembers, *member)
+			}
+		}
 		// PatchObject will not be generated when only span changes.
-		if len(addedPods)+len(removedPods) > 0 {
+		if len(addedPods)+len(removedPods)+len(addedMembers)+len(removedMembers) > 0 {
 			event.PatchObject = new(networking.AddressGroupPatch)
 			event.PatchObject.UID = event.CurrGroup.UID
 			event.PatchObject.Name = event.CurrGroup.Name
 			event.PatchObject.AddedPods = addedPods
 			event.PatchObject.RemovedPods = removedPods
+			event.PatchObject.AddedGroupMembers = addedMembers
+			event.PatchObject.RemovedGroupMembers = removedMembers
 		}
 	}
 
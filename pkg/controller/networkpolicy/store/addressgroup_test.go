@@ -35,6 +35,13 @@ func newAddressGroupMember(ip string) *networking.GroupMemberPod {
 	return &networking.GroupMemberPod{IP: networking.IPAddress(net.ParseIP(ip))}
 }
 
+func newAddressGroupExternalEntityMember(name, ip string) *networking.GroupMember {
+	return &networking.GroupMember{
+		ExternalEntity: &networking.ExternalEntityReference{Name: name, Namespace: "ns1"},
+		Endpoints:      []networking.Endpoint{{IP: networking.IPAddress(net.ParseIP(ip))}},
+	}
+}
+
 func TestWatchAddressGroupEvent(t *testing.T) {
 	testCases := map[string]struct {
 		fieldSelector fields.Selector
@@ -71,6 +78,65 @@ func TestWatchAddressGroupEvent(t *testing.T) {
 				}},
 			},
 		},
+		"updated-member-ports": {
+			// A member whose ports have changed should be added again.
+			fieldSelector: fields.Everything(),
+			operations: func(store storage.Interface) {
+				member := newAddressGroupMember("1.1.1.1")
+				member.Ports = []networking.NamedPort{{Port: 80, Protocol: networking.ProtocolTCP}}
+				store.Create(&types.AddressGroup{
+					Name:     "foo",
+					SpanMeta: types.SpanMeta{sets.NewString("node1")},
+					Pods:     networking.NewGroupMemberPodSet(member),
+				})
+				updatedMember := newAddressGroupMember("1.1.1.1")
+				updatedMember.Ports = []networking.NamedPort{{Port: 8080, Protocol: networking.ProtocolTCP}}
+				store.Update(&types.AddressGroup{
+					Name:     "foo",
+					SpanMeta: types.SpanMeta{sets.NewString("node1")},
+					Pods:     networking.NewGroupMemberPodSet(updatedMember),
+				})
+			},
+			expected: []watch.Event{
+				{watch.Bookmark, nil},
+				{watch.Added, &networking.AddressGroup{
+					ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+					Pods:       []networking.GroupMemberPod{{IP: networking.IPAddress(net.ParseIP("1.1.1.1")), Ports: []networking.NamedPort{{Port: 80, Protocol: networking.ProtocolTCP}}}},
+				}},
+				{watch.Modified, &networking.AddressGroupPatch{
+					ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+					AddedPods:  []networking.GroupMemberPod{{IP: networking.IPAddress(net.ParseIP("1.1.1.1")), Ports: []networking.NamedPort{{Port: 8080, Protocol: networking.ProtocolTCP}}}},
+				}},
+			},
+		},
+		"external-entity-members": {
+			// All events should be watched.
+			fieldSelector: fields.Everything(),
+			operations: func(store storage.Interface) {
+				store.Create(&types.AddressGroup{
+					Name:         "foo",
+					SpanMeta:     types.SpanMeta{sets.NewString("node1")},
+					GroupMembers: networking.NewGroupMemberSet(newAddressGroupExternalEntityMember("ee1", "1.1.1.1"), newAddressGroupExternalEntityMember("ee2", "2.2.2.2")),
+				})
+				store.Update(&types.AddressGroup{
+					Name:         "foo",
+					SpanMeta:     types.SpanMeta{sets.NewString("node1")},
+					GroupMembers: networking.NewGroupMemberSet(newAddressGroupExternalEntityMember("ee1", "1.1.1.1"), newAddressGroupExternalEntityMember("ee3", "3.3.3.3")),
+				})
+			},
+			expected: []watch.Event{
+				{watch.Bookmark, nil},
+				{watch.Added, &networking.AddressGroup{
+					ObjectMeta:   metav1.ObjectMeta{Name: "foo"},
+					GroupMembers: []networking.GroupMember{*newAddressGroupExternalEntityMember("ee1", "1.1.1.1"), *newAddressGroupExternalEntityMember("ee2", "2.2.2.2")},
+				}},
+				{watch.Modified, &networking.AddressGroupPatch{
+					ObjectMeta:          metav1.ObjectMeta{Name: "foo"},
+					AddedGroupMembers:   []networking.GroupMember{*newAddressGroupExternalEntityMember("ee3", "3.3.3.3")},
+					RemovedGroupMembers: []networking.GroupMember{*newAddressGroupExternalEntityMember("ee2", "2.2.2.2")},
+				}},
+			},
+		},
 		"node-scoped-watcher": {
 			// Only events that span node3 should be watched.
 			fieldSelector: fields.SelectorFromSet(fields.Set{"nodeName": "node3"}),
@@ -141,6 +207,9 @@ func TestWatchAddressGroupEvent(t *testing.T) {
 					if !assert.ElementsMatch(t, expectedObj.Pods, actualObj.Pods) {
 						t.Errorf("Expected IPAddresses %v, got %v", expectedObj.Pods, actualObj.Pods)
 					}
+					if !assert.ElementsMatch(t, expectedObj.GroupMembers, actualObj.GroupMembers) {
+						t.Errorf("Expected GroupMembers %v, got %v", expectedObj.GroupMembers, actualObj.GroupMembers)
+					}
 				case watch.Modified:
 					actualObj := actualEvent.Object.(*networking.AddressGroupPatch)
 					expectedObj := expectedEvent.Object.(*networking.AddressGroupPatch)
@@ -153,6 +222,12 @@ func TestWatchAddressGroupEvent(t *testing.T) {
 					if !assert.ElementsMatch(t, expectedObj.RemovedPods, actualObj.RemovedPods) {
 						t.Errorf("Expected RemovedIPAddresses %v, got %v", expectedObj.RemovedPods, actualObj.RemovedPods)
 					}
+					if !assert.ElementsMatch(t, expectedObj.AddedGroupMembers, actualObj.AddedGroupMembers) {
+						t.Errorf("Expected AddedGroupMembers %v, got %v", expectedObj.AddedGroupMembers, actualObj.AddedGroupMembers)
+					}
+					if !assert.ElementsMatch(t, expectedObj.RemovedGroupMembers, actualObj.RemovedGroupMembers) {
+						t.Errorf("Expected RemovedGroupMembers %v, got %v", expectedObj.RemovedGroupMembers, actualObj.RemovedGroupMembers)
+					}
 				}
 			}
 			select {
@@ -43,11 +43,11 @@ type appliedToGroupEvent struct {
 // 1. Added event will be generated if the Selectors was not interested in the object but is now.
 // 2. Modified event will be generated if the Selectors was and is interested in the object.
 // 3. Deleted event will be generated if the Selectors was interested in the object but is not now.
-// 4. If nodeName is specified, only Pods that hosted by the Node will be in the event.
+// 4. If nodeName is specified, only Pods and GroupMembers that hosted by the Node will be in the event.
 func (event *appliedToGroupEvent) ToWatchEvent(selectors *storage.Selectors, isInitEvent bool) *watch.Event {
 	prevObjSelected, currObjSelected := isSelected(event.Key, event.PrevGroup, event.CurrGroup, selectors, isInitEvent)
 
-	// If nodeName is specified in selectors, only Pods that hosted by the Node should be in the event.
+	// If nodeName is specified in selectors, only Pods and GroupMembers that hosted by the Node should be in the event.
 	nodeName, nodeSpecified := selectors.Field.RequiresExactMatch("nodeName")
 
 	switch {
@@ -89,7 +89,28 @@ func (event *appliedToGroupEvent) ToWatchEvent(selectors *storage.Selectors, isI
 		for _, pod := range prevPods.Difference(currPods) {
 			obj.RemovedPods = append(obj.RemovedPods, *pod)
 		}
-		if len(obj.AddedPods)+len(obj.RemovedPods) == 0 {
+
+		var currMembers, prevMembers networking.GroupMemberSet
+		if nodeSpecified {
+			currMembers = event.CurrGroup.GroupMemberByNode[nodeName]
+			prevMembers = event.PrevGroup.GroupMemberByNode[nodeName]
+		} else {
+			currMembers = networking.GroupMemberSet{}
+			for _, members := range event.CurrGroup.GroupMemberByNode {
+				currMembers = currMembers.Union(members)
+			}
+			prevMembers = networking.GroupMemberSet{}
+			for _, members := range event.PrevGroup.GroupMemberByNode {
+				prevMembers = prevMembers.Union(members)
+			}
+		}
+		for _, member := range currMembers.Difference(prevMembers) {
+			obj.AddedGroupMembers = append(obj.AddedGroupMembers, *member)
+		}
+		for _, member := range prevMembers.Difference(currMembers) {
+			obj.RemovedGroupMembers = append(obj.RemovedGroupMembers, *member)
+		}
+		if len(obj.AddedPods)+len(obj.RemovedPods)+len(obj.AddedGroupMembers)+len(obj.RemovedGroupMembers) == 0 {
 			// No change for the watcher.
 			return nil
 		}
@@ -132,12 +153,12 @@ func genAppliedToGroupEvent(key string, prevObj, currObj interface{}, rv uint64)
 }
 
 // ToAppliedToGroupMsg converts the stored AppliedToGroup to its message form.
-// If includeBody is true, Pods will be copied.
-// If nodeName is provided, only Pods that hosted by the Node will be copied.
+// If includeBody is true, Pods and GroupMembers will be copied.
+// If nodeName is provided, only Pods and GroupMembers that hosted by the Node will be copied.
 func ToAppliedToGroupMsg(in *types.AppliedToGroup, out *networking.AppliedToGroup, includeBody bool, nodeName *string) {
 	out.Name = in.Name
 	out.UID = in.UID
-	if !includeBody || in.PodsByNode == nil {
+	if !includeBody {
 		return
 	}
 	if nodeName != nil {
@@ -146,12 +167,22 @@ func ToAppliedToGroupMsg(in *types.AppliedToGroup, out *networking.AppliedToGrou
 				out.Pods = append(out.Pods, *pod)
 			}
 		}
+		if members, exists := in.GroupMemberByNode[*nodeName]; exists {
+			for _, member := range members {
+				out.GroupMembers = append(out.GroupMembers, *member)
+			}
+		}
 	} else {
 		for _, pods := range in.PodsByNode {
 			for _, pod := range pods {
 				out.Pods = append(out.Pods, *pod)
 			}
 		}
+		for _, members := range in.GroupMemberByNode {
+			for _, member := range members {
+				out.GroupMembers = append(out.GroupMembers, *member)
+			}
+		}
 	}
 }
 
@@ -17,6 +17,7 @@ package store
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
@@ -55,7 +56,7 @@ type networkPolicyEvent struct {
 // 2. Modified event will be generated if the Selectors was and is interested in the object.
 // 3. Deleted event will be generated if the Selectors was interested in the object but is not now.
 func (event *networkPolicyEvent) ToWatchEvent(selectors *storage.Selectors, isInitEvent bool) *watch.Event {
-	prevObjSelected, currObjSelected := isSelected(event.Key, event.PrevPolicy, event.CurrPolicy, selectors, isInitEvent)
+	prevObjSelected, currObjSelected := isSelected(networkPolicyNameKey(event.Key), event.PrevPolicy, event.CurrPolicy, selectors, isInitEvent)
 
 	switch {
 	case !currObjSelected && !prevObjSelected:
@@ -113,17 +114,54 @@ func ToNetworkPolicyMsg(in *types.NetworkPolicy, out *networking.NetworkPolicy,
 	out.Rules = in.Rules
 	out.AppliedToGroups = in.AppliedToGroups
 	out.Priority = in.Priority
+	out.TierPriority = in.TierPriority
+	out.Generation = in.Generation
 }
 
+// antreaNetworkPolicyKeyPrefix is the prefix of the keys of the NetworkPolicies
+// translated from Antrea NetworkPolicies.
+const antreaNetworkPolicyKeyPrefix = "AntreaNetworkPolicy:"
+
 // NetworkPolicyKeyFunc knows how to get the key of a NetworkPolicy.
 func NetworkPolicyKeyFunc(obj interface{}) (string, error) {
 	policy, ok := obj.(*types.NetworkPolicy)
 	if !ok {
 		return "", fmt.Errorf("object is not *types.NetworkPolicy: %v", obj)
 	}
+	// Only Antrea-native policies have a priority.
+	if policy.Priority != nil {
+		return AntreaPolicyKey(policy.Namespace, policy.Name), nil
+	}
 	return k8s.NamespacedName(policy.Namespace, policy.Name), nil
 }
 
+// AntreaPolicyKey returns the key of the NetworkPolicy translated from the
+// ClusterNetworkPolicy or the Antrea NetworkPolicy with the given Namespace and
+// name. The key of an Antrea NetworkPolicy is prefixed with its type, so that
+// it does not conflict with a K8s NetworkPolicy with the same Namespace and
+// name, whose key is its namespaced name.
+func AntreaPolicyKey(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return antreaNetworkPolicyKeyPrefix + k8s.NamespacedName(namespace, name)
+}
+
+// networkPolicyNameKey returns the namespaced name of the NetworkPolicy stored
+// with the given key, which is the key of both the K8s NetworkPolicy and the
+// Antrea NetworkPolicy with this Namespace and name.
+func networkPolicyNameKey(key string) string {
+	return strings.TrimPrefix(key, antreaNetworkPolicyKeyPrefix)
+}
+
+// networkPolicySelectFunc returns whether the provided selectors matches the
+// NetworkPolicy. The key of the selectors is a namespaced name, which selects
+// both the K8s NetworkPolicy and the Antrea NetworkPolicy with this Namespace
+// and name.
+func networkPolicySelectFunc(selectors *storage.Selectors, key string, obj interface{}) bool {
+	return keyAndSpanSelectFunc(selectors, networkPolicyNameKey(key), obj)
+}
+
 // NewNetworkPolicyStore creates a store of NetworkPolicy.
 func NewNetworkPolicyStore() storage.Interface {
 	// Build indices with the appliedToGroups and the addressGroups so that
@@ -159,5 +197,5 @@ func NewNetworkPolicyStore() storage.Interface {
 			return groupNames, nil
 		},
 	}
-	return ram.NewStore(NetworkPolicyKeyFunc, indexers, genNetworkPolicyEvent, keyAndSpanSelectFunc, func() runtime.Object { return new(networking.NetworkPolicy) })
+	return ram.NewStore(NetworkPolicyKeyFunc, indexers, genNetworkPolicyEvent, networkPolicySelectFunc, func() runtime.Object { return new(networking.NetworkPolicy) })
 }
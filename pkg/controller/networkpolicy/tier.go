@@ -0,0 +1,160 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog"
+
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/controller/networkpolicy/store"
+	"github.com/vmware-tanzu/antrea/pkg/features"
+)
+
+const (
+	// DefaultTierName is the name of the Tier to which Antrea-native policies
+	// which do not specify a Tier belong.
+	DefaultTierName = "application"
+	// DefaultTierPriority is the priority of the default Tier.
+	DefaultTierPriority = int32(250)
+	// maxCreateTierRetries is the maximum number of attempts to create a
+	// default Tier.
+	maxCreateTierRetries = 10
+	// createTierRetryInterval is the interval between two attempts to create a
+	// default Tier.
+	createTierRetryInterval = 2 * time.Second
+)
+
+// defaultTiers are the Tiers created by antrea-controller on startup. They
+// are ordered from the highest to the lowest precedence, and leave room for
+// custom Tiers to be created in between.
+var defaultTiers = []*secv1alpha1.Tier{
+	{
+		ObjectMeta: metav1.ObjectMeta{Name: "emergency"},
+		Spec: secv1alpha1.TierSpec{
+			Priority:    50,
+			Description: "[READ-ONLY]: System generated Emergency Tier",
+		},
+	},
+	{
+		ObjectMeta: metav1.ObjectMeta{Name: "securityops"},
+		Spec: secv1alpha1.TierSpec{
+			Priority:    100,
+			Description: "[READ-ONLY]: System generated SecurityOps Tier",
+		},
+	},
+	{
+		ObjectMeta: metav1.ObjectMeta{Name: "networkops"},
+		Spec: secv1alpha1.TierSpec{
+			Priority:    150,
+			Description: "[READ-ONLY]: System generated NetworkOps Tier",
+		},
+	},
+	{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+		Spec: secv1alpha1.TierSpec{
+			Priority:    200,
+			Description: "[READ-ONLY]: System generated Platform Tier",
+		},
+	},
+	{
+		ObjectMeta: metav1.ObjectMeta{Name: DefaultTierName},
+		Spec: secv1alpha1.TierSpec{
+			Priority:    DefaultTierPriority,
+			Description: "[READ-ONLY]: System generated Application Tier",
+		},
+	},
+}
+
+// initializeTiers creates the default Tiers which do not exist yet. It must be
+// called after the Tier informer has been synced.
+func (n *NetworkPolicyController) initializeTiers() {
+	for _, t := range defaultTiers {
+		// Tiers may already exist, e.g. if antrea-controller was restarted.
+		if _, err := n.tierLister.Get(t.Name); err == nil {
+			continue
+		}
+		n.initTier(t)
+	}
+}
+
+// initTier creates the given Tier, retrying a few times in case of failure.
+func (n *NetworkPolicyController) initTier(t *secv1alpha1.Tier) {
+	var err error
+	for i := 0; i < maxCreateTierRetries; i++ {
+		klog.V(2).Infof("Creating default Tier %s", t.Name)
+		_, err = n.crdClient.SecurityV1alpha1().Tiers().Create(context.TODO(), t, metav1.CreateOptions{})
+		if err == nil || errors.IsAlreadyExists(err) {
+			return
+		}
+		klog.Warningf("Failed to create default Tier %s, retrying: %v", t.Name, err)
+		time.Sleep(createTierRetryInterval)
+	}
+	klog.Errorf("Failed to create default Tier %s: %v", t.Name, err)
+}
+
+// getTierPriority returns the priority of the Tier with the given name. An
+// empty name refers to the default Tier. If the Tier cannot be found, the
+// priority of the default Tier is returned.
+func (n *NetworkPolicyController) getTierPriority(tier string) int32 {
+	if tier == "" {
+		return DefaultTierPriority
+	}
+	t, err := n.tierLister.Get(tier)
+	if err != nil {
+		klog.Errorf("Failed to retrieve Tier %s, using the priority of the default Tier instead: %v", tier, err)
+		return DefaultTierPriority
+	}
+	return t.Spec.Priority
+}
+
+// addTier receives Tier ADD events and re-processes the Antrea-native
+// policies which refer to it, as they may have been processed before the Tier
+// was created.
+func (n *NetworkPolicyController) addTier(obj interface{}) {
+	defer n.heartbeat("addTier")
+	t := obj.(*secv1alpha1.Tier)
+	klog.V(2).Infof("Processing Tier %s ADD event", t.Name)
+	if features.DefaultFeatureGate.Enabled(features.ClusterNetworkPolicy) {
+		cnps, _ := n.cnpLister.List(labels.Everything())
+		for _, cnp := range cnps {
+			if cnp.Spec.Tier != t.Name || !n.isProcessed(cnp) {
+				continue
+			}
+			n.updateCNP(cnp, cnp)
+		}
+	}
+	if features.DefaultFeatureGate.Enabled(features.AntreaNetworkPolicy) {
+		anps, _ := n.anpLister.List(labels.Everything())
+		for _, anp := range anps {
+			if anp.Spec.Tier != t.Name || !n.isProcessed(anp) {
+				continue
+			}
+			n.updateANP(anp, anp)
+		}
+	}
+}
+
+// isProcessed returns true if the internal NetworkPolicy corresponding to the
+// given policy exists.
+func (n *NetworkPolicyController) isProcessed(np metav1.Object) bool {
+	_, found, _ := n.internalNetworkPolicyStore.Get(store.AntreaPolicyKey(np.GetNamespace(), np.GetName()))
+	return found
+}
@@ -0,0 +1,512 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+
+	admv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/features"
+	"github.com/vmware-tanzu/antrea/pkg/k8s"
+)
+
+// NetworkPolicyValidator is responsible for validating the admission requests
+// of the resources related to Antrea-native policies.
+type NetworkPolicyValidator struct {
+	networkPolicyController *NetworkPolicyController
+}
+
+// NewNetworkPolicyValidator returns a new *NetworkPolicyValidator.
+func NewNetworkPolicyValidator(networkPolicyController *NetworkPolicyController) *NetworkPolicyValidator {
+	return &NetworkPolicyValidator{
+		networkPolicyController: networkPolicyController,
+	}
+}
+
+// Validate validates the request of the given AdmissionReview and returns
+// whether it is allowed.
+func (v *NetworkPolicyValidator) Validate(ar *admv1.AdmissionReview) *admv1.AdmissionResponse {
+	var msg string
+	allowed := true
+	klog.V(2).Infof("Validating %s %s for resource %s", ar.Request.Operation, ar.Request.Kind.Kind, ar.Request.Name)
+	switch ar.Request.Kind.Kind {
+	case "Tier":
+		var curTier, oldTier secv1alpha1.Tier
+		if ar.Request.Object.Raw != nil {
+			if err := json.Unmarshal(ar.Request.Object.Raw, &curTier); err != nil {
+				klog.Errorf("Error de-serializing current Tier: %v", err)
+				return getAdmissionResponseForErr(err)
+			}
+		}
+		if ar.Request.OldObject.Raw != nil {
+			if err := json.Unmarshal(ar.Request.OldObject.Raw, &oldTier); err != nil {
+				klog.Errorf("Error de-serializing old Tier: %v", err)
+				return getAdmissionResponseForErr(err)
+			}
+		}
+		msg, allowed = v.validateTier(ar.Request.Name, &curTier, &oldTier, ar.Request.Operation)
+	case "ClusterNetworkPolicy":
+		var curCNP, oldCNP secv1alpha1.ClusterNetworkPolicy
+		if ar.Request.Object.Raw == nil {
+			// Only the creation and the update of the spec are validated.
+			break
+		}
+		if err := json.Unmarshal(ar.Request.Object.Raw, &curCNP); err != nil {
+			klog.Errorf("Error de-serializing current ClusterNetworkPolicy: %v", err)
+			return getAdmissionResponseForErr(err)
+		}
+		checkPriority := true
+		if ar.Request.Operation == admv1.Update && ar.Request.OldObject.Raw != nil {
+			if err := json.Unmarshal(ar.Request.OldObject.Raw, &oldCNP); err != nil {
+				klog.Errorf("Error de-serializing old ClusterNetworkPolicy: %v", err)
+				return getAdmissionResponseForErr(err)
+			}
+			checkPriority = priorityChanged(curCNP.Spec.Tier, curCNP.Spec.Priority, oldCNP.Spec.Tier, oldCNP.Spec.Priority)
+		}
+		msg, allowed = v.validateCNP(ar.Request.Name, &curCNP, checkPriority)
+	case "NetworkPolicy":
+		var curANP, oldANP secv1alpha1.NetworkPolicy
+		if ar.Request.Object.Raw == nil {
+			// Only the creation and the update of the spec are validated.
+			break
+		}
+		if err := json.Unmarshal(ar.Request.Object.Raw, &curANP); err != nil {
+			klog.Errorf("Error de-serializing current Antrea NetworkPolicy: %v", err)
+			return getAdmissionResponseForErr(err)
+		}
+		checkPriority := true
+		if ar.Request.Operation == admv1.Update && ar.Request.OldObject.Raw != nil {
+			if err := json.Unmarshal(ar.Request.OldObject.Raw, &oldANP); err != nil {
+				klog.Errorf("Error de-serializing old Antrea NetworkPolicy: %v", err)
+				return getAdmissionResponseForErr(err)
+			}
+			checkPriority = priorityChanged(curANP.Spec.Tier, curANP.Spec.Priority, oldANP.Spec.Tier, oldANP.Spec.Priority)
+		}
+		// The Namespace of the request is set even if the object doesn't
+		// have it yet.
+		msg, allowed = v.validateANP(ar.Request.Namespace, ar.Request.Name, &curANP, checkPriority)
+	}
+	var result *metav1.Status
+	if msg != "" {
+		result = &metav1.Status{
+			Message: msg,
+		}
+	}
+	return &admv1.AdmissionResponse{
+		Allowed: allowed,
+		Result:  result,
+	}
+}
+
+// validateTier validates the admission of a Tier resource. The priority of a
+// Tier cannot be updated, and a Tier cannot be deleted while it is referenced
+// by any Antrea-native policy.
+func (v *NetworkPolicyValidator) validateTier(name string, curTier, oldTier *secv1alpha1.Tier, op admv1.Operation) (string, bool) {
+	switch op {
+	case admv1.Update:
+		if curTier.Spec.Priority != oldTier.Spec.Priority {
+			return fmt.Sprintf("update to the priority of Tier %s is not allowed", name), false
+		}
+	case admv1.Delete:
+		if refs := v.getTierReferences(name); len(refs) > 0 {
+			return fmt.Sprintf("Tier %s is referenced by %d Antrea-native policies (%v) and cannot be deleted", name, len(refs), refs), false
+		}
+	}
+	return "", true
+}
+
+// getTierReferences returns the namespaced names of the Antrea-native policies
+// which refer to the Tier with the given name. Policies which do not specify
+// a Tier refer to the default Tier.
+func (v *NetworkPolicyValidator) getTierReferences(name string) []string {
+	n := v.networkPolicyController
+	refersTo := func(tier string) bool {
+		if tier == "" {
+			tier = DefaultTierName
+		}
+		return tier == name
+	}
+	var refs []string
+	if features.DefaultFeatureGate.Enabled(features.ClusterNetworkPolicy) {
+		cnps, _ := n.cnpLister.List(labels.Everything())
+		for _, cnp := range cnps {
+			if refersTo(cnp.Spec.Tier) {
+				refs = append(refs, cnp.Name)
+			}
+		}
+	}
+	if features.DefaultFeatureGate.Enabled(features.AntreaNetworkPolicy) {
+		anps, _ := n.anpLister.List(labels.Everything())
+		for _, anp := range anps {
+			if refersTo(anp.Spec.Tier) {
+				refs = append(refs, k8s.NamespacedName(anp.Namespace, anp.Name))
+			}
+		}
+	}
+	return refs
+}
+
+// priorityChanged returns whether an update changes the Tier or the priority
+// of an Antrea-native policy. The priority of a policy which keeps both is not
+// checked against the other policies, so that the policies which already
+// share their priority with another policy can still be edited.
+func priorityChanged(curTier string, curPriority float64, oldTier string, oldPriority float64) bool {
+	if curTier == "" {
+		curTier = DefaultTierName
+	}
+	if oldTier == "" {
+		oldTier = DefaultTierName
+	}
+	return curTier != oldTier || curPriority != oldPriority
+}
+
+// validateCNP validates the spec of the ClusterNetworkPolicy with the given
+// name. The priority is only checked against the other policies if
+// checkPriority is true.
+func (v *NetworkPolicyValidator) validateCNP(name string, cnp *secv1alpha1.ClusterNetworkPolicy, checkPriority bool) (string, bool) {
+	specPath := field.NewPath("spec")
+	allErrs := v.validatePriority(cnp.Spec.Tier, cnp.Spec.Priority, "", name, checkPriority, specPath.Child("priority"))
+	allErrs = append(allErrs, validateAppliedTo(cnp.Spec.AppliedTo, "", specPath.Child("appliedTo"))...)
+	allErrs = append(allErrs, validateRules(cnp.Spec.Ingress, "", networking.DirectionIn, specPath.Child("ingress"))...)
+	allErrs = append(allErrs, validateRules(cnp.Spec.Egress, "", networking.DirectionOut, specPath.Child("egress"))...)
+	return getValidationResult(allErrs)
+}
+
+// validateANP validates the spec of the Antrea NetworkPolicy with the given
+// Namespace and name. The priority is only checked against the other policies
+// if checkPriority is true.
+func (v *NetworkPolicyValidator) validateANP(namespace, name string, anp *secv1alpha1.NetworkPolicy, checkPriority bool) (string, bool) {
+	specPath := field.NewPath("spec")
+	allErrs := v.validatePriority(anp.Spec.Tier, anp.Spec.Priority, namespace, name, checkPriority, specPath.Child("priority"))
+	allErrs = append(allErrs, validateAppliedTo(anp.Spec.AppliedTo, namespace, specPath.Child("appliedTo"))...)
+	allErrs = append(allErrs, validateRules(anp.Spec.Ingress, namespace, networking.DirectionIn, specPath.Child("ingress"))...)
+	allErrs = append(allErrs, validateRules(anp.Spec.Egress, namespace, networking.DirectionOut, specPath.Child("egress"))...)
+	return getValidationResult(allErrs)
+}
+
+// getValidationResult converts the validation errors of a resource to the
+// result of the admission.
+func getValidationResult(allErrs field.ErrorList) (string, bool) {
+	if len(allErrs) == 0 {
+		return "", true
+	}
+	return allErrs.ToAggregate().Error(), false
+}
+
+// validatePriority validates the priority of an Antrea-native policy, which
+// must be a finite number within the range supported by the CRDs and must not
+// be used by another policy of the same Tier which may apply to the same
+// workloads, as the order in which such policies are evaluated is undefined.
+// A ClusterNetworkPolicy may apply to the same workloads as any other policy,
+// while Antrea NetworkPolicies of different Namespaces never do. The namespace
+// is empty for ClusterNetworkPolicies. The priority is only checked against
+// the other policies if checkDuplicate is true.
+func (v *NetworkPolicyValidator) validatePriority(tier string, priority float64, namespace, name string, checkDuplicate bool, path *field.Path) field.ErrorList {
+	if math.IsNaN(priority) || math.IsInf(priority, 0) {
+		return field.ErrorList{field.Invalid(path, priority, "must be a finite number")}
+	}
+	if priority < 1 || priority > 10000 {
+		return field.ErrorList{field.Invalid(path, priority, "must be between 1 and 10000")}
+	}
+	if !checkDuplicate {
+		return nil
+	}
+	n := v.networkPolicyController
+	if tier == "" {
+		tier = DefaultTierName
+	}
+	sameTier := func(otherTier string) bool {
+		if otherTier == "" {
+			otherTier = DefaultTierName
+		}
+		return tier == otherTier
+	}
+	if features.DefaultFeatureGate.Enabled(features.ClusterNetworkPolicy) {
+		cnps, _ := n.cnpLister.List(labels.Everything())
+		for _, cnp := range cnps {
+			if namespace == "" && cnp.Name == name {
+				continue
+			}
+			if sameTier(cnp.Spec.Tier) && cnp.Spec.Priority == priority {
+				return field.ErrorList{field.Invalid(path, priority, fmt.Sprintf("priority is already used by ClusterNetworkPolicy %s in the same Tier", cnp.Name))}
+			}
+		}
+	}
+	if features.DefaultFeatureGate.Enabled(features.AntreaNetworkPolicy) {
+		var anps []*secv1alpha1.NetworkPolicy
+		if namespace == "" {
+			anps, _ = n.anpLister.List(labels.Everything())
+		} else {
+			anps, _ = n.anpLister.NetworkPolicies(namespace).List(labels.Everything())
+		}
+		for _, anp := range anps {
+			if anp.Namespace == namespace && anp.Name == name {
+				continue
+			}
+			if sameTier(anp.Spec.Tier) && anp.Spec.Priority == priority {
+				return field.ErrorList{field.Invalid(path, priority, fmt.Sprintf("priority is already used by Antrea NetworkPolicy %s in the same Tier", k8s.NamespacedName(anp.Namespace, anp.Name)))}
+			}
+		}
+	}
+	return nil
+}
+
+// validateAppliedTo validates the appliedTo of an Antrea-native policy. The
+// namespace is empty for ClusterNetworkPolicies.
+func validateAppliedTo(appliedTo []secv1alpha1.NetworkPolicyPeer, namespace string, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(appliedTo) == 0 {
+		return append(allErrs, field.Required(path, "at least one appliedTo must be set"))
+	}
+	for i, peer := range appliedTo {
+		peerPath := path.Index(i)
+		if peer.IPBlock != nil {
+			allErrs = append(allErrs, field.Forbidden(peerPath.Child("ipBlock"), "cannot be set in appliedTo"))
+		}
+		if peer.NodeSelector != nil {
+			allErrs = append(allErrs, field.Forbidden(peerPath.Child("nodeSelector"), "cannot be set in appliedTo"))
+		}
+		if peer.FQDN != "" {
+			allErrs = append(allErrs, field.Forbidden(peerPath.Child("fqdn"), "cannot be set in appliedTo"))
+		}
+		if peer.ServiceAccount != nil {
+			if peer.PodSelector != nil || peer.NamespaceSelector != nil || peer.ExternalEntitySelector != nil || peer.Group != "" {
+				allErrs = append(allErrs, field.Forbidden(peerPath, "serviceAccount cannot be set with any other selector"))
+			}
+		} else if namespace != "" {
+			if peer.NamespaceSelector != nil {
+				allErrs = append(allErrs, field.Forbidden(peerPath.Child("namespaceSelector"), "cannot be set in the appliedTo of Antrea NetworkPolicies"))
+			}
+			if peer.PodSelector == nil && peer.ExternalEntitySelector == nil {
+				allErrs = append(allErrs, field.Required(peerPath, "podSelector, externalEntitySelector or serviceAccount must be set"))
+			}
+		} else if peer.PodSelector == nil && peer.NamespaceSelector == nil && peer.ExternalEntitySelector == nil && peer.Group == "" {
+			allErrs = append(allErrs, field.Required(peerPath, "podSelector, namespaceSelector, externalEntitySelector, serviceAccount or group must be set"))
+		}
+		allErrs = append(allErrs, validateSelectors(peer, namespace, peerPath)...)
+	}
+	return allErrs
+}
+
+// validateRules validates the ingress or egress rules of an Antrea-native
+// policy. The namespace is empty for ClusterNetworkPolicies.
+func validateRules(rules []secv1alpha1.Rule, namespace string, direction networking.Direction, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, rule := range rules {
+		rulePath := path.Index(i)
+		if rule.Action == nil {
+			allErrs = append(allErrs, field.Required(rulePath.Child("action"), ""))
+		}
+		allErrs = append(allErrs, validatePorts(rule.Ports, rulePath.Child("ports"))...)
+		if direction == networking.DirectionIn {
+			if len(rule.To) > 0 {
+				allErrs = append(allErrs, field.Forbidden(rulePath.Child("to"), "cannot be set in ingress rules"))
+			}
+			if len(rule.ToServices) > 0 {
+				allErrs = append(allErrs, field.Forbidden(rulePath.Child("toServices"), "cannot be set in ingress rules"))
+			}
+			allErrs = append(allErrs, validatePeers(rule.From, namespace, direction, rulePath.Child("from"))...)
+			continue
+		}
+		if len(rule.From) > 0 {
+			allErrs = append(allErrs, field.Forbidden(rulePath.Child("from"), "cannot be set in egress rules"))
+		}
+		allErrs = append(allErrs, validatePeers(rule.To, namespace, direction, rulePath.Child("to"))...)
+		if len(rule.ToServices) == 0 {
+			continue
+		}
+		if len(rule.To) > 0 || len(rule.Ports) > 0 {
+			allErrs = append(allErrs, field.Forbidden(rulePath.Child("toServices"), "cannot be set with to or ports"))
+		}
+		for j, serviceRef := range rule.ToServices {
+			if serviceRef.Name == "" {
+				allErrs = append(allErrs, field.Required(rulePath.Child("toServices").Index(j).Child("name"), ""))
+			}
+			if namespace == "" && serviceRef.Namespace == "" {
+				allErrs = append(allErrs, field.Required(rulePath.Child("toServices").Index(j).Child("namespace"), "must be set in ClusterNetworkPolicies"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validatePeers validates the from or to peers of a rule of an Antrea-native
+// policy. The namespace is empty for ClusterNetworkPolicies.
+func validatePeers(peers []secv1alpha1.NetworkPolicyPeer, namespace string, direction networking.Direction, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, peer := range peers {
+		peerPath := path.Index(i)
+		// IPBlock, FQDN, Group, NodeSelector and ServiceAccount cannot be set
+		// with any other selector.
+		exclusiveSelectors := 0
+		for _, set := range []bool{peer.IPBlock != nil, peer.FQDN != "", peer.Group != "", peer.NodeSelector != nil, peer.ServiceAccount != nil} {
+			if set {
+				exclusiveSelectors++
+			}
+		}
+		if peer.PodSelector != nil || peer.NamespaceSelector != nil || peer.ExternalEntitySelector != nil {
+			exclusiveSelectors++
+		}
+		if exclusiveSelectors > 1 {
+			allErrs = append(allErrs, field.Forbidden(peerPath, "ipBlock, fqdn, group, nodeSelector and serviceAccount cannot be set with any other selector"))
+		}
+		if peer.IPBlock != nil {
+			allErrs = append(allErrs, validateIPBlock(peer.IPBlock, peerPath.Child("ipBlock"))...)
+		}
+		if peer.FQDN != "" {
+			if direction != networking.DirectionOut {
+				allErrs = append(allErrs, field.Forbidden(peerPath.Child("fqdn"), "can only be set in egress rules"))
+			} else if _, err := toAntreaFQDNForCRD(peer.FQDN); err != nil {
+				allErrs = append(allErrs, field.Invalid(peerPath.Child("fqdn"), peer.FQDN, err.Error()))
+			}
+		}
+		allErrs = append(allErrs, validateSelectors(peer, namespace, peerPath)...)
+	}
+	return allErrs
+}
+
+// validateSelectors validates the label selectors and the group of a peer.
+func validateSelectors(peer secv1alpha1.NetworkPolicyPeer, namespace string, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if peer.PodSelector != nil && peer.ExternalEntitySelector != nil {
+		allErrs = append(allErrs, field.Forbidden(path, "podSelector and externalEntitySelector cannot be set together"))
+	}
+	if peer.Group != "" && namespace != "" {
+		allErrs = append(allErrs, field.Forbidden(path.Child("group"), "can only be set in ClusterNetworkPolicies"))
+	}
+	if peer.PodSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(peer.PodSelector, path.Child("podSelector"))...)
+	}
+	if peer.NamespaceSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(peer.NamespaceSelector, path.Child("namespaceSelector"))...)
+	}
+	if peer.ExternalEntitySelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(peer.ExternalEntitySelector, path.Child("externalEntitySelector"))...)
+	}
+	if peer.NodeSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(peer.NodeSelector, path.Child("nodeSelector"))...)
+	}
+	if peer.ServiceAccount != nil {
+		allErrs = append(allErrs, validateServiceAccountSelector(peer.ServiceAccount, namespace, path.Child("serviceAccount"))...)
+	}
+	return allErrs
+}
+
+// validateServiceAccountSelector validates the name, the Namespace and the
+// label selector of a ServiceAccount selector. The namespace is empty for
+// ClusterNetworkPolicies.
+func validateServiceAccountSelector(saSelector *secv1alpha1.ServiceAccountSelector, namespace string, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if saSelector.Name != "" && saSelector.Selector != nil {
+		allErrs = append(allErrs, field.Forbidden(path, "name and selector cannot be set together"))
+	} else if saSelector.Name == "" && saSelector.Selector == nil {
+		allErrs = append(allErrs, field.Required(path, "name or selector must be set"))
+	}
+	if namespace != "" && saSelector.Namespace != "" && saSelector.Namespace != namespace {
+		allErrs = append(allErrs, field.Invalid(path.Child("namespace"), saSelector.Namespace, "must be the Namespace of the Antrea NetworkPolicy"))
+	}
+	if namespace == "" && saSelector.Name != "" && saSelector.Namespace == "" {
+		allErrs = append(allErrs, field.Required(path.Child("namespace"), "must be set with name in ClusterNetworkPolicies"))
+	}
+	if saSelector.Selector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(saSelector.Selector, path.Child("selector"))...)
+	}
+	return allErrs
+}
+
+// validateIPBlock validates the CIDR of an IPBlock and its Except CIDRs, which
+// must be contained in the CIDR.
+func validateIPBlock(ipBlock *secv1alpha1.IPBlock, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	_, ipNet, err := net.ParseCIDR(ipBlock.CIDR)
+	if err != nil {
+		return append(allErrs, field.Invalid(path.Child("cidr"), ipBlock.CIDR, "must be a valid CIDR"))
+	}
+	prefixLength, _ := ipNet.Mask.Size()
+	for i, except := range ipBlock.Except {
+		_, exceptNet, err := net.ParseCIDR(except)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("except").Index(i), except, "must be a valid CIDR"))
+			continue
+		}
+		exceptPrefixLength, _ := exceptNet.Mask.Size()
+		if exceptPrefixLength < prefixLength || !ipNet.Contains(exceptNet.IP) {
+			allErrs = append(allErrs, field.Invalid(path.Child("except").Index(i), except, fmt.Sprintf("must be contained in CIDR %s", ipBlock.CIDR)))
+		}
+	}
+	return allErrs
+}
+
+// validatePorts validates the ports of a rule of an Antrea-native policy.
+func validatePorts(ports []secv1alpha1.NetworkPolicyPort, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	supportedProtocols := []string{string(v1.ProtocolTCP), string(v1.ProtocolUDP), string(v1.ProtocolSCTP), string(networking.ProtocolICMP)}
+	for i, port := range ports {
+		portPath := path.Index(i)
+		if port.Protocol != nil && !sets.NewString(supportedProtocols...).Has(string(*port.Protocol)) {
+			allErrs = append(allErrs, field.NotSupported(portPath.Child("protocol"), *port.Protocol, supportedProtocols))
+		}
+		if port.IPProtocol != nil && (port.Protocol != nil || port.Port != nil) {
+			allErrs = append(allErrs, field.Forbidden(portPath.Child("ipProtocol"), "cannot be set with protocol or port"))
+		}
+		if port.Port != nil {
+			if port.Port.Type == intstr.String {
+				for _, msg := range validation.IsValidPortName(port.Port.StrVal) {
+					allErrs = append(allErrs, field.Invalid(portPath.Child("port"), port.Port.StrVal, msg))
+				}
+			} else {
+				for _, msg := range validation.IsValidPortNum(int(port.Port.IntVal)) {
+					allErrs = append(allErrs, field.Invalid(portPath.Child("port"), port.Port.IntVal, msg))
+				}
+			}
+		}
+		if port.EndPort != nil {
+			if port.Port == nil || port.Port.Type != intstr.Int {
+				allErrs = append(allErrs, field.Required(portPath.Child("port"), "a numerical port must be set with endPort"))
+			} else if *port.EndPort < port.Port.IntVal {
+				allErrs = append(allErrs, field.Invalid(portPath.Child("endPort"), *port.EndPort, "must be greater than or equal to port"))
+			}
+		}
+		if (port.ICMPType != nil || port.ICMPCode != nil) && (port.Protocol == nil || *port.Protocol != v1.Protocol(networking.ProtocolICMP)) {
+			allErrs = append(allErrs, field.Forbidden(portPath, "icmpType and icmpCode can only be set when protocol is ICMP"))
+		}
+	}
+	return allErrs
+}
+
+// getAdmissionResponseForErr returns an AdmissionResponse which rejects the
+// request because of the given error.
+func getAdmissionResponseForErr(err error) *admv1.AdmissionResponse {
+	return &admv1.AdmissionResponse{
+		Result: &metav1.Status{
+			Message: err.Error(),
+		},
+	}
+}
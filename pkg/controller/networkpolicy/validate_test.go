@@ -0,0 +1,487 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/features"
+)
+
+func TestValidateTier(t *testing.T) {
+	tierA := &secv1alpha1.Tier{
+		ObjectMeta: metav1.ObjectMeta{Name: "tier-a"},
+		Spec:       secv1alpha1.TierSpec{Priority: 20},
+	}
+	tierAUpdated := tierA.DeepCopy()
+	tierAUpdated.Spec.Description = "updated description"
+	tierAReprioritized := tierA.DeepCopy()
+	tierAReprioritized.Spec.Priority = 30
+	tests := []struct {
+		name            string
+		operation       admv1.Operation
+		curTier         *secv1alpha1.Tier
+		oldTier         *secv1alpha1.Tier
+		expectedAllowed bool
+	}{
+		{
+			name:            "update-description",
+			operation:       admv1.Update,
+			curTier:         tierAUpdated,
+			oldTier:         tierA,
+			expectedAllowed: true,
+		},
+		{
+			name:            "update-priority",
+			operation:       admv1.Update,
+			curTier:         tierAReprioritized,
+			oldTier:         tierA,
+			expectedAllowed: false,
+		},
+		{
+			name:            "delete-unreferenced",
+			operation:       admv1.Delete,
+			oldTier:         tierA,
+			expectedAllowed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, c := newController()
+			v := NewNetworkPolicyValidator(c.NetworkPolicyController)
+			ar := &admv1.AdmissionReview{
+				Request: &admv1.AdmissionRequest{
+					Kind:      metav1.GroupVersionKind{Group: "security.antrea.tanzu.vmware.com", Version: "v1alpha1", Kind: "Tier"},
+					Name:      tt.oldTier.Name,
+					Operation: tt.operation,
+				},
+			}
+			if tt.curTier != nil {
+				raw, _ := json.Marshal(tt.curTier)
+				ar.Request.Object = runtime.RawExtension{Raw: raw}
+			}
+			raw, _ := json.Marshal(tt.oldTier)
+			ar.Request.OldObject = runtime.RawExtension{Raw: raw}
+			response := v.Validate(ar)
+			assert.Equal(t, tt.expectedAllowed, response.Allowed)
+		})
+	}
+}
+
+func TestValidateCNP(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.ClusterNetworkPolicy, true)()
+	allowAction := secv1alpha1.RuleActionAllow
+	protocolTCP := v1.ProtocolTCP
+	protocolFoo := v1.Protocol("FOO")
+	int80 := intstr.FromInt(80)
+	int79 := int32(79)
+	portHTTP := intstr.FromString("http")
+	selectorA := metav1.LabelSelector{MatchLabels: map[string]string{"foo1": "bar1"}}
+	appliedTo := []secv1alpha1.NetworkPolicyPeer{{PodSelector: &selectorA}}
+	existingCNP := &secv1alpha1.ClusterNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cnp-existing", UID: "uid-existing"},
+		Spec: secv1alpha1.ClusterNetworkPolicySpec{
+			AppliedTo: appliedTo,
+			Priority:  5,
+		},
+	}
+	tests := []struct {
+		name            string
+		spec            secv1alpha1.ClusterNetworkPolicySpec
+		expectedAllowed bool
+		expectedMessage string
+	}{
+		{
+			name: "valid",
+			spec: secv1alpha1.ClusterNetworkPolicySpec{
+				AppliedTo: appliedTo,
+				Priority:  10,
+				Ingress: []secv1alpha1.Rule{
+					{
+						Action: &allowAction,
+						Ports:  []secv1alpha1.NetworkPolicyPort{{Protocol: &protocolTCP, Port: &int80}},
+						From: []secv1alpha1.NetworkPolicyPeer{
+							{IPBlock: &secv1alpha1.IPBlock{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}}},
+						},
+					},
+				},
+			},
+			expectedAllowed: true,
+		},
+		{
+			name: "empty-appliedTo",
+			spec: secv1alpha1.ClusterNetworkPolicySpec{
+				Priority: 10,
+			},
+			expectedAllowed: false,
+			expectedMessage: "spec.appliedTo: Required value: at least one appliedTo must be set",
+		},
+		{
+			name: "duplicate-priority",
+			spec: secv1alpha1.ClusterNetworkPolicySpec{
+				AppliedTo: appliedTo,
+				Priority:  5,
+			},
+			expectedAllowed: false,
+			expectedMessage: "spec.priority: Invalid value: 5: priority is already used by ClusterNetworkPolicy cnp-existing in the same Tier",
+		},
+		{
+			name: "invalid-cidr",
+			spec: secv1alpha1.ClusterNetworkPolicySpec{
+				AppliedTo: appliedTo,
+				Priority:  10,
+				Egress: []secv1alpha1.Rule{
+					{
+						Action: &allowAction,
+						To:     []secv1alpha1.NetworkPolicyPeer{{IPBlock: &secv1alpha1.IPBlock{CIDR: "10.0.0.0/33"}}},
+					},
+				},
+			},
+			expectedAllowed: false,
+			expectedMessage: `spec.egress[0].to[0].ipBlock.cidr: Invalid value: "10.0.0.0/33": must be a valid CIDR`,
+		},
+		{
+			name: "except-not-contained",
+			spec: secv1alpha1.ClusterNetworkPolicySpec{
+				AppliedTo: appliedTo,
+				Priority:  10,
+				Egress: []secv1alpha1.Rule{
+					{
+						Action: &allowAction,
+						To:     []secv1alpha1.NetworkPolicyPeer{{IPBlock: &secv1alpha1.IPBlock{CIDR: "10.0.0.0/16", Except: []string{"10.1.0.0/24"}}}},
+					},
+				},
+			},
+			expectedAllowed: false,
+			expectedMessage: `spec.egress[0].to[0].ipBlock.except[0]: Invalid value: "10.1.0.0/24": must be contained in CIDR 10.0.0.0/16`,
+		},
+		{
+			name: "unsupported-protocol",
+			spec: secv1alpha1.ClusterNetworkPolicySpec{
+				AppliedTo: appliedTo,
+				Priority:  10,
+				Ingress: []secv1alpha1.Rule{
+					{
+						Action: &allowAction,
+						Ports:  []secv1alpha1.NetworkPolicyPort{{Protocol: &protocolFoo}},
+					},
+				},
+			},
+			expectedAllowed: false,
+			expectedMessage: `spec.ingress[0].ports[0].protocol: Unsupported value: "FOO": supported values: "TCP", "UDP", "SCTP", "ICMP"`,
+		},
+		{
+			name: "endPort-lower-than-port",
+			spec: secv1alpha1.ClusterNetworkPolicySpec{
+				AppliedTo: appliedTo,
+				Priority:  10,
+				Ingress: []secv1alpha1.Rule{
+					{
+						Action: &allowAction,
+						Ports:  []secv1alpha1.NetworkPolicyPort{{Protocol: &protocolTCP, Port: &int80, EndPort: &int79}},
+					},
+				},
+			},
+			expectedAllowed: false,
+			expectedMessage: "spec.ingress[0].ports[0].endPort: Invalid value: 79: must be greater than or equal to port",
+		},
+		{
+			name: "endPort-with-named-port",
+			spec: secv1alpha1.ClusterNetworkPolicySpec{
+				AppliedTo: appliedTo,
+				Priority:  10,
+				Ingress: []secv1alpha1.Rule{
+					{
+						Action: &allowAction,
+						Ports:  []secv1alpha1.NetworkPolicyPort{{Protocol: &protocolTCP, Port: &portHTTP, EndPort: &int79}},
+					},
+				},
+			},
+			expectedAllowed: false,
+			expectedMessage: "spec.ingress[0].ports[0].port: Required value: a numerical port must be set with endPort",
+		},
+		{
+			name: "serviceAccount-name-without-namespace",
+			spec: secv1alpha1.ClusterNetworkPolicySpec{
+				AppliedTo: appliedTo,
+				Priority:  10,
+				Ingress: []secv1alpha1.Rule{
+					{
+						Action: &allowAction,
+						From:   []secv1alpha1.NetworkPolicyPeer{{ServiceAccount: &secv1alpha1.ServiceAccountSelector{Name: "sa-a"}}},
+					},
+				},
+			},
+			expectedAllowed: false,
+			expectedMessage: "spec.ingress[0].from[0].serviceAccount.namespace: Required value: must be set with name in ClusterNetworkPolicies",
+		},
+		{
+			name: "missing-action",
+			spec: secv1alpha1.ClusterNetworkPolicySpec{
+				AppliedTo: appliedTo,
+				Priority:  10,
+				Ingress:   []secv1alpha1.Rule{{}},
+			},
+			expectedAllowed: false,
+			expectedMessage: "spec.ingress[0].action: Required value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, c := newController()
+			c.cnpStore.Add(existingCNP)
+			v := NewNetworkPolicyValidator(c.NetworkPolicyController)
+			cnp := &secv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "cnp-a", UID: "uid-a"},
+				Spec:       tt.spec,
+			}
+			raw, _ := json.Marshal(cnp)
+			ar := &admv1.AdmissionReview{
+				Request: &admv1.AdmissionRequest{
+					Kind:      metav1.GroupVersionKind{Group: "security.antrea.tanzu.vmware.com", Version: "v1alpha1", Kind: "ClusterNetworkPolicy"},
+					Name:      cnp.Name,
+					Operation: admv1.Create,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			}
+			response := v.Validate(ar)
+			assert.Equal(t, tt.expectedAllowed, response.Allowed)
+			if !tt.expectedAllowed {
+				assert.Equal(t, tt.expectedMessage, response.Result.Message)
+			}
+		})
+	}
+}
+
+func TestValidateANP(t *testing.T) {
+	allowAction := secv1alpha1.RuleActionAllow
+	selectorA := metav1.LabelSelector{MatchLabels: map[string]string{"foo1": "bar1"}}
+	selectorB := metav1.LabelSelector{MatchLabels: map[string]string{"foo2": "bar2"}}
+	tests := []struct {
+		name            string
+		spec            secv1alpha1.NetworkPolicySpec
+		expectedAllowed bool
+		expectedMessage string
+	}{
+		{
+			name: "valid",
+			spec: secv1alpha1.NetworkPolicySpec{
+				AppliedTo: []secv1alpha1.NetworkPolicyPeer{{PodSelector: &selectorA}},
+				Priority:  10,
+				Egress: []secv1alpha1.Rule{
+					{
+						Action:     &allowAction,
+						ToServices: []secv1alpha1.ServiceReference{{Name: "svc-a"}},
+					},
+				},
+			},
+			expectedAllowed: true,
+		},
+		{
+			name: "namespaceSelector-in-appliedTo",
+			spec: secv1alpha1.NetworkPolicySpec{
+				AppliedTo: []secv1alpha1.NetworkPolicyPeer{{PodSelector: &selectorA, NamespaceSelector: &selectorB}},
+				Priority:  10,
+			},
+			expectedAllowed: false,
+			expectedMessage: "spec.appliedTo[0].namespaceSelector: Forbidden: cannot be set in the appliedTo of Antrea NetworkPolicies",
+		},
+		{
+			name: "group-in-peer",
+			spec: secv1alpha1.NetworkPolicySpec{
+				AppliedTo: []secv1alpha1.NetworkPolicyPeer{{PodSelector: &selectorA}},
+				Priority:  10,
+				Ingress: []secv1alpha1.Rule{
+					{
+						Action: &allowAction,
+						From:   []secv1alpha1.NetworkPolicyPeer{{Group: "cg-a"}},
+					},
+				},
+			},
+			expectedAllowed: false,
+			expectedMessage: "spec.ingress[0].from[0].group: Forbidden: can only be set in ClusterNetworkPolicies",
+		},
+		{
+			name: "serviceAccount-in-other-namespace",
+			spec: secv1alpha1.NetworkPolicySpec{
+				AppliedTo: []secv1alpha1.NetworkPolicyPeer{{ServiceAccount: &secv1alpha1.ServiceAccountSelector{Name: "sa-a", Namespace: "ns2"}}},
+				Priority:  10,
+			},
+			expectedAllowed: false,
+			expectedMessage: `spec.appliedTo[0].serviceAccount.namespace: Invalid value: "ns2": must be the Namespace of the Antrea NetworkPolicy`,
+		},
+		{
+			name: "priority-out-of-range",
+			spec: secv1alpha1.NetworkPolicySpec{
+				AppliedTo: []secv1alpha1.NetworkPolicyPeer{{PodSelector: &selectorA}},
+				Priority:  10001,
+			},
+			expectedAllowed: false,
+			expectedMessage: "spec.priority: Invalid value: 10001: must be between 1 and 10000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, c := newController()
+			v := NewNetworkPolicyValidator(c.NetworkPolicyController)
+			anp := &secv1alpha1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "anp-a", UID: "uid-a"},
+				Spec:       tt.spec,
+			}
+			raw, _ := json.Marshal(anp)
+			ar := &admv1.AdmissionReview{
+				Request: &admv1.AdmissionRequest{
+					Kind:      metav1.GroupVersionKind{Group: "security.antrea.tanzu.vmware.com", Version: "v1alpha1", Kind: "NetworkPolicy"},
+					Namespace: anp.Namespace,
+					Name:      anp.Name,
+					Operation: admv1.Create,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			}
+			response := v.Validate(ar)
+			assert.Equal(t, tt.expectedAllowed, response.Allowed)
+			if !tt.expectedAllowed {
+				assert.Equal(t, tt.expectedMessage, response.Result.Message)
+			}
+		})
+	}
+}
+
+func TestValidatePriority(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.ClusterNetworkPolicy, true)()
+	defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.AntreaNetworkPolicy, true)()
+	selectorA := metav1.LabelSelector{MatchLabels: map[string]string{"foo1": "bar1"}}
+	appliedTo := []secv1alpha1.NetworkPolicyPeer{{PodSelector: &selectorA}}
+	existingCNP := &secv1alpha1.ClusterNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cnp-existing", UID: "uid-cnp"},
+		Spec:       secv1alpha1.ClusterNetworkPolicySpec{AppliedTo: appliedTo, Priority: 5},
+	}
+	existingANP := &secv1alpha1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "anp-existing", UID: "uid-anp"},
+		Spec:       secv1alpha1.NetworkPolicySpec{AppliedTo: appliedTo, Priority: 6},
+	}
+	newCNP := func(priority float64) *secv1alpha1.ClusterNetworkPolicy {
+		return &secv1alpha1.ClusterNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "cnp-a", UID: "uid-a"},
+			Spec:       secv1alpha1.ClusterNetworkPolicySpec{AppliedTo: appliedTo, Priority: priority},
+		}
+	}
+	// The Namespace of a created object may only be set in the request.
+	newANP := func(priority float64) *secv1alpha1.NetworkPolicy {
+		return &secv1alpha1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "anp-a", UID: "uid-a"},
+			Spec:       secv1alpha1.NetworkPolicySpec{AppliedTo: appliedTo, Priority: priority},
+		}
+	}
+	tests := []struct {
+		name            string
+		kind            string
+		namespace       string
+		operation       admv1.Operation
+		curObj          interface{}
+		oldObj          interface{}
+		expectedAllowed bool
+		expectedMessage string
+	}{
+		{
+			name:            "cnp-create-priority-of-anp",
+			kind:            "ClusterNetworkPolicy",
+			operation:       admv1.Create,
+			curObj:          newCNP(6),
+			expectedAllowed: false,
+			expectedMessage: "spec.priority: Invalid value: 6: priority is already used by Antrea NetworkPolicy ns2/anp-existing in the same Tier",
+		},
+		{
+			name:            "cnp-update-unchanged-duplicate-priority",
+			kind:            "ClusterNetworkPolicy",
+			operation:       admv1.Update,
+			curObj:          newCNP(5),
+			oldObj:          newCNP(5),
+			expectedAllowed: true,
+		},
+		{
+			name:            "cnp-update-to-duplicate-priority",
+			kind:            "ClusterNetworkPolicy",
+			operation:       admv1.Update,
+			curObj:          newCNP(5),
+			oldObj:          newCNP(10),
+			expectedAllowed: false,
+			expectedMessage: "spec.priority: Invalid value: 5: priority is already used by ClusterNetworkPolicy cnp-existing in the same Tier",
+		},
+		{
+			name:            "anp-create-priority-of-anp-in-same-namespace",
+			kind:            "NetworkPolicy",
+			namespace:       "ns2",
+			operation:       admv1.Create,
+			curObj:          newANP(6),
+			expectedAllowed: false,
+			expectedMessage: "spec.priority: Invalid value: 6: priority is already used by Antrea NetworkPolicy ns2/anp-existing in the same Tier",
+		},
+		{
+			name:            "anp-create-priority-of-anp-in-other-namespace",
+			kind:            "NetworkPolicy",
+			namespace:       "ns1",
+			operation:       admv1.Create,
+			curObj:          newANP(6),
+			expectedAllowed: true,
+		},
+		{
+			name:            "anp-update-unchanged-duplicate-priority",
+			kind:            "NetworkPolicy",
+			namespace:       "ns1",
+			operation:       admv1.Update,
+			curObj:          newANP(5),
+			oldObj:          newANP(5),
+			expectedAllowed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, c := newController()
+			c.cnpStore.Add(existingCNP)
+			c.anpStore.Add(existingANP)
+			v := NewNetworkPolicyValidator(c.NetworkPolicyController)
+			raw, _ := json.Marshal(tt.curObj)
+			ar := &admv1.AdmissionReview{
+				Request: &admv1.AdmissionRequest{
+					Kind:      metav1.GroupVersionKind{Group: "security.antrea.tanzu.vmware.com", Version: "v1alpha1", Kind: tt.kind},
+					Namespace: tt.namespace,
+					Name:      tt.curObj.(metav1.Object).GetName(),
+					Operation: tt.operation,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			}
+			if tt.oldObj != nil {
+				oldRaw, _ := json.Marshal(tt.oldObj)
+				ar.Request.OldObject = runtime.RawExtension{Raw: oldRaw}
+			}
+			response := v.Validate(ar)
+			assert.Equal(t, tt.expectedAllowed, response.Allowed)
+			if !tt.expectedAllowed {
+				assert.Equal(t, tt.expectedMessage, response.Result.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,344 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stats aggregates the traffic stats of NetworkPolicies reported by
+// antrea-agents.
+package stats
+
+import (
+	"sort"
+	"sync"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	networkinginformers "k8s.io/client-go/informers/networking/v1"
+	networkinglisters "k8s.io/client-go/listers/networking/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	statsv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1"
+	secinformers "github.com/vmware-tanzu/antrea/pkg/client/informers/externalversions/security/v1alpha1"
+	seclisters "github.com/vmware-tanzu/antrea/pkg/client/listers/security/v1alpha1"
+	"github.com/vmware-tanzu/antrea/pkg/features"
+)
+
+// policyStats is the accumulated traffic stats of a policy.
+type policyStats struct {
+	trafficStats statsv1alpha1.TrafficStats
+	// ruleTrafficStats is only set for Antrea-native policies.
+	ruleTrafficStats map[ruleKey]*statsv1alpha1.TrafficStats
+}
+
+// ruleKey identifies a rule within a policy.
+type ruleKey struct {
+	direction string
+	index     int32
+}
+
+// Aggregator accumulates the traffic stats of NetworkPolicies reported by
+// antrea-agents, and serves them along with the metadata of the policies. The
+// stats of a policy are reset when it is deleted.
+type Aggregator struct {
+	// npStats, cnpStats and anpStats store the stats of K8s NetworkPolicies,
+	// Antrea ClusterNetworkPolicies and Antrea NetworkPolicies respectively,
+	// keyed by the namespaced names of the policies.
+	npStats  map[string]*policyStats
+	cnpStats map[string]*policyStats
+	anpStats map[string]*policyStats
+	lock     sync.RWMutex
+
+	networkPolicyLister networkinglisters.NetworkPolicyLister
+	// cnpLister is nil if the ClusterNetworkPolicy feature is disabled.
+	cnpLister seclisters.ClusterNetworkPolicyLister
+	// anpLister is nil if the AntreaNetworkPolicy feature is disabled.
+	anpLister seclisters.NetworkPolicyLister
+}
+
+// NewAggregator returns a new *Aggregator.
+func NewAggregator(networkPolicyInformer networkinginformers.NetworkPolicyInformer, cnpInformer secinformers.ClusterNetworkPolicyInformer, anpInformer secinformers.NetworkPolicyInformer) *Aggregator {
+	aggregator := &Aggregator{
+		npStats:             map[string]*policyStats{},
+		cnpStats:            map[string]*policyStats{},
+		anpStats:            map[string]*policyStats{},
+		networkPolicyLister: networkPolicyInformer.Lister(),
+	}
+	networkPolicyInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: aggregator.deleteNetworkPolicy,
+	})
+	if features.DefaultFeatureGate.Enabled(features.ClusterNetworkPolicy) {
+		aggregator.cnpLister = cnpInformer.Lister()
+		cnpInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			DeleteFunc: aggregator.deleteCNP,
+		})
+	}
+	if features.DefaultFeatureGate.Enabled(features.AntreaNetworkPolicy) {
+		aggregator.anpLister = anpInformer.Lister()
+		anpInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			DeleteFunc: aggregator.deleteANP,
+		})
+	}
+	return aggregator
+}
+
+func (a *Aggregator) deleteNetworkPolicy(obj interface{}) {
+	if key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj); err == nil {
+		a.lock.Lock()
+		defer a.lock.Unlock()
+		delete(a.npStats, key)
+	}
+}
+
+func (a *Aggregator) deleteCNP(obj interface{}) {
+	if key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj); err == nil {
+		a.lock.Lock()
+		defer a.lock.Unlock()
+		delete(a.cnpStats, key)
+	}
+}
+
+func (a *Aggregator) deleteANP(obj interface{}) {
+	if key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj); err == nil {
+		a.lock.Lock()
+		defer a.lock.Unlock()
+		delete(a.anpStats, key)
+	}
+}
+
+// Collect adds the traffic stats reported by a Node to the accumulated ones.
+// The stats of the policies which do not exist anymore are ignored.
+func (a *Aggregator) Collect(summary *networking.NodeStatsSummary) {
+	klog.V(4).Infof("Collecting NetworkPolicy stats reported by Node %s", summary.Name)
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	for i := range summary.NetworkPolicies {
+		stats := &summary.NetworkPolicies[i]
+		if _, err := a.networkPolicyLister.NetworkPolicies(stats.Namespace).Get(stats.Name); err != nil {
+			continue
+		}
+		addPolicyStats(a.npStats, stats)
+	}
+	for i := range summary.ClusterNetworkPolicies {
+		stats := &summary.ClusterNetworkPolicies[i]
+		if a.cnpLister == nil {
+			break
+		}
+		if _, err := a.cnpLister.Get(stats.Name); err != nil {
+			continue
+		}
+		addPolicyStats(a.cnpStats, stats)
+	}
+	for i := range summary.AntreaNetworkPolicies {
+		stats := &summary.AntreaNetworkPolicies[i]
+		if a.anpLister == nil {
+			break
+		}
+		if _, err := a.anpLister.NetworkPolicies(stats.Namespace).Get(stats.Name); err != nil {
+			continue
+		}
+		addPolicyStats(a.anpStats, stats)
+	}
+}
+
+func addPolicyStats(statsMap map[string]*policyStats, stats *networking.NetworkPolicyStats) {
+	key := stats.Name
+	if stats.Namespace != "" {
+		key = stats.Namespace + "/" + stats.Name
+	}
+	curStats, exists := statsMap[key]
+	if !exists {
+		curStats = &policyStats{ruleTrafficStats: map[ruleKey]*statsv1alpha1.TrafficStats{}}
+		statsMap[key] = curStats
+	}
+	addTrafficStats(&curStats.trafficStats, &stats.TrafficStats)
+	for _, ruleStats := range stats.RuleTrafficStats {
+		direction := "Ingress"
+		if ruleStats.Direction == networking.DirectionOut {
+			direction = "Egress"
+		}
+		key := ruleKey{direction: direction, index: ruleStats.Priority}
+		curRuleStats, exists := curStats.ruleTrafficStats[key]
+		if !exists {
+			curRuleStats = &statsv1alpha1.TrafficStats{}
+			curStats.ruleTrafficStats[key] = curRuleStats
+		}
+		addTrafficStats(curRuleStats, &ruleStats.TrafficStats)
+	}
+}
+
+func addTrafficStats(stats *statsv1alpha1.TrafficStats, increment *networking.TrafficStats) {
+	stats.Packets += increment.Packets
+	stats.Bytes += increment.Bytes
+	stats.Sessions += increment.Sessions
+}
+
+// getStats returns a copy of the stats of the policy with the provided key,
+// or empty stats if nothing has been reported for it. It must be called with
+// the lock held.
+func getStats(statsMap map[string]*policyStats, key string) (statsv1alpha1.TrafficStats, []statsv1alpha1.RuleTrafficStats) {
+	stats, exists := statsMap[key]
+	if !exists {
+		return statsv1alpha1.TrafficStats{}, nil
+	}
+	var ruleTrafficStats []statsv1alpha1.RuleTrafficStats
+	for key, ruleStats := range stats.ruleTrafficStats {
+		ruleTrafficStats = append(ruleTrafficStats, statsv1alpha1.RuleTrafficStats{
+			Direction:    key.direction,
+			Index:        key.index,
+			TrafficStats: *ruleStats,
+		})
+	}
+	sort.Slice(ruleTrafficStats, func(i, j int) bool {
+		if ruleTrafficStats[i].Direction != ruleTrafficStats[j].Direction {
+			return ruleTrafficStats[i].Direction == "Ingress"
+		}
+		return ruleTrafficStats[i].Index < ruleTrafficStats[j].Index
+	})
+	return stats.trafficStats, ruleTrafficStats
+}
+
+func newObjectMeta(obj metav1.Object) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:              obj.GetName(),
+		Namespace:         obj.GetNamespace(),
+		UID:               obj.GetUID(),
+		CreationTimestamp: obj.GetCreationTimestamp(),
+	}
+}
+
+func (a *Aggregator) newNetworkPolicyStats(np *networkingv1.NetworkPolicy) *statsv1alpha1.NetworkPolicyStats {
+	trafficStats, _ := getStats(a.npStats, np.Namespace+"/"+np.Name)
+	return &statsv1alpha1.NetworkPolicyStats{
+		ObjectMeta:   newObjectMeta(np),
+		TrafficStats: trafficStats,
+	}
+}
+
+// GetNetworkPolicyStats returns the stats of the K8s NetworkPolicy with the
+// provided namespace and name, and false if it doesn't exist.
+func (a *Aggregator) GetNetworkPolicyStats(namespace, name string) (*statsv1alpha1.NetworkPolicyStats, bool) {
+	np, err := a.networkPolicyLister.NetworkPolicies(namespace).Get(name)
+	if err != nil {
+		return nil, false
+	}
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return a.newNetworkPolicyStats(np), true
+}
+
+// ListNetworkPolicyStats returns the stats of all the K8s NetworkPolicies in
+// the provided namespace, or in all namespaces if it is empty.
+func (a *Aggregator) ListNetworkPolicyStats(namespace string) []statsv1alpha1.NetworkPolicyStats {
+	var nps []*networkingv1.NetworkPolicy
+	if namespace == "" {
+		nps, _ = a.networkPolicyLister.List(labels.Everything())
+	} else {
+		nps, _ = a.networkPolicyLister.NetworkPolicies(namespace).List(labels.Everything())
+	}
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	items := make([]statsv1alpha1.NetworkPolicyStats, 0, len(nps))
+	for _, np := range nps {
+		items = append(items, *a.newNetworkPolicyStats(np))
+	}
+	return items
+}
+
+func (a *Aggregator) newClusterNetworkPolicyStats(cnp *secv1alpha1.ClusterNetworkPolicy) *statsv1alpha1.ClusterNetworkPolicyStats {
+	trafficStats, ruleTrafficStats := getStats(a.cnpStats, cnp.Name)
+	return &statsv1alpha1.ClusterNetworkPolicyStats{
+		ObjectMeta:       newObjectMeta(cnp),
+		TrafficStats:     trafficStats,
+		RuleTrafficStats: ruleTrafficStats,
+	}
+}
+
+// GetClusterNetworkPolicyStats returns the stats of the Antrea
+// ClusterNetworkPolicy with the provided name, and false if it doesn't exist.
+func (a *Aggregator) GetClusterNetworkPolicyStats(name string) (*statsv1alpha1.ClusterNetworkPolicyStats, bool) {
+	if a.cnpLister == nil {
+		return nil, false
+	}
+	cnp, err := a.cnpLister.Get(name)
+	if err != nil {
+		return nil, false
+	}
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return a.newClusterNetworkPolicyStats(cnp), true
+}
+
+// ListClusterNetworkPolicyStats returns the stats of all the Antrea
+// ClusterNetworkPolicies.
+func (a *Aggregator) ListClusterNetworkPolicyStats() []statsv1alpha1.ClusterNetworkPolicyStats {
+	if a.cnpLister == nil {
+		return nil
+	}
+	cnps, _ := a.cnpLister.List(labels.Everything())
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	items := make([]statsv1alpha1.ClusterNetworkPolicyStats, 0, len(cnps))
+	for _, cnp := range cnps {
+		items = append(items, *a.newClusterNetworkPolicyStats(cnp))
+	}
+	return items
+}
+
+func (a *Aggregator) newAntreaNetworkPolicyStats(anp *secv1alpha1.NetworkPolicy) *statsv1alpha1.AntreaNetworkPolicyStats {
+	trafficStats, ruleTrafficStats := getStats(a.anpStats, anp.Namespace+"/"+anp.Name)
+	return &statsv1alpha1.AntreaNetworkPolicyStats{
+		ObjectMeta:       newObjectMeta(anp),
+		TrafficStats:     trafficStats,
+		RuleTrafficStats: ruleTrafficStats,
+	}
+}
+
+// GetAntreaNetworkPolicyStats returns the stats of the Antrea NetworkPolicy
+// with the provided namespace and name, and false if it doesn't exist.
+func (a *Aggregator) GetAntreaNetworkPolicyStats(namespace, name string) (*statsv1alpha1.AntreaNetworkPolicyStats, bool) {
+	if a.anpLister == nil {
+		return nil, false
+	}
+	anp, err := a.anpLister.NetworkPolicies(namespace).Get(name)
+	if err != nil {
+		return nil, false
+	}
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return a.newAntreaNetworkPolicyStats(anp), true
+}
+
+// ListAntreaNetworkPolicyStats returns the stats of all the Antrea
+// NetworkPolicies in the provided namespace, or in all namespaces if it is
+// empty.
+func (a *Aggregator) ListAntreaNetworkPolicyStats(namespace string) []statsv1alpha1.AntreaNetworkPolicyStats {
+	if a.anpLister == nil {
+		return nil
+	}
+	var anps []*secv1alpha1.NetworkPolicy
+	if namespace == "" {
+		anps, _ = a.anpLister.List(labels.Everything())
+	} else {
+		anps, _ = a.anpLister.NetworkPolicies(namespace).List(labels.Everything())
+	}
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	items := make([]statsv1alpha1.AntreaNetworkPolicyStats, 0, len(anps))
+	for _, anp := range anps {
+		items = append(items, *a.newAntreaNetworkPolicyStats(anp))
+	}
+	return items
+}
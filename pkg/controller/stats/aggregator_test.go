@@ -0,0 +1,139 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+
+	"github.com/vmware-tanzu/antrea/pkg/apis/networking"
+	secv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/security/v1alpha1"
+	statsv1alpha1 "github.com/vmware-tanzu/antrea/pkg/apis/stats/v1alpha1"
+	fakeversioned "github.com/vmware-tanzu/antrea/pkg/client/clientset/versioned/fake"
+	crdinformers "github.com/vmware-tanzu/antrea/pkg/client/informers/externalversions"
+	"github.com/vmware-tanzu/antrea/pkg/features"
+)
+
+var (
+	np1  = &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "np1", UID: "uid1"}}
+	np2  = &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "np2", UID: "uid2"}}
+	cnp1 = &secv1alpha1.ClusterNetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cnp1", UID: "uid3"}}
+)
+
+type testAggregator struct {
+	*Aggregator
+	informerFactory    informers.SharedInformerFactory
+	crdInformerFactory crdinformers.SharedInformerFactory
+}
+
+func newTestAggregator() *testAggregator {
+	client := fake.NewSimpleClientset()
+	crdClient := fakeversioned.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
+	aggregator := NewAggregator(informerFactory.Networking().V1().NetworkPolicies(),
+		crdInformerFactory.Security().V1alpha1().ClusterNetworkPolicies(),
+		crdInformerFactory.Security().V1alpha1().NetworkPolicies())
+	return &testAggregator{aggregator, informerFactory, crdInformerFactory}
+}
+
+func TestAggregatorCollect(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.ClusterNetworkPolicy, true)()
+	a := newTestAggregator()
+	a.informerFactory.Networking().V1().NetworkPolicies().Informer().GetIndexer().Add(np1)
+	a.informerFactory.Networking().V1().NetworkPolicies().Informer().GetIndexer().Add(np2)
+	a.crdInformerFactory.Security().V1alpha1().ClusterNetworkPolicies().Informer().GetIndexer().Add(cnp1)
+
+	summaries := []*networking.NodeStatsSummary{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+			NetworkPolicies: []networking.NetworkPolicyStats{
+				{Namespace: "ns1", Name: "np1", TrafficStats: networking.TrafficStats{Packets: 1, Bytes: 10, Sessions: 1}},
+				// Stats of unknown policies are ignored.
+				{Namespace: "ns1", Name: "np3", TrafficStats: networking.TrafficStats{Packets: 1, Bytes: 10, Sessions: 1}},
+			},
+			ClusterNetworkPolicies: []networking.NetworkPolicyStats{
+				{
+					Name:         "cnp1",
+					TrafficStats: networking.TrafficStats{Packets: 3, Bytes: 30, Sessions: 1},
+					RuleTrafficStats: []networking.RuleTrafficStats{
+						{Direction: networking.DirectionOut, Priority: 0, TrafficStats: networking.TrafficStats{Packets: 2, Bytes: 20}},
+						{Direction: networking.DirectionIn, Priority: 1, TrafficStats: networking.TrafficStats{Packets: 1, Bytes: 10, Sessions: 1}},
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node2"},
+			NetworkPolicies: []networking.NetworkPolicyStats{
+				{Namespace: "ns1", Name: "np1", TrafficStats: networking.TrafficStats{Packets: 2, Bytes: 20, Sessions: 2}},
+			},
+			ClusterNetworkPolicies: []networking.NetworkPolicyStats{
+				{
+					Name:         "cnp1",
+					TrafficStats: networking.TrafficStats{Packets: 1, Bytes: 10},
+					RuleTrafficStats: []networking.RuleTrafficStats{
+						{Direction: networking.DirectionOut, Priority: 0, TrafficStats: networking.TrafficStats{Packets: 1, Bytes: 10}},
+					},
+				},
+			},
+		},
+	}
+	for _, summary := range summaries {
+		a.Collect(summary)
+	}
+
+	npStats, found := a.GetNetworkPolicyStats("ns1", "np1")
+	require.True(t, found)
+	assert.Equal(t, &statsv1alpha1.NetworkPolicyStats{
+		ObjectMeta:   metav1.ObjectMeta{Namespace: "ns1", Name: "np1", UID: "uid1"},
+		TrafficStats: statsv1alpha1.TrafficStats{Packets: 3, Bytes: 30, Sessions: 3},
+	}, npStats)
+	_, found = a.GetNetworkPolicyStats("ns1", "np3")
+	assert.False(t, found)
+	assert.ElementsMatch(t, []statsv1alpha1.NetworkPolicyStats{
+		*npStats,
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "np2", UID: "uid2"}},
+	}, a.ListNetworkPolicyStats(""))
+	assert.Len(t, a.ListNetworkPolicyStats("ns2"), 1)
+
+	cnpStats, found := a.GetClusterNetworkPolicyStats("cnp1")
+	require.True(t, found)
+	assert.Equal(t, &statsv1alpha1.ClusterNetworkPolicyStats{
+		ObjectMeta:   metav1.ObjectMeta{Name: "cnp1", UID: "uid3"},
+		TrafficStats: statsv1alpha1.TrafficStats{Packets: 4, Bytes: 40, Sessions: 1},
+		RuleTrafficStats: []statsv1alpha1.RuleTrafficStats{
+			{Direction: "Ingress", Index: 1, TrafficStats: statsv1alpha1.TrafficStats{Packets: 1, Bytes: 10, Sessions: 1}},
+			{Direction: "Egress", Index: 0, TrafficStats: statsv1alpha1.TrafficStats{Packets: 3, Bytes: 30}},
+		},
+	}, cnpStats)
+	assert.Equal(t, []statsv1alpha1.ClusterNetworkPolicyStats{*cnpStats}, a.ListClusterNetworkPolicyStats())
+
+	// Antrea NetworkPolicies are not watched when the feature is disabled.
+	assert.Empty(t, a.ListAntreaNetworkPolicyStats(""))
+
+	// The stats are reset when the policy is deleted.
+	a.deleteNetworkPolicy(np1)
+	npStats, found = a.GetNetworkPolicyStats("ns1", "np1")
+	require.True(t, found)
+	assert.Equal(t, statsv1alpha1.TrafficStats{}, npStats.TrafficStats)
+}
@@ -37,9 +37,10 @@ func (meta *SpanMeta) Has(nodeName string) bool {
 	return meta.NodeNames.Has(nodeName)
 }
 
-// GroupSelector describes how to select Pods.
+// GroupSelector describes how to select Pods or ExternalEntities.
 type GroupSelector struct {
-	// The normalized name is calculated from Namespace, PodSelector, and NamespaceSelector.
+	// The normalized name is calculated from Namespace, PodSelector, NamespaceSelector and
+	// ExternalEntitySelector.
 	// If multiple policies have same selectors, they should share this group by comparing NormalizedName.
 	// It's also used to generate Name and UUID of group.
 	NormalizedName string
@@ -51,9 +52,33 @@ type GroupSelector struct {
 	PodSelector labels.Selector
 	// This is a label selector which selects Namespaces. It this field is set, Namespace can not be set.
 	NamespaceSelector labels.Selector
+	// This is a label selector which selects ExternalEntities. It can not be set with PodSelector.
+	// If Namespace is also set, it selects the ExternalEntities in the Namespace.
+	// If NamespaceSelector is also set, it selects the ExternalEntities in the Namespaces selected by
+	// NamespaceSelector.
+	// If Namespace and NamespaceSelector both are unset, it selects the ExternalEntities in all the Namespaces.
+	ExternalEntitySelector labels.Selector
+	// This is a reference to a Service. If it is set, the other fields except NormalizedName are unset, and the
+	// group contains the ClusterIP and the Endpoints of the Service instead of the selected Pods.
+	ServiceReference *networking.ServiceReference
+	// This is a label selector which selects Nodes. If it is set, the other fields except NormalizedName are unset,
+	// and the group contains the InternalIPs and the gateway IPs of the selected Nodes instead of Pods.
+	NodeSelector labels.Selector
+	// This selects the Pods running with the matching ServiceAccounts. If it is set, only Namespace can be set
+	// among the other fields except NormalizedName, in which case only the ServiceAccounts in the Namespace are
+	// matched.
+	ServiceAccountSelector *ServiceAccountSelector
 }
 
-// AppliedToGroup describes a set of Pods to apply Network Policies to.
+// ServiceAccountSelector selects ServiceAccounts either by name or by labels.
+type ServiceAccountSelector struct {
+	// Name of the ServiceAccount. If it is set, Selector is unset.
+	Name string
+	// This is a label selector which selects ServiceAccounts.
+	Selector labels.Selector
+}
+
+// AppliedToGroup describes a set of Pods or ExternalEntities to apply Network Policies to.
 type AppliedToGroup struct {
 	SpanMeta
 	// UID is generated from the hash value of GroupSelector.NormalizedName.
@@ -66,6 +91,11 @@ type AppliedToGroup struct {
 	// It will be converted to a slice of GroupMemberPod for transferring according
 	// to client's selection.
 	PodsByNode map[string]networking.GroupMemberPodSet
+	// GroupMemberByNode is a mapping from nodeName to a set of GroupMembers on the Node.
+	// For ExternalEntities, the nodeName is the ExternalNode of the ExternalEntity.
+	// It will be converted to a slice of GroupMember for transferring according
+	// to client's selection.
+	GroupMemberByNode map[string]networking.GroupMemberSet
 }
 
 // AddressGroup describes a set of addresses used as source or destination of Network Policy rules.
@@ -81,6 +111,10 @@ type AddressGroup struct {
 	// It will be converted to a slice of GroupMemberPod for transferring according
 	// to client's selection.
 	Pods networking.GroupMemberPodSet
+	// GroupMembers is a set of GroupMembers selected by this group.
+	// It will be converted to a slice of GroupMember for transferring according
+	// to client's selection.
+	GroupMembers networking.GroupMemberSet
 }
 
 // NetworkPolicy describes what network traffic is allowed for a set of Pods.
@@ -96,6 +130,13 @@ type NetworkPolicy struct {
 	// Priority represents the relative priority of this Network Policy as compared to
 	// other Network Policies. Priority will be unset (nil) for K8s Network Policy.
 	Priority *float64
+	// TierPriority represents the priority of the Tier associated with this Network
+	// Policy. The TierPriority will remain nil for K8s NetworkPolicy.
+	TierPriority *int32
+	// Generation is the generation of the original Antrea-native policy. It is
+	// used to tell whether the status reported by Nodes is up to date. It will
+	// be 0 for K8s NetworkPolicy.
+	Generation int64
 	// Rules is a list of rules to be applied to the selected Pods.
 	Rules []networking.NetworkPolicyRule
 	// AppliedToGroups is a list of names of AppliedToGroups to which this policy applies.
@@ -33,6 +33,10 @@ const (
 	// Allows to apply cluster-wide NetworkPolicies.
 	ClusterNetworkPolicy featuregate.Feature = "ClusterNetworkPolicy"
 
+	// alpha: v0.9
+	// Allows to apply Namespaced Antrea NetworkPolicies.
+	AntreaNetworkPolicy featuregate.Feature = "AntreaNetworkPolicy"
+
 	// alpha: v0.8
 	// Enable antrea proxy which provides ServiceLB for in-cluster services in antrea agent.
 	// It should be enabled on Windows, otherwise NetworkPolicy will not take effect on
@@ -46,6 +50,11 @@ const (
 	// alpha: v0.9
 	// Flow exporter exports IPFIX flow records of Antrea flows seen in conntrack module.
 	FlowExporter featuregate.Feature = "FlowExporter"
+
+	// alpha: v0.9
+	// Collects the traffic stats of the rules of NetworkPolicies and exposes
+	// them through the stats API.
+	NetworkPolicyStats featuregate.Feature = "NetworkPolicyStats"
 )
 
 var (
@@ -61,9 +70,11 @@ var (
 	// available throughout Antrea binaries.
 	defaultAntreaFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 		ClusterNetworkPolicy: {Default: false, PreRelease: featuregate.Alpha},
+		AntreaNetworkPolicy:  {Default: false, PreRelease: featuregate.Alpha},
 		AntreaProxy:          {Default: false, PreRelease: featuregate.Alpha},
 		Traceflow:            {Default: false, PreRelease: featuregate.Alpha},
 		FlowExporter:         {Default: false, PreRelease: featuregate.Alpha},
+		NetworkPolicyStats:   {Default: false, PreRelease: featuregate.Alpha},
 	}
 )
 
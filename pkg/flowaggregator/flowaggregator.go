@@ -0,0 +1,346 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flowaggregator
+
+import (
+	"net"
+	"time"
+
+	"k8s.io/klog"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter/ipfix"
+)
+
+const (
+	templateIDv4 = ipfix.MinTemplateID
+	templateIDv6 = ipfix.MinTemplateID + 1
+	// flushInterval is how often the aggregated records are exported, and the
+	// records waiting for their correlation are checked for expiration.
+	flushInterval = time.Second
+)
+
+// The IEs common to the IPv4 and IPv6 templates, which follow the source and
+// destination addresses. They are the IEs exported by the agents, and the name
+// of the Node of the destination Pod.
+var commonElements = []*ipfix.InfoElement{
+	ipfix.FlowStartSeconds,
+	ipfix.FlowEndSeconds,
+	ipfix.FlowEndReason,
+	ipfix.SourceTransportPort,
+	ipfix.DestinationTransportPort,
+	ipfix.ProtocolIdentifier,
+	ipfix.PacketTotalCount,
+	ipfix.OctetTotalCount,
+	ipfix.ReversePacketTotalCount,
+	ipfix.ReverseOctetTotalCount,
+	ipfix.PacketDeltaCount,
+	ipfix.OctetDeltaCount,
+	ipfix.ReversePacketDeltaCount,
+	ipfix.ReverseOctetDeltaCount,
+	ipfix.SourcePodNamespace,
+	ipfix.SourcePodName,
+	ipfix.DestinationPodNamespace,
+	ipfix.DestinationPodName,
+	ipfix.SourceNodeName,
+	ipfix.DestinationNodeName,
+	ipfix.DestinationServicePortName,
+	ipfix.IngressNetworkPolicyName,
+	ipfix.IngressNetworkPolicyNamespace,
+	ipfix.EgressNetworkPolicyName,
+	ipfix.EgressNetworkPolicyNamespace,
+}
+
+var (
+	templateV4 = &ipfix.Template{
+		ID:       templateIDv4,
+		Elements: append([]*ipfix.InfoElement{ipfix.SourceIPv4Address, ipfix.DestinationIPv4Address}, commonElements...),
+	}
+	templateV6 = &ipfix.Template{
+		ID:       templateIDv6,
+		Elements: append([]*ipfix.InfoElement{ipfix.SourceIPv6Address, ipfix.DestinationIPv6Address}, commonElements...),
+	}
+)
+
+// The IEs of the destination side of a connection, which are only known by the
+// agent of the Node of the destination Pod.
+var destinationElements = []*ipfix.InfoElement{
+	ipfix.DestinationPodNamespace,
+	ipfix.DestinationPodName,
+	ipfix.IngressNetworkPolicyName,
+	ipfix.IngressNetworkPolicyNamespace,
+}
+
+// The delta counters, which are not exported for the records of the destination
+// side which could not be correlated.
+var deltaCounterElements = []*ipfix.InfoElement{
+	ipfix.PacketDeltaCount,
+	ipfix.OctetDeltaCount,
+	ipfix.ReversePacketDeltaCount,
+	ipfix.ReverseOctetDeltaCount,
+}
+
+// flowKey is the 5-tuple of a connection, as exported by the agents of both
+// Nodes. The destination is the Endpoint selected for connections to Services.
+type flowKey struct {
+	sourceAddress      string
+	destinationAddress string
+	protocol           uint8
+	sourcePort         uint16
+	destinationPort    uint16
+}
+
+// pendingRecord is a record of one side of a connection, waiting for the record
+// of the other side.
+type pendingRecord struct {
+	record     *ipfix.DecodedRecord
+	receivedAt time.Time
+}
+
+// FlowAggregator collects the flow records exported by the agents of all Nodes,
+// and exports them to a collector. An inter-Node Pod-to-Pod connection is
+// exported by the agents of both Nodes, which only know their local Pod: the
+// records of both sides are correlated by their 5-tuple and merged into a
+// single record. Records which cannot be correlated within the correlation
+// timeout are exported as they are.
+type FlowAggregator struct {
+	collector          *ipfix.CollectingProcess
+	process            *ipfix.ExportingProcess
+	correlationTimeout time.Duration
+	// pendingRecords is only accessed by the goroutine of Run.
+	pendingRecords map[flowKey]*pendingRecord
+	recordsV4      []ipfix.DataRecord
+	recordsV6      []ipfix.DataRecord
+}
+
+// NewFlowAggregator returns a new *FlowAggregator receiving the records of the
+// agents from collector, and exporting the aggregated records with process.
+func NewFlowAggregator(collector *ipfix.CollectingProcess, process *ipfix.ExportingProcess, correlationTimeout time.Duration) *FlowAggregator {
+	process.AddTemplate(templateV4)
+	process.AddTemplate(templateV6)
+	return &FlowAggregator{
+		collector:          collector,
+		process:            process,
+		correlationTimeout: correlationTimeout,
+		pendingRecords:     make(map[flowKey]*pendingRecord),
+	}
+}
+
+// Run aggregates the received records until stopCh is closed.
+func (fa *FlowAggregator) Run(stopCh <-chan struct{}) {
+	klog.Infof("Starting flow aggregator")
+	defer fa.process.Close()
+	go fa.collector.Run(stopCh)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case record := <-fa.collector.Records():
+			fa.aggregate(record, time.Now())
+		case <-ticker.C:
+			if err := fa.flush(time.Now()); err != nil {
+				// The connection to the collector is established again in
+				// the next cycle.
+				klog.Errorf("Error when exporting aggregated flow records: %v", err)
+			}
+		}
+	}
+}
+
+// aggregate correlates a received record with the pending record of the other
+// side of the connection. The records of connections whose source and
+// destination Pods are both known, i.e. intra-Node connections, or which have
+// no Pod are exported without correlation, the destination Node of intra-Node
+// connections being the source Node.
+func (fa *FlowAggregator) aggregate(record *ipfix.DecodedRecord, now time.Time) {
+	key, ok := getFlowKey(record)
+	if !ok {
+		klog.V(2).Infof("Ignoring flow record of template %d from %s without 5-tuple", record.TemplateID, record.ExporterAddr)
+		return
+	}
+	isSource := stringValue(record, ipfix.SourcePodName) != ""
+	isDestination := stringValue(record, ipfix.DestinationPodName) != ""
+	if isSource == isDestination {
+		if isSource {
+			if nodeName, ok := record.Values[ipfix.SourceNodeName.Name]; ok {
+				record.Values[ipfix.DestinationNodeName.Name] = nodeName
+			}
+		}
+		fa.addRecord(record.Values)
+		return
+	}
+	pending, ok := fa.pendingRecords[key]
+	if !ok {
+		fa.pendingRecords[key] = &pendingRecord{record: record, receivedAt: now}
+		return
+	}
+	if pendingIsSource := stringValue(pending.record, ipfix.SourcePodName) != ""; pendingIsSource == isSource {
+		// The other side was not received before the next record of the
+		// same side, which replaces the pending one.
+		fa.addUncorrelatedRecord(pending.record)
+		fa.pendingRecords[key] = &pendingRecord{record: record, receivedAt: now}
+		return
+	}
+	delete(fa.pendingRecords, key)
+	if isSource {
+		fa.addRecord(mergeRecords(record, pending.record))
+	} else {
+		fa.addRecord(mergeRecords(pending.record, record))
+	}
+}
+
+// mergeRecords returns the values of the record of the source side of a
+// connection, completed with the metadata of the destination side. The
+// counters are the ones of the source side only, so that the traffic of the
+// connection is not counted twice.
+func mergeRecords(source, destination *ipfix.DecodedRecord) map[string]interface{} {
+	values := make(map[string]interface{}, len(source.Values)+1)
+	for name, value := range source.Values {
+		values[name] = value
+	}
+	for _, ie := range destinationElements {
+		if value, ok := destination.Values[ie.Name]; ok {
+			values[ie.Name] = value
+		}
+	}
+	if value, ok := destination.Values[ipfix.SourceNodeName.Name]; ok {
+		values[ipfix.DestinationNodeName.Name] = value
+	}
+	// The connection ends with the last record of both sides.
+	if destinationEnd, ok := destination.Values[ipfix.FlowEndSeconds.Name].(time.Time); ok {
+		if sourceEnd, ok := values[ipfix.FlowEndSeconds.Name].(time.Time); !ok || destinationEnd.After(sourceEnd) {
+			values[ipfix.FlowEndSeconds.Name] = destinationEnd
+		}
+	}
+	return values
+}
+
+// flush exports the records which are not pending anymore, after exporting the
+// pending records which could not be correlated within the correlation timeout.
+func (fa *FlowAggregator) flush(now time.Time) error {
+	for key, pending := range fa.pendingRecords {
+		if now.Sub(pending.receivedAt) >= fa.correlationTimeout {
+			fa.addUncorrelatedRecord(pending.record)
+			delete(fa.pendingRecords, key)
+		}
+	}
+	recordsV4, recordsV6 := fa.recordsV4, fa.recordsV6
+	// The records are dropped if they cannot be sent, so that they do not
+	// accumulate while the collector is unreachable.
+	fa.recordsV4, fa.recordsV6 = nil, nil
+	if len(recordsV4) > 0 {
+		if err := fa.process.SendDataRecords(templateV4, recordsV4); err != nil {
+			return err
+		}
+	}
+	if len(recordsV6) > 0 {
+		if err := fa.process.SendDataRecords(templateV6, recordsV6); err != nil {
+			return err
+		}
+	}
+	klog.V(2).Infof("Exported %d aggregated flow records, %d records waiting for correlation", len(recordsV4)+len(recordsV6), len(fa.pendingRecords))
+	return nil
+}
+
+// addUncorrelatedRecord adds a pending record which could not be correlated to
+// export. The delta counters of a record of the destination side are zero, as
+// the traffic of the connection is counted by the records of the source side,
+// which may still be received after the correlation timeout.
+func (fa *FlowAggregator) addUncorrelatedRecord(record *ipfix.DecodedRecord) {
+	if stringValue(record, ipfix.SourcePodName) == "" {
+		for _, ie := range deltaCounterElements {
+			record.Values[ie.Name] = uint64(0)
+		}
+	}
+	fa.addRecord(record.Values)
+}
+
+// addRecord adds a record to export with the template of its address family.
+// The IEs which were not received are exported with zero values.
+func (fa *FlowAggregator) addRecord(values map[string]interface{}) {
+	template := templateV6
+	if _, ok := values[ipfix.SourceIPv4Address.Name]; ok {
+		template = templateV4
+	}
+	record := make(ipfix.DataRecord, len(template.Elements))
+	for i, ie := range template.Elements {
+		if value, ok := values[ie.Name]; ok {
+			record[i] = value
+		} else {
+			record[i] = zeroValue(ie)
+		}
+	}
+	if template == templateV4 {
+		fa.recordsV4 = append(fa.recordsV4, record)
+	} else {
+		fa.recordsV6 = append(fa.recordsV6, record)
+	}
+}
+
+// getFlowKey returns the 5-tuple of a record, and false if it is incomplete.
+func getFlowKey(record *ipfix.DecodedRecord) (flowKey, bool) {
+	var key flowKey
+	sourceIE, destinationIE := ipfix.SourceIPv4Address, ipfix.DestinationIPv4Address
+	if _, ok := record.Values[sourceIE.Name]; !ok {
+		sourceIE, destinationIE = ipfix.SourceIPv6Address, ipfix.DestinationIPv6Address
+	}
+	sourceAddress, ok1 := record.Values[sourceIE.Name].(net.IP)
+	destinationAddress, ok2 := record.Values[destinationIE.Name].(net.IP)
+	protocol, ok3 := record.Values[ipfix.ProtocolIdentifier.Name].(uint8)
+	sourcePort, ok4 := record.Values[ipfix.SourceTransportPort.Name].(uint16)
+	destinationPort, ok5 := record.Values[ipfix.DestinationTransportPort.Name].(uint16)
+	if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 {
+		return key, false
+	}
+	key = flowKey{
+		sourceAddress:      sourceAddress.String(),
+		destinationAddress: destinationAddress.String(),
+		protocol:           protocol,
+		sourcePort:         sourcePort,
+		destinationPort:    destinationPort,
+	}
+	return key, true
+}
+
+// stringValue returns the value of a string IE of a record, or "" if it is
+// missing.
+func stringValue(record *ipfix.DecodedRecord, ie *ipfix.InfoElement) string {
+	value, _ := record.Values[ie.Name].(string)
+	return value
+}
+
+// zeroValue returns the value of the IE exported when it was not received.
+func zeroValue(ie *ipfix.InfoElement) interface{} {
+	switch ie.DataType {
+	case ipfix.Unsigned8:
+		return uint8(0)
+	case ipfix.Unsigned16:
+		return uint16(0)
+	case ipfix.Unsigned32:
+		return uint32(0)
+	case ipfix.Unsigned64:
+		return uint64(0)
+	case ipfix.DateTimeSeconds:
+		return time.Unix(0, 0)
+	case ipfix.IPv4Address:
+		return net.IPv4zero
+	case ipfix.IPv6Address:
+		return net.IPv6zero
+	default:
+		return ""
+	}
+}
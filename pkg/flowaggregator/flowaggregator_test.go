@@ -0,0 +1,216 @@
+// Copyright 2020 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flowaggregator
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/flowexporter/ipfix"
+)
+
+// newRecord returns a record of the connection from 10.10.0.1:50000 to
+// 10.10.1.2:8080 exported by the agent of the given Node.
+func newRecord(nodeName string, values map[string]interface{}) *ipfix.DecodedRecord {
+	record := &ipfix.DecodedRecord{
+		TemplateID: ipfix.MinTemplateID,
+		Values: map[string]interface{}{
+			ipfix.SourceIPv4Address.Name:        net.IP{10, 10, 0, 1},
+			ipfix.DestinationIPv4Address.Name:   net.IP{10, 10, 1, 2},
+			ipfix.ProtocolIdentifier.Name:       uint8(6),
+			ipfix.SourceTransportPort.Name:      uint16(50000),
+			ipfix.DestinationTransportPort.Name: uint16(8080),
+			ipfix.SourceNodeName.Name:           nodeName,
+		},
+	}
+	for name, value := range values {
+		record.Values[name] = value
+	}
+	return record
+}
+
+// recordValue returns the value of the IE in an aggregated IPv4 record.
+func recordValue(record ipfix.DataRecord, ie *ipfix.InfoElement) interface{} {
+	for i, e := range templateV4.Elements {
+		if e == ie {
+			return record[i]
+		}
+	}
+	return nil
+}
+
+func TestFlowAggregatorMergeRecords(t *testing.T) {
+	refTime := time.Unix(1600000000, 0)
+	sourceRecord := newRecord("node1", map[string]interface{}{
+		ipfix.FlowEndSeconds.Name:          refTime,
+		ipfix.PacketTotalCount.Name:        uint64(10),
+		ipfix.SourcePodNamespace.Name:      "ns1",
+		ipfix.SourcePodName.Name:           "pod1",
+		ipfix.EgressNetworkPolicyName.Name: "np1",
+	})
+	destinationRecord := newRecord("node2", map[string]interface{}{
+		ipfix.FlowEndSeconds.Name:           refTime.Add(time.Second),
+		ipfix.PacketTotalCount.Name:         uint64(9),
+		ipfix.DestinationPodNamespace.Name:  "ns2",
+		ipfix.DestinationPodName.Name:       "pod2",
+		ipfix.IngressNetworkPolicyName.Name: "np2",
+	})
+	// The correlation does not depend on the order of the records.
+	for name, records := range map[string][]*ipfix.DecodedRecord{
+		"source-first":      {sourceRecord, destinationRecord},
+		"destination-first": {destinationRecord, sourceRecord},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fa := &FlowAggregator{correlationTimeout: time.Minute, pendingRecords: make(map[flowKey]*pendingRecord)}
+			fa.aggregate(records[0], refTime)
+			assert.Len(t, fa.pendingRecords, 1)
+			assert.Empty(t, fa.recordsV4)
+			fa.aggregate(records[1], refTime)
+			assert.Empty(t, fa.pendingRecords)
+			require.Len(t, fa.recordsV4, 1)
+
+			record := fa.recordsV4[0]
+			assert.Equal(t, "pod1", recordValue(record, ipfix.SourcePodName))
+			assert.Equal(t, "pod2", recordValue(record, ipfix.DestinationPodName))
+			assert.Equal(t, "ns2", recordValue(record, ipfix.DestinationPodNamespace))
+			assert.Equal(t, "node1", recordValue(record, ipfix.SourceNodeName))
+			assert.Equal(t, "node2", recordValue(record, ipfix.DestinationNodeName))
+			assert.Equal(t, "np1", recordValue(record, ipfix.EgressNetworkPolicyName))
+			assert.Equal(t, "np2", recordValue(record, ipfix.IngressNetworkPolicyName))
+			// The counters of the source side only are exported.
+			assert.Equal(t, uint64(10), recordValue(record, ipfix.PacketTotalCount))
+			assert.Equal(t, refTime.Add(time.Second), recordValue(record, ipfix.FlowEndSeconds))
+			// The IEs which were not received have zero values.
+			assert.Equal(t, uint64(0), recordValue(record, ipfix.OctetTotalCount))
+			assert.Equal(t, "", recordValue(record, ipfix.DestinationServicePortName))
+		})
+	}
+}
+
+func TestFlowAggregatorIntraNodeRecord(t *testing.T) {
+	fa := &FlowAggregator{correlationTimeout: time.Minute, pendingRecords: make(map[flowKey]*pendingRecord)}
+	fa.aggregate(newRecord("node1", map[string]interface{}{
+		ipfix.SourcePodName.Name:      "pod1",
+		ipfix.DestinationPodName.Name: "pod2",
+	}), time.Now())
+	assert.Empty(t, fa.pendingRecords)
+	require.Len(t, fa.recordsV4, 1)
+	assert.Equal(t, "node1", recordValue(fa.recordsV4[0], ipfix.DestinationNodeName))
+}
+
+func TestFlowAggregatorUncorrelatedRecords(t *testing.T) {
+	refTime := time.Unix(1600000000, 0)
+	counters := map[string]interface{}{
+		ipfix.PacketTotalCount.Name:        uint64(10),
+		ipfix.PacketDeltaCount.Name:        uint64(5),
+		ipfix.OctetDeltaCount.Name:         uint64(500),
+		ipfix.ReversePacketDeltaCount.Name: uint64(4),
+		ipfix.ReverseOctetDeltaCount.Name:  uint64(400),
+	}
+	tests := []struct {
+		name                string
+		podIE               *ipfix.InfoElement
+		expectedDeltaCounts []uint64
+	}{
+		{
+			name:                "source",
+			podIE:               ipfix.SourcePodName,
+			expectedDeltaCounts: []uint64{5, 500, 4, 400},
+		},
+		{
+			// The traffic is counted by the record of the source side,
+			// which may be received later.
+			name:                "destination",
+			podIE:               ipfix.DestinationPodName,
+			expectedDeltaCounts: []uint64{0, 0, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newSideRecord := func() *ipfix.DecodedRecord {
+				record := newRecord("node1", counters)
+				record.Values[tt.podIE.Name] = "pod1"
+				return record
+			}
+			fa := &FlowAggregator{correlationTimeout: time.Minute, pendingRecords: make(map[flowKey]*pendingRecord)}
+			// The pending record is exported uncorrelated when it is
+			// replaced by the next record of the same side.
+			fa.aggregate(newSideRecord(), refTime)
+			fa.aggregate(newSideRecord(), refTime.Add(time.Minute))
+			assert.Len(t, fa.pendingRecords, 1)
+			require.Len(t, fa.recordsV4, 1)
+
+			record := fa.recordsV4[0]
+			for i, ie := range deltaCounterElements {
+				assert.Equal(t, tt.expectedDeltaCounts[i], recordValue(record, ie), ie.Name)
+			}
+			// The total counters are exported as they are.
+			assert.Equal(t, uint64(10), recordValue(record, ipfix.PacketTotalCount))
+		})
+	}
+}
+
+func TestFlowAggregatorFlush(t *testing.T) {
+	collector, err := ipfix.NewCollectingProcess("127.0.0.1:0", "udp")
+	require.NoError(t, err)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go collector.Run(stopCh)
+	process, err := ipfix.NewExportingProcess(collector.Addr().String(), "udp", 1)
+	require.NoError(t, err)
+	defer process.Close()
+
+	refTime := time.Now()
+	fa := NewFlowAggregator(nil, process, 30*time.Second)
+	fa.aggregate(newRecord("node1", map[string]interface{}{ipfix.SourcePodName.Name: "pod1"}), refTime)
+	// The record is not exported before the correlation timeout.
+	require.NoError(t, fa.flush(refTime.Add(10*time.Second)))
+	assert.Len(t, fa.pendingRecords, 1)
+	require.NoError(t, fa.flush(refTime.Add(30*time.Second)))
+	assert.Empty(t, fa.pendingRecords)
+
+	select {
+	case record := <-collector.Records():
+		assert.Equal(t, templateIDv4, record.TemplateID)
+		assert.Equal(t, "pod1", record.Values[ipfix.SourcePodName.Name])
+		assert.Equal(t, "", record.Values[ipfix.DestinationPodName.Name])
+		assert.Equal(t, net.IP{10, 10, 1, 2}, record.Values[ipfix.DestinationIPv4Address.Name])
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timeout when waiting for aggregated flow record")
+	}
+
+	// The delta counters of a record of the destination side exported on
+	// timeout are zero.
+	fa.aggregate(newRecord("node2", map[string]interface{}{
+		ipfix.DestinationPodName.Name: "pod2",
+		ipfix.PacketTotalCount.Name:   uint64(10),
+		ipfix.PacketDeltaCount.Name:   uint64(5),
+	}), refTime)
+	require.NoError(t, fa.flush(refTime.Add(30*time.Second)))
+	assert.Empty(t, fa.pendingRecords)
+
+	select {
+	case record := <-collector.Records():
+		assert.Equal(t, "pod2", record.Values[ipfix.DestinationPodName.Name])
+		assert.Equal(t, uint64(10), record.Values[ipfix.PacketTotalCount.Name])
+		assert.Equal(t, uint64(0), record.Values[ipfix.PacketDeltaCount.Name])
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timeout when waiting for aggregated flow record")
+	}
+}
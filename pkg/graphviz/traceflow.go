@@ -221,7 +221,7 @@ func genSubGraph(graph *cgraph.Graph, result opsv1alpha1.NodeResult, firstNodeNa
 			} else {
 				edge.SetMinLen(1)
 			}
-			if o.Action == opsv1alpha1.Dropped && dir == cgraph.BackDir {
+			if (o.Action == opsv1alpha1.Dropped || o.Action == opsv1alpha1.Rejected) && dir == cgraph.BackDir {
 				edge.SetStyle("invis")
 			}
 		}
@@ -234,7 +234,7 @@ func genSubGraph(graph *cgraph.Graph, result opsv1alpha1.NodeResult, firstNodeNa
 		if o.Component == opsv1alpha1.NetworkPolicy && len(o.NetworkPolicy) > 0 {
 			labelStr += "\nNetpol: " + o.NetworkPolicy
 		}
-		if o.Action == opsv1alpha1.Dropped {
+		if o.Action == opsv1alpha1.Dropped || o.Action == opsv1alpha1.Rejected {
 			node.SetColor(fireBrick)
 			node.SetFillColor(mistyRose)
 		} else {
@@ -62,6 +62,7 @@ const (
 	NxmFieldARPOp       = "NXM_OF_ARP_OP"
 	NxmFieldReg         = "NXM_NX_REG"
 	NxmFieldTunMetadata = "NXM_NX_TUN_METADATA"
+	NxmFieldTunIPv4Dst  = "NXM_NX_TUN_IPV4_DST"
 )
 
 const (
@@ -220,6 +221,18 @@ type FlowBuilder interface {
 	MatchTCPDstPort(port uint16) FlowBuilder
 	MatchUDPDstPort(port uint16) FlowBuilder
 	MatchSCTPDstPort(port uint16) FlowBuilder
+	// MatchTCPDstPortMask matches the TCP destination port with the given mask.
+	MatchTCPDstPortMask(port, mask uint16) FlowBuilder
+	// MatchUDPDstPortMask matches the UDP destination port with the given mask.
+	MatchUDPDstPortMask(port, mask uint16) FlowBuilder
+	// MatchSCTPDstPortMask matches the SCTP destination port with the given mask.
+	MatchSCTPDstPortMask(port, mask uint16) FlowBuilder
+	// MatchICMPType matches the type of ICMP messages.
+	MatchICMPType(icmpType uint8) FlowBuilder
+	// MatchICMPCode matches the code of ICMP messages.
+	MatchICMPCode(icmpCode uint8) FlowBuilder
+	// MatchIPProtocolValue matches the IP protocol with its number, e.g. 47 for GRE.
+	MatchIPProtocolValue(protocol uint8) FlowBuilder
 	MatchTunMetadata(index int, data uint32) FlowBuilder
 	// MatchCTSrcIP matches the source IPv4 address of the connection tracker original direction tuple.
 	MatchCTSrcIP(ip net.IP) FlowBuilder
@@ -301,12 +314,16 @@ type PacketOutBuilder interface {
 	SetTCPSrcPort(port uint16) PacketOutBuilder
 	SetTCPDstPort(port uint16) PacketOutBuilder
 	SetTCPFlags(flags uint8) PacketOutBuilder
+	SetTCPSeqNum(seqNum uint32) PacketOutBuilder
+	SetTCPAckNum(ackNum uint32) PacketOutBuilder
 	SetUDPSrcPort(port uint16) PacketOutBuilder
 	SetUDPDstPort(port uint16) PacketOutBuilder
+	SetUDPData(data []byte) PacketOutBuilder
 	SetICMPType(icmpType uint8) PacketOutBuilder
 	SetICMPCode(icmpCode uint8) PacketOutBuilder
 	SetICMPID(id uint16) PacketOutBuilder
 	SetICMPSequence(seq uint16) PacketOutBuilder
+	SetICMPData(data []byte) PacketOutBuilder
 	SetInport(inPort uint32) PacketOutBuilder
 	SetOutport(outport uint32) PacketOutBuilder
 	AddLoadAction(name string, data uint64, rng Range) PacketOutBuilder
@@ -105,6 +105,7 @@ func (t *ofTable) DumpFlows(cookieID, cookieMask uint64) (map[uint64]*FlowStates
 		s := &FlowStates{
 			TableID:         stat.TableId,
 			PacketCount:     stat.PacketCount,
+			ByteCount:       stat.ByteCount,
 			DurationNSecond: stat.DurationNSec,
 		}
 		flowStats[cookie] = s
@@ -310,6 +311,7 @@ func (b *OFBridge) DumpFlows(cookieID, cookieMask uint64) (map[uint64]*FlowState
 		s := &FlowStates{
 			TableID:         stat.TableId,
 			PacketCount:     stat.PacketCount,
+			ByteCount:       stat.ByteCount,
 			DurationNSecond: stat.DurationNSec,
 		}
 		flowStats[cookie] = s
@@ -341,6 +341,71 @@ func (b *ofFlowBuilder) MatchSCTPDstPort(port uint16) FlowBuilder {
 	return b
 }
 
+// MatchTCPDstPortMask adds match condition for matching TCP destination port
+// with a mask, e.g. to match a range of ports with a single flow.
+func (b *ofFlowBuilder) MatchTCPDstPortMask(port, mask uint16) FlowBuilder {
+	b.MatchProtocol(ProtocolTCP)
+	b.Match.TcpDstPort = port
+	b.Match.TcpDstPortMask = mask
+	b.matchers = append(b.matchers, maskedPortMatcher(port, mask))
+	return b
+}
+
+// MatchUDPDstPortMask adds match condition for matching UDP destination port
+// with a mask.
+func (b *ofFlowBuilder) MatchUDPDstPortMask(port, mask uint16) FlowBuilder {
+	b.MatchProtocol(ProtocolUDP)
+	b.Match.UdpDstPort = port
+	b.Match.UdpDstPortMask = mask
+	b.matchers = append(b.matchers, maskedPortMatcher(port, mask))
+	return b
+}
+
+// MatchSCTPDstPortMask adds match condition for matching SCTP destination port
+// with a mask.
+func (b *ofFlowBuilder) MatchSCTPDstPortMask(port, mask uint16) FlowBuilder {
+	b.MatchProtocol(ProtocolSCTP)
+	b.Match.SctpDstPort = port
+	b.Match.SctpDstPortMask = mask
+	b.matchers = append(b.matchers, maskedPortMatcher(port, mask))
+	return b
+}
+
+// MatchICMPType adds match condition for matching the type of ICMP messages.
+func (b *ofFlowBuilder) MatchICMPType(icmpType uint8) FlowBuilder {
+	b.MatchProtocol(ProtocolICMP)
+	b.Match.Icmp4Type = &icmpType
+	b.matchers = append(b.matchers, fmt.Sprintf("icmp_type=%d", icmpType))
+	return b
+}
+
+// MatchICMPCode adds match condition for matching the code of ICMP messages.
+func (b *ofFlowBuilder) MatchICMPCode(icmpCode uint8) FlowBuilder {
+	b.MatchProtocol(ProtocolICMP)
+	b.Match.Icmp4Code = &icmpCode
+	b.matchers = append(b.matchers, fmt.Sprintf("icmp_code=%d", icmpCode))
+	return b
+}
+
+// MatchIPProtocolValue adds match condition for matching the IP protocol with
+// its number. It is used for the protocols which are not defined as Protocol.
+func (b *ofFlowBuilder) MatchIPProtocolValue(protocol uint8) FlowBuilder {
+	b.Match.Ethertype = 0x0800
+	b.Match.IpProto = protocol
+	b.matchers = append(b.matchers, fmt.Sprintf("nw_proto=%d", protocol))
+	return b
+}
+
+// maskedPortMatcher returns the flow matching string of a masked transport
+// destination port, in the format used by the OVS command line tools. A port
+// matched with a full mask is printed as an exact match.
+func maskedPortMatcher(port, mask uint16) string {
+	if mask == 0xffff {
+		return fmt.Sprintf("tp_dst=%d", port)
+	}
+	return fmt.Sprintf("tp_dst=0x%x/0x%x", port, mask)
+}
+
 // MatchCTSrcIP matches the source IPv4 address of the connection tracker original direction tuple. This match requires
 // a match to valid connection tracking state as a prerequisite, and valid connection tracking state matches include
 // "+new", "+est", "+rel" and "+trk-inv".
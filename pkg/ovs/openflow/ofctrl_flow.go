@@ -11,6 +11,7 @@ import (
 type FlowStates struct {
 	TableID         uint8
 	PacketCount     uint64
+	ByteCount       uint64
 	DurationNSecond uint32
 }
 
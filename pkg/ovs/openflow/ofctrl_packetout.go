@@ -24,9 +24,12 @@ import (
 )
 
 type ofPacketOutBuilder struct {
-	pktOut  *ofctrl.PacketOut
-	icmpID  *uint16
-	icmpSeq *uint16
+	pktOut    *ofctrl.PacketOut
+	icmpID    *uint16
+	icmpSeq   *uint16
+	icmpData  []byte
+	tcpSeqNum *uint32
+	tcpAckNum *uint32
 }
 
 // SetSrcMAC sets the packet's source MAC with the provided value.
@@ -124,6 +127,26 @@ func (b *ofPacketOutBuilder) SetTCPFlags(flags uint8) PacketOutBuilder {
 	return b
 }
 
+// SetTCPSeqNum sets the sequence number in the packet's TCP header. If it is
+// not set, a random sequence number is used.
+func (b *ofPacketOutBuilder) SetTCPSeqNum(seqNum uint32) PacketOutBuilder {
+	if b.pktOut.TCPHeader == nil {
+		b.pktOut.TCPHeader = new(protocol.TCP)
+	}
+	b.tcpSeqNum = &seqNum
+	return b
+}
+
+// SetTCPAckNum sets the acknowledgement number in the packet's TCP header. If
+// it is not set, a random acknowledgement number is used.
+func (b *ofPacketOutBuilder) SetTCPAckNum(ackNum uint32) PacketOutBuilder {
+	if b.pktOut.TCPHeader == nil {
+		b.pktOut.TCPHeader = new(protocol.TCP)
+	}
+	b.tcpAckNum = &ackNum
+	return b
+}
+
 // SetUDPSrcPort sets the source port in the packet's UDP header.
 func (b *ofPacketOutBuilder) SetUDPSrcPort(port uint16) PacketOutBuilder {
 	if b.pktOut.UDPHeader == nil {
@@ -142,6 +165,15 @@ func (b *ofPacketOutBuilder) SetUDPDstPort(port uint16) PacketOutBuilder {
 	return b
 }
 
+// SetUDPData sets the payload of the UDP packet.
+func (b *ofPacketOutBuilder) SetUDPData(data []byte) PacketOutBuilder {
+	if b.pktOut.UDPHeader == nil {
+		b.pktOut.UDPHeader = new(protocol.UDP)
+	}
+	b.pktOut.UDPHeader.Data = data
+	return b
+}
+
 // SetICMPType sets the type in the packet's ICMP header.
 func (b *ofPacketOutBuilder) SetICMPType(icmpType uint8) PacketOutBuilder {
 	if b.pktOut.ICMPHeader == nil {
@@ -178,6 +210,17 @@ func (b *ofPacketOutBuilder) SetICMPSequence(seq uint16) PacketOutBuilder {
 	return b
 }
 
+// SetICMPData sets the data in the packet's ICMP header, which follows the
+// type, code and checksum fields. It takes precedence over the identifier and
+// the sequence number.
+func (b *ofPacketOutBuilder) SetICMPData(data []byte) PacketOutBuilder {
+	if b.pktOut.ICMPHeader == nil {
+		b.pktOut.ICMPHeader = new(protocol.ICMP)
+	}
+	b.icmpData = data
+	return b
+}
+
 // SetInport sets the in_port field of the packetOut message.
 func (b *ofPacketOutBuilder) SetInport(inPort uint32) PacketOutBuilder {
 	b.pktOut.InPort = inPort
@@ -205,8 +248,16 @@ func (b *ofPacketOutBuilder) Done() *ofctrl.PacketOut {
 		b.pktOut.IPHeader.Length = 20 + b.pktOut.ICMPHeader.Len()
 	} else if b.pktOut.TCPHeader != nil {
 		b.pktOut.TCPHeader.HdrLen = 5
-		b.pktOut.TCPHeader.SeqNum = rand.Uint32()
-		b.pktOut.TCPHeader.AckNum = rand.Uint32()
+		if b.tcpSeqNum != nil {
+			b.pktOut.TCPHeader.SeqNum = *b.tcpSeqNum
+		} else {
+			b.pktOut.TCPHeader.SeqNum = rand.Uint32()
+		}
+		if b.tcpAckNum != nil {
+			b.pktOut.TCPHeader.AckNum = *b.tcpAckNum
+		} else {
+			b.pktOut.TCPHeader.AckNum = rand.Uint32()
+		}
 		b.pktOut.TCPHeader.Checksum = b.tcpHeaderChecksum()
 		b.pktOut.IPHeader.Length = 20 + b.pktOut.TCPHeader.Len()
 	} else if b.pktOut.UDPHeader != nil {
@@ -226,6 +277,10 @@ func (b *ofPacketOutBuilder) Done() *ofctrl.PacketOut {
 }
 
 func (b *ofPacketOutBuilder) setICMPData() {
+	if b.icmpData != nil {
+		b.pktOut.ICMPHeader.Data = b.icmpData
+		return
+	}
 	data := make([]byte, 4)
 	if b.icmpID != nil {
 		binary.BigEndian.PutUint16(data, *b.icmpID)
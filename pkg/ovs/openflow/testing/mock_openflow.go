@@ -1374,6 +1374,48 @@ func (mr *MockFlowBuilderMockRecorder) MatchDstMAC(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchDstMAC", reflect.TypeOf((*MockFlowBuilder)(nil).MatchDstMAC), arg0)
 }
 
+// MatchICMPCode mocks base method
+func (m *MockFlowBuilder) MatchICMPCode(arg0 uint8) openflow.FlowBuilder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchICMPCode", arg0)
+	ret0, _ := ret[0].(openflow.FlowBuilder)
+	return ret0
+}
+
+// MatchICMPCode indicates an expected call of MatchICMPCode
+func (mr *MockFlowBuilderMockRecorder) MatchICMPCode(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchICMPCode", reflect.TypeOf((*MockFlowBuilder)(nil).MatchICMPCode), arg0)
+}
+
+// MatchICMPType mocks base method
+func (m *MockFlowBuilder) MatchICMPType(arg0 uint8) openflow.FlowBuilder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchICMPType", arg0)
+	ret0, _ := ret[0].(openflow.FlowBuilder)
+	return ret0
+}
+
+// MatchICMPType indicates an expected call of MatchICMPType
+func (mr *MockFlowBuilderMockRecorder) MatchICMPType(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchICMPType", reflect.TypeOf((*MockFlowBuilder)(nil).MatchICMPType), arg0)
+}
+
+// MatchIPProtocolValue mocks base method
+func (m *MockFlowBuilder) MatchIPProtocolValue(arg0 uint8) openflow.FlowBuilder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchIPProtocolValue", arg0)
+	ret0, _ := ret[0].(openflow.FlowBuilder)
+	return ret0
+}
+
+// MatchIPProtocolValue indicates an expected call of MatchIPProtocolValue
+func (mr *MockFlowBuilderMockRecorder) MatchIPProtocolValue(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchIPProtocolValue", reflect.TypeOf((*MockFlowBuilder)(nil).MatchIPProtocolValue), arg0)
+}
+
 // MatchInPort mocks base method
 func (m *MockFlowBuilder) MatchInPort(arg0 uint32) openflow.FlowBuilder {
 	m.ctrl.T.Helper()
@@ -1458,6 +1500,20 @@ func (mr *MockFlowBuilderMockRecorder) MatchSCTPDstPort(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchSCTPDstPort", reflect.TypeOf((*MockFlowBuilder)(nil).MatchSCTPDstPort), arg0)
 }
 
+// MatchSCTPDstPortMask mocks base method
+func (m *MockFlowBuilder) MatchSCTPDstPortMask(arg0, arg1 uint16) openflow.FlowBuilder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchSCTPDstPortMask", arg0, arg1)
+	ret0, _ := ret[0].(openflow.FlowBuilder)
+	return ret0
+}
+
+// MatchSCTPDstPortMask indicates an expected call of MatchSCTPDstPortMask
+func (mr *MockFlowBuilderMockRecorder) MatchSCTPDstPortMask(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchSCTPDstPortMask", reflect.TypeOf((*MockFlowBuilder)(nil).MatchSCTPDstPortMask), arg0, arg1)
+}
+
 // MatchSrcIP mocks base method
 func (m *MockFlowBuilder) MatchSrcIP(arg0 net.IP) openflow.FlowBuilder {
 	m.ctrl.T.Helper()
@@ -1514,6 +1570,20 @@ func (mr *MockFlowBuilderMockRecorder) MatchTCPDstPort(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchTCPDstPort", reflect.TypeOf((*MockFlowBuilder)(nil).MatchTCPDstPort), arg0)
 }
 
+// MatchTCPDstPortMask mocks base method
+func (m *MockFlowBuilder) MatchTCPDstPortMask(arg0, arg1 uint16) openflow.FlowBuilder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchTCPDstPortMask", arg0, arg1)
+	ret0, _ := ret[0].(openflow.FlowBuilder)
+	return ret0
+}
+
+// MatchTCPDstPortMask indicates an expected call of MatchTCPDstPortMask
+func (mr *MockFlowBuilderMockRecorder) MatchTCPDstPortMask(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchTCPDstPortMask", reflect.TypeOf((*MockFlowBuilder)(nil).MatchTCPDstPortMask), arg0, arg1)
+}
+
 // MatchTunMetadata mocks base method
 func (m *MockFlowBuilder) MatchTunMetadata(arg0 int, arg1 uint32) openflow.FlowBuilder {
 	m.ctrl.T.Helper()
@@ -1542,6 +1612,20 @@ func (mr *MockFlowBuilderMockRecorder) MatchUDPDstPort(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchUDPDstPort", reflect.TypeOf((*MockFlowBuilder)(nil).MatchUDPDstPort), arg0)
 }
 
+// MatchUDPDstPortMask mocks base method
+func (m *MockFlowBuilder) MatchUDPDstPortMask(arg0, arg1 uint16) openflow.FlowBuilder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchUDPDstPortMask", arg0, arg1)
+	ret0, _ := ret[0].(openflow.FlowBuilder)
+	return ret0
+}
+
+// MatchUDPDstPortMask indicates an expected call of MatchUDPDstPortMask
+func (mr *MockFlowBuilderMockRecorder) MatchUDPDstPortMask(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchUDPDstPortMask", reflect.TypeOf((*MockFlowBuilder)(nil).MatchUDPDstPortMask), arg0, arg1)
+}
+
 // SetHardTimeout mocks base method
 func (m *MockFlowBuilder) SetHardTimeout(arg0 uint16) openflow.FlowBuilder {
 	m.ctrl.T.Helper()
@@ -24,6 +24,7 @@ import (
 
 	"github.com/spf13/afero"
 
+	"github.com/vmware-tanzu/antrea/pkg/agent/controller/networkpolicy"
 	"github.com/vmware-tanzu/antrea/pkg/agent/util/iptables"
 )
 
@@ -40,6 +41,9 @@ func (d *agentDumper) DumpLog(basedir string) error {
 	if err := fileCopy(d.fs, path.Join(basedir, "logs", "agent"), logDir, "antrea-agent"); err != nil {
 		return err
 	}
+	if err := fileCopy(d.fs, path.Join(basedir, "logs", "networkpolicy"), path.Join(logDir, networkpolicy.AuditLogSubDir), networkpolicy.AuditLogFileName); err != nil {
+		return err
+	}
 	return fileCopy(d.fs, path.Join(basedir, "logs", "ovs"), logDir, "ovs")
 }
 
@@ -19,6 +19,8 @@ package support
 import (
 	"flag"
 	"path"
+
+	"github.com/vmware-tanzu/antrea/pkg/agent/controller/networkpolicy"
 )
 
 const antreaWindowsWellKnownLogDir = `C:\k\antrea\logs`
@@ -34,7 +36,10 @@ func (d *agentDumper) DumpLog(basedir string) error {
 	} else {
 		logDir = logDirFlag.Value.String()
 	}
-	return fileCopy(d.fs, path.Join(basedir, "logs", "agent"), logDir, "rancher-wins-antrea-agent")
+	if err := fileCopy(d.fs, path.Join(basedir, "logs", "agent"), logDir, "rancher-wins-antrea-agent"); err != nil {
+		return err
+	}
+	return fileCopy(d.fs, path.Join(basedir, "logs", "networkpolicy"), path.Join(logDir, networkpolicy.AuditLogSubDir), networkpolicy.AuditLogFileName)
 }
 
 // TODO: maybe collect interfaces on Windows Node in future.
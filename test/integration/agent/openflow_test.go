@@ -49,6 +49,7 @@ var (
 const (
 	ingressRuleTable    = uint8(90)
 	ingressDefaultTable = uint8(100)
+	ingressMetricTable  = uint8(101)
 	contrackCommitTable = uint8(105)
 	priorityNormal      = 200
 )
@@ -338,7 +339,7 @@ func TestNetworkPolicyFlows(t *testing.T) {
 
 	err = c.InstallPolicyRuleFlows(ruleID, rule, "np1", "ns1")
 	require.Nil(t, err, "Failed to InstallPolicyRuleFlows")
-	checkConjunctionFlows(t, ingressRuleTable, ingressDefaultTable, contrackCommitTable, priorityNormal, ruleID, rule, assert.True)
+	checkConjunctionFlows(t, ingressRuleTable, ingressDefaultTable, ingressMetricTable, priorityNormal, ruleID, rule, assert.True)
 	checkDefaultDropFlows(t, ingressDefaultTable, priorityNormal, types.DstAddress, toIPList, true)
 
 	addedFrom := prepareIPNetAddresses([]string{"192.168.5.0/24", "192.169.1.0/24"})
@@ -394,7 +395,7 @@ func TestNetworkPolicyFlows(t *testing.T) {
 
 	_, err = c.UninstallPolicyRuleFlows(ruleID)
 	require.Nil(t, err, "Failed to DeletePolicyRuleService")
-	checkConjunctionFlows(t, ingressRuleTable, ingressDefaultTable, contrackCommitTable, priorityNormal, ruleID, rule, assert.False)
+	checkConjunctionFlows(t, ingressRuleTable, ingressDefaultTable, ingressMetricTable, priorityNormal, ruleID, rule, assert.False)
 	checkDefaultDropFlows(t, ingressDefaultTable, priorityNormal, types.DstAddress, toIPList, false)
 	checkOVSFlowMetrics(t, c)
 }
@@ -496,6 +497,11 @@ func checkConjunctionFlows(t *testing.T, ruleTable uint8, dropTable uint8, allow
 	flow := &ofTestUtils.ExpectFlow{MatchStr: conjunctionActionMatch, ActStr: fmt.Sprintf("load:0x%x->NXM_NX_REG%d[],goto_table:%d", ruleID, conjReg, allowTable)}
 	testFunc(t, ofTestUtils.OfctlFlowMatch(flowList, ruleTable, flow), "Failed to update conjunction action flow")
 
+	metricFlowList, err := ofTestUtils.OfctlDumpTableFlows(ovsCtlClient, ingressMetricTable)
+	require.Nil(t, err, "Failed to dump flows")
+	metricFlow := &ofTestUtils.ExpectFlow{MatchStr: fmt.Sprintf("priority=200,ip,reg%d=0x%x", conjReg, ruleID), ActStr: fmt.Sprintf("goto_table:%d", contrackCommitTable)}
+	testFunc(t, ofTestUtils.OfctlFlowMatch(metricFlowList, ingressMetricTable, metricFlow), "Failed to update conjunction metric flow")
+
 	for _, addr := range rule.From {
 		conjMatch := fmt.Sprintf("priority=%d,ip,%s=%s", priority, getCmdMatchKey(addr.GetMatchKey(types.SrcAddress)), addr.GetMatchValue())
 		flow := &ofTestUtils.ExpectFlow{MatchStr: conjMatch, ActStr: fmt.Sprintf("conjunction(%d,1/3)", ruleID)}
@@ -767,6 +773,10 @@ func prepareDefaultFlows() []expectTableFlows {
 		},
 		{
 			uint8(60),
+			[]*ofTestUtils.ExpectFlow{{"priority=0", "goto_table:61"}},
+		},
+		{
+			uint8(61),
 			[]*ofTestUtils.ExpectFlow{{"priority=0", "goto_table:70"}},
 		},
 		{
@@ -787,13 +797,17 @@ func prepareDefaultFlows() []expectTableFlows {
 		},
 		{
 			uint8(100),
+			[]*ofTestUtils.ExpectFlow{{"priority=0", "goto_table:101"}},
+		},
+		{
+			uint8(101),
 			[]*ofTestUtils.ExpectFlow{{"priority=0", "goto_table:105"}},
 		},
 		{
 			uint8(105),
 			[]*ofTestUtils.ExpectFlow{
-				{"priority=200,ct_state=+new+trk,ip,reg0=0x1/0xffff", "ct(commit,table=106,zone=65520,exec(load:0x20->NXM_NX_CT_MARK[])"},
-				{"priority=190,ct_state=+new+trk,ip", "ct(commit,table=106,zone=65520)"},
+				{"priority=200,ct_state=+new+trk,ip,reg0=0x1/0xffff", "ct(commit,table=106,zone=65520,exec(load:0x20->NXM_NX_CT_MARK[],move:NXM_NX_REG6[]->NXM_NX_CT_LABEL[0..31],move:NXM_NX_REG5[]->NXM_NX_CT_LABEL[32..63]))"},
+				{"priority=190,ct_state=+new+trk,ip", "ct(commit,table=106,zone=65520,exec(move:NXM_NX_REG6[]->NXM_NX_CT_LABEL[0..31],move:NXM_NX_REG5[]->NXM_NX_CT_LABEL[32..63]))"},
 				{"priority=0", "goto_table:106"}},
 		},
 		{